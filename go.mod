@@ -11,17 +11,20 @@ require (
 	github.com/golang/mock v1.4.3
 	github.com/jmoiron/jsonq v0.0.0-20150511023944-e874b168d07e
 	github.com/manifoldco/promptui v0.8.0
-	github.com/olekukonko/tablewriter v0.0.4
 	github.com/onsi/ginkgo v1.10.1
 	github.com/onsi/gomega v1.7.0
 	github.com/pkg/browser v0.0.0-20180916011732-0a3d74bf9ce4
 	github.com/spf13/cobra v0.0.6
+	golang.org/x/crypto v0.0.0-20200220183623-bac4c82f6975
 	golang.org/x/lint v0.0.0-20191125180803-fdd1cda4f05f
+	golang.org/x/sys v0.0.0-20200317113312-5766fd39f98d
 	gopkg.in/yaml.v2 v2.2.8
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/api v0.17.0
 	k8s.io/apimachinery v0.17.0
 	k8s.io/client-go v11.0.1-0.20190409021438-1a26190bd76a+incompatible
 	k8s.io/metrics v0.16.8
+	sigs.k8s.io/yaml v1.1.0
 )
 
 replace (
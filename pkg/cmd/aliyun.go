@@ -15,14 +15,13 @@
 package cmd
 
 import (
-	"errors"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // NewAliyunCmd returns a new aliyun command.
-func NewAliyunCmd(targetReader TargetReader) *cobra.Command {
+func NewAliyunCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:          "aliyun <args>",
 		Short:        "e.g. \"gardenctl aliyun ecs DescribeRegions\"",
@@ -30,13 +29,18 @@ func NewAliyunCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "aliyun"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 
 			arguments := "aliyun " + strings.Join(args[:], " ")
-			operate("aliyun", arguments)
-
-			return nil
+			_, err := operate(targetReader, "aliyun", arguments)
+			return err
 		},
 	}
 }
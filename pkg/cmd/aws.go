@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,7 +23,7 @@ import (
 )
 
 // NewAwsCmd returns a new aws command.
-func NewAwsCmd(targetReader TargetReader) *cobra.Command {
+func NewAwsCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "aws <args>",
 		Short:              "e.g. \"gardenctl aws ec2 describe-security-groups\"",
@@ -33,15 +32,25 @@ func NewAwsCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "aws"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 			if !CheckToolInstalled("aws") {
 				fmt.Println("Please go to https://docs.aws.amazon.com/cli/latest/userguide/cli-chap-install.html for how to install aws cli")
-				os.Exit(2)
+				return NewToolMissingError("aws")
 			}
 
 			arguments := strings.Join(os.Args[2:], " ")
-			fmt.Println(operate("aws", arguments))
+			output, err := operate(targetReader, "aws", arguments)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
@@ -0,0 +1,64 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"github.com/gardener/gardenctl/pkg/cmd"
+	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aws command", func() {
+	var (
+		ctrl         *gomock.Controller
+		targetReader *mockcmd.MockTargetReader
+		target       *mockcmd.MockTargetInterface
+		configReader *mockcmd.MockConfigReader
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		targetReader = mockcmd.NewMockTargetReader(ctrl)
+		target = mockcmd.NewMockTargetInterface(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should not hand out the credentials of a shoot of a read-only garden", func() {
+		targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+		target.EXPECT().Stack().Return([]cmd.TargetMeta{
+			{Kind: cmd.TargetKindGarden, Name: "test-garden"},
+			{Kind: cmd.TargetKindProject, Name: "prod"},
+			{Kind: cmd.TargetKindShoot, Name: "test-shoot"},
+		}).AnyTimes()
+		configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+			GardenClusters: []cmd.GardenClusterMeta{{Name: "test-garden", ReadOnly: true}},
+		})
+
+		ioStreams, _, _, _ := cmd.NewTestIOStreams()
+		command := cmd.NewAwsCmd(targetReader, configReader, ioStreams)
+		command.SetArgs([]string{"ec2", "describe-instances"})
+		err := command.Execute()
+
+		Expect(cmd.IsForbidden(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("test-garden/prod/test-shoot is read-only, aws is refused"))
+	})
+})
@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,7 +23,7 @@ import (
 )
 
 // NewAzCmd returns a new az command.
-func NewAzCmd(targetReader TargetReader) *cobra.Command {
+func NewAzCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "az <args>",
 		Short:              "\"gardenctl az network vnet show\"",
@@ -33,15 +32,25 @@ func NewAzCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "az"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 			if !CheckToolInstalled("az") {
 				fmt.Println("Please go to https://docs.microsoft.com/en-us/cli/azure/install-azure-cli?view=azure-cli-latest for how to install az cli")
-				os.Exit(2)
+				return NewToolMissingError("az")
 			}
 
 			arguments := strings.Join(os.Args[2:], " ")
-			fmt.Println(operate("az", arguments))
+			output, err := operate(targetReader, "az", arguments)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
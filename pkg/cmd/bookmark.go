@@ -0,0 +1,161 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/gardener/gardenctl/pkg/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// targetInfoBookmark is the name of the targeted bookmark
+const targetInfoBookmark = "bookmark"
+
+// reservedBookmarkNames are the arguments of the target command which cannot be used as bookmark names
+var reservedBookmarkNames = []string{"garden", "project", "seed", "shoot", "namespace", "server", "dashboardUrl", "save", "push", "pop"}
+
+// NewBookmarkCmd returns a new bookmark command.
+func NewBookmarkCmd(ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bookmark [ls|rm NAME]",
+		Short: "List or remove the targets saved with \"gardenctl target save NAME\", e.g. \"gardenctl bookmark ls\"",
+		Example: `
+	# Save the current target and target it again later.
+	gardenctl target save api-prod
+	gardenctl target api-prod
+
+	# List the bookmarks.
+	gardenctl bookmark ls
+
+	# Remove a bookmark.
+	gardenctl bookmark rm api-prod`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return printBookmarks(ioStreams, outputFormat)
+			}
+			switch args[0] {
+			case "ls":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: bookmark ls")
+				}
+				return printBookmarks(ioStreams, outputFormat)
+			case "rm":
+				if len(args) != 2 {
+					return errors.New("command must be in the format: bookmark rm NAME")
+				}
+				if err := gardenctl.RemoveBookmark(bookmarkPath(), args[1]); err != nil {
+					return err
+				}
+				fmt.Fprintf(ioStreams.Out, "Removed bookmark %s\n", args[1])
+				return nil
+			}
+			return errors.New("command must be in the format: bookmark [ls|rm NAME]")
+		},
+		ValidArgs: []string{"ls", "rm"},
+	}
+	return cmd
+}
+
+// printBookmarks prints the bookmarks with their target paths
+func printBookmarks(ioStreams IOStreams, outFormat string) error {
+	bookmarks, err := gardenctl.ReadBookmarks(bookmarkPath())
+	if err != nil {
+		return err
+	}
+	list := Bookmarks{}
+	for _, b := range bookmarks {
+		list.Bookmarks = append(list.Bookmarks, BookmarkMeta{Name: b.Name, Target: TargetPathFromStack(b.Target).String()})
+	}
+	return PrintoutObject(list, ioStreams.Out, outFormat)
+}
+
+// saveBookmark saves the current target under name
+func saveBookmark(targetReader TargetReader, ioStreams IOStreams, name string) error {
+	for _, reserved := range reservedBookmarkNames {
+		if name == reserved {
+			return fmt.Errorf("invalid bookmark name %q, it is an argument of the target command", name)
+		}
+	}
+	target := targetReader.ReadTarget(pathTarget)
+	if err := gardenctl.SaveBookmark(bookmarkPath(), name, target.Stack()); err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Saved bookmark %s for %s\n", name, TargetPathFromStack(target.Stack()).String())
+	return nil
+}
+
+// findBookmark returns the bookmark with the given name or nil
+func findBookmark(name string) (*gardenctl.Bookmark, error) {
+	bookmarks, err := gardenctl.ReadBookmarks(bookmarkPath())
+	if err != nil {
+		return nil, err
+	}
+	return gardenctl.FindBookmark(bookmarks, name), nil
+}
+
+// targetBookmark resolves the target of the bookmark again and targets it
+func targetBookmark(bookmark *gardenctl.Bookmark, targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams) error {
+	path := TargetPathFromStack(bookmark.Target).String()
+	if err := targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, path); err != nil {
+		if IsNotFound(err) {
+			return NewNotFoundError("bookmark %s targets %s which does not exist anymore (%v), save it again with \"gardenctl target save %s\" or remove it with \"gardenctl bookmark rm %s\"", bookmark.Name, path, err, bookmark.Name, bookmark.Name)
+		}
+		return err
+	}
+	targetInfo[targetInfoBookmark] = bookmark.Name
+	return nil
+}
+
+// bookmarkPromptItems returns the bookmarks as items of the history picker
+func bookmarkPromptItems() ([]history.PromptItem, error) {
+	bookmarks, err := gardenctl.ReadBookmarks(bookmarkPath())
+	if err != nil {
+		return nil, err
+	}
+	var items []history.PromptItem
+	for _, b := range bookmarks {
+		tp := TargetPathFromStack(b.Target)
+		items = append(items, history.PromptItem{
+			Cmd:       "gardenctl target " + b.Name,
+			Garden:    tp.Garden,
+			Project:   tp.Project,
+			Seed:      tp.Seed,
+			Shoot:     tp.Shoot,
+			Namespace: tp.Namespace,
+			Bookmark:  b.Name,
+		})
+	}
+	return items, nil
+}
+
+// bookmarkPath returns the path of the bookmarks file
+func bookmarkPath() string {
+	return filepath.Join(pathGardenHome, "bookmarks.yaml")
+}
+
+// PrintTable prints the bookmarks as table
+func (b Bookmarks) PrintTable(w io.Writer, wide bool) error {
+	var rows [][]string
+	for _, bookmark := range b.Bookmarks {
+		rows = append(rows, []string{bookmark.Name, bookmark.Target})
+	}
+	return printTable(w, []string{"NAME", "TARGET"}, rows)
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/gardener/gardenctl/pkg/internal/cache"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// defaultCacheTTL is the time to live of cached credentials if it is not configured
+	defaultCacheTTL = 24 * time.Hour
+	// cachePassphraseEnvVar is the environment variable holding the passphrase of the cache encryption
+	cachePassphraseEnvVar = "GARDENCTL_CACHE_PASSPHRASE"
+	// cacheEncryptionPassphrase derives the key of the cache encryption from a passphrase
+	cacheEncryptionPassphrase = "passphrase"
+	// cacheEncryptionKeyring derives the key of the cache encryption from a passphrase stored in the keyring
+	cacheEncryptionKeyring = "keyring"
+)
+
+var (
+	cacheExample = `
+	# Show which credentials are cached on disk.
+	gardenctl cache ls
+
+	# Remove all cached credentials of the garden prod.
+	gardenctl cache clean prod
+
+	# Remove the expired credentials only.
+	gardenctl cache clean --expired
+
+	# Remove the expired credentials except those of the current target and the orphaned decrypted copies.
+	gardenctl cache gc`
+
+	// cacheCleanExpired removes expired entries only
+	cacheCleanExpired bool
+
+	// credentials caches the kubeconfigs, ssh keys and terraform files fetched from the gardens
+	credentials *cache.Cache
+	// credentialsConfig is the configuration of the credentials cache
+	credentialsConfig CacheConfig
+)
+
+// NewCacheCmd returns a new cache command.
+func NewCacheCmd(ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "cache (ls|clean|gc)",
+		Short:        "List and remove the cached credentials, e.g. \"gardenctl cache clean\"",
+		Example:      cacheExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("command must be in the format: cache (ls|clean|gc)")
+			}
+			switch args[0] {
+			case "ls":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: cache ls")
+				}
+				return printCache(credentialCache(), ioStreams.Out, outputFormat)
+			case "clean":
+				return cleanCache(credentialCache(), ioStreams.Out, args[1:], cacheCleanExpired)
+			case "gc":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: cache gc")
+				}
+				var target Target
+				if err := ReadTarget(pathTarget, &target); err != nil {
+					return err
+				}
+				return gcCache(credentialCache(), ioStreams.Out, targetCachePaths(target.Stack()))
+			}
+
+			return errors.New("command must be in the format: cache (ls|clean|gc)")
+		},
+		ValidArgs: []string{"ls", "clean", "gc"},
+	}
+
+	cmd.PersistentFlags().BoolVar(&cacheCleanExpired, "expired", false, "remove expired credentials only")
+
+	return cmd
+}
+
+// initCredentialCache configures the credentials cache. Expired credentials are fetched again when
+// they are used and removed by "gardenctl cache gc".
+func initCredentialCache(config CacheConfig) error {
+	ttl := defaultCacheTTL
+	if config.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(config.TTL); err != nil {
+			return fmt.Errorf("invalid cache ttl %q: %v", config.TTL, err)
+		}
+	}
+	switch config.Encryption {
+	case "", cacheEncryptionPassphrase:
+	case cacheEncryptionKeyring:
+		if len(strings.Fields(config.KeyringCommand)) == 0 {
+			return errors.New("cache encryption keyring requires a keyringCommand")
+		}
+	default:
+		return fmt.Errorf("invalid cache encryption %q, must be %s or %s", config.Encryption, cacheEncryptionPassphrase, cacheEncryptionKeyring)
+	}
+
+	dir := filepath.Join(pathGardenHome, "cache")
+	credentials = cache.New(dir, cachePlainDir(dir), ttl)
+	credentialsConfig = config
+	return nil
+}
+
+// credentialCache returns the credentials cache without deriving the encryption key,
+// which is sufficient to list and remove entries
+func credentialCache() *cache.Cache {
+	if credentials == nil {
+		dir := filepath.Join(pathGardenHome, "cache")
+		credentials = cache.New(dir, cachePlainDir(dir), 0)
+	}
+	return credentials
+}
+
+// unlockedCredentialCache returns the credentials cache with the encryption key derived if the encryption is configured
+func unlockedCredentialCache() (*cache.Cache, error) {
+	c := credentialCache()
+	if credentialsConfig.Encryption == "" || c.Encrypted() {
+		return c, nil
+	}
+	passphrase, err := cachePassphrase(credentialsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return c, c.EnableEncryption(passphrase)
+}
+
+// writeCacheFile stores data in the credentials cache and returns the path to hand to external tools
+func writeCacheFile(path string, data []byte) (string, error) {
+	c, err := unlockedCredentialCache()
+	if err != nil {
+		return "", err
+	}
+	plainPath, err := c.Write(path, data)
+	return plainPath, plainDirError(err)
+}
+
+// cachedPath returns the path of the cached file or directory to hand to external tools
+func cachedPath(path string) (string, error) {
+	c, err := unlockedCredentialCache()
+	if err != nil {
+		return "", err
+	}
+	plainPath, err := c.Path(path)
+	return plainPath, plainDirError(err)
+}
+
+// plainDirError explains the error if there is no directory for decrypted copies of the cache
+func plainDirError(err error) error {
+	if err == cache.ErrNoPlainDir {
+		return errors.New("the cache encryption requires $XDG_RUNTIME_DIR to be set to a private directory, decrypted credentials are not written to persistent storage")
+	}
+	return err
+}
+
+// cachePassphrase returns the passphrase of the cache encryption
+func cachePassphrase(config CacheConfig) ([]byte, error) {
+	if config.Encryption == cacheEncryptionKeyring {
+		command := strings.Fields(config.KeyringCommand)
+		if len(command) == 0 {
+			return nil, errors.New("cache encryption keyring requires a keyringCommand")
+		}
+		passphrase, err := ExecCmdReturnOutput(command[0], command[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cache passphrase from the keyring: %v", err)
+		}
+		return []byte(passphrase), nil
+	}
+
+	if passphrase := os.Getenv(cachePassphraseEnvVar); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("the cache is encrypted, please provide the passphrase via $%s", cachePassphraseEnvVar)
+	}
+	fmt.Fprint(os.Stderr, "Cache passphrase: ")
+	passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return passphrase, err
+}
+
+// cachePlainDir returns the directory of the decrypted copies of the cache in dir on the tmpfs of
+// $XDG_RUNTIME_DIR, which is private to the user and cleared on logout. It is empty if there is no
+// such directory, decrypted copies are never written to persistent storage.
+func cachePlainDir(dir string) string {
+	base := os.Getenv("XDG_RUNTIME_DIR")
+	if base == "" {
+		return ""
+	}
+	if info, err := os.Stat(base); err != nil || !info.IsDir() || info.Mode().Perm()&0077 != 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(base, "gardenctl", hex.EncodeToString(sum[:8]))
+}
+
+// printCache prints the entries of the cache
+func printCache(c *cache.Cache, writer io.Writer, outFormat string) error {
+	entries, err := c.List()
+	if err != nil {
+		return err
+	}
+	var cacheInfo CacheInfo
+	cacheInfo.Dir = c.Dir
+	cacheInfo.Encryption = credentialsConfig.Encryption
+	if c.TTL > 0 {
+		cacheInfo.TTL = c.TTL.String()
+	}
+	cacheInfo.Entries = entries
+	return PrintoutObject(cacheInfo, writer, outFormat)
+}
+
+// cleanCache removes the entries of the cache below the given paths, or all entries if no path is given
+func cleanCache(c *cache.Cache, writer io.Writer, paths []string, expiredOnly bool) error {
+	entries, err := c.List()
+	if err != nil {
+		return err
+	}
+	var removed []cache.Entry
+	for _, entry := range entries {
+		if (!expiredOnly || entry.Expired) && cachePathMatches(entry.Path, paths) {
+			removed = append(removed, entry)
+		}
+	}
+	if err := c.Remove(removed); err != nil {
+		return err
+	}
+	for _, entry := range removed {
+		fmt.Fprintf(writer, "Removed %s %s\n", entry.Kind, entry.Path)
+	}
+	fmt.Fprintf(writer, "Removed %d cached files\n", len(removed))
+	return nil
+}
+
+// gcCache removes the expired entries of the cache except the keep paths, restricts the permissions
+// of the remaining ones and removes the decrypted copies of files which do not exist anymore
+func gcCache(c *cache.Cache, writer io.Writer, keep []string) error {
+	removed, err := c.Prune(keep...)
+	if err != nil {
+		return err
+	}
+	for _, entry := range removed {
+		fmt.Fprintf(writer, "Removed %s %s\n", entry.Kind, entry.Path)
+	}
+	fmt.Fprintf(writer, "Removed %d cached files\n", len(removed))
+	return nil
+}
+
+// targetCachePaths returns the cached files of the target stack and of the session kubeconfig
+func targetCachePaths(stack []TargetMeta) []string {
+	paths := []string{filepath.Dir(sessionKubeconfigPath(sessionID))}
+	if len(stack) > 1 && stack[1].Kind == TargetKindSeed {
+		paths = append(paths, gardenctl.SeedKubeconfigPath(filepath.Join(pathGardenHome, "cache"), stack[0].Name, stack[1].Name))
+	}
+	if dir := shootCacheDir(stack); dir != "" {
+		paths = append(paths, dir)
+	}
+	return paths
+}
+
+// cachePathMatches returns whether path is below one of the given paths, or true if no path is given
+func cachePathMatches(path string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		if path == p || strings.HasPrefix(path, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintTable prints the entries of the cache as table, wide adds the modification and expiration time
+func (c CacheInfo) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"PATH", "KIND", "SIZE", "EXPIRED", "ENCRYPTED"}
+	if wide {
+		header = append(header, "MODIFIED", "EXPIRES")
+	}
+	var rows [][]string
+	for _, entry := range c.Entries {
+		row := []string{entry.Path, entry.Kind, fmt.Sprint(entry.Size), fmt.Sprint(entry.Expired), fmt.Sprint(entry.Encrypted)}
+		if wide {
+			expires := "-"
+			if entry.Expires != nil {
+				expires = entry.Expires.Local().Format(time.RFC3339)
+			}
+			row = append(row, entry.Modified.Local().Format(time.RFC3339), expires)
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// Names returns the paths of the entries of the cache
+func (c CacheInfo) Names() []string {
+	var names []string
+	for _, entry := range c.Entries {
+		names = append(names, entry.Path)
+	}
+	return names
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
+	"github.com/gardener/gardenctl/pkg/internal/cache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		tmpDir string
+		c      *cache.Cache
+		path   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "gardenctl-cache")
+		Expect(err).NotTo(HaveOccurred())
+		c = cache.New(filepath.Join(tmpDir, "cache"), filepath.Join(tmpDir, "plain"), time.Hour)
+		path = filepath.Join(c.Dir, "prod", "seeds", "aws", "kubeconfig.yaml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should write files readable by the owner only", func() {
+		plainPath, err := c.Write(path, []byte("secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plainPath).To(Equal(path))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		info, err = os.Stat(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+	})
+
+	It("should encrypt files at rest and decrypt them for external tools", func() {
+		Expect(c.EnableEncryption([]byte("passphrase"))).To(Succeed())
+
+		plainPath, err := c.Write(path, []byte("secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plainPath).To(Equal(filepath.Join(tmpDir, "plain", "prod", "seeds", "aws", "kubeconfig.yaml")))
+		Expect(ioutil.ReadFile(path)).NotTo(ContainSubstring("secret"))
+		Expect(ioutil.ReadFile(plainPath)).To(Equal([]byte("secret")))
+		Expect(c.Read(path)).To(Equal([]byte("secret")))
+
+		Expect(os.RemoveAll(c.PlainDir)).To(Succeed())
+		Expect(c.Path(path)).To(Equal(plainPath))
+		Expect(ioutil.ReadFile(plainPath)).To(Equal([]byte("secret")))
+
+		other := cache.New(c.Dir, c.PlainDir, time.Hour)
+		Expect(other.EnableEncryption([]byte("wrong"))).To(Succeed())
+		_, err = other.Read(path)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse to decrypt files without a directory for the decrypted copies", func() {
+		Expect(c.EnableEncryption([]byte("passphrase"))).To(Succeed())
+		_, err := c.Write(path, []byte("secret"))
+		Expect(err).NotTo(HaveOccurred())
+
+		noPlainDir := cache.New(c.Dir, "", time.Hour)
+		Expect(noPlainDir.EnableEncryption([]byte("passphrase"))).To(Succeed())
+		_, err = noPlainDir.Path(path)
+		Expect(err).To(Equal(cache.ErrNoPlainDir))
+		_, err = noPlainDir.Write(path, []byte("secret"))
+		Expect(err).To(Equal(cache.ErrNoPlainDir))
+		Expect(noPlainDir.Read(path)).To(Equal([]byte("secret")))
+	})
+
+	It("should remove expired files and restrict the permissions of old files", func() {
+		oldPath := filepath.Join(c.Dir, "prod", "projects", "dev", "shoot", "kubeconfig.yaml")
+		_, err := c.Write(oldPath, []byte("old"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.Write(path, []byte("new"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chtimes(oldPath, time.Now(), time.Now().Add(-2*time.Hour))).To(Succeed())
+		Expect(os.Chmod(path, 0644)).To(Succeed())
+
+		removed, err := c.Prune()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(HaveLen(1))
+		Expect(removed[0].Path).To(Equal(filepath.Join("prod", "projects", "dev", "shoot", "kubeconfig.yaml")))
+		Expect(oldPath).NotTo(BeAnExistingFile())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		entries, err := c.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Kind).To(Equal("kubeconfig"))
+		Expect(entries[0].Expired).To(BeFalse())
+	})
+
+	It("should keep expired files of the current target", func() {
+		shootDir := filepath.Join(c.Dir, "prod", "projects", "dev", "shoot")
+		shootPath := filepath.Join(shootDir, "kubeconfig.yaml")
+		for _, p := range []string{path, shootPath} {
+			_, err := c.Write(p, []byte("old"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Chtimes(p, time.Now(), time.Now().Add(-2*time.Hour))).To(Succeed())
+			Expect(c.Expired(p)).To(BeTrue())
+		}
+
+		removed, err := c.Prune(shootDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(HaveLen(1))
+		Expect(path).NotTo(BeAnExistingFile())
+		Expect(shootPath).To(BeAnExistingFile())
+		Expect(c.Expired(path)).To(BeFalse())
+	})
+
+	DescribeTable("validation",
+		func(args []string, expectedErr string) {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command := cmd.NewCacheCmd(ioStreams)
+			command.SetArgs(args)
+
+			err := command.Execute()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(expectedErr))
+		},
+		Entry("without args", []string{}, "command must be in the format: cache (ls|clean|gc)"),
+		Entry("with unknown action", []string{"foo"}, "command must be in the format: cache (ls|clean|gc)"),
+		Entry("ls with args", []string{"ls", "foo"}, "command must be in the format: cache ls"),
+		Entry("gc with args", []string{"gc", "foo"}, "command must be in the format: cache gc"),
+	)
+})
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+// GardenctlClientFactory implements ClientFactory. It caches the rest configs and clients per
+// kubeconfig, so the same cluster is only loaded once and clients to different clusters can be
+// used concurrently.
+type GardenctlClientFactory = gardenctl.ClientFactory
+
+// NewClientFactory returns a client factory which looks up the garden kubeconfigs in the configuration.
+// Warnings about kubeconfigs are written to warnings.
+func NewClientFactory(configReader ConfigReader, warnings io.Writer) *GardenctlClientFactory {
+	factory := gardenctl.NewClientFactory(&readerConfigLoader{reader: configReader}, credentialKubeconfigs{})
+	factory.Warnings = warnings
+	return factory
+}
+
+// readerConfigLoader loads the configuration at pathGardenConfig with a ConfigReader
+type readerConfigLoader struct {
+	reader ConfigReader
+}
+
+// LoadConfig returns the configuration read by the ConfigReader
+func (l *readerConfigLoader) LoadConfig() (*GardenConfig, error) {
+	return l.reader.ReadConfig(pathGardenConfig), nil
+}
+
+// credentialKubeconfigs are the kubeconfigs in the credentials cache
+type credentialKubeconfigs struct{}
+
+// Dir returns the directory of the credentials cache
+func (credentialKubeconfigs) Dir() string {
+	return filepath.Join(pathGardenHome, "cache")
+}
+
+// Path returns the path of the cached kubeconfig to hand to clients and external tools
+func (credentialKubeconfigs) Path(path string) (string, error) {
+	return cachedPath(path)
+}
+
+// Expired returns whether the cached kubeconfig at path has expired
+func (credentialKubeconfigs) Expired(path string) bool {
+	return credentialCache().Expired(path)
+}
+
+// Write stores the kubeconfig at path in the credentials cache
+func (credentialKubeconfigs) Write(path string, data []byte) (string, error) {
+	return writeCacheFile(path, data)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
+	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client factory", func() {
+	var (
+		ctrl         *gomock.Controller
+		configReader *mockcmd.MockConfigReader
+		factory      *cmd.GardenctlClientFactory
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		configReader = mockcmd.NewMockConfigReader(ctrl)
+		factory = cmd.NewClientFactory(configReader, ioutil.Discard)
+		configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+			GardenClusters: []cmd.GardenClusterMeta{
+				{Name: "prod", KubeConfig: "/kubeconfigs/prod.yaml"},
+			},
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	DescribeTable("resolving the kubeconfig of a cluster in the target stack",
+		func(stack []cmd.TargetMeta, kind cmd.TargetKind, expected string) {
+			path, err := factory.KubeconfigPath(stack, kind)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(HaveSuffix(expected))
+		},
+		Entry("garden", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}}, cmd.TargetKindGarden, "/kubeconfigs/prod.yaml"),
+		Entry("garden of a project", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindProject, Name: "prjct"}}, cmd.TargetKindProject, "/kubeconfigs/prod.yaml"),
+		Entry("seed", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindSeed, Name: "aws"}}, cmd.TargetKindSeed, "cache/prod/seeds/aws/kubeconfig.yaml"),
+		Entry("shoot of a seed", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindSeed, Name: "aws"}, {Kind: cmd.TargetKindShoot, Name: "myshoot"}}, cmd.TargetKindShoot, "cache/prod/seeds/aws/myshoot/kubeconfig.yaml"),
+		Entry("shoot of a project", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindProject, Name: "prjct"}, {Kind: cmd.TargetKindShoot, Name: "myshoot"}, {Kind: cmd.TargetKindNamespace, Name: "default"}}, cmd.TargetKindShoot, "cache/prod/projects/prjct/myshoot/kubeconfig.yaml"),
+		Entry("seed of a shoot targeted via seed", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindSeed, Name: "aws"}, {Kind: cmd.TargetKindShoot, Name: "myshoot"}}, cmd.TargetKindSeed, "cache/prod/seeds/aws/kubeconfig.yaml"),
+	)
+
+	DescribeTable("rejecting clusters which are not targeted",
+		func(stack []cmd.TargetMeta, kind cmd.TargetKind, expected string) {
+			_, err := factory.KubeconfigPath(stack, kind)
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("empty stack", []cmd.TargetMeta{}, cmd.TargetKindGarden, "no garden cluster targeted"),
+		Entry("unknown garden", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "dev"}}, cmd.TargetKindGarden, `garden "dev" is not configured`),
+		Entry("seed of a project", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindProject, Name: "prjct"}}, cmd.TargetKindSeed, "no seed targeted"),
+		Entry("shoot of a seed", []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindSeed, Name: "aws"}}, cmd.TargetKindShoot, "no shoot targeted"),
+	)
+
+	It("should write the warnings about a kubeconfig to the given writer", func() {
+		kubeconfig, err := ioutil.TempFile("", "kubeconfig")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(kubeconfig.Name())
+		_, err = kubeconfig.WriteString(`apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://api.prod.example.com
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+current-context: prod
+users:
+- name: prod
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: login
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kubeconfig.Close()).To(Succeed())
+
+		configReader := mockcmd.NewMockConfigReader(ctrl)
+		configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+			GardenClusters: []cmd.GardenClusterMeta{{Name: "exec", KubeConfig: kubeconfig.Name()}},
+		}).AnyTimes()
+		warnings := &bytes.Buffer{}
+		factory := cmd.NewClientFactory(configReader, warnings)
+
+		_, err = factory.RESTConfig([]cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "exec"}}, cmd.TargetKindGarden)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings.String()).To(ContainSubstring("contains exec configurations"))
+	})
+
+	It("should be used by targets read via the target reader", func() {
+		clientFactory := mockcmd.NewMockClientFactory(ctrl)
+		reader := &cmd.GardenctlTargetReader{ClientFactory: clientFactory}
+		stack := []cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindSeed, Name: "aws"}}
+		clientFactory.EXPECT().KubeconfigPath(stack, cmd.TargetKindSeed).Return("/tmp/seed.yaml", nil)
+
+		targetFile, err := ioutil.TempFile("", "target")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(targetFile.Name())
+		_, err = targetFile.WriteString("target:\n- kind: garden\n  name: prod\n- kind: seed\n  name: aws\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(targetFile.Close()).To(Succeed())
+
+		target := reader.ReadTarget(targetFile.Name())
+		Expect(target.KubeconfigPathToKind(cmd.TargetKindSeed)).To(Equal("/tmp/seed.yaml"))
+	})
+})
@@ -0,0 +1,241 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configExample = `
+	# Check the configuration for errors.
+	gardenctl config validate
+
+	# Migrate the configuration to the current version, the former file is kept as ~/.garden/config.bak.
+	gardenctl config migrate
+
+	# Add a garden and mark it read-only.
+	gardenctl config add-garden prod ~/clusters/prod/kubeconfig.yaml --dashboard-url https://dashboard.prod.example.com
+	gardenctl config set gardenClusters.prod.readOnly true
+
+	# Add a garden for every kubeconfig in a directory, named after the file.
+	gardenctl config import ~/clusters`
+
+	// configDashboardURL is the dashboard URL of the garden added with config add-garden
+	configDashboardURL string
+)
+
+// NewConfigCmd returns a new config command.
+func NewConfigCmd(ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "config (view|validate|migrate|add-garden|remove-garden|set|import)",
+		Short:        "View, validate and edit the gardenctl configuration, e.g. \"gardenctl config validate\"",
+		Example:      configExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("command must be in the format: config (view|validate|migrate|add-garden|remove-garden|set|import)")
+			}
+			switch args[0] {
+			case "view":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: config view")
+				}
+				return viewConfig(ioStreams, outputFormat)
+			case "validate":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: config validate")
+				}
+				return validateConfig(ioStreams)
+			case "migrate":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: config migrate")
+				}
+				return migrateConfig(ioStreams)
+			case "add-garden":
+				if len(args) != 3 {
+					return errors.New("command must be in the format: config add-garden NAME KUBECONFIG [--dashboard-url URL]")
+				}
+				return addGarden(ioStreams, args[1], args[2], configDashboardURL)
+			case "remove-garden":
+				if len(args) != 2 {
+					return errors.New("command must be in the format: config remove-garden NAME")
+				}
+				return editConfig(func(config *GardenConfig) error {
+					if err := config.RemoveGarden(args[1]); err != nil {
+						return err
+					}
+					fmt.Fprintf(ioStreams.Out, "Removed garden %s\n", args[1])
+					return nil
+				})
+			case "set":
+				if len(args) != 3 {
+					return fmt.Errorf("command must be in the format: config set KEY VALUE, with KEY one of: %s", strings.Join(gardenctl.ConfigKeys, ", "))
+				}
+				return editConfig(func(config *GardenConfig) error {
+					return config.Set(args[1], args[2])
+				})
+			case "import":
+				if len(args) != 2 {
+					return errors.New("command must be in the format: config import DIR")
+				}
+				return importGardens(ioStreams, args[1])
+			}
+
+			return errors.New("command must be in the format: config (view|validate|migrate|add-garden|remove-garden|set|import)")
+		},
+		ValidArgs: []string{"view", "validate", "migrate", "add-garden", "remove-garden", "set", "import"},
+	}
+
+	cmd.PersistentFlags().StringVar(&configDashboardURL, "dashboard-url", "", "dashboard URL of the garden added with add-garden")
+
+	return cmd
+}
+
+// viewConfig prints the configuration
+func viewConfig(ioStreams IOStreams, outputFormat string) error {
+	config, err := gardenctl.LoadConfig(pathGardenConfig)
+	if err != nil {
+		return err
+	}
+	return PrintoutObject(config, ioStreams.Out, outputFormat)
+}
+
+// validateConfig prints the errors of the configuration with their line and fails if there are any
+func validateConfig(ioStreams IOStreams) error {
+	content, err := ioutil.ReadFile(pathGardenConfig)
+	if err != nil {
+		return err
+	}
+	errs := gardenctl.ValidateConfig(content, ioStreams.ErrOut)
+	if len(errs) == 0 {
+		fmt.Fprintf(ioStreams.Out, "Configuration %s is valid\n", pathGardenConfig)
+		return nil
+	}
+	for _, e := range errs {
+		fmt.Fprintln(ioStreams.Out, configErrorLocation(e))
+	}
+	return fmt.Errorf("configuration %s has %d error(s)", pathGardenConfig, len(errs))
+}
+
+// migrateConfig converts the configuration to the current version and keeps a backup of the former file
+func migrateConfig(ioStreams IOStreams) error {
+	backup, version, warnings, err := gardenctl.MigrateConfig(pathGardenConfig)
+	if err != nil {
+		return err
+	}
+	if backup == "" {
+		fmt.Fprintf(ioStreams.Out, "Configuration %s already has version %s\n", pathGardenConfig, version)
+		return nil
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(ioStreams.ErrOut, "Warning: dropped %s\n", configErrorLocation(w))
+	}
+	if version == "" {
+		version = "without apiVersion"
+	}
+	fmt.Fprintf(ioStreams.Out, "Migrated configuration %s from %s to %s, the former file is kept as %s\n", pathGardenConfig, version, gardenctl.ConfigAPIVersion, backup)
+	return nil
+}
+
+// warnConfig prints the warnings about unknown and duplicate keys of the configuration, errors are
+// left to the commands reading the configuration
+func warnConfig(w io.Writer) {
+	_, warnings, err := gardenctl.LoadConfigWithWarnings(pathGardenConfig)
+	if err != nil {
+		return
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "Warning: %s\n", configErrorLocation(warning))
+	}
+}
+
+// configErrorLocation formats an error of the configuration as path:line:column: field: message
+func configErrorLocation(e ConfigError) string {
+	location := pathGardenConfig
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, e.Line)
+	}
+	if e.Line > 0 && e.Column > 0 {
+		location = fmt.Sprintf("%s:%d", location, e.Column)
+	}
+	if e.Path != "" {
+		location += ": " + e.Path
+	}
+	return location + ": " + e.Message
+}
+
+// editConfig applies edit to the configuration and saves it
+func editConfig(edit func(config *GardenConfig) error) error {
+	config, err := gardenctl.LoadConfig(pathGardenConfig)
+	if err != nil {
+		return err
+	}
+	if err = edit(config); err != nil {
+		return err
+	}
+	return gardenctl.SaveConfig(pathGardenConfig, config)
+}
+
+// addGarden adds a garden with the given kubeconfig to the configuration
+func addGarden(ioStreams IOStreams, name, kubeconfig, dashboardURL string) error {
+	if err := gardenctl.ValidateKubeconfig(kubeconfig, ioStreams.ErrOut); err != nil {
+		return err
+	}
+	return editConfig(func(config *GardenConfig) error {
+		if err := config.AddGarden(GardenClusterMeta{Name: name, KubeConfig: kubeconfig, DashboardURL: dashboardURL}); err != nil {
+			return err
+		}
+		fmt.Fprintf(ioStreams.Out, "Added garden %s\n", name)
+		return nil
+	})
+}
+
+// importGardens adds a garden for every kubeconfig in dir, gardens which are already configured are skipped
+func importGardens(ioStreams IOStreams, dir string) error {
+	gardens, skipped, err := gardenctl.ImportGardens(TidyKubeconfigWithHomeDir(dir))
+	if err != nil {
+		return err
+	}
+	var files []string
+	for file := range skipped {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		fmt.Fprintf(ioStreams.ErrOut, "Warning: skipping %s, it is no kubeconfig: %v\n", file, skipped[file])
+	}
+
+	return editConfig(func(config *GardenConfig) error {
+		for _, garden := range gardens {
+			if config.Garden(garden.Name) != nil {
+				fmt.Fprintf(ioStreams.Out, "Skipped garden %s, it is already configured\n", garden.Name)
+				continue
+			}
+			if err := config.AddGarden(garden); err != nil {
+				return err
+			}
+			fmt.Fprintf(ioStreams.Out, "Added garden %s with kubeconfig %s\n", garden.Name, garden.KubeConfig)
+		}
+		return nil
+	})
+}
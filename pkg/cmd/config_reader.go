@@ -14,9 +14,17 @@
 
 package cmd
 
-// ReadConfig reads the configuration.
+import (
+	"fmt"
+	"os"
+)
+
+// ReadConfig reads the configuration, which is empty if the configuration file cannot be read.
 func (r *GardenConfigReader) ReadConfig(gardenConfigPath string) *GardenConfig {
 	var gardenConfig GardenConfig
-	GetGardenConfig(gardenConfigPath, &gardenConfig)
+	if err := GetGardenConfig(gardenConfigPath, &gardenConfig); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read garden config %s: %v\n", gardenConfigPath, err)
+		return &GardenConfig{}
+	}
 	return &gardenConfig
 }
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd_test
+
+import (
+	"github.com/gardener/gardenctl/pkg/cmd"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config command", func() {
+
+	var (
+		command *cobra.Command
+
+		execute = func(command *cobra.Command, args []string) error {
+			command.SetArgs(args)
+			return command.Execute()
+		}
+	)
+
+	BeforeEach(func() {
+		ioStreams, _, _, _ := cmd.NewTestIOStreams()
+		command = cmd.NewConfigCmd(ioStreams)
+	})
+
+	Context("without args", func() {
+		It("should return error", func() {
+			err := execute(command, []string{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("command must be in the format: config (view|validate|migrate|add-garden|remove-garden|set|import)"))
+		})
+	})
+
+	Context("with add-garden and 2 args", func() {
+		It("should return error", func() {
+			err := execute(command, []string{"add-garden", "prod"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("command must be in the format: config add-garden NAME KUBECONFIG [--dashboard-url URL]"))
+		})
+	})
+
+	Context("with set and 2 args", func() {
+		It("should return error listing the keys", func() {
+			err := execute(command, []string{"set", "email"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("command must be in the format: config set KEY VALUE, with KEY one of: email, "))
+		})
+	})
+})
@@ -15,20 +15,16 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
@@ -41,255 +37,343 @@ func NewDiagCmd(reader TargetReader, ioStreams IOStreams) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := reader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
 			}
 
 			shoot, err := FetchShootFromTarget(target)
-			checkError(err)
-			getShootInformation(shoot, target)
-			return nil
+			if err != nil {
+				return err
+			}
+			diagnosis, err := getShootInformation(shoot, target)
+			if err != nil {
+				return err
+			}
+			return PrintoutObject(diagnosis, ioStreams.Out, outputFormatOf(cmd, outputFormatTable))
 		},
 	}
 	return cmd
 }
 
-//getShootInformation prints all information regarding a shoot
-func getShootInformation(shoot *v1beta1.Shoot, target TargetInterface) {
-	fmt.Println("The shoot diagnostic information are as follows:")
-	fmt.Println()
-	fmt.Println("Shoot: " + shoot.Name)
-	fmt.Println("Kubernetes Version: " + shoot.Spec.Kubernetes.Version)
-	fmt.Println("Created At: " + shoot.ObjectMeta.CreationTimestamp.String())
-	annotationsMap := shoot.GetObjectMeta().GetAnnotations()
-	fmt.Println("Created By: " + annotationsMap["gardener.cloud/created-by"])
-	fmt.Println("Cloud Profile: " + shoot.Spec.CloudProfileName)
-	fmt.Println("Region: " + shoot.Spec.Region)
-	fmt.Println("Purpose: " + *shoot.Spec.Purpose)
-	fmt.Println("Seed Name: " + *shoot.Status.SeedName)
-	fmt.Println()
-
-	fmt.Println("Last Operation:")
-	fmt.Println()
-	lastOperationMsg := []string{shoot.Status.LastOperation.Description, fmt.Sprintf("%v", shoot.Status.LastOperation.Type), fmt.Sprintf("%v", shoot.Status.LastOperation.State)}
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Description", "Last Operation Type", "Last Operation Type State"})
-	table.Append(lastOperationMsg)
-	table.Render()
-	fmt.Println()
-
-	fmt.Println("Shoot Conditions: ")
-	fmt.Println()
-	data := [][]string{}
-	for i := range shoot.Status.Conditions {
-		condition := shoot.Status.Conditions[i]
-		codesValue := condition.Codes
-		codesString := fmt.Sprintf("%v", codesValue)
-		data = append(data, []string{condition.Message, condition.LastTransitionTime.String(), codesString})
-	}
-	table = tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Message", "Last Transition Time", "Codes"})
-	for _, v := range data {
-		table.Append(v)
-	}
-	table.Render()
-	fmt.Println()
-	fmt.Println("Workers Groups:")
-	fmt.Println()
-	data = [][]string{}
-	table = tablewriter.NewWriter(os.Stdout)
-	for w := range shoot.Spec.Provider.Workers {
-		worker := shoot.Spec.Provider.Workers[w]
-		if worker.Volume != nil {
-			data = append(data, []string{worker.Name, strconv.Itoa(int(worker.Minimum)), strconv.Itoa(int(worker.Maximum)), fmt.Sprintf("%v", worker.MaxUnavailable), fmt.Sprintf("%v", worker.MaxSurge), worker.Machine.Image.Name, *worker.Machine.Image.Version, worker.Machine.Type, fmt.Sprintf("%v", worker.Zones), fmt.Sprintf("%v", worker.Volume.Name), fmt.Sprintf("%v", worker.Volume.Type), worker.Volume.VolumeSize})
-			table.SetHeader([]string{"Worker Name", "Min", "Max", "Max Unavailable", "Max Surge", "Image Name", "Image Version", "Image Type", "Zones", "Volume Name", "Volume Type", "Volume Size"})
-		} else {
-			data = append(data, []string{worker.Name, strconv.Itoa(int(worker.Minimum)), strconv.Itoa(int(worker.Maximum)), fmt.Sprintf("%v", worker.MaxUnavailable), fmt.Sprintf("%v", worker.MaxSurge), worker.Machine.Image.Name, *worker.Machine.Image.Version, worker.Machine.Type, fmt.Sprintf("%v", worker.Zones)})
-			table.SetHeader([]string{"Worker Name", "Min", "Max", "Max Unavailable", "Max Surge", "Image Name", "Image Version", "Image Type", "Zones"})
+//getShootInformation collects all information regarding a shoot
+func getShootInformation(shoot *v1beta1.Shoot, target TargetInterface) (*Diagnosis, error) {
+	diagnosis := &Diagnosis{
+		Shoot: DiagnosisShootMeta{
+			Name:              shoot.Name,
+			KubernetesVersion: shoot.Spec.Kubernetes.Version,
+			CreationTimestamp: shoot.CreationTimestamp.Time,
+			CreatedBy:         shoot.Annotations["gardener.cloud/created-by"],
+			CloudProfile:      shoot.Spec.CloudProfileName,
+			Region:            shoot.Spec.Region,
+			Hibernated:        shoot.Status.IsHibernated,
+		},
+	}
+	if shoot.Spec.Purpose != nil {
+		diagnosis.Shoot.Purpose = string(*shoot.Spec.Purpose)
+	}
+	if shoot.Status.SeedName != nil {
+		diagnosis.Shoot.Seed = *shoot.Status.SeedName
+	}
+	if op := shoot.Status.LastOperation; op != nil {
+		diagnosis.LastOperation = &LastOperationMeta{
+			Description:    op.Description,
+			LastUpdateTime: op.LastUpdateTime.String(),
+			Progress:       int(op.Progress),
+			State:          string(op.State),
+			Type:           string(op.Type),
 		}
 	}
-	for _, v := range data {
-		table.Append(v)
+	now := time.Now()
+	for _, condition := range shoot.Status.Conditions {
+		diagnosis.Conditions = append(diagnosis.Conditions, toConditionMeta(condition, now))
 	}
-	table.Render()
-
-	if !shoot.Status.IsHibernated {
-		var err error
-		var shootClient kubernetes.Interface
-		if shootClient, err = target.K8SClientToKind(TargetKindShoot); err != nil {
-			checkError(err)
+	for _, worker := range shoot.Spec.Provider.Workers {
+		wm := DiagnosisWorkerMeta{
+			Name:        worker.Name,
+			Minimum:     worker.Minimum,
+			Maximum:     worker.Maximum,
+			MachineType: worker.Machine.Type,
+			Zones:       worker.Zones,
+		}
+		if worker.MaxUnavailable != nil {
+			wm.MaxUnavailable = worker.MaxUnavailable.String()
 		}
-		var nodes *corev1.NodeList
-		if nodes, err = shootClient.CoreV1().Nodes().List(metav1.ListOptions{}); err != nil {
-			checkError(err)
+		if worker.MaxSurge != nil {
+			wm.MaxSurge = worker.MaxSurge.String()
 		}
+		if image := worker.Machine.Image; image != nil {
+			wm.ImageName = image.Name
+			if image.Version != nil {
+				wm.ImageVersion = *image.Version
+			}
+		}
+		if volume := worker.Volume; volume != nil {
+			wm.VolumeSize = volume.VolumeSize
+			if volume.Name != nil {
+				wm.VolumeName = *volume.Name
+			}
+			if volume.Type != nil {
+				wm.VolumeType = *volume.Type
+			}
+		}
+		diagnosis.Workers = append(diagnosis.Workers, wm)
+	}
 
-		kubeconfig, err := ioutil.ReadFile(*kubeconfig)
-		checkError(err)
-		clientConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
-		checkError(err)
-		config, err := clientConfig.ClientConfig()
-		checkError(err)
-		metricsClientset, err := metricsv.NewForConfig(config)
-		checkError(err)
-		nodeMetricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(metav1.ListOptions{})
-		checkError(err)
-		fmt.Println()
-		fmt.Println("Node Metrics:")
-		fmt.Println()
-		data = [][]string{}
-		for _, metric := range nodeMetricsList.Items {
-			cpuUsage, _ := metric.Usage.Cpu().AsInt64()
-			memUsage, _ := metric.Usage.Memory().AsInt64()
+	if shoot.Status.IsHibernated {
+		return diagnosis, nil
+	}
 
-			data = append(data, []string{metric.GetName(), fmt.Sprintf("%v", cpuUsage), fmt.Sprintf("%v", memUsage/1000)})
-		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Node Name", "CPU Usage (Core)", "Memory Usage (MB)"})
+	shootClient, err := target.K8SClientToKind(TargetKindShoot)
+	if err != nil {
+		return nil, err
+	}
+	config, err := target.RESTConfigToKind(TargetKindShoot)
+	if err != nil {
+		return nil, err
+	}
+	metricsClientset, err := metricsv.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	nodeMetricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, metric := range nodeMetricsList.Items {
+		diagnosis.NodeMetrics = append(diagnosis.NodeMetrics, NodeMetricsMeta{
+			Name:           metric.Name,
+			CPUMilliCores:  metric.Usage.Cpu().MilliValue(),
+			MemoryMebibyte: metric.Usage.Memory().Value() / (1 << 20),
+		})
+	}
+
+	systemPods, err := shootClient.CoreV1().Pods("kube-system").List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range systemPods.Items {
+		diagnosis.SystemComponents = append(diagnosis.SystemComponents, toPodMeta(pod))
+	}
+
+	daemonSets, err := shootClient.AppsV1().DaemonSets("kube-system").List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range daemonSets.Items {
+		diagnosis.DaemonSets = append(diagnosis.DaemonSets, DaemonSetMeta{
+			Name:      ds.Name,
+			Desired:   ds.Status.DesiredNumberScheduled,
+			Available: ds.Status.NumberAvailable,
+		})
+	}
 
-		for _, v := range data {
-			table.Append(v)
+	nodes, err := shootClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		nm := NodeMeta{
+			Name:           node.Name,
+			ProviderID:     node.Spec.ProviderID,
+			CPUCores:       node.Status.Capacity.Cpu().Value(),
+			MemoryMebibyte: node.Status.Capacity.Memory().Value() / (1 << 20),
 		}
-		table.Render()
-		fmt.Println()
-		fmt.Println("System Components:")
-		fmt.Println()
-		systemPods, err := shootClient.CoreV1().Pods("kube-system").List(metav1.ListOptions{})
-		checkError(err)
-		data := [][]string{}
-		for _, pod := range systemPods.Items {
-			readyNumber := 0
-			totalNumber := 0
-			for pss := range pod.Status.ContainerStatuses {
-				if pod.Status.ContainerStatuses[pss].Ready {
-					readyNumber++
-				}
-				if pod.Status.ContainerStatuses[pss].State.Terminated == nil {
-					totalNumber++
-				}
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				nm.InternalIP = address.Address
 			}
-			podstatusPhase := string(pod.Status.Phase)
-			podCreationTime := pod.GetCreationTimestamp()
-			age := time.Since(podCreationTime.Time).Round(time.Second)
-			timeString := fmt.Sprintf("%v", podCreationTime)
+		}
+		diagnosis.Nodes = append(diagnosis.Nodes, nm)
+	}
 
-			data = append(data, []string{pod.GetName(), podstatusPhase, strconv.Itoa(readyNumber), strconv.Itoa(totalNumber), timeString, age.String()})
+	pdbs, err := shootClient.PolicyV1beta1().PodDisruptionBudgets("kube-system").List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pdb := range pdbs.Items {
+		pm := PodDisruptionBudgetMeta{Name: pdb.Name}
+		if pdb.Spec.MinAvailable != nil {
+			pm.MinAvailable = pdb.Spec.MinAvailable.String()
 		}
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Name", "Phase", "Ready", "Total", "Created", "Age"})
-		for _, v := range data {
-			table.Append(v)
+		if pdb.Spec.MaxUnavailable != nil {
+			pm.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
 		}
-		table.Render()
+		diagnosis.PodDisruptionBudgets = append(diagnosis.PodDisruptionBudgets, pm)
+	}
 
-		daemonSets, err := shootClient.AppsV1().DaemonSets("kube-system").List(metav1.ListOptions{})
-		checkError(err)
-		fmt.Println()
-		fmt.Println("DaemonSets:")
-		fmt.Println()
-		data = [][]string{}
-		for _, ds := range daemonSets.Items {
-			data = append(data, []string{ds.GetName(), strconv.Itoa(int(ds.Status.DesiredNumberScheduled)), strconv.Itoa(int(ds.Status.NumberAvailable))})
-		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Name", "Desired Number", "Current Number"})
-		for _, v := range data {
-			table.Append(v)
+	mutatingWebhookConfigurations, err := shootClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, mwc := range mutatingWebhookConfigurations.Items {
+		for _, webhook := range mwc.Webhooks {
+			diagnosis.MutatingWebhooks = append(diagnosis.MutatingWebhooks, webhook.Name)
 		}
-		table.Render()
+	}
 
-		fmt.Println()
-		fmt.Println("Nodes:")
-		fmt.Println()
-		data = [][]string{}
-		for _, n := range nodes.Items {
-			addString := ""
-			for a := range n.Status.Addresses {
-				if n.Status.Addresses[a].Type == "InternalIP" {
-					addString = n.Status.Addresses[a].Address
-				}
+	seedClient, err := target.K8SClientToKind(TargetKindSeed)
+	if err != nil {
+		return nil, err
+	}
+	controlPlanePods, err := seedClient.CoreV1().Pods(shoot.Status.TechnicalID).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range controlPlanePods.Items {
+		diagnosis.ControlPlanePods = append(diagnosis.ControlPlanePods, toPodMeta(pod))
+	}
+	return diagnosis, nil
+}
+
+// PrintTable prints the diagnosis as a table per section
+func (d Diagnosis) PrintTable(w io.Writer, wide bool) error {
+	now := time.Now()
+	fmt.Fprintln(w, "Shoot: "+d.Shoot.Name)
+	fmt.Fprintln(w, "Kubernetes Version: "+d.Shoot.KubernetesVersion)
+	fmt.Fprintln(w, "Created At: "+d.Shoot.CreationTimestamp.String())
+	fmt.Fprintln(w, "Created By: "+orDash(d.Shoot.CreatedBy))
+	fmt.Fprintln(w, "Cloud Profile: "+d.Shoot.CloudProfile)
+	fmt.Fprintln(w, "Region: "+d.Shoot.Region)
+	fmt.Fprintln(w, "Purpose: "+orDash(d.Shoot.Purpose))
+	fmt.Fprintln(w, "Seed Name: "+orDash(d.Shoot.Seed))
+	fmt.Fprintln(w)
+
+	type section struct {
+		title string
+		print func(w io.Writer) error
+	}
+	sections := []section{
+		{"Last Operation", func(w io.Writer) error {
+			var rows [][]string
+			if op := d.LastOperation; op != nil {
+				rows = append(rows, []string{op.Type, op.State, fmt.Sprintf("%d%%", op.Progress), op.Description})
 			}
-			cpuCount, _ := n.Status.Capacity.Cpu().AsInt64()
-			memoryCount, _ := n.Status.Capacity.Memory().AsInt64()
-			data = append(data, []string{n.Name, n.Spec.ProviderID, addString, strconv.Itoa(int(cpuCount)), strconv.Itoa(int(memoryCount / 1000))})
-		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Node Name", "Provider ID", "Address", "CPU Cores", "Memory (MB)"})
-		for _, v := range data {
-			table.Append(v)
-		}
-		table.Render()
-		fmt.Println()
-		fmt.Println("BlockingDisruptionBudgets:")
-		fmt.Println()
-		dbds, err := shootClient.PolicyV1beta1().PodDisruptionBudgets("kube-system").List(metav1.ListOptions{})
-		checkError(err)
-		data = [][]string{}
-		for dbdsIndex := range dbds.Items {
-			pdb := dbds.Items[dbdsIndex]
-			data = append(data, []string{pdb.Spec.Selector.MatchLabels["k8s-app"], fmt.Sprintf("%v", pdb.Spec.MinAvailable), fmt.Sprintf("%v", pdb.Spec.MaxUnavailable)})
-		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Name", "Min Available", "Max Unavailable"})
-		for _, v := range data {
-			table.Append(v)
-		}
-		table.Render()
-		fmt.Println()
-		fmt.Println("MutatingWebhookConfigurations:")
-		fmt.Println()
-		data = [][]string{}
-		mutatingWebhookConfigurations, err := shootClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
-		checkError(err)
-		for mwc := range mutatingWebhookConfigurations.Items {
-			whList := mutatingWebhookConfigurations.Items[mwc].Webhooks
-			for whIndex := range whList {
-				wh := whList[whIndex]
-				data = append(data, []string{wh.Name})
+			return printTable(w, []string{"TYPE", "STATE", "PROGRESS", "DESCRIPTION"}, rows)
+		}},
+		{"Shoot Conditions", func(w io.Writer) error {
+			var rows [][]string
+			for _, c := range d.Conditions {
+				rows = append(rows, []string{c.Type, c.Status, orDash(strings.Join(c.Codes, ",")), formatAge(c.LastTransitionTime, now), c.Message})
 			}
-		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"MutatingWebhookConfiguration Name"})
-
-		for _, v := range data {
-			table.Append(v)
-		}
-		table.Render()
-		seedClient, err := target.K8SClientToKind(TargetKindSeed)
-		checkError(err)
-		controlPlanePods, err := seedClient.CoreV1().Pods(shoot.Status.TechnicalID).List(metav1.ListOptions{})
-		checkError(err)
-		fmt.Println()
-		fmt.Println("Control Plane Pods:")
-		fmt.Println()
-		data = [][]string{}
-		for _, pod := range controlPlanePods.Items {
-			readyNumber := 0
-			totalNumber := 0
-			for pss := range pod.Status.ContainerStatuses {
-				if pod.Status.ContainerStatuses[pss].Ready {
-					readyNumber++
-				}
-				if pod.Status.ContainerStatuses[pss].State.Terminated == nil {
-					totalNumber++
+			return printTable(w, []string{"TYPE", "STATUS", "CODES", "SINCE", "MESSAGE"}, rows)
+		}},
+		{"Workers Groups", func(w io.Writer) error {
+			header := []string{"NAME", "MIN", "MAX", "MACHINE TYPE", "IMAGE", "ZONES"}
+			if wide {
+				header = append(header, "MAX UNAVAILABLE", "MAX SURGE", "VOLUME NAME", "VOLUME TYPE", "VOLUME SIZE")
+			}
+			var rows [][]string
+			for _, worker := range d.Workers {
+				row := []string{worker.Name, fmt.Sprint(worker.Minimum), fmt.Sprint(worker.Maximum), worker.MachineType,
+					orDash(strings.Trim(worker.ImageName+" "+worker.ImageVersion, " ")), orDash(strings.Join(worker.Zones, ","))}
+				if wide {
+					row = append(row, orDash(worker.MaxUnavailable), orDash(worker.MaxSurge), orDash(worker.VolumeName), orDash(worker.VolumeType), orDash(worker.VolumeSize))
 				}
+				rows = append(rows, row)
 			}
-			podstatusPhase := string(pod.Status.Phase)
-			podCreationTime := pod.GetCreationTimestamp()
-			age := time.Since(podCreationTime.Time).Round(time.Second)
-			data = append(data, []string{pod.GetName(), podstatusPhase, strconv.Itoa(readyNumber), strconv.Itoa(totalNumber), fmt.Sprintf("%v", podCreationTime), age.String()})
+			return printTable(w, header, rows)
+		}},
+	}
+	if !d.Shoot.Hibernated {
+		sections = append(sections, []section{
+			{"Node Metrics", func(w io.Writer) error {
+				var rows [][]string
+				for _, m := range d.NodeMetrics {
+					rows = append(rows, []string{m.Name, fmt.Sprintf("%dm", m.CPUMilliCores), fmt.Sprintf("%dMi", m.MemoryMebibyte)})
+				}
+				return printTable(w, []string{"NAME", "CPU", "MEMORY"}, rows)
+			}},
+			{"System Components", func(w io.Writer) error {
+				return Pods{Pods: d.SystemComponents}.PrintTable(w, wide)
+			}},
+			{"DaemonSets", func(w io.Writer) error {
+				var rows [][]string
+				for _, ds := range d.DaemonSets {
+					rows = append(rows, []string{ds.Name, fmt.Sprint(ds.Desired), fmt.Sprint(ds.Available)})
+				}
+				return printTable(w, []string{"NAME", "DESIRED", "AVAILABLE"}, rows)
+			}},
+			{"Nodes", func(w io.Writer) error {
+				var rows [][]string
+				for _, n := range d.Nodes {
+					rows = append(rows, []string{n.Name, orDash(n.InternalIP), fmt.Sprint(n.CPUCores), fmt.Sprintf("%dMi", n.MemoryMebibyte), orDash(n.ProviderID)})
+				}
+				return printTable(w, []string{"NAME", "INTERNAL IP", "CPU", "MEMORY", "PROVIDER ID"}, rows)
+			}},
+			{"PodDisruptionBudgets", func(w io.Writer) error {
+				var rows [][]string
+				for _, pdb := range d.PodDisruptionBudgets {
+					rows = append(rows, []string{pdb.Name, orDash(pdb.MinAvailable), orDash(pdb.MaxUnavailable)})
+				}
+				return printTable(w, []string{"NAME", "MIN AVAILABLE", "MAX UNAVAILABLE"}, rows)
+			}},
+			{"MutatingWebhookConfigurations", func(w io.Writer) error {
+				var rows [][]string
+				for _, name := range d.MutatingWebhooks {
+					rows = append(rows, []string{name})
+				}
+				return printTable(w, []string{"NAME"}, rows)
+			}},
+			{"Control Plane Pods", func(w io.Writer) error {
+				return Pods{Pods: d.ControlPlanePods}.PrintTable(w, wide)
+			}},
+		}...)
+	}
+	for _, section := range sections {
+		if err := printSection(w, section.title, section.print); err != nil {
+			return err
 		}
-		table = tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"Name", "Phase", "Ready Number", "Total Number", "Creation Time", "Age"})
+	}
+	if d.Shoot.Hibernated {
+		fmt.Fprintln(w, "This shoot is now in hibernating status")
+		fmt.Fprintln(w, "Information like Nodes/Metrics/PDBs/Web hooks/etc will not be displayed")
+	}
+	return nil
+}
 
-		for _, v := range data {
-			table.Append(v)
+// toPodMeta returns the printed information of a pod
+func toPodMeta(pod corev1.Pod) PodMeta {
+	pm := PodMeta{
+		Name:              pod.Name,
+		Namespace:         pod.Namespace,
+		Status:            string(pod.Status.Phase),
+		CreationTimestamp: pod.CreationTimestamp.Time,
+		IP:                pod.Status.PodIP,
+		Node:              pod.Spec.NodeName,
+	}
+	if pod.Status.Reason != "" {
+		pm.Status = pod.Status.Reason
+	}
+	if pod.DeletionTimestamp != nil {
+		pm.Status = "Terminating"
+	}
+	ready := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+		pm.Restarts += status.RestartCount
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			pm.Status = waiting.Reason
 		}
-		table.Render()
-
-	} else {
-		fmt.Println()
-		fmt.Println("This shoot is now in hibernating status")
-		fmt.Println("Information like Nodes/Metrics/PDBs/Web hooks/etc will not be displayed")
 	}
+	pm.Ready = strconv.Itoa(ready) + "/" + strconv.Itoa(len(pod.Spec.Containers))
+	return pm
+}
 
+// PrintTable prints the pods as table, wide adds the ip and the node
+func (p Pods) PrintTable(w io.Writer, wide bool) error {
+	now := time.Now()
+	header := []string{"NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE"}
+	if wide {
+		header = append(header, "IP", "NODE")
+	}
+	var rows [][]string
+	for _, pod := range p.Pods {
+		row := []string{pod.Namespace, pod.Name, pod.Ready, orDash(pod.Status), fmt.Sprint(pod.Restarts), formatAge(pod.CreationTimestamp, now)}
+		if wide {
+			row = append(row, orDash(pod.IP), orDash(pod.Node))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
 }
@@ -23,7 +23,6 @@ import (
 	"strings"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s "k8s.io/client-go/kubernetes"
@@ -31,7 +30,7 @@ import (
 )
 
 // NewDownloadCmd returns a new download command.
-func NewDownloadCmd(targetReader TargetReader) *cobra.Command {
+func NewDownloadCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:          "download tf + (infra|internal-dns|external-dns|ingress|backup)\n  gardenctl download logs vpn\n ",
 		Short:        "Download terraform configuration/state for local execution for the targeted shoot or log files, e.g. \"gardenctl download logs vpn\" to download vpn logs",
@@ -40,14 +39,20 @@ func NewDownloadCmd(targetReader TargetReader) *cobra.Command {
 			if len(args) != 2 || !(args[1] == "infra" || args[1] == "internal-dns" || args[1] == "external-dns" || args[1] == "ingress" || args[1] == "backup" || args[1] == "vpn") {
 				return errors.New("Command must be in the format:\n  download tf + (infra|internal-dns|external-dns|ingress|backup)\n  download logs vpn")
 			}
+			if err := enforceTargetAccessRestrictions(targetReader.ReadTarget(pathTarget), configReader, ioStreams); err != nil {
+				return err
+			}
 			switch args[0] {
 			case "tf":
-				path := downloadTerraformFiles(args[1], targetReader)
+				path, err := downloadTerraformFiles(args[1], targetReader)
+				if err != nil {
+					return err
+				}
 				fmt.Println("Downloaded to " + path)
 			case "logs":
-				downloadLogs(args[1], targetReader)
+				return downloadLogs(args[1], targetReader)
 			default:
-				fmt.Println("Command must be in the format:\n  download tf + (infra|internal-dns|external-dns|ingress|backup)\n  download logs vpn")
+				return errors.New("Command must be in the format:\n  download tf + (infra|internal-dns|external-dns|ingress|backup)\n  download logs vpn")
 			}
 			return nil
 		},
@@ -56,136 +61,168 @@ func NewDownloadCmd(targetReader TargetReader) *cobra.Command {
 }
 
 // downloadTerraformFiles downloads the corresponding tf file
-func downloadTerraformFiles(option string, targetReader TargetReader) string {
+func downloadTerraformFiles(option string, targetReader TargetReader) (string, error) {
 	namespace := ""
 	target := targetReader.ReadTarget(pathTarget)
+	role, err := getRole(targetReader)
+	if err != nil {
+		return "", err
+	}
 	// return path allow non operator download key file
-	if getRole(targetReader) == "user" {
+	if role == "user" {
 		if (len(target.Stack()) < 3) || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace") {
-			fmt.Println("No Shoot targeted")
-			os.Exit(2)
+			return "", NewNotTargetedError(TargetKindShoot)
 		} else if target.Stack()[1].Kind == "seed" {
-			fmt.Println("Currently target stack is garden/seed/shoot which doesn't allow user role to access shoot via seed")
-			fmt.Println("Please target shoot via `gardenctl target shoot` directly or target shoot via project")
-			os.Exit(2)
+			return "", NewForbiddenError("the target stack garden/seed/shoot doesn't allow user role to access shoot via seed, please target shoot via `gardenctl target shoot` directly or target shoot via project")
 		}
 		gardenName := target.Stack()[0].Name
 		projectName := target.Stack()[1].Name
 		shootName := target.Stack()[2].Name
 		pathTerraform := filepath.Join(pathGardenHome, "cache", gardenName, "projects", projectName, shootName)
-		return filepath.Join(pathGardenHome, pathTerraform)
+		return filepath.Join(pathGardenHome, pathTerraform), nil
 	}
 
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
+	client, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
 	gardenName := target.Stack()[0].Name
 	pathSeedCache := filepath.Join("cache", gardenName, "seeds")
 	pathProjectCache := filepath.Join("cache", gardenName, "projects")
 	if (len(target.Stack()) < 3 && (option == "infra" || option == "internal-dns" || option == "external-dns" || option == "ingress" || option == "backup")) || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace") {
-		fmt.Println("No Shoot targeted")
-		os.Exit(2)
+		return "", NewNotTargetedError(TargetKindShoot)
 	} else if len(target.Stack()) < 3 {
-		fmt.Println("Command must be in the format:\n download tf + (infra|internal-dns|external-dns|ingress|backup)\n  download logs vpn")
-		os.Exit(2)
+		return "", errors.New("Command must be in the format:\n download tf + (infra|internal-dns|external-dns|ingress|backup)\n  download logs vpn")
 	} else {
-		var err error
-		Client, err = clientToTarget("garden")
-		checkError(err)
-		gardenClientset, err := gardencoreclientset.NewForConfig(NewConfigFromBytes(*kubeconfig))
-		checkError(err)
+		gardenClientset, err := target.GardenerClient()
+		if err != nil {
+			return "", err
+		}
 		var shoot *gardencorev1beta1.Shoot
 		if target.Stack()[1].Kind == "project" {
 			project, err := gardenClientset.CoreV1beta1().Projects().Get(target.Stack()[1].Name, metav1.GetOptions{})
-			checkError(err)
+			if err != nil {
+				return "", err
+			}
 			shoot, err = gardenClientset.CoreV1beta1().Shoots(*project.Spec.Namespace).Get(target.Stack()[2].Name, metav1.GetOptions{})
-			checkError(err)
+			if err != nil {
+				return "", err
+			}
 		} else {
 			shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-			checkError(err)
+			if err != nil {
+				return "", err
+			}
 			for index, s := range shootList.Items {
-				if s.Name == target.Stack()[2].Name && *s.Spec.SeedName == target.Stack()[1].Name {
+				if s.Name == target.Stack()[2].Name && s.Spec.SeedName != nil && *s.Spec.SeedName == target.Stack()[1].Name {
 					shoot = &shootList.Items[index]
 					break
 				}
 			}
+			if shoot == nil {
+				return "", NewNotFoundError("shoot %q not found on seed %q", target.Stack()[2].Name, target.Stack()[1].Name)
+			}
 		}
 		namespace = shoot.Status.TechnicalID
 		seed, err := gardenClientset.CoreV1beta1().Seeds().Get(*shoot.Spec.SeedName, metav1.GetOptions{})
-		checkError(err)
-		kubeSecret, err := Client.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
+		kubeSecret, err := client.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
 		pathSeed := filepath.Join(pathGardenHome, pathSeedCache, seed.Spec.SecretRef.Name)
-		pathToKubeconfig := filepath.Join(pathSeed, "kubeconfig.yaml")
-		err = os.MkdirAll(pathSeed, os.ModePerm)
-		checkError(err)
-		err = ioutil.WriteFile(pathToKubeconfig, kubeSecret.Data["kubeconfig"], 0644)
-		checkError(err)
-		KUBECONFIG = pathToKubeconfig
+		pathToKubeconfig, err := writeCacheFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"])
+		if err != nil {
+			return "", err
+		}
 		config, err := clientcmd.BuildConfigFromFlags("", pathToKubeconfig)
-		checkError(err)
-		Client, err = k8s.NewForConfig(config)
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
+		client, err = k8s.NewForConfig(config)
+		if err != nil {
+			return "", err
+		}
+	}
+	cmTfConfig, err := client.CoreV1().ConfigMaps(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-config"), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	cmTfState, err := client.CoreV1().ConfigMaps(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-state"), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	secret, err := client.CoreV1().Secrets(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-vars"), metav1.GetOptions{})
+	if err != nil {
+		return "", err
 	}
-	cmTfConfig, err := Client.CoreV1().ConfigMaps(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-config"), metav1.GetOptions{})
-	checkError(err)
-	cmTfState, err := Client.CoreV1().ConfigMaps(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-state"), metav1.GetOptions{})
-	checkError(err)
-	secret, err := Client.CoreV1().Secrets(namespace).Get((target.Stack()[2].Name + "." + option + ".tf-vars"), metav1.GetOptions{})
-	checkError(err)
 	pathTerraform := ""
 	if target.Stack()[1].Kind == "project" {
-		CreateDir(filepath.Join(pathGardenHome, pathProjectCache, target.Stack()[1].Name, target.Stack()[2].Name, "terraform"), 0751)
-		pathTerraform = filepath.Join("cache", gardenName, "projects", target.Stack()[1].Name, target.Stack()[2].Name, "terraform")
-
+		pathTerraform = filepath.Join(pathGardenHome, pathProjectCache, target.Stack()[1].Name, target.Stack()[2].Name, "terraform")
 	} else if target.Stack()[1].Kind == "seed" {
-		CreateDir(filepath.Join(pathGardenHome, pathSeedCache, target.Stack()[1].Name, target.Stack()[2].Name, "terraform"), 0751)
-		pathTerraform = filepath.Join("cache", gardenName, "seeds", target.Stack()[1].Name, target.Stack()[2].Name, "terraform")
+		pathTerraform = filepath.Join(pathGardenHome, pathSeedCache, target.Stack()[1].Name, target.Stack()[2].Name, "terraform")
+	}
+	_, err = writeCacheFile(filepath.Join(pathTerraform, "main.tf"), []byte(cmTfConfig.Data["main.tf"]))
+	if err != nil {
+		return "", err
+	}
+	_, err = writeCacheFile(filepath.Join(pathTerraform, "variables.tf"), []byte(cmTfConfig.Data["variables.tf"]))
+	if err != nil {
+		return "", err
+	}
+	_, err = writeCacheFile(filepath.Join(pathTerraform, "terraform.tfstate"), []byte(cmTfState.Data["terraform.tfstate"]))
+	if err != nil {
+		return "", err
+	}
+	_, err = writeCacheFile(filepath.Join(pathTerraform, "terraform.tfvars"), []byte(secret.Data["terraform.tfvars"]))
+	if err != nil {
+		return "", err
 	}
-	err = ioutil.WriteFile(filepath.Join(pathGardenHome, pathTerraform, "main.tf"), []byte(cmTfConfig.Data["main.tf"]), 0644)
-	checkError(err)
-	err = ioutil.WriteFile(filepath.Join(pathGardenHome, pathTerraform, "variables.tf"), []byte(cmTfConfig.Data["variables.tf"]), 0644)
-	checkError(err)
-	err = ioutil.WriteFile(filepath.Join(pathGardenHome, pathTerraform, "terraform.tfstate"), []byte(cmTfState.Data["terraform.tfstate"]), 0644)
-	checkError(err)
-	err = ioutil.WriteFile(filepath.Join(pathGardenHome, pathTerraform, "terraform.tfvars"), []byte(secret.Data["terraform.tfvars"]), 0644)
-	checkError(err)
-	return filepath.Join(pathGardenHome, pathTerraform)
+	return cachedPath(pathTerraform)
 }
 
-func downloadLogs(option string, targetReader TargetReader) {
+func downloadLogs(option string, targetReader TargetReader) error {
 	dir, err := os.Getwd()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	target := targetReader.ReadTarget(pathTarget)
-	Client, err = clientToTarget("garden")
-	checkError(err)
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
 	gardenName := target.Stack()[0].Name
 	pathSeedCache := filepath.Join("cache", gardenName, "seeds")
-	gardenClientset, err := gardencoreclientset.NewForConfig(NewConfigFromBytes(*kubeconfig))
-	checkError(err)
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return err
+	}
 	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	for _, shoot := range shootList.Items {
+		if shoot.Spec.SeedName == nil {
+			continue
+		}
 		seed, err := gardenClientset.CoreV1beta1().Seeds().Get(*shoot.Spec.SeedName, metav1.GetOptions{})
 		if err != nil {
 			fmt.Println("Could not get seed")
 			continue
 		}
-		kubeSecret, err := Client.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
+		kubeSecret, err := gardenClient.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
 		if err != nil {
 			fmt.Println("Could not get kubeSecret")
 			continue
 		}
 		pathSeed := filepath.Join(pathGardenHome, pathSeedCache, seed.Spec.SecretRef.Name)
-		err = os.MkdirAll(pathSeed, os.ModePerm)
-		checkError(err)
-		err = ioutil.WriteFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"], 0644)
+		pathToKubeconfig, err := writeCacheFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"])
 		if err != nil {
 			fmt.Println("Could not write logs")
 			continue
 		}
-		pathToKubeconfig := filepath.Join(pathGardenHome, pathSeedCache, seed.Spec.SecretRef.Name, "kubeconfig.yaml")
-		KUBECONFIG = pathToKubeconfig
 		config, err := clientcmd.BuildConfigFromFlags("", pathToKubeconfig)
 		if err != nil {
 			fmt.Println("Could not build config")
@@ -201,11 +238,13 @@ func downloadLogs(option string, targetReader TargetReader) {
 			fmt.Println("Shoot " + shoot.Name + " has no pods in " + shoot.Status.TechnicalID + " namespace")
 			continue
 		}
-		CreateDir(filepath.Join(dir, "seeds", *shoot.Spec.SeedName, shoot.ObjectMeta.GetNamespace(), shoot.Name, "logs", "vpn"), 0751)
+		if err := CreateDir(filepath.Join(dir, "seeds", *shoot.Spec.SeedName, shoot.ObjectMeta.GetNamespace(), shoot.Name, "logs", "vpn"), 0751); err != nil {
+			return err
+		}
 		pathLogsSeeds := filepath.Join(dir, "seeds", *shoot.Spec.SeedName, shoot.ObjectMeta.GetNamespace(), shoot.Name, "logs", "vpn")
 		for _, pod := range pods.Items {
 			if strings.Contains(pod.Name, "prometheus-0") {
-				args := []string{"--kubeconfig=" + KUBECONFIG, "logs", pod.Name, "-c", "prometheus", "-n", shoot.Status.TechnicalID}
+				args := []string{"--kubeconfig=" + pathToKubeconfig, "logs", pod.Name, "-c", "prometheus", "-n", shoot.Status.TechnicalID}
 				fmt.Println(strings.Join(append([]string{"kubectl"}, args...), " "))
 				output, err := ExecCmdReturnOutput("kubectl", args...)
 				if err != nil {
@@ -219,7 +258,7 @@ func downloadLogs(option string, targetReader TargetReader) {
 				}
 			}
 			if strings.Contains(pod.Name, "kube-apiserver") {
-				args := []string{"--kubeconfig=" + KUBECONFIG, "logs", pod.Name, "-c", "vpn-seed", "-n", shoot.Status.TechnicalID}
+				args := []string{"--kubeconfig=" + pathToKubeconfig, "logs", pod.Name, "-c", "vpn-seed", "-n", shoot.Status.TechnicalID}
 				fmt.Println(strings.Join(append([]string{"kubectl"}, args...), " "))
 				output, err := ExecCmdReturnOutput("kubectl", args...)
 				if err != nil {
@@ -240,22 +279,21 @@ func downloadLogs(option string, targetReader TargetReader) {
 			continue
 		}
 		pathShootKubeconfig := filepath.Join(pathGardenHome, pathSeedCache, seed.Name, shoot.Name)
-		err = os.MkdirAll(pathShootKubeconfig, os.ModePerm)
-		checkError(err)
-		err = ioutil.WriteFile(filepath.Join(pathShootKubeconfig, "kubeconfig.yaml"), kubeSecretShoot.Data["kubeconfig"], 0644)
+		pathToKubeconfig, err = writeCacheFile(filepath.Join(pathShootKubeconfig, "kubeconfig.yaml"), kubeSecretShoot.Data["kubeconfig"])
 		if err != nil {
 			fmt.Println("Could not write kubeconfig")
 			continue
 		}
-		pathToKubeconfig = filepath.Join(pathShootKubeconfig, "kubeconfig.yaml")
-		KUBECONFIG = pathToKubeconfig
 		config, err = clientcmd.BuildConfigFromFlags("", pathToKubeconfig)
 		if err != nil {
 			fmt.Println("Could not build config")
 			continue
 		}
 		clientShoot, err := k8s.NewForConfig(config)
-		checkError(err)
+		if err != nil {
+			fmt.Println("Could not get shoot client")
+			continue
+		}
 		pods, err = clientShoot.CoreV1().Pods("kube-system").List(metav1.ListOptions{})
 		if err != nil {
 			fmt.Println("Shoot " + shoot.Name + " has no pods in kube-system namespace")
@@ -264,7 +302,7 @@ func downloadLogs(option string, targetReader TargetReader) {
 		pathLogsShoots := filepath.Join(dir, "seeds", *shoot.Spec.SeedName, shoot.ObjectMeta.GetNamespace(), shoot.Name, "logs", "vpn")
 		for _, pod := range pods.Items {
 			if strings.Contains(pod.Name, "vpn-shoot-") {
-				args := []string{"--kubeconfig=" + KUBECONFIG, "logs", pod.Name, "-n", "kube-system"}
+				args := []string{"--kubeconfig=" + pathToKubeconfig, "logs", pod.Name, "-n", "kube-system"}
 				fmt.Println(strings.Join(append([]string{"kubectl"}, args...), " "))
 				output, err := ExecCmdReturnOutput("kubectl", args...)
 				if err != nil {
@@ -279,4 +317,5 @@ func downloadLogs(option string, targetReader TargetReader) {
 			}
 		}
 	}
+	return nil
 }
@@ -17,55 +17,58 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // NewDropCmd returns a new drop command.
-func NewDropCmd(targetReader TargetReader, targetWriter TargetWriter, ioStreams IOStreams) *cobra.Command {
+func NewDropCmd(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "drop [(project|seed)]",
-		Short:        "Drop scope for next operations (default: last target), e.g. \"gardenctl drop\" drops last target, \"gardenctl drop project\" drops project in current stack",
+		Use:          "drop [(project|seed|namespace|all)]",
+		Short:        "Drop scope for next operations (default: last target), e.g. \"gardenctl drop\" drops last target, \"gardenctl drop project\" drops project in current stack, \"gardenctl drop all\" drops everything but the garden",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
-				return errors.New("command must be in the format: gardenctl drop [(project|seed)]")
+				return errors.New("command must be in the format: gardenctl drop [(project|seed|namespace|all)]")
+			}
+			if len(args) == 1 && args[0] == "all" {
+				return dropAll(targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			}
 			if len(args) == 0 {
 				target := targetReader.ReadTarget(pathTarget)
 				stackLength := len(target.Stack())
 				if stackLength == 0 {
-					return errors.New("target stack is empty")
+					return errTargetStackEmpty
 				}
 
 				fmt.Fprintf(ioStreams.Out, "Dropped %s %s\n", target.Stack()[stackLength-1].Kind, target.Stack()[stackLength-1].Name)
 
-				if target.Stack()[stackLength-1].Kind == "namespace" {
-					setNamespaceDefault()
-				}
-
 				target.SetStack(target.Stack()[:stackLength-1])
 				if err := targetWriter.WriteTarget(pathTarget, target); err != nil {
 					return err
 				}
 			} else if len(args) == 1 {
 				var target Target
-				ReadTarget(pathTarget, &target)
+				if err := ReadTarget(pathTarget, &target); err != nil {
+					return err
+				}
 				switch args[0] {
 				case "project":
 					if len(target.Target) == 2 && target.Target[1].Kind == "project" {
-						drop(targetWriter)
+						if err := drop(targetWriter, 1); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
 					} else if len(target.Target) == 3 && target.Target[1].Kind == "project" {
-						drop(targetWriter)
-						drop(targetWriter)
+						if err := drop(targetWriter, 2); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[2].Kind, target.Target[2].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
 					} else if len(target.Target) == 4 && target.Target[1].Kind == "project" {
-						drop(targetWriter)
-						drop(targetWriter)
-						drop(targetWriter)
+						if err := drop(targetWriter, 3); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[3].Kind, target.Target[3].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[2].Kind, target.Target[2].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
@@ -74,17 +77,20 @@ func NewDropCmd(targetReader TargetReader, targetWriter TargetWriter, ioStreams
 					}
 				case "seed":
 					if len(target.Target) == 2 && target.Target[1].Kind == "seed" {
-						drop(targetWriter)
+						if err := drop(targetWriter, 1); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
 					} else if len(target.Target) == 3 && target.Target[1].Kind == "seed" {
-						drop(targetWriter)
-						drop(targetWriter)
+						if err := drop(targetWriter, 2); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[2].Kind, target.Target[2].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
 					} else if len(target.Target) == 4 && target.Target[1].Kind == "seed" {
-						drop(targetWriter)
-						drop(targetWriter)
-						drop(targetWriter)
+						if err := drop(targetWriter, 3); err != nil {
+							return err
+						}
 						fmt.Printf("Dropped %s %s\n", target.Target[3].Kind, target.Target[3].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[2].Kind, target.Target[2].Name)
 						fmt.Printf("Dropped %s %s\n", target.Target[1].Kind, target.Target[1].Name)
@@ -94,8 +100,9 @@ func NewDropCmd(targetReader TargetReader, targetWriter TargetWriter, ioStreams
 				case "namespace":
 					if len(target.Target) > 1 && len(target.Target) < 5 {
 						if target.Target[len(target.Target)-1].Kind == "namespace" {
-							setNamespaceDefault()
-							drop(targetWriter)
+							if err := drop(targetWriter, 1); err != nil {
+								return err
+							}
 							fmt.Printf("Dropped %s %s\n", target.Target[len(target.Target)-1].Kind, target.Target[len(target.Target)-1].Name)
 						} else {
 							fmt.Println("No namespace targeted")
@@ -106,44 +113,31 @@ func NewDropCmd(targetReader TargetReader, targetWriter TargetWriter, ioStreams
 						fmt.Println("Size of target stack is illegal")
 					}
 				default:
-					fmt.Println("Command must be in the format: gardenctl drop <project|seed|namespace>")
+					fmt.Println("Command must be in the format: gardenctl drop <project|seed|namespace|all>")
 				}
 			}
 
 			return nil
 		},
-		ValidArgs: []string{"project", "seed", "namespace"},
+		ValidArgs: []string{"project", "seed", "namespace", "all"},
 	}
 
 	return cmd
 }
 
-// drop drops target until stack is empty
-func drop(targetWriter TargetWriter) {
+// drop drops the last count elements of the target until stack is empty
+func drop(targetWriter TargetWriter, count int) error {
 	var target Target
-	ReadTarget(pathTarget, &target)
-	if len(target.Target) > 0 {
+	if err := ReadTarget(pathTarget, &target); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		if len(target.Target) == 0 {
+			fmt.Println("Target stack is empty")
+			break
+		}
 		target.Target = target.Target[:len(target.Target)-1]
-	} else {
-		fmt.Println("Target stack is empty")
 	}
 
-	err := targetWriter.WriteTarget(pathTarget, &target)
-	checkError(err)
-
-	KUBECONFIG = getKubeConfigOfCurrentTarget()
-}
-
-//set current namespace to default
-func setNamespaceDefault() {
-	cfg := getKubeConfigOfCurrentTarget()
-	out, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+cfg, "config", "current-context")
-	if err != nil {
-		fmt.Println(err)
-	}
-	currentConext := strings.TrimSuffix(string(out), "\n")
-	_, err = ExecCmdReturnOutput("kubectl", "--kubeconfig="+cfg, "config", "set-context "+currentConext, " --namespace=default")
-	if err != nil {
-		fmt.Println(err)
-	}
+	return targetWriter.WriteTarget(pathTarget, &target)
 }
@@ -27,10 +27,12 @@ import (
 var _ = Describe("Drop command", func() {
 
 	var (
-		ctrl         *gomock.Controller
-		targetReader *mockcmd.MockTargetReader
-		targetWriter *mockcmd.MockTargetWriter
-		command      *cobra.Command
+		ctrl          *gomock.Controller
+		targetReader  *mockcmd.MockTargetReader
+		targetWriter  *mockcmd.MockTargetWriter
+		configReader  *mockcmd.MockConfigReader
+		historyWriter *mockcmd.MockHistoryWriter
+		command       *cobra.Command
 
 		execute = func(command *cobra.Command, args []string) error {
 			command.SetArgs(args)
@@ -42,6 +44,8 @@ var _ = Describe("Drop command", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		targetReader = mockcmd.NewMockTargetReader(ctrl)
 		targetWriter = mockcmd.NewMockTargetWriter(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
+		historyWriter = mockcmd.NewMockHistoryWriter(ctrl)
 	})
 
 	AfterEach(func() {
@@ -54,7 +58,7 @@ var _ = Describe("Drop command", func() {
 			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
 
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewDropCmd(targetReader, targetWriter, ioStreams)
+			command = cmd.NewDropCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			err := execute(command, []string{})
 
 			Expect(err).To(HaveOccurred())
@@ -89,7 +93,7 @@ var _ = Describe("Drop command", func() {
 			targetWriter.EXPECT().WriteTarget(gomock.Any(), expectedTarget)
 
 			ioStreams, _, out, _ := cmd.NewTestIOStreams()
-			command = cmd.NewDropCmd(targetReader, targetWriter, ioStreams)
+			command = cmd.NewDropCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			err := execute(command, []string{})
 
 			Expect(err).NotTo(HaveOccurred())
@@ -100,11 +104,24 @@ var _ = Describe("Drop command", func() {
 	Context("with >= 2 args", func() {
 		It("should return error", func() {
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewDropCmd(targetReader, targetWriter, ioStreams)
+			command = cmd.NewDropCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			err := execute(command, []string{"project", "seed"})
 
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("command must be in the format: gardenctl drop [(project|seed)]"))
+			Expect(err.Error()).To(Equal("command must be in the format: gardenctl drop [(project|seed|namespace|all)]"))
+		})
+	})
+
+	Context("with all", func() {
+		It("should return err when target stack is empty", func() {
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(&cmd.Target{})
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewDropCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams)
+			err := execute(command, []string{"all"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("target stack is empty"))
 		})
 	})
 })
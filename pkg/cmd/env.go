@@ -0,0 +1,237 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// shell is a shell for which environment statements can be printed
+type shell string
+
+const (
+	shellBash       shell = "bash"
+	shellZsh        shell = "zsh"
+	shellFish       shell = "fish"
+	shellPowershell shell = "powershell"
+)
+
+var (
+	envExample = `
+	# Point kubectl in the current shell to the current target.
+	eval $(gardenctl env)
+
+	# The same for fish and PowerShell.
+	eval (gardenctl env fish)
+	& gardenctl env powershell | Invoke-Expression
+
+	# Remove the variables from the current shell again.
+	eval $(gardenctl env --unset)`
+
+	// envUnset prints statements removing the variables instead of setting them
+	envUnset bool
+
+	validShells = []string{string(shellBash), string(shellZsh), string(shellFish), string(shellPowershell)}
+)
+
+// envVar is an environment variable printed by the env command
+type envVar struct {
+	name  string
+	value string
+}
+
+// NewEnvCmd returns a new env command.
+func NewEnvCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "env [bash|zsh|fish|powershell]",
+		Short:        "Print the statements to export the current target into the shell, e.g. \"eval $(gardenctl env)\"",
+		Example:      envExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("command must be in the format: env [bash|zsh|fish|powershell]")
+			}
+			sh := detectShell()
+			if len(args) == 1 {
+				var err error
+				if sh, err = parseShell(args[0]); err != nil {
+					return err
+				}
+			}
+
+			if envUnset {
+				printUnsetEnv(ioStreams.Out, sh)
+				return nil
+			}
+
+			vars, err := targetEnvVars(targetReader.ReadTarget(pathTarget), configReader)
+			if err != nil {
+				return err
+			}
+			printEnv(ioStreams.Out, sh, vars)
+			return nil
+		},
+		ValidArgs: validShells,
+	}
+
+	cmd.PersistentFlags().BoolVarP(&envUnset, "unset", "u", false, "print the statements to remove the variables from the shell")
+
+	return cmd
+}
+
+// envVarNames returns the names of all variables which are set by the env command
+func envVarNames() []string {
+	return []string{
+		"KUBECONFIG",
+		sessionIDEnvVar,
+		"GARDENCTL_GARDEN",
+		"GARDENCTL_PROJECT",
+		"GARDENCTL_SEED",
+		"GARDENCTL_SHOOT",
+		"GARDENCTL_NAMESPACE",
+		"GARDENCTL_SHOOT_TECHNICAL_ID",
+	}
+}
+
+// targetEnvVars returns the variables describing the given target,
+// variables of kinds which are not targeted are omitted
+func targetEnvVars(target TargetInterface, configReader ConfigReader) ([]envVar, error) {
+	vars := []envVar{}
+	kubeconfig, err := kubeconfigPathOfStack(configReader, target.Stack())
+	if err != nil {
+		return nil, err
+	}
+	if kubeconfig != "" {
+		vars = append(vars, envVar{"KUBECONFIG", kubeconfig})
+	}
+	if sessionID != "" {
+		vars = append(vars, envVar{sessionIDEnvVar, sessionID})
+	}
+
+	shootTargeted := false
+	for _, t := range target.Stack() {
+		switch t.Kind {
+		case TargetKindGarden:
+			vars = append(vars, envVar{"GARDENCTL_GARDEN", t.Name})
+		case TargetKindProject:
+			vars = append(vars, envVar{"GARDENCTL_PROJECT", t.Name})
+		case TargetKindSeed:
+			vars = append(vars, envVar{"GARDENCTL_SEED", t.Name})
+		case TargetKindShoot:
+			vars = append(vars, envVar{"GARDENCTL_SHOOT", t.Name})
+			shootTargeted = true
+		case TargetKindNamespace:
+			vars = append(vars, envVar{"GARDENCTL_NAMESPACE", t.Name})
+		}
+	}
+
+	if shootTargeted {
+		shoot, err := FetchShootFromTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		if shoot != nil && shoot.Status.TechnicalID != "" {
+			vars = append(vars, envVar{"GARDENCTL_SHOOT_TECHNICAL_ID", shoot.Status.TechnicalID})
+		}
+	}
+
+	return vars, nil
+}
+
+// printEnv prints the statements setting the given variables in the given shell
+func printEnv(writer io.Writer, sh shell, vars []envVar) {
+	for _, v := range vars {
+		fmt.Fprintln(writer, sh.export(v.name, v.value))
+	}
+	fmt.Fprintln(writer, "# Run this command to configure the current shell:")
+	fmt.Fprintln(writer, "# "+sh.evalCommand("gardenctl env "+string(sh)))
+}
+
+// printUnsetEnv prints the statements removing all variables of the env command in the given shell
+func printUnsetEnv(writer io.Writer, sh shell) {
+	for _, name := range envVarNames() {
+		fmt.Fprintln(writer, sh.unset(name))
+	}
+	fmt.Fprintln(writer, "# Run this command to reset the current shell:")
+	fmt.Fprintln(writer, "# "+sh.evalCommand("gardenctl env "+string(sh)+" --unset"))
+}
+
+// detectShell returns the shell from $SHELL, bash is used if it is unknown
+func detectShell() shell {
+	if sh, err := parseShell(filepath.Base(os.Getenv("SHELL"))); err == nil {
+		return sh
+	}
+	return shellBash
+}
+
+// parseShell returns the shell with the given name
+func parseShell(name string) (shell, error) {
+	switch strings.ToLower(name) {
+	case "bash", "sh":
+		return shellBash, nil
+	case "zsh":
+		return shellZsh, nil
+	case "fish":
+		return shellFish, nil
+	case "powershell", "pwsh":
+		return shellPowershell, nil
+	}
+	return "", fmt.Errorf("unsupported shell %q, must be one of: %s", name, strings.Join(validShells, ", "))
+}
+
+// export returns the statement setting the variable in the shell
+func (sh shell) export(name, value string) string {
+	switch sh {
+	case shellFish:
+		return fmt.Sprintf("set -gx %s %s;", name, quoteSingle(strings.Replace(value, `\`, `\\`, -1), `\'`))
+	case shellPowershell:
+		return fmt.Sprintf("$Env:%s = %s", name, quoteSingle(value, `''`))
+	}
+	return fmt.Sprintf("export %s=%s;", name, quoteSingle(value, `'\''`))
+}
+
+// unset returns the statement removing the variable from the shell
+func (sh shell) unset(name string) string {
+	switch sh {
+	case shellFish:
+		return fmt.Sprintf("set -e %s;", name)
+	case shellPowershell:
+		return fmt.Sprintf("Remove-Item -ErrorAction SilentlyContinue Env:\\%s", name)
+	}
+	return fmt.Sprintf("unset %s;", name)
+}
+
+// evalCommand returns the statement evaluating the output of the given command in the shell
+func (sh shell) evalCommand(command string) string {
+	switch sh {
+	case shellFish:
+		return fmt.Sprintf("eval (%s)", command)
+	case shellPowershell:
+		return fmt.Sprintf("& %s | Invoke-Expression", command)
+	}
+	return fmt.Sprintf("eval $(%s)", command)
+}
+
+// quoteSingle puts the value into single quotes, contained single quotes are replaced by escaped
+func quoteSingle(value, escaped string) string {
+	return "'" + strings.Replace(value, "'", escaped, -1) + "'"
+}
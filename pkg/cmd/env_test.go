@@ -0,0 +1,137 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"github.com/gardener/gardenctl/pkg/cmd"
+	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorefake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Env command", func() {
+	var (
+		ctrl         *gomock.Controller
+		configReader *mockcmd.MockConfigReader
+		targetReader *mockcmd.MockTargetReader
+		target       *mockcmd.MockTargetInterface
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		configReader = mockcmd.NewMockConfigReader(ctrl)
+		targetReader = mockcmd.NewMockTargetReader(ctrl)
+		target = mockcmd.NewMockTargetInterface(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should print the exports of the targeted shoot", func() {
+		namespace := "garden-prjct"
+		clientSet := gardencorefake.NewSimpleClientset(
+			&gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "prjct"},
+				Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace},
+			},
+			&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "myshoot", Namespace: namespace},
+				Status:     gardencorev1beta1.ShootStatus{TechnicalID: "shoot--prjct--myshoot"},
+			},
+		)
+		targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+		target.EXPECT().Stack().Return([]cmd.TargetMeta{
+			{Kind: cmd.TargetKindGarden, Name: "test-garden"},
+			{Kind: cmd.TargetKindProject, Name: "prjct"},
+			{Kind: cmd.TargetKindShoot, Name: "myshoot"},
+		}).AnyTimes()
+		target.EXPECT().GardenerClient().Return(clientSet, nil)
+
+		ioStreams, _, out, _ := cmd.NewTestIOStreams()
+		command := cmd.NewEnvCmd(targetReader, configReader, ioStreams)
+		command.SetArgs([]string{"bash"})
+		err := command.Execute()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(MatchRegexp("export KUBECONFIG='.*cache/test-garden/projects/prjct/myshoot/kubeconfig.yaml';\n"))
+		Expect(out.String()).To(ContainSubstring("export GARDENCTL_GARDEN='test-garden';\n"))
+		Expect(out.String()).To(ContainSubstring("export GARDENCTL_PROJECT='prjct';\n"))
+		Expect(out.String()).To(ContainSubstring("export GARDENCTL_SHOOT='myshoot';\n"))
+		Expect(out.String()).To(ContainSubstring("export GARDENCTL_SHOOT_TECHNICAL_ID='shoot--prjct--myshoot';\n"))
+		Expect(out.String()).NotTo(ContainSubstring("GARDENCTL_SEED"))
+		Expect(out.String()).To(ContainSubstring("# eval $(gardenctl env bash)"))
+	})
+
+	It("should print the exports of the targeted garden", func() {
+		targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+		target.EXPECT().Stack().Return([]cmd.TargetMeta{
+			{Kind: cmd.TargetKindGarden, Name: "test-garden"},
+			{Kind: cmd.TargetKindNamespace, Name: "it's"},
+		}).AnyTimes()
+		configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+			GardenClusters: []cmd.GardenClusterMeta{
+				{Name: "test-garden", KubeConfig: "/kubeconfig.yaml"},
+			},
+		})
+
+		ioStreams, _, out, _ := cmd.NewTestIOStreams()
+		command := cmd.NewEnvCmd(targetReader, configReader, ioStreams)
+		command.SetArgs([]string{"fish"})
+		err := command.Execute()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(ContainSubstring("set -gx KUBECONFIG '/kubeconfig.yaml';\n"))
+		Expect(out.String()).To(ContainSubstring("set -gx GARDENCTL_NAMESPACE 'it\\'s';\n"))
+		Expect(out.String()).To(ContainSubstring("# eval (gardenctl env fish)"))
+	})
+
+	DescribeTable("unset",
+		func(shell string, expected string) {
+			ioStreams, _, out, _ := cmd.NewTestIOStreams()
+			command := cmd.NewEnvCmd(targetReader, configReader, ioStreams)
+			command.SetArgs([]string{shell, "--unset"})
+			err := command.Execute()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring(expected))
+		},
+		Entry("bash", "bash", "unset KUBECONFIG;\n"),
+		Entry("zsh", "zsh", "unset GARDENCTL_SHOOT_TECHNICAL_ID;\n"),
+		Entry("fish", "fish", "set -e GARDEN_SESSION_ID;\n"),
+		Entry("powershell", "powershell", "Remove-Item -ErrorAction SilentlyContinue Env:\\KUBECONFIG\n"),
+	)
+
+	DescribeTable("validation",
+		func(args []string, expectedErr string) {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command := cmd.NewEnvCmd(targetReader, configReader, ioStreams)
+			command.SetArgs(args)
+
+			err := command.Execute()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(expectedErr))
+		},
+		Entry("with too many args", []string{"bash", "zsh"}, "command must be in the format: env [bash|zsh|fish|powershell]"),
+		Entry("with unknown shell", []string{"csh"}, "unsupported shell \"csh\", must be one of: bash, zsh, fish, powershell"),
+	)
+})
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+// Exit codes of gardenctl. Automation relies on them, so they must not change.
+const (
+	// ExitCodeError is returned for failures without a more specific exit code
+	ExitCodeError = 1
+	// ExitCodeNotTargeted is returned if a command requires a target which is not set
+	ExitCodeNotTargeted = 2
+	// ExitCodeNotFound is returned if a garden, project, seed, shoot or other object does not exist
+	ExitCodeNotFound = 3
+	// ExitCodeForbidden is returned if the user is not allowed to perform an operation
+	ExitCodeForbidden = 4
+	// ExitCodeAmbiguousMatch is returned if a name matches more than one object
+	ExitCodeAmbiguousMatch = 5
+	// ExitCodeToolMissing is returned if a required external tool is not installed
+	ExitCodeToolMissing = 6
+	// ExitCodeRemoteFailure is returned if a cluster or cloud provider cannot be reached or fails
+	ExitCodeRemoteFailure = 7
+)
+
+// Error is a failure of gardenctl with a reason which determines the exit code
+type Error = gardenctl.Error
+
+// ErrorReason classifies the failures of gardenctl
+type ErrorReason = gardenctl.ErrorReason
+
+// These are the reasons of the failures of gardenctl.
+const (
+	ReasonNotTargeted    = gardenctl.ReasonNotTargeted
+	ReasonNotFound       = gardenctl.ReasonNotFound
+	ReasonForbidden      = gardenctl.ReasonForbidden
+	ReasonAmbiguousMatch = gardenctl.ReasonAmbiguousMatch
+	ReasonToolMissing    = gardenctl.ReasonToolMissing
+	ReasonRemoteFailure  = gardenctl.ReasonRemoteFailure
+)
+
+// The errors of the commands are created and classified by the gardenctl library.
+var (
+	NewNotTargetedError    = gardenctl.NewNotTargetedError
+	NewNotFoundError       = gardenctl.NewNotFoundError
+	NewForbiddenError      = gardenctl.NewForbiddenError
+	NewAmbiguousMatchError = gardenctl.NewAmbiguousMatchError
+	NewToolMissingError    = gardenctl.NewToolMissingError
+	NewRemoteError         = gardenctl.NewRemoteError
+
+	ReasonForError   = gardenctl.ReasonForError
+	IsNotTargeted    = gardenctl.IsNotTargeted
+	IsNotFound       = gardenctl.IsNotFound
+	IsForbidden      = gardenctl.IsForbidden
+	IsAmbiguousMatch = gardenctl.IsAmbiguousMatch
+	IsToolMissing    = gardenctl.IsToolMissing
+	IsRemoteFailure  = gardenctl.IsRemoteFailure
+)
+
+// errTargetStackEmpty is returned by commands which require at least a targeted garden
+var errTargetStackEmpty = &Error{Reason: ReasonNotTargeted, Message: "target stack is empty"}
+
+// ToolError is a failure of an external tool like kubectl or terraform. gardenctl exits with the
+// exit status of the tool, like it would have been called directly.
+type ToolError struct {
+	Tool     string
+	ExitCode int
+	Err      error
+}
+
+// Error returns the message of the error, including the stderr output of the tool if it was captured
+func (e *ToolError) Error() string {
+	var exitErr *exec.ExitError
+	if errors.As(e.Err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		return fmt.Sprintf("%s failed: %v: %s", e.Tool, e.Err, bytes.TrimSpace(exitErr.Stderr))
+	}
+	return fmt.Sprintf("%s failed: %v", e.Tool, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ToolError) Unwrap() error {
+	return e.Err
+}
+
+// toolError classifies the error of running the external tool, a missing tool is reported as
+// ToolMissing and a non-zero exit status as ToolError
+func toolError(tool string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return NewToolMissingError(tool)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ToolError{Tool: tool, ExitCode: exitErr.ExitCode(), Err: err}
+	}
+	return err
+}
+
+// ExitCode returns the exit code of gardenctl for err
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) && toolErr.ExitCode > 0 {
+		return toolErr.ExitCode
+	}
+	switch ReasonForError(err) {
+	case ReasonNotTargeted:
+		return ExitCodeNotTargeted
+	case ReasonNotFound:
+		return ExitCodeNotFound
+	case ReasonForbidden:
+		return ExitCodeForbidden
+	case ReasonAmbiguousMatch:
+		return ExitCodeAmbiguousMatch
+	case ReasonToolMissing:
+		return ExitCodeToolMissing
+	case ReasonRemoteFailure:
+		return ExitCodeRemoteFailure
+	}
+	return ExitCodeError
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Errors", func() {
+	shoots := schema.GroupResource{Group: "core.gardener.cloud", Resource: "shoots"}
+
+	DescribeTable("#ExitCode",
+		func(err error, expected int) {
+			Expect(cmd.ExitCode(err)).To(Equal(expected))
+		},
+		Entry("no error", nil, 0),
+		Entry("generic error", errors.New("foo"), cmd.ExitCodeError),
+		Entry("not targeted", cmd.NewNotTargetedError(cmd.TargetKindShoot), cmd.ExitCodeNotTargeted),
+		Entry("not found", cmd.NewNotFoundError("no match for %q", "foo"), cmd.ExitCodeNotFound),
+		Entry("forbidden", cmd.NewForbiddenError("not allowed"), cmd.ExitCodeForbidden),
+		Entry("ambiguous match", cmd.NewAmbiguousMatchError(cmd.TargetKindShoot, "foo", []string{"a/foo", "b/foo"}), cmd.ExitCodeAmbiguousMatch),
+		Entry("tool missing", cmd.NewToolMissingError("terraform"), cmd.ExitCodeToolMissing),
+		Entry("remote failure", cmd.NewRemoteError(errors.New("foo"), "aws CLI failed"), cmd.ExitCodeRemoteFailure),
+		Entry("wrapped gardenctl error", fmt.Errorf("wrapped: %w", cmd.NewNotTargetedError(cmd.TargetKindSeed)), cmd.ExitCodeNotTargeted),
+		Entry("api not found", apierrors.NewNotFound(shoots, "foo"), cmd.ExitCodeNotFound),
+		Entry("api forbidden", apierrors.NewForbidden(shoots, "foo", errors.New("foo")), cmd.ExitCodeForbidden),
+		Entry("api unauthorized", apierrors.NewUnauthorized("foo"), cmd.ExitCodeForbidden),
+		Entry("api timeout", apierrors.NewServerTimeout(shoots, "get", 1), cmd.ExitCodeRemoteFailure),
+		Entry("api conflict", apierrors.NewConflict(shoots, "foo", errors.New("foo")), cmd.ExitCodeError),
+		Entry("url error", &url.Error{Op: "Get", URL: "https://api.example.com", Err: errors.New("connection refused")}, cmd.ExitCodeRemoteFailure),
+		Entry("tool failure", &cmd.ToolError{Tool: "kubectl", ExitCode: 42, Err: errors.New("exit status 42")}, 42),
+		Entry("wrapped tool failure", fmt.Errorf("wrapped: %w", &cmd.ToolError{Tool: "kubectl", ExitCode: 42, Err: errors.New("exit status 42")}), 42),
+		Entry("tool failure without exit status", &cmd.ToolError{Tool: "kubectl", ExitCode: -1, Err: errors.New("signal: killed")}, cmd.ExitCodeError),
+	)
+
+	DescribeTable("#Error",
+		func(err error, expected string) {
+			Expect(err.Error()).To(Equal(expected))
+		},
+		Entry("not targeted garden", cmd.NewNotTargetedError(cmd.TargetKindGarden), "no garden cluster targeted"),
+		Entry("not targeted shoot", cmd.NewNotTargetedError(cmd.TargetKindShoot), "no shoot targeted"),
+		Entry("ambiguous match", cmd.NewAmbiguousMatchError(cmd.TargetKindShoot, "foo", []string{"a/foo", "b/foo"}), "\"foo\" matches 2 shoots: a/foo, b/foo"),
+		Entry("tool missing", cmd.NewToolMissingError("kubectl"), "kubectl is not installed on your system"),
+		Entry("remote failure", cmd.NewRemoteError(errors.New("exit status 1"), "aws CLI failed with %s", "denied"), "aws CLI failed with denied: exit status 1"),
+	)
+
+	It("should unwrap the cause of a remote failure", func() {
+		cause := errors.New("foo")
+		Expect(errors.Is(cmd.NewRemoteError(cause, "bar"), cause)).To(BeTrue())
+	})
+})
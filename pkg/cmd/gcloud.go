@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,7 +23,7 @@ import (
 )
 
 // NewGcloudCmd return a new gcloud command.
-func NewGcloudCmd(targetReader TargetReader) *cobra.Command {
+func NewGcloudCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "gcloud <args>",
 		Short:              "e.g. \"gardenctl gcloud compute networks subnets delete net_name\"",
@@ -33,15 +32,25 @@ func NewGcloudCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "gcloud"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 			if !CheckToolInstalled("gcloud") {
 				fmt.Println("Please go to https://cloud.google.com/sdk/install for how to install gcloud")
-				os.Exit(2)
+				return NewToolMissingError("gcloud")
 			}
 
 			arguments := strings.Join(os.Args[2:], " ")
-			fmt.Println(operate("gcp", arguments))
+			output, err := operate(targetReader, "gcp", arguments)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
@@ -46,38 +46,51 @@ func NewGetCmd(targetReader TargetReader, configReader ConfigReader,
 			case "project":
 				if IsTargeted(targetReader, "project") {
 					err = printProjectKubeconfig(name, targetReader, ioStreams.Out, outputFormat)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 				} else {
-					return errors.New("no project targeted")
+					return NewNotTargetedError(TargetKindProject)
 				}
 
 			case "garden":
 				if IsTargeted(targetReader, "garden") {
 					err = printGardenKubeconfig(name, configReader, targetReader, kubeconfigReader, ioStreams.Out, outputFormat)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 				} else {
 					return errors.New("no garden targeted")
 				}
 
 			case "seed":
 				if IsTargeted(targetReader, "seed") || IsTargeted(targetReader, "project", "shoot") {
+					if err := enforceTargetAccessRestrictions(targetReader.ReadTarget(pathTarget), configReader, ioStreams); err != nil {
+						return err
+					}
 					err = printSeedKubeconfig(name, targetReader, ioStreams.Out, outputFormat)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 				} else {
 					return errors.New("no seed targeted targeted or shoot targeted")
 				}
 
 			case "shoot":
-				if IsTargeted(targetReader, "shoot") {
-					err = printShootKubeconfig(name, targetReader, kubeconfigWriter, ioStreams.Out, outputFormat)
-					checkError(err)
-				} else {
-					return errors.New("no shoot targeted")
+				if !IsTargeted(targetReader, "shoot") {
+					return NewNotTargetedError(TargetKindShoot)
+				}
+				if kubeconfigTTL != 0 || kubeconfigRole != "" {
+					return printShootAccessKubeconfig(name, targetReader, configReader, ioStreams, outputFormat)
+				}
+				err = printShootKubeconfig(name, targetReader, configReader, kubeconfigWriter, ioStreams, outputFormat)
+				if err != nil {
+					return err
 				}
 
 			case "target":
 				if !IsTargeted(targetReader) {
-					return errors.New("target stack is empty")
+					return errTargetStackEmpty
 				}
 
 				err = printTarget(targetReader, ioStreams.Out, outputFormat)
@@ -93,6 +106,9 @@ func NewGetCmd(targetReader TargetReader, configReader ConfigReader,
 		ValidArgs: []string{"project", "garden", "seed", "shoot", "target"},
 	}
 
+	cmd.Flags().DurationVar(&kubeconfigTTL, "kubeconfig-ttl", 0, fmt.Sprintf("return a short-lived kubeconfig of a service account expiring after the given duration (default %s if --role is set) instead of the admin kubeconfig of the shoot", defaultKubeconfigTTL))
+	cmd.Flags().StringVar(&kubeconfigRole, "role", "", "role of the short-lived kubeconfig of the shoot, one of: view (default), edit, admin")
+
 	return cmd
 }
 
@@ -118,7 +134,9 @@ func printGardenKubeconfig(name string, configReader ConfigReader, targetReader
 	if name == "" {
 		var err error
 		name, err = GetTargetName(targetReader, "garden")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 	}
 
 	config := configReader.ReadConfig(pathGardenConfig)
@@ -172,7 +190,7 @@ func printSeedKubeconfig(name string, targetReader TargetReader, writer io.Write
 }
 
 // printShootKubeconfig lists kubeconfig of shoot
-func printShootKubeconfig(name string, targetReader TargetReader, kubeconfigWriter KubeconfigWriter, writer io.Writer, outFormat string) error {
+func printShootKubeconfig(name string, targetReader TargetReader, configReader ConfigReader, kubeconfigWriter KubeconfigWriter, ioStreams IOStreams, outFormat string) error {
 	target := targetReader.ReadTarget(pathTarget)
 
 	client, err := target.K8SClientToKind(TargetKindGarden)
@@ -183,10 +201,20 @@ func printShootKubeconfig(name string, targetReader TargetReader, kubeconfigWrit
 	var shoot *v1beta1.Shoot
 	if name == "" {
 		shoot, err = GetTargetedShootObject(targetReader)
-		checkError(err)
+		if err != nil {
+			return err
+		}
 	} else {
 		shoot, err = GetShootObject(targetReader, name)
-		checkError(err)
+		if err != nil {
+			return err
+		}
+	}
+	if err := checkShootReadOnly(target, shoot, configReader, "get shoot"); err != nil {
+		return err
+	}
+	if err := enforceAccessRestrictions(target, shoot, configReader, ioStreams); err != nil {
+		return err
 	}
 
 	namespace := shoot.Status.TechnicalID
@@ -200,11 +228,14 @@ func printShootKubeconfig(name string, targetReader TargetReader, kubeconfigWrit
 		return err
 	}
 	gardenName, err := GetTargetName(targetReader, "garden")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	kubeconfigPath := filepath.Join(pathGardenHome, "cache", gardenName, "seeds", seed.Spec.SecretRef.Name, "kubeconfig.yaml")
 	err = kubeconfigWriter.Write(kubeconfigPath, kubeSecret.Data["kubeconfig"])
-	checkError(err)
-	KUBECONFIG = kubeconfigPath
+	if err != nil {
+		return err
+	}
 
 	seedClient, err := target.K8SClientToKind(TargetKindSeed)
 	if err != nil {
@@ -216,7 +247,7 @@ func printShootKubeconfig(name string, targetReader TargetReader, kubeconfigWrit
 		return err
 	}
 
-	return PrintoutObject(fmt.Sprintf("%s\n", kubeSecret.Data["kubeconfig"]), writer, outFormat)
+	return PrintoutObject(fmt.Sprintf("%s\n", kubeSecret.Data["kubeconfig"]), ioStreams.Out, outFormat)
 }
 
 // printTarget prints the target stack.
@@ -224,3 +255,12 @@ func printTarget(targetReader TargetReader, writer io.Writer, outFormat string)
 	target := targetReader.ReadTarget(pathTarget)
 	return PrintoutObject(target, writer, outFormat)
 }
+
+// PrintTable prints the target stack as table
+func (t *Target) PrintTable(w io.Writer, wide bool) error {
+	var rows [][]string
+	for _, meta := range t.Target {
+		rows = append(rows, []string{string(meta.Kind), meta.Name})
+	}
+	return printTable(w, []string{"KIND", "NAME"}, rows)
+}
@@ -195,8 +195,25 @@ var _ = Describe("Get command", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("should refuse a short-lived kubeconfig with unknown role or too short ttl", func() {
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
+				target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+
+				ioStreams, _, _, _ := cmd.NewTestIOStreams()
+				command = cmd.NewGetCmd(targetReader, configReader, kubeconfigReader, kubeconfigWriter, ioStreams)
+				command.SetArgs([]string{"shoot", "--role", "owner"})
+				err := command.Execute()
+				Expect(err).To(MatchError(`unknown role "owner", must be one of: admin, edit, view`))
+
+				command = cmd.NewGetCmd(targetReader, configReader, kubeconfigReader, kubeconfigWriter, ioStreams)
+				command.SetArgs([]string{"shoot", "--kubeconfig-ttl", "5m"})
+				err = command.Execute()
+				Expect(err).To(MatchError("kubeconfig ttl 5m0s is too short, it must be at least 10m0s"))
+			})
+
 			It("should pass on get seed", func() {
 				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
 				target.EXPECT().K8SClientToKind(cmd.TargetKindGarden).Return(k8sClientToGarden, nil)
 				target.EXPECT().Stack().Return(targetMeta).AnyTimes()
 				target.EXPECT().GardenerClient().Return(clientSet, nil).AnyTimes()
@@ -211,6 +228,7 @@ var _ = Describe("Get command", func() {
 
 			It("should pass on get shoot", func() {
 				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig).Times(2)
 				target.EXPECT().K8SClientToKind(cmd.TargetKindGarden).Return(k8sClientToGarden, nil).AnyTimes()
 				target.EXPECT().K8SClientToKind(cmd.TargetKindSeed).Return(k8sClientToGarden, nil).AnyTimes()
 				target.EXPECT().Stack().Return(targetMeta).AnyTimes()
@@ -225,6 +243,53 @@ var _ = Describe("Get command", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("should not print the kubeconfig of a shoot if a restriction denies the access", func() {
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+					GardenClusters: []cmd.GardenClusterMeta{{
+						Name: "test-garden",
+						AccessRestrictions: []cmd.AccessRestriction{{
+							Key:         "production",
+							Msg:         "production seed",
+							Enforcement: cmd.RestrictionEnforcementDeny,
+							Selector:    &cmd.AccessRestrictionSelector{Seeds: []string{seedName}},
+						}},
+					}},
+				}).Times(2)
+				target.EXPECT().K8SClientToKind(cmd.TargetKindGarden).Return(k8sClientToGarden, nil).AnyTimes()
+				target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+				target.EXPECT().GardenerClient().Return(clientSet, nil).AnyTimes()
+
+				ioStreams, _, out, errOut := cmd.NewTestIOStreams()
+				command = cmd.NewGetCmd(targetReader, configReader, kubeconfigReader, kubeconfigWriter, ioStreams)
+				command.SetArgs([]string{"shoot"})
+				err := command.Execute()
+
+				Expect(cmd.IsForbidden(err)).To(BeTrue())
+				Expect(err.Error()).To(Equal(`access to shoot "test-shoot" is denied by access restriction "production"`))
+				Expect(errOut.String()).To(Equal("production seed\n"))
+				Expect(out.String()).To(BeEmpty())
+			})
+
+			It("should not print the kubeconfig of a shoot of a read-only garden", func() {
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+					GardenClusters: []cmd.GardenClusterMeta{{Name: "test-garden", ReadOnly: true}},
+				}).AnyTimes()
+				target.EXPECT().K8SClientToKind(cmd.TargetKindGarden).Return(k8sClientToGarden, nil).AnyTimes()
+				target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+				target.EXPECT().GardenerClient().Return(clientSet, nil).AnyTimes()
+
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewGetCmd(targetReader, configReader, kubeconfigReader, kubeconfigWriter, ioStreams)
+				command.SetArgs([]string{"shoot"})
+				err := command.Execute()
+
+				Expect(cmd.IsForbidden(err)).To(BeTrue())
+				Expect(err.Error()).To(Equal("test-garden/prod/test-shoot is read-only, get shoot is refused"))
+				Expect(out.String()).To(BeEmpty())
+			})
+
 			It("should pass on get target", func() {
 				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
 				target.EXPECT().Stack().Return([]cmd.TargetMeta{})
@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,7 +23,7 @@ import (
 )
 
 // NewHcloudCmd returns a new hetzner cloud command.
-func NewHcloudCmd(targetReader TargetReader) *cobra.Command {
+func NewHcloudCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "hcloud <args>",
 		Short:              "e.g. \"gardenctl hcloud server list\"",
@@ -33,15 +32,25 @@ func NewHcloudCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "hcloud"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 			if !CheckToolInstalled("hcloud") {
 				fmt.Println("Please go to https://github.com/hetznercloud/cli for how to install hcloud cli")
-				os.Exit(2)
+				return NewToolMissingError("hcloud")
 			}
 
 			arguments := strings.Join(os.Args[2:], " ")
-			fmt.Println(operate("hcloud", arguments))
+			output, err := operate(targetReader, "hcloud", arguments)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
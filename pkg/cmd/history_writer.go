@@ -17,32 +17,33 @@ package cmd
 import (
 	"encoding/json"
 	"errors"
-	"os"
-)
+	"time"
 
-var tmp string
+	"github.com/gardener/gardenctl/pkg/internal/history"
+)
 
-// WriteStringln writes history to given path
+// WriteStringln writes a history entry with the current time for the given target info or history
+// line to the given path
 func (w *GardenctlHistoryWriter) WriteStringln(historyPath string, i interface{}) error {
-	f, err := os.OpenFile(historyPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
-	if err != nil {
-		return err
-	}
-
+	var line string
 	switch x := i.(type) {
 	case map[string]string:
 		j, err := json.Marshal(x)
 		if err != nil {
 			return err
 		}
-		tmp = string(j)
+		line = string(j)
 
 	case string:
-		tmp = x
+		line = x
 	default:
 		return errors.New("Invalid type not supported")
 	}
 
-	_, err = f.WriteString(tmp + "\n")
-	return err
+	entry, err := history.ParseEntry(line)
+	if err != nil {
+		return err
+	}
+	entry.Timestamp = time.Now()
+	return history.Append(historyPath, entry)
 }
@@ -15,10 +15,9 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
+	"io"
 	"sort"
-
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -35,7 +34,7 @@ func NewInfoCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
 			target := targetReader.ReadTarget(pathTarget)
 			targetStack := target.Stack()
 			if len(targetStack) < 1 {
-				return errors.New("no garden cluster targeted")
+				return NewNotTargetedError(TargetKindGarden)
 			}
 
 			// Show landscape
@@ -49,48 +48,62 @@ func NewInfoCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
 				return err
 			}
 
-			var (
-				unscheduled                  = 0
-				hibernatedShootsCount        = 0
-				totalShootsCountPerSeed      = make(map[string]int)
-				hibernatedShootsCountPerSeed = make(map[string]int)
-			)
-
+			landscape := Landscape{Garden: targetStack[0].Name}
+			seeds := make(map[string]*LandscapeSeedMeta)
 			for _, shoot := range shootList.Items {
+				landscape.Total++
 				if shoot.Spec.SeedName == nil {
-					unscheduled++
+					landscape.Unscheduled++
 					continue
 				}
-				totalShootsCountPerSeed[*shoot.Spec.SeedName]++
+				seed, ok := seeds[*shoot.Spec.SeedName]
+				if !ok {
+					seed = &LandscapeSeedMeta{Seed: *shoot.Spec.SeedName}
+					seeds[*shoot.Spec.SeedName] = seed
+				}
+				seed.Total++
 				if shoot.Status.IsHibernated {
-					hibernatedShootsCountPerSeed[*shoot.Spec.SeedName]++
-					hibernatedShootsCount++
+					seed.Hibernated++
+					landscape.Hibernated++
+				} else {
+					seed.Active++
+					landscape.Active++
 				}
 			}
-
-			var sortedSeeds []string
-			for seed := range totalShootsCountPerSeed {
-				sortedSeeds = append(sortedSeeds, seed)
+			for _, seed := range seeds {
+				landscape.Seeds = append(landscape.Seeds, *seed)
 			}
-			sort.Strings(sortedSeeds)
+			sort.Slice(landscape.Seeds, func(i, j int) bool { return landscape.Seeds[i].Seed < landscape.Seeds[j].Seed })
+
+			return PrintoutObject(landscape, ioStreams.Out, outputFormatOf(cmd, outputFormatTable))
+		},
+	}
+}
 
-			fmt.Fprintf(ioStreams.Out, "Garden: %s\n", targetStack[0].Name)
+// PrintTable prints the number of shoots per seed of the landscape as table
+func (l Landscape) PrintTable(w io.Writer, wide bool) error {
+	fmt.Fprintf(w, "Garden: %s\n", l.Garden)
 
-			w := tabwriter.NewWriter(ioStreams.Out, 6, 0, 20, ' ', 0)
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "Seed", "Total", "Active", "Hibernated")
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "----", "-----", "------", "----------")
+	tw := tabwriter.NewWriter(w, 6, 0, 20, ' ', 0)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "Seed", "Total", "Active", "Hibernated")
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "----", "-----", "------", "----------")
 
-			for _, seed := range sortedSeeds {
-				fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", seed, totalShootsCountPerSeed[seed], totalShootsCountPerSeed[seed]-hibernatedShootsCountPerSeed[seed], hibernatedShootsCountPerSeed[seed])
-			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "----", "-----", "------", "----------")
-			fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", "TOTAL", len(shootList.Items), len(shootList.Items)-hibernatedShootsCount-unscheduled, hibernatedShootsCount)
-			fmt.Fprintf(w, "%s\t%d\n", "Unscheduled", unscheduled)
+	for _, seed := range l.Seeds {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", seed.Seed, seed.Total, seed.Active, seed.Hibernated)
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", "----", "-----", "------", "----------")
+	fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", "TOTAL", l.Total, l.Active, l.Hibernated)
+	fmt.Fprintf(tw, "%s\t%d\n", "Unscheduled", l.Unscheduled)
 
-			fmt.Fprintln(w)
-			w.Flush()
+	fmt.Fprintln(tw)
+	return tw.Flush()
+}
 
-			return nil
-		},
+// Names returns the names of the seeds of the landscape
+func (l Landscape) Names() []string {
+	var names []string
+	for _, seed := range l.Seeds {
+		names = append(names, seed.Seed)
 	}
+	return names
 }
@@ -14,17 +14,8 @@
 
 package cmd
 
-import (
-	"io/ioutil"
-	"os"
-	"path"
-)
-
-// Write writes kubeconfig to given path
+// Write writes kubeconfig to given path in the credentials cache
 func (w *GardenctlKubeconfigWriter) Write(kubeconfigPath string, kubeconfig []byte) error {
-	if err := os.MkdirAll(path.Dir(kubeconfigPath), os.ModePerm); err != nil {
-		return err
-	}
-
-	return ioutil.WriteFile(kubeconfigPath, kubeconfig, 0644)
+	_, err := writeCacheFile(kubeconfigPath, kubeconfig)
+	return err
 }
@@ -15,88 +15,99 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // NewKubectlCmd returns a new kubectl command.
-func NewKubectlCmd() *cobra.Command {
+func NewKubectlCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "kubectl <args>",
 		Short:              "e.g. \"gardenctl kubectl get pods -n kube-system\"",
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		Aliases:            []string{"k"},
-		Run: func(cmd *cobra.Command, args []string) {
-			arguments := "kubectl " + strings.Join(os.Args[2:], " ")
-			kube(arguments)
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments := "kubectl " + strings.Join(withoutConfirmRestrictionFlags(os.Args[2:]), " ")
+			return kube(targetReader, configReader, ioStreams, arguments)
 		},
 	}
 }
 
 // NewKaCmd returns a new 'kubectl --all-namespaces' command.
-func NewKaCmd() *cobra.Command {
+func NewKaCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "ka",
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		Hidden:             true,
-		Run: func(cmd *cobra.Command, args []string) {
-			arguments := "kubectl " + strings.Join(os.Args[2:], " ") + " --all-namespaces=true"
-			kube(arguments)
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments := "kubectl " + strings.Join(withoutConfirmRestrictionFlags(os.Args[2:]), " ") + " --all-namespaces=true"
+			return kube(targetReader, configReader, ioStreams, arguments)
 		},
 	}
 }
 
 // NewKsCmd returns a new 'kubectl --namespace=kube-system' command.
-func NewKsCmd() *cobra.Command {
+func NewKsCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "ks",
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		Hidden:             true,
-		Run: func(cmd *cobra.Command, args []string) {
-			arguments := "kubectl " + strings.Join(os.Args[2:], " ") + " --namespace=kube-system"
-			kube(arguments)
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments := "kubectl " + strings.Join(withoutConfirmRestrictionFlags(os.Args[2:]), " ") + " --namespace=kube-system"
+			return kube(targetReader, configReader, ioStreams, arguments)
 		},
 	}
 }
 
 // NewKgCmd returns a new 'kubectl --namespace=garden' command.
-func NewKgCmd() *cobra.Command {
+func NewKgCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "kg",
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		Hidden:             true,
-		Run: func(cmd *cobra.Command, args []string) {
-			arguments := "kubectl " + strings.Join(os.Args[2:], " ") + " --namespace=garden"
-			kube(arguments)
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments := "kubectl " + strings.Join(withoutConfirmRestrictionFlags(os.Args[2:]), " ") + " --namespace=garden"
+			return kube(targetReader, configReader, ioStreams, arguments)
 		},
 	}
 }
 
 // NewKnCmd returns a new 'kubectl --namespace=<arg>' command.
-func NewKnCmd() *cobra.Command {
+func NewKnCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:                "kn",
 		FParseErrWhitelist: cobra.FParseErrWhitelist{UnknownFlags: true},
 		Hidden:             true,
-		Run: func(cmd *cobra.Command, args []string) {
-			arguments := "kubectl --namespace=" + strings.Join(os.Args[2:], " ")
-			kube(arguments)
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments := "kubectl --namespace=" + strings.Join(withoutConfirmRestrictionFlags(os.Args[2:]), " ")
+			return kube(targetReader, configReader, ioStreams, arguments)
 		},
 	}
 }
 
 // kube executes a kubectl command on targeted cluster
-func kube(args string) {
-	KUBECONFIG = getKubeConfigOfCurrentTarget()
-	_, err := exec.LookPath("kubectl")
+func kube(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams, args string) error {
+	target := targetReader.ReadTarget(pathTarget)
+	if kubectlArgs := strings.Fields(args)[1:]; !IsReadOnlyKubectlCommand(kubectlArgs) {
+		command, _ := kubectlCommand(kubectlArgs)
+		if err := checkReadOnly(target, configReader, "kubectl "+command); err != nil {
+			return err
+		}
+	}
+	if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+		return err
+	}
+
+	kubeconfig, err := getKubeConfigOfCurrentTarget(configReader)
 	if err != nil {
-		fmt.Println("Kubectl is not installed on your system")
-		os.Exit(2)
+		return err
 	}
-	err = ExecCmd(nil, args, false, "KUBECONFIG="+KUBECONFIG)
-	checkError(err)
+	return ExecCmd(nil, args, false, "KUBECONFIG="+kubeconfig)
 }
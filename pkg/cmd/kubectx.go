@@ -16,7 +16,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"strings"
 
@@ -24,27 +23,28 @@ import (
 )
 
 // NewKubectxCmd returns a new kubectx command.
-func NewKubectxCmd() *cobra.Command {
+func NewKubectxCmd(configReader ConfigReader) *cobra.Command {
 	return &cobra.Command{
-		Use:     "kubectx <args>",
-		Aliases: []string{"kx"},
-		Short:   "e.g. \"gardenctl kubectx context_name\"",
-		Run: func(cmd *cobra.Command, args []string) {
+		Use:          "kubectx <args>",
+		Aliases:      []string{"kx"},
+		Short:        "e.g. \"gardenctl kubectx context_name\"",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			arguments := "kubectx " + strings.Join(args[:], " ")
-			kubectx(arguments)
+			return kubectx(configReader, arguments)
 		},
 	}
 }
 
 // kubectx executes a kubectx command on targeted cluster
-func kubectx(args string) {
-	KUBECONFIG = getKubeConfigOfCurrentTarget()
-	_, err := exec.LookPath("kubectx")
+func kubectx(configReader ConfigReader, args string) error {
+	kubeconfig, err := getKubeConfigOfCurrentTarget(configReader)
 	if err != nil {
-		fmt.Println("Kubectx is not installed on your system")
+		return err
+	}
+	if _, err = exec.LookPath("kubectx"); err != nil {
 		fmt.Println("Please go to https://github.com/ahmetb/kubectx for how to install it")
-		os.Exit(2)
+		return NewToolMissingError("kubectx")
 	}
-	err = ExecCmd(nil, args, false, "KUBECONFIG="+KUBECONFIG)
-	checkError(err)
+	return ExecCmd(nil, args, false, "KUBECONFIG="+kubeconfig)
 }
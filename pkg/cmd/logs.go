@@ -18,11 +18,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
 	"path"
-	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,7 +30,6 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 const (
@@ -45,7 +42,7 @@ const (
 var flags *logFlags
 
 // NewLogsCmd returns a new logs command.
-func NewLogsCmd(targetReader TargetReader) *cobra.Command {
+func NewLogsCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	flags = newLogsFlags()
 	cmd := &cobra.Command{
 		Use:          "logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-main-backup|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|gardenlet|tf (infra|dns|ingress)|cluster-autoscaler)",
@@ -56,9 +53,13 @@ func NewLogsCmd(targetReader TargetReader) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			validateFlags(flags)
-			runCommand(targetReader, args)
-			return nil
+			if err := validateFlags(flags); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(targetReader.ReadTarget(pathTarget), configReader, ioStreams); err != nil {
+				return err
+			}
+			return runCommand(targetReader, args)
 		},
 		ValidArgs: []string{"gardener-apiserver", "gardener-controller-manager", "gardener-dashboard", "api", "scheduler", "controller-manager", "etcd-operator", "etcd-main", "etcd-events", "addon-manager", "vpn-seed", "vpn-shoot", "auto-node-repair", "kubernetes-dashboard", "prometheus", "grafana", "gardenlet", "tf"},
 		Aliases:   []string{"log"},
@@ -75,160 +76,194 @@ func validateArgs(targetReader TargetReader, args []string) error {
 	if len(args) < 1 || len(args) > 3 {
 		return errors.New("Command must be in the format: logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|gardenlet|tf (infra|dns|ingress)|cluster-autoscaler flags(--loki|--tail|--since|--since-time|--timestamps)")
 	}
-	var t Target
-	ReadTarget(pathTarget, &t)
 	if !IsTargeted(targetReader, "shoot") && args[0] != "all" && args[0] != "api" && args[0] != "scheduler" && args[0] != "controller-manager" && args[0] != "etcd-main" && args[0] != "etcd-events" && args[0] != "machine-controller-manager" && args[0] != "prometheus" && args[0] != "cluster-autoscaler" && args[0] != "vpn-seed" && args[0] != "gardenlet" && args[0] != "gardener-apiserver" && args[0] != "gardener-controller-manager" && args[0] != "tf" && args[0] != "kubernetes-dashboard" {
-		return errors.New("No shoot targeted")
+		return NewNotTargetedError(TargetKindShoot)
 	} else if !(IsTargeted(targetReader, "project") || IsTargeted(targetReader, "shoot") || IsTargeted(targetReader, "seed") || IsTargeted(targetReader, "namespace")) && args[0] == "tf" {
-		return errors.New("No seed or shoot targeted")
+		return &Error{Reason: ReasonNotTargeted, Message: "no seed or shoot targeted"}
 	} else if !IsTargeted(targetReader) {
-		return errors.New("Target stack is empty")
+		return NewNotTargetedError(TargetKindGarden)
 	}
 	return nil
 }
 
-func validateFlags(flags *logFlags) {
+func validateFlags(flags *logFlags) error {
 	if flags.sinceSeconds != 0 && flags.sinceTime != emptyString {
-		fmt.Println("Logs command can not contains --since and --since-time in the same time")
-		os.Exit(2)
+		return errors.New("logs command can not contain --since and --since-time at the same time")
 	} else if flags.sinceTime != emptyString {
 		value, err := time.Parse(time.RFC3339, flags.sinceTime)
 		if err != nil {
-			fmt.Println("Incorrect value for flag: --since-time")
-			os.Exit(2)
-		} else {
-			flags.sinceSeconds = time.Since(value)
+			return errors.New("incorrect value for flag: --since-time")
 		}
+		flags.sinceSeconds = time.Since(value)
 	} else if flags.tail < 0 {
-		fmt.Println("Incorrect value for flag: --tail, value must be greater 0")
-		os.Exit(2)
+		return errors.New("incorrect value for flag: --tail, value must be greater 0")
 	} else if flags.loki && flags.tail > maxLokiLogs {
-		fmt.Printf("Maximum number of logs that can be fetched from loki is %d", maxLokiLogs)
-		os.Exit(2)
+		return fmt.Errorf("maximum number of logs that can be fetched from loki is %d", maxLokiLogs)
 	}
+	return nil
 }
 
-func runCommand(targetReader TargetReader, args []string) {
+func runCommand(targetReader TargetReader, args []string) error {
 	switch args[0] {
 	case "all":
-		saveLogsAll(targetReader)
+		return saveLogsAll(targetReader)
 	case "gardener-apiserver":
-		logsGardenerApiserver()
+		return logsGardenerApiserver(targetReader)
 	case "gardener-controller-manager":
-		logsGardenerControllerManager(targetReader)
+		return logsGardenerControllerManager(targetReader)
 	case "gardener-dashboard":
-		logsGardenerDashboard()
+		return logsGardenerDashboard(targetReader)
 	case "api":
-		logsAPIServer(targetReader)
+		return logsAPIServer(targetReader)
 	case "scheduler":
-		logsScheduler(targetReader)
+		return logsScheduler(targetReader)
 	case "controller-manager":
-		logsControllerManager(targetReader)
+		return logsControllerManager(targetReader)
 	case "etcd-operator":
-		logsEtcdOpertor()
+		return logsEtcdOpertor(targetReader)
 	case "etcd-main":
 		if len(args) == 2 {
-			logsEtcdMain(targetReader, args[1])
-		} else {
-			logsEtcdMain(targetReader, emptyString)
+			return logsEtcdMain(targetReader, args[1])
 		}
+		return logsEtcdMain(targetReader, emptyString)
 	case "etcd-main-backup":
-		logsEtcdMainBackup(targetReader)
+		return logsEtcdMainBackup(targetReader)
 	case "etcd-events":
 		if len(args) == 2 {
-			logsEtcdEvents(targetReader, args[1])
-		} else {
-			logsEtcdEvents(targetReader, emptyString)
+			return logsEtcdEvents(targetReader, args[1])
 		}
+		return logsEtcdEvents(targetReader, emptyString)
 	case "addon-manager":
-		logsAddonManager(targetReader)
+		return logsAddonManager(targetReader)
 	case "vpn-seed":
 		if len(args) == 2 {
-			logsVpnSeed(targetReader, args[1])
-		} else {
-			logsVpnSeed(targetReader, emptyString)
+			return logsVpnSeed(targetReader, args[1])
 		}
+		return logsVpnSeed(targetReader, emptyString)
 	case "vpn-shoot":
-		logsVpnShoot()
+		return logsVpnShoot(targetReader)
 	case "machine-controller-manager":
-		logsMachineControllerManager(targetReader)
+		return logsMachineControllerManager(targetReader)
 	case "kubernetes-dashboard":
-		logsKubernetesDashboard(targetReader)
+		return logsKubernetesDashboard(targetReader)
 	case "prometheus":
-		logsPrometheus(targetReader)
+		return logsPrometheus(targetReader)
 	case "grafana":
-		logsGrafana(targetReader)
+		return logsGrafana(targetReader)
 	case "gardenlet":
-		logsGardenlet()
+		return logsGardenlet(targetReader)
 	case "cluster-autoscaler":
-		logsClusterAutoscaler(targetReader)
+		return logsClusterAutoscaler(targetReader)
 	case "tf":
 		if len(args) == 1 || len(args) < 3 {
-			logsTfHelp()
-			break
+			return logsTfHelp()
 		}
 
 		var prefixName string = (args[02])
 		switch args[1] {
 		case "infra":
 			str := prefixName + ".infra.tf"
-			logsInfra(str)
+			return logsInfra(targetReader, str)
 		case "dns":
 			str := prefixName + ".dns.tf"
-			logsDNS(str)
+			return logsDNS(targetReader, str)
 		case "ingress":
 			str := prefixName + ".ingress.tf"
-			logsIngress(str)
+			return logsIngress(targetReader, str)
 		default:
-			fmt.Println("Command must be in the format: logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|auto-node-repair|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
+			return errors.New("Command must be in the format: logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|auto-node-repair|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
 		}
 	default:
-		fmt.Println("Command must be in the format: logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|auto-node-repair|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
+		return errors.New("Command must be in the format: logs (gardener-apiserver|gardener-controller-manager|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main[etcd backup-restore]|etcd-events[etcd backup-restore]|addon-manager|vpn-seed|vpn-shoot|auto-node-repair|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
 	}
 }
 
-func saveLogsAll(targetReader TargetReader) {
+func saveLogsAll(targetReader TargetReader) error {
 	//flags.sinceSeconds = 600 * time.Second
 	if _, err := os.Stat("./logs/"); !os.IsNotExist(err) {
 		os.RemoveAll("./logs/")
 	}
 	err := os.MkdirAll("./logs/", os.ModePerm)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println("APIServer/Scheduler/ControllerManager/etcd/AddonManager/VpnShoot/Dashboard/Prometheus/Gardenlet/Autoscaler logs will be downloaded")
 
-	saveLogsAPIServer(targetReader)
-	saveLogsScheduler(targetReader)
-	saveLogsControllerManager(targetReader)
-	saveLogsEtcdMain(targetReader, "etcd")
-	saveLogsEtcdMain(targetReader, "backup-restore")
-	saveLogsEtcdMainBackup(targetReader)
-	saveLogsEtcdEvents(targetReader, "etcd")
-	saveLogsEtcdEvents(targetReader, "backup-restore")
-	saveLogsAddonManager(targetReader)
-	saveLogsVpnShoot()
-	saveLogsMachineControllerManager(targetReader)
-	saveLogsKubernetesDashboard()
-	saveLogsPrometheus(targetReader)
-	saveLogsGardenlet()
-	saveLogsClusterAutoscaler(targetReader)
-	saveLogsGrafana(targetReader)
-
-	var target Target
-	ReadTarget(pathTarget, &target)
-	if !(len(target.Target) < 3 || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace")) {
+	if err := saveLogsAPIServer(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsScheduler(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsControllerManager(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsEtcdMain(targetReader, "etcd"); err != nil {
+		return err
+	}
+	if err := saveLogsEtcdMain(targetReader, "backup-restore"); err != nil {
+		return err
+	}
+	if err := saveLogsEtcdMainBackup(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsEtcdEvents(targetReader, "etcd"); err != nil {
+		return err
+	}
+	if err := saveLogsEtcdEvents(targetReader, "backup-restore"); err != nil {
+		return err
+	}
+	if err := saveLogsAddonManager(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsVpnShoot(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsMachineControllerManager(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsKubernetesDashboard(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsPrometheus(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsGardenlet(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsClusterAutoscaler(targetReader); err != nil {
+		return err
+	}
+	if err := saveLogsGrafana(targetReader); err != nil {
+		return err
+	}
+
+	target := targetReader.ReadTarget(pathTarget)
+	if !(len(target.Stack()) < 3 || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace")) {
 		shoot, err := GetTargetedShootObject(targetReader)
-		checkError(err)
-		saveLogsTerraform(shoot.Name + ".infra.tf")
-		saveLogsTerraform(shoot.Name + ".dns.tf")
-		saveLogsTerraform(shoot.Name + ".ingress.tf")
+		if err != nil {
+			return err
+		}
+		if err := saveLogsTerraform(targetReader, shoot.Name+".infra.tf"); err != nil {
+			return err
+		}
+		if err := saveLogsTerraform(targetReader, shoot.Name+".dns.tf"); err != nil {
+			return err
+		}
+		if err := saveLogsTerraform(targetReader, shoot.Name+".ingress.tf"); err != nil {
+			return err
+		}
 
 	}
 
 	path, err := os.Getwd()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println("All logs have been saved in " + path + "/logs/ folder")
+	return nil
 }
 
 //VersionGreaterThanLokiRelease checks if provided version supports Loki
@@ -237,7 +272,7 @@ func VersionGreaterThanLokiRelease(version string) bool {
 
 	ver, err := semver.NewVersion(version)
 	if err != nil {
-		log.Fatal(err)
+		return false
 	}
 
 	if ver.Compare(lokiRelease) > 0 {
@@ -247,90 +282,100 @@ func VersionGreaterThanLokiRelease(version string) bool {
 }
 
 // showPod is an abstraction to show pods in seed cluster controlplane or kube-system namespace of shoot
-func logPod(targetReader TargetReader, toMatch string, toTarget string, container string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
+func logPod(targetReader TargetReader, toMatch string, toTarget string, container string) error {
 	if !IsTargeted(targetReader, "shoot") {
-		log.Fatal("No shoot targeted")
+		return NewNotTargetedError(TargetKindShoot)
 	}
 
-	namespace := GetFromTargetInfo(targetReader, "shootTechnicalID")
-	var err error
+	namespace, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	shoot, err := GetTargetedShootObject(targetReader)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
-	Client, err = clientToTarget("seed")
-	checkError(err)
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
 	if toTarget == "shoot" {
 		namespace = "kube-system"
-		Client, err = clientToTarget(TargetKindShoot)
-		checkError(err)
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindShoot)
+		if err != nil {
+			return err
+		}
 	}
 
 	if flags.loki {
-		if VersionGreaterThanLokiRelease(shoot.Status.Gardener.Version) {
-			showLogsFromLoki(namespace, toMatch, container)
-		} else {
-			fmt.Println("--loki flag is available only for gardener version >= 1.8.0")
-			fmt.Println("Current version: " + shoot.Status.Gardener.Version)
-			os.Exit(2)
+		if !VersionGreaterThanLokiRelease(shoot.Status.Gardener.Version) {
+			return fmt.Errorf("--loki flag is available only for gardener version >= 1.8.0, current version: %s", shoot.Status.Gardener.Version)
 		}
-
-	} else {
-		showLogsFromKubectl(namespace, toMatch, container)
+		return showLogsFromLoki(kubeconfig, namespace, toMatch, container)
 	}
+	return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 }
 
 // showPod is an abstraction to show pods in seed cluster controlplane or kube-system namespace of shoot
-func saveLogPod(targetReader TargetReader, toMatch string, toTarget string, container string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	if len(target.Target) < 3 || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace") {
-		fmt.Println("No shoot targeted")
-		os.Exit(2)
-	}
-	namespace := getSeedNamespaceNameForShoot(target.Target[2].Name)
-	var err error
+func saveLogPod(targetReader TargetReader, toMatch string, toTarget string, container string) error {
+	target := targetReader.ReadTarget(pathTarget)
+	if len(target.Stack()) < 3 || (len(target.Stack()) == 3 && target.Stack()[2].Kind == "namespace") {
+		return NewNotTargetedError(TargetKindShoot)
+	}
+	namespace, err := getSeedNamespaceNameForShoot(targetReader, target.Stack()[2].Name)
+	if err != nil {
+		return err
+	}
 	shoot, err := GetTargetedShootObject(targetReader)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	gardenerVersion, err := semver.NewVersion(shoot.Status.Gardener.Version)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	greaterThanLokiRelease, err := semver.NewConstraint(">=1.8.0")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
-	Client, err = clientToTarget("seed")
-	checkError(err)
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
 	if toTarget == "shoot" {
 		namespace = "kube-system"
-		Client, err = clientToTarget(TargetKindShoot)
-		checkError(err)
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindShoot)
+		if err != nil {
+			return err
+		}
 	}
 
 	if flags.loki {
-		if greaterThanLokiRelease.Check(gardenerVersion) {
-			saveLogsFromLoki(namespace, toMatch, container)
-		} else {
-			fmt.Println("--loki flag is available only for gardener version >= 1.8.0")
-			fmt.Println("Current version: " + gardenerVersion.String())
-			os.Exit(2)
+		if !greaterThanLokiRelease.Check(gardenerVersion) {
+			return fmt.Errorf("--loki flag is available only for gardener version >= 1.8.0, current version: %s", gardenerVersion.String())
 		}
-
-	} else {
-		saveLogsFromKubectl(namespace, toMatch, container)
+		return saveLogsFromLoki(kubeconfig, namespace, toMatch, container)
 	}
+	return saveLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 }
 
-func saveLogsFromLoki(namespace, toMatch, container string) {
-	args := BuildLokiCommandArgs(KUBECONFIG, namespace, toMatch, container, flags.tail, flags.sinceSeconds)
+func saveLogsFromLoki(kubeconfig string, namespace, toMatch, container string) error {
+	args := BuildLokiCommandArgs(kubeconfig, namespace, toMatch, container, flags.tail, flags.sinceSeconds)
 	cmdResult := "kubectl " + strings.Join(args, " ")
 	output, err := ExecCmdReturnOutput("bash", "-c", cmdResult)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	byteOutput := []byte(output)
 	var response logResponseLoki
 	err = json.Unmarshal(byteOutput, &response)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	fmt.Println("the response is")
 	fmt.Println(response)
 
@@ -342,57 +387,79 @@ func saveLogsFromLoki(namespace, toMatch, container string) {
 	fileName = fileName + ".log"
 
 	f, err := os.Create(fileName)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	defer f.Close()
 	_, err = f.WriteString(fmt.Sprintf("%v", response))
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	err = f.Sync()
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
-func showLogsFromKubectl(namespace, toMatch, container string) {
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
+func showLogsFromKubectl(client kubernetes.Interface, kubeconfig string, namespace, toMatch, container string) error {
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, toMatch) {
-			output, err := ExecCmdReturnOutput("kubectl", BuildLogCommandArgs(KUBECONFIG, namespace, pod.Name, container, flags.tail, flags.sinceSeconds)...)
-			checkError(err)
+			output, err := ExecCmdReturnOutput("kubectl", BuildLogCommandArgs(kubeconfig, namespace, pod.Name, container, flags.tail, flags.sinceSeconds)...)
+			if err != nil {
+				return err
+			}
 			fmt.Println(output)
 		}
 	}
+	return nil
 }
 
-func saveLogsFromKubectl(namespace, toMatch, container string) {
+func saveLogsFromKubectl(client kubernetes.Interface, kubeconfig string, namespace, toMatch, container string) error {
 	fileName := "./logs/"
 	fileName += namespace + "_" + toMatch
 	if container != emptyString {
 		fileName = fileName + "_" + container
 	}
 	fileName = fileName + ".log"
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, toMatch) {
-			args := BuildLogCommandArgs(KUBECONFIG, namespace, pod.Name, container, flags.tail, flags.sinceSeconds)
+			args := BuildLogCommandArgs(kubeconfig, namespace, pod.Name, container, flags.tail, flags.sinceSeconds)
 			cmdResult := "kubectl " + strings.Join(args, " ")
 			err := ExecCmdSaveOutputFile(nil, cmdResult, fileName)
-			checkError(err)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func showLogsFromLoki(namespace, toMatch, container string) {
-	output, err := ExecCmdReturnOutput("kubectl", BuildLokiCommandArgs(KUBECONFIG, namespace, toMatch, container, flags.tail, flags.sinceSeconds)...)
-	checkError(err)
+func showLogsFromLoki(kubeconfig string, namespace, toMatch, container string) error {
+	output, err := ExecCmdReturnOutput("kubectl", BuildLokiCommandArgs(kubeconfig, namespace, toMatch, container, flags.tail, flags.sinceSeconds)...)
+	if err != nil {
+		return err
+	}
 
 	byteOutput := []byte(output)
 	var response logResponseLoki
 	err = json.Unmarshal(byteOutput, &response)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println(response)
-	fmt.Println("COMMAND: ", "kubectl", BuildLokiCommandArgs(KUBECONFIG, namespace, toMatch, container, flags.tail, flags.sinceSeconds))
+	fmt.Println("COMMAND: ", "kubectl", BuildLokiCommandArgs(kubeconfig, namespace, toMatch, container, flags.tail, flags.sinceSeconds))
 	fmt.Println("KRIS LENGTH: ", len(response.Data.Result))
+	return nil
 }
 
 //BuildLogCommandArgs build kubectl command to get logs
@@ -460,79 +527,85 @@ func BuildLokiCommandArgs(kubeconfig string, namespace, podName, container strin
 }
 
 // logPodGarden print logfiles for garden pods
-func logPodGarden(toMatch, namespace string) {
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	showLogsFromKubectl(namespace, toMatch, emptyString)
+func logPodGarden(targetReader TargetReader, toMatch, namespace string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, emptyString)
 }
 
 // logPodSeed print logfiles for Seed pods
-func logPodSeed(toMatch, namespace string, container string) {
-	var err error
-	Client, err = clientToTarget(TargetKindSeed)
-	checkError(err)
+func logPodSeed(targetReader TargetReader, toMatch, namespace string, container string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
 	if container != emptyString {
-		showLogsFromKubectl(namespace, toMatch, container)
-	} else {
-		showLogsFromKubectl(namespace, toMatch, emptyString)
+		return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 	}
+	return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, emptyString)
 }
 
-func saveLogPodSeed(toMatch, namespace string, container string) {
-	var err error
-	Client, err = clientToTarget(TargetKindSeed)
-	checkError(err)
+func saveLogPodSeed(targetReader TargetReader, toMatch, namespace string, container string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
 	if container != emptyString {
-		saveLogsFromKubectl(namespace, toMatch, container)
-	} else {
-		saveLogsFromKubectl(namespace, toMatch, emptyString)
+		return saveLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 	}
+	return saveLogsFromKubectl(client, kubeconfig, namespace, toMatch, emptyString)
 }
 
 // logPodShoot print logfiles for shoot pods
-func logPodShoot(toMatch, namespace string, container string) {
-	var err error
-	Client, err = clientToTarget(TargetKindShoot)
-	checkError(err)
+func logPodShoot(targetReader TargetReader, toMatch, namespace string, container string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindShoot)
+	if err != nil {
+		return err
+	}
 	if container != emptyString {
-		showLogsFromKubectl(namespace, toMatch, container)
-	} else {
-		showLogsFromKubectl(namespace, toMatch, emptyString)
+		return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 	}
+	return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, emptyString)
 }
 
-func saveLogPodShoot(toMatch, namespace string, container string) {
-	var err error
-	Client, err = clientToTarget(TargetKindShoot)
-	checkError(err)
+func saveLogPodShoot(targetReader TargetReader, toMatch, namespace string, container string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindShoot)
+	if err != nil {
+		return err
+	}
 	if container != emptyString {
 		container = " -c " + container
-		saveLogsFromKubectl(namespace, toMatch, container)
-	} else {
-		saveLogsFromKubectl(namespace, toMatch, emptyString)
+		return saveLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 	}
+	return saveLogsFromKubectl(client, kubeconfig, namespace, toMatch, emptyString)
 }
 
 // logPodGardenImproved print logfiles for garden pods
-func logPodGardenImproved(targetReader TargetReader, podName string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	Client, err := clientToTarget("garden")
-	checkError(err)
-	pods, err := Client.CoreV1().Pods("garden").List(metav1.ListOptions{})
-	checkError(err)
+func logPodGardenImproved(targetReader TargetReader, podName string) error {
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods("garden").List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
 	project, err := GetTargetName(targetReader, "project")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	shootName, err := GetTargetName(targetReader, "shoot")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, podName) {
-			output, err := ExecCmdReturnOutput("kubectl", BuildLogCommandArgs(KUBECONFIG, "garden", pod.Name, emptyString, flags.tail, flags.sinceSeconds)...)
+			output, err := ExecCmdReturnOutput("kubectl", BuildLogCommandArgs(kubeconfig, "garden", pod.Name, emptyString, flags.tail, flags.sinceSeconds)...)
 			if err != nil {
-				fmt.Println("Cmd was unsuccessful")
-				os.Exit(2)
+				return err
 			}
 			lines := strings.Split("time="+output, `time=`)
 			for _, line := range lines {
@@ -542,320 +615,331 @@ func logPodGardenImproved(targetReader TargetReader, podName string) {
 			}
 		}
 	}
+	return nil
 }
 
 // logsGardenerApiserver prints the logfile of the garndener-api-server
-func logsGardenerApiserver() {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	logPodGarden("gardener-apiserver", "garden")
+func logsGardenerApiserver(targetReader TargetReader) error {
+	return logPodGarden(targetReader, "gardener-apiserver", "garden")
 }
 
 // logsGardenerControllerManager prints the logfile of the gardener-controller-manager
-func logsGardenerControllerManager(targetReader TargetReader) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	if len(target.Target) != 3 {
-		logPodGarden("gardener-controller-manager", "garden")
-	} else {
-		logPodGardenImproved(targetReader, "gardener-controller-manager")
+func logsGardenerControllerManager(targetReader TargetReader) error {
+	target := targetReader.ReadTarget(pathTarget)
+	if len(target.Stack()) != 3 {
+		return logPodGarden(targetReader, "gardener-controller-manager", "garden")
 	}
+	return logPodGardenImproved(targetReader, "gardener-controller-manager")
 }
 
 // logsGardenerDashboard
-func logsGardenerDashboard() {
-	logPodGarden("gardener", "garden")
+func logsGardenerDashboard(targetReader TargetReader) error {
+	return logPodGarden(targetReader, "gardener", "garden")
 }
 
 //logPodWhileControlPlaneTargeted get log pod in shoot namespace while control plane targeted
-func logPodWhileControlPlaneTargeted(targetReader TargetReader, toMatch string, toTarget string, container string) {
+func logPodWhileControlPlaneTargeted(targetReader TargetReader, toMatch string, toTarget string, container string) error {
 	target := targetReader.ReadTarget(pathTarget)
 	if len(target.Stack()) != 3 {
-		fmt.Println("Control Plane not targeted")
-		os.Exit(2)
+		return NewNotTargetedError("control plane")
 	}
 	namespace := target.Stack()[2].Name
 	seed, err := GetSeedObject(targetReader, target.Stack()[1].Name)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	greaterThanLokiRelease, err := semver.NewConstraint(">=1.8.0")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	gardenerVersion, err := semver.NewVersion(seed.Status.Gardener.Version)
-	checkError(err)
-	Client, err = clientToTarget("seed")
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
 	if flags.loki {
-		if greaterThanLokiRelease.Check(gardenerVersion) {
-			showLogsFromLoki(namespace, toMatch, container)
-		} else {
-			fmt.Println("--loki flag is available only for gardener version >= 1.8.0")
-			fmt.Println("Current version: " + gardenerVersion.String())
-			os.Exit(2)
+		if !greaterThanLokiRelease.Check(gardenerVersion) {
+			return fmt.Errorf("--loki flag is available only for gardener version >= 1.8.0, current version: %s", gardenerVersion.String())
 		}
-
-	} else {
-		showLogsFromKubectl(namespace, toMatch, container)
+		return showLogsFromLoki(kubeconfig, namespace, toMatch, container)
 	}
+	return showLogsFromKubectl(client, kubeconfig, namespace, toMatch, container)
 }
 
 // logsAPIServer prints the logfile of the api-server
-func logsAPIServer(targetReader TargetReader) {
+func logsAPIServer(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "kube-apiserver", "seed", "kube-apiserver")
-	} else {
-		logPod(targetReader, "kube-apiserver", "seed", "kube-apiserver")
+		return logPodWhileControlPlaneTargeted(targetReader, "kube-apiserver", "seed", "kube-apiserver")
 	}
+	return logPod(targetReader, "kube-apiserver", "seed", "kube-apiserver")
 }
 
-func saveLogsAPIServer(targetReader TargetReader) {
-	saveLogPod(targetReader, "kube-apiserver", "seed", "kube-apiserver")
+func saveLogsAPIServer(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "kube-apiserver", "seed", "kube-apiserver")
 }
 
 // logsScheduler prints the logfile of the scheduler
-func logsScheduler(targetReader TargetReader) {
+func logsScheduler(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "kube-scheduler", "seed", emptyString)
-	} else {
-		logPod(targetReader, "kube-scheduler", "seed", emptyString)
+		return logPodWhileControlPlaneTargeted(targetReader, "kube-scheduler", "seed", emptyString)
 	}
+	return logPod(targetReader, "kube-scheduler", "seed", emptyString)
 }
 
-func saveLogsScheduler(targetReader TargetReader) {
-	saveLogPod(targetReader, "kube-scheduler", "seed", emptyString)
+func saveLogsScheduler(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "kube-scheduler", "seed", emptyString)
 }
 
 // logsAPIServer prints the logfile of the controller-manager
-func logsControllerManager(targetReader TargetReader) {
+func logsControllerManager(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "kube-controller-manager", "seed", emptyString)
-	} else {
-		logPod(targetReader, "kube-controller-manager", "seed", emptyString)
+		return logPodWhileControlPlaneTargeted(targetReader, "kube-controller-manager", "seed", emptyString)
 	}
+	return logPod(targetReader, "kube-controller-manager", "seed", emptyString)
 }
 
-func saveLogsControllerManager(targetReader TargetReader) {
-	saveLogPod(targetReader, "kube-controller-manager", "seed", emptyString)
+func saveLogsControllerManager(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "kube-controller-manager", "seed", emptyString)
 }
 
 // logsVpnSeed prints the logfile of the vpn-seed container
-func logsVpnSeed(targetReader TargetReader, shootTechnicalID string) {
+func logsVpnSeed(targetReader TargetReader, shootTechnicalID string) error {
 	fmt.Println("-----------------------Kube-Apiserver")
 	if IsControlPlaneTargeted(targetReader) {
 		target := targetReader.ReadTarget(pathTarget)
 		shootTechID := target.Stack()[2].Name
 		fmt.Println("in logsVpnSeed the namespace is" + shootTechID)
-		logPodSeed("kube-apiserver", shootTechID, "vpn-seed")
-	} else {
-		if shootTechnicalID == emptyString {
-			shootTechnicalID = GetFromTargetInfo(targetReader, "shootTechnicalID")
-			logPodSeed("kube-apiserver", shootTechnicalID, "vpn-seed")
-		} else {
-			logPodSeed("kube-apiserver", shootTechnicalID, "vpn-seed")
+		return logPodSeed(targetReader, "kube-apiserver", shootTechID, "vpn-seed")
+	}
+	if shootTechnicalID == emptyString {
+		var err error
+		shootTechnicalID, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+		if err != nil {
+			return err
 		}
 	}
+	return logPodSeed(targetReader, "kube-apiserver", shootTechnicalID, "vpn-seed")
 }
 
 // logsEtcdOpertor prints the logfile of the etcd-operator
-func logsEtcdOpertor() {
-	logPodGarden("etcd-operator", "kube-system")
+func logsEtcdOpertor(targetReader TargetReader) error {
+	return logPodGarden(targetReader, "etcd-operator", "kube-system")
 }
 
 // logsEtcdMain prints the logfile of etcd-main
-func logsEtcdMain(targetReader TargetReader, containerName string) {
+func logsEtcdMain(targetReader TargetReader, containerName string) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "etcd-main", "seed", containerName)
-	} else {
-		logPod(targetReader, "etcd-main", "seed", containerName)
+		return logPodWhileControlPlaneTargeted(targetReader, "etcd-main", "seed", containerName)
 	}
+	return logPod(targetReader, "etcd-main", "seed", containerName)
 }
 
-func saveLogsEtcdMain(targetReader TargetReader, containerName string) {
-	saveLogPod(targetReader, "etcd-main", "seed", containerName)
+func saveLogsEtcdMain(targetReader TargetReader, containerName string) error {
+	return saveLogPod(targetReader, "etcd-main", "seed", containerName)
 }
 
 // logsEtcdMainBackup prints logfiles of etcd-main-backup-sidecar pod
-func logsEtcdMainBackup(targetReader TargetReader) {
-	logPod(targetReader, "etcd-main-backup-sidecar", "seed", emptyString)
+func logsEtcdMainBackup(targetReader TargetReader) error {
+	return logPod(targetReader, "etcd-main-backup-sidecar", "seed", emptyString)
 }
 
-func saveLogsEtcdMainBackup(targetReader TargetReader) {
-	saveLogPod(targetReader, "etcd-main-backup-sidecar", "seed", emptyString)
+func saveLogsEtcdMainBackup(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "etcd-main-backup-sidecar", "seed", emptyString)
 }
 
 // logsEtcdEvents prints the logfile of etcd-events
-func logsEtcdEvents(targetReader TargetReader, containerName string) {
+func logsEtcdEvents(targetReader TargetReader, containerName string) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "etcd-events-", "seed", containerName)
-	} else {
-		logPod(targetReader, "etcd-events-", "seed", containerName)
+		return logPodWhileControlPlaneTargeted(targetReader, "etcd-events-", "seed", containerName)
 	}
+	return logPod(targetReader, "etcd-events-", "seed", containerName)
 }
 
-func saveLogsEtcdEvents(targetReader TargetReader, containerName string) {
-	saveLogPod(targetReader, "etcd-events-", "seed", containerName)
+func saveLogsEtcdEvents(targetReader TargetReader, containerName string) error {
+	return saveLogPod(targetReader, "etcd-events-", "seed", containerName)
 }
 
 // logsAddonManager prints the logfile of addon-manager
-func logsAddonManager(targetReader TargetReader) {
-	logPod(targetReader, "addon-manager", "seed", emptyString)
+func logsAddonManager(targetReader TargetReader) error {
+	return logPod(targetReader, "addon-manager", "seed", emptyString)
 }
 
-func saveLogsAddonManager(targetReader TargetReader) {
-	saveLogPod(targetReader, "addon-manager", "seed", emptyString)
+func saveLogsAddonManager(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "addon-manager", "seed", emptyString)
 }
 
 // logsVpnShoot prints the logfile of vpn-shoot
-func logsVpnShoot() {
+func logsVpnShoot(targetReader TargetReader) error {
 	fmt.Println("-----------------------vpn-shoot")
-	logPodShoot("vpn-shoot", "kube-system", emptyString)
+	return logPodShoot(targetReader, "vpn-shoot", "kube-system", emptyString)
 }
 
-func saveLogsVpnShoot() {
+func saveLogsVpnShoot(targetReader TargetReader) error {
 	fmt.Println("-----------------------vpn-shoot")
-	saveLogPodShoot("vpn-shoot", "kube-system", emptyString)
+	return saveLogPodShoot(targetReader, "vpn-shoot", "kube-system", emptyString)
 }
 
 // logsMachineControllerManager prints the logfile of machine-controller-manager
-func logsMachineControllerManager(targetReader TargetReader) {
+func logsMachineControllerManager(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "machine-controller-manager", "seed", emptyString)
-	} else {
-		logPod(targetReader, "machine-controller-manager", "seed", "machine-controller-manager")
+		return logPodWhileControlPlaneTargeted(targetReader, "machine-controller-manager", "seed", emptyString)
 	}
+	return logPod(targetReader, "machine-controller-manager", "seed", "machine-controller-manager")
 }
 
-func saveLogsMachineControllerManager(targetReader TargetReader) {
-	saveLogPod(targetReader, "machine-controller-manager", "seed", "machine-controller-manager")
+func saveLogsMachineControllerManager(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "machine-controller-manager", "seed", "machine-controller-manager")
 }
 
 // logsKubernetesDashboard prints the logfile of the dashboard
-func logsKubernetesDashboard(targetReader TargetReader) {
-	var target Target
-	ReadTarget(pathTarget, &target)
+func logsKubernetesDashboard(targetReader TargetReader) error {
+	var client kubernetes.Interface
+	var kubeconfig string
+	target := targetReader.ReadTarget(pathTarget)
 	namespace := "kube-system"
 	if IsTargeted(targetReader, "shoot") {
 		var err error
-		Client, err = clientToTarget("shoot")
-		checkError(err)
-	} else if len(target.Target) == 2 && target.Target[1].Kind == "seed" {
-		gardenName := target.Stack()[0].Name
-		KUBECONFIG = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Target[1].Name, "kubeconfig.yaml")
-		config, err := clientcmd.BuildConfigFromFlags(emptyString, KUBECONFIG)
-		checkError(err)
-		Client, err = kubernetes.NewForConfig(config)
-		checkError(err)
-	} else if len(target.Target) == 2 && target.Target[1].Kind == "project" {
-		fmt.Println("Project targeted")
-		os.Exit(2)
-	} else if len(target.Target) == 1 {
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindShoot)
+		if err != nil {
+			return err
+		}
+	} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "seed" {
 		var err error
-		Client, err = clientToTarget("garden")
-		checkError(err)
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindSeed)
+		if err != nil {
+			return err
+		}
+	} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "project" {
+		return errors.New("project targeted, target a garden, seed or shoot")
+	} else if len(target.Stack()) == 1 {
+		var err error
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindGarden)
+		if err != nil {
+			return err
+		}
 	} else {
-		fmt.Println("No target")
-		os.Exit(2)
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
 	}
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, "kubernetes-dashboard") {
-			err := ExecCmd(nil, "kubectl logs --tail="+strconv.Itoa(int(flags.tail))+" "+pod.Name+" -n "+namespace, false, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+			err := ExecCmd(nil, "kubectl logs --tail="+strconv.Itoa(int(flags.tail))+" "+pod.Name+" -n "+namespace, false, "KUBECONFIG="+kubeconfig)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func saveLogsKubernetesDashboard() {
-	var target Target
-	ReadTarget(pathTarget, &target)
+func saveLogsKubernetesDashboard(targetReader TargetReader) error {
+	var client kubernetes.Interface
+	var kubeconfig string
+	target := targetReader.ReadTarget(pathTarget)
 	namespace := "kube-system"
-	if len(target.Target) == 3 {
+	if len(target.Stack()) == 3 {
+		var err error
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindShoot)
+		if err != nil {
+			return err
+		}
+	} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "seed" {
 		var err error
-		Client, err = clientToTarget("shoot")
-		checkError(err)
-	} else if len(target.Target) == 2 && target.Target[1].Kind == "seed" {
-		gardenName := target.Stack()[0].Name
-		KUBECONFIG = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Target[1].Name, "kubeconfig.yaml")
-		config, err := clientcmd.BuildConfigFromFlags(emptyString, KUBECONFIG)
-		checkError(err)
-		Client, err = kubernetes.NewForConfig(config)
-		checkError(err)
-	} else if len(target.Target) == 2 && target.Target[1].Kind == "project" {
-		fmt.Println("Project targeted")
-		os.Exit(2)
-	} else if len(target.Target) == 1 {
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindSeed)
+		if err != nil {
+			return err
+		}
+	} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "project" {
+		return errors.New("project targeted, target a garden, seed or shoot")
+	} else if len(target.Stack()) == 1 {
 		var err error
-		Client, err = clientToTarget("garden")
-		checkError(err)
+		client, kubeconfig, err = clusterOfCurrentTarget(targetReader, TargetKindGarden)
+		if err != nil {
+			return err
+		}
 	} else {
-		fmt.Println("No target")
-		os.Exit(2)
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
 	}
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
 	p, err := os.Getwd()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, "kubernetes-dashboard") {
 			fileName := path.Join(p, "logs", pod.Name)
-			err := ExecCmdSaveOutputFile(nil, "kubectl logs --tail="+strconv.Itoa(int(flags.tail))+" "+pod.Name+" -n "+namespace, fileName, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+			err := ExecCmdSaveOutputFile(nil, "kubectl logs --tail="+strconv.Itoa(int(flags.tail))+" "+pod.Name+" -n "+namespace, fileName, "KUBECONFIG="+kubeconfig)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
 // logsPrometheus prints the logfiles of prometheus pod
-func logsPrometheus(targetReader TargetReader) {
+func logsPrometheus(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "prometheus", "seed", "prometheus")
-	} else {
-		logPod(targetReader, "prometheus", "seed", "prometheus")
+		return logPodWhileControlPlaneTargeted(targetReader, "prometheus", "seed", "prometheus")
 	}
+	return logPod(targetReader, "prometheus", "seed", "prometheus")
 }
 
-func saveLogsPrometheus(targetReader TargetReader) {
-	saveLogPod(targetReader, "prometheus", "seed", "prometheus")
+func saveLogsPrometheus(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "prometheus", "seed", "prometheus")
 }
 
 // logsGrafana prints the logfiles of grafana pod
-func logsGrafana(targetReader TargetReader) {
-	logPod(targetReader, "grafana", "seed", "grafana")
+func logsGrafana(targetReader TargetReader) error {
+	return logPod(targetReader, "grafana", "seed", "grafana")
 }
 
-func saveLogsGrafana(targetReader TargetReader) {
-	saveLogPod(targetReader, "grafana", "seed", "grafana")
+func saveLogsGrafana(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "grafana", "seed", "grafana")
 }
 
-func logsGardenlet() {
-	logPodSeed("gardenlet", "garden", emptyString)
+func logsGardenlet(targetReader TargetReader) error {
+	return logPodSeed(targetReader, "gardenlet", "garden", emptyString)
 }
 
-func saveLogsGardenlet() {
-	saveLogPodSeed("gardenlet", "garden", emptyString)
+func saveLogsGardenlet(targetReader TargetReader) error {
+	return saveLogPodSeed(targetReader, "gardenlet", "garden", emptyString)
 }
 
 // logsClusterAutoscaler prints the logfiles of cluster-autoscaler
-func logsClusterAutoscaler(targetReader TargetReader) {
+func logsClusterAutoscaler(targetReader TargetReader) error {
 	if IsControlPlaneTargeted(targetReader) {
-		logPodWhileControlPlaneTargeted(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
-	} else {
-		logPod(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
+		return logPodWhileControlPlaneTargeted(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
 	}
+	return logPod(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
 }
 
-func saveLogsClusterAutoscaler(targetReader TargetReader) {
-	saveLogPod(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
+func saveLogsClusterAutoscaler(targetReader TargetReader) error {
+	return saveLogPod(targetReader, "cluster-autoscaler", "seed", "cluster-autoscaler")
 }
 
 // logsTerraform prints the logfiles of tf pod
-func logsTerraform(toMatch string) {
+func logsTerraform(targetReader TargetReader, toMatch string) error {
 	var latestTime int64
 	var podName [100]string
 	var podNamespace [100]string
-	var err error
-	Client, err = clientToTarget("seed")
-	checkError(err)
-	pods, err := Client.CoreV1().Pods(emptyString).List(metav1.ListOptions{})
-	checkError(err)
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods(emptyString).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
 	count := 0
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, toMatch) && pod.Status.Phase == "Running" {
@@ -872,21 +956,27 @@ func logsTerraform(toMatch string) {
 	} else {
 		for i := 0; i < count; i++ {
 			fmt.Println("gardenctl logs " + podName[i] + " namespace=" + podNamespace[i])
-			err = ExecCmd(nil, "kubectl logs "+podName[i]+" -n "+podNamespace[i], false, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+			err = ExecCmd(nil, "kubectl logs "+podName[i]+" -n "+podNamespace[i], false, "KUBECONFIG="+kubeconfig)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func saveLogsTerraform(toMatch string) {
+func saveLogsTerraform(targetReader TargetReader, toMatch string) error {
 	var latestTime int64
 	var podName [100]string
 	var podNamespace [100]string
-	var err error
-	Client, err = clientToTarget("seed")
-	checkError(err)
-	pods, err := Client.CoreV1().Pods(emptyString).List(metav1.ListOptions{})
-	checkError(err)
+	client, kubeconfig, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods(emptyString).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
 	count := 0
 	for _, pod := range pods.Items {
 		if strings.Contains(pod.Name, toMatch) && pod.Status.Phase == "Running" {
@@ -899,36 +989,42 @@ func saveLogsTerraform(toMatch string) {
 		}
 	}
 	p, err := os.Getwd()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	if len(podName) == 0 || len(podNamespace) == 0 {
 		fmt.Println("No running tf " + toMatch)
 	} else {
 		for i := 0; i < count; i++ {
 			fileName := path.Join(p, "logs", podName[i])
-			err := ExecCmdSaveOutputFile(nil, "kubectl logs "+podName[i]+" -n "+podNamespace[i], fileName, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+			err := ExecCmdSaveOutputFile(nil, "kubectl logs "+podName[i]+" -n "+podNamespace[i], fileName, "KUBECONFIG="+kubeconfig)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
 // logsTf prints the logfiles of tf job
-func logsTfHelp() {
+func logsTfHelp() error {
 	fmt.Println("Command must be in the format: logs tf (infra|dns|ingress) shoot name")
+	return nil
 }
 
 // logsInfra prints the logfiles of tf infra job
-func logsInfra(str string) {
-	logsTerraform(str)
+func logsInfra(targetReader TargetReader, str string) error {
+	return logsTerraform(targetReader, str)
 }
 
 // logsDNS prints the logfiles of tf dns job
-func logsDNS(str string) {
-	logsTerraform(str)
+func logsDNS(targetReader TargetReader, str string) error {
+	return logsTerraform(targetReader, str)
 }
 
 // logsIngress prints the logfiles of tf ingress job
-func logsIngress(str string) {
-	logsTerraform(str)
+func logsIngress(targetReader TargetReader, str string) error {
+	return logsTerraform(targetReader, str)
 }
 
 type logFlags struct {
@@ -1042,18 +1138,23 @@ func (response logResponseLoki) String() string {
 	return allLogs.String()
 }
 
+// parseTimeInRFC returns the time of the unix timestamp in nanoseconds, or the zero time if it is malformed
 func parseTimeInRFC(unixTime string) time.Time {
 	intTime, err := strconv.ParseInt(unixTime, 10, 64)
-	checkError(err)
+	if err != nil {
+		return time.Time{}
+	}
 
 	return time.Unix(0, intTime)
 }
 
+// parseLogMessage returns the structured log message, or the message as plain log if it is not json
 func parseLogMessage(logMsg string) logMessage {
 	byteOutput := []byte(logMsg)
 	var log logMessage
-	err := json.Unmarshal(byteOutput, &log)
-	checkError(err)
+	if err := json.Unmarshal(byteOutput, &log); err != nil {
+		return logMessage{Log: logMsg}
+	}
 
 	return log
 }
@@ -32,6 +32,7 @@ var _ = Describe("Logs and kubecmd command", func() {
 	var (
 		ctrl         *gomock.Controller
 		targetReader *mockcmd.MockTargetReader
+		configReader *mockcmd.MockConfigReader
 		command      *cobra.Command
 		execute      = func(command *cobra.Command, args []string) error {
 			command.SetArgs(args)
@@ -42,6 +43,7 @@ var _ = Describe("Logs and kubecmd command", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		targetReader = mockcmd.NewMockTargetReader(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
 	})
 
 	AfterEach(func() {
@@ -52,7 +54,8 @@ var _ = Describe("Logs and kubecmd command", func() {
 
 	Context("with < 1 args", func() {
 		It("should return error", func() {
-			command = cmd.NewLogsCmd(targetReader)
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewLogsCmd(targetReader, configReader, ioStreams)
 			err := execute(command, []string{})
 
 			Expect(err).To(HaveOccurred())
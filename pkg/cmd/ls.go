@@ -18,10 +18,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
+	"io/ioutil"
+	"strings"
+	"time"
 
-	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,58 +30,80 @@ import (
 // NewLsCmd returns a new ls command.
 func NewLsCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "ls [gardens|projects|seeds|shoots|issues|namespaces]",
+		Use:          "ls [gardens|projects|seeds|shoots|issues|namespaces] [PATTERN]",
 		Short:        "List all resource instances, e.g. \"gardenctl ls shoots\" to list shoots, \"gardenctl ls issues\" to list issues",
+		Example: `
+	# List the shoots of the targeted garden, project or seed as table.
+	gardenctl ls shoots
+
+	# List the awake AWS shoots in eu regions with a Kubernetes version below 1.18 as yaml.
+	gardenctl ls shoots --provider aws --region 'eu-*' --kubernetes-version '< 1.18' --awake -o yaml
+
+	# List the shoots with the label team=core created more than 30 days ago.
+	gardenctl ls shoots -l team=core --min-age 720h
+
+	# List the issues of at least high severity existing for more than an hour as table.
+	gardenctl ls issues --min-severity high --failing-for 1h -o table
+
+	# Count the shoots with issues per error code of their root cause.
+	gardenctl ls issues --group-by-code -o table
+
+	# List the seeds with the most free capacity first.
+	gardenctl ls seeds --sort-by free`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) < 1 || len(args) > 2 {
 				return errors.New("command must be in the format: ls [gardens|projects|seeds|shoots|issues|namespaces]")
 			}
+			if err := checkFilterFlags(cmd, args[0]); err != nil {
+				return err
+			}
+
+			// m filters the listed objects by name, nil lists all of them
+			var m *gardenctl.Matcher
+			if len(args) == 2 {
+				if m, err = newMatcher(args[1]); err != nil {
+					return err
+				}
+			}
 
 			target := targetReader.ReadTarget(pathTarget)
 			if (len(target.Stack()) == 0) && args[0] != "gardens" {
-				return errors.New("target stack is empty")
+				return errTargetStackEmpty
 			}
 			switch args[0] {
 			case "projects":
-				return printProjectsWithShoots(target, ioStreams.Out, outputFormat)
+				return printProjectsWithShoots(target, m, nil, ioStreams.Out, outputFormat)
 			case "gardens":
-				return PrintGardenClusters(configReader, ioStreams.Out, outputFormat)
+				return printGardenClusters(configReader, m, ioStreams.Out, outputFormat)
 			case "seeds":
-				clientset, err := target.GardenerClient()
-				checkError(err)
-				seedList := getSeeds(clientset)
-				var seeds Seeds
-				for _, seed := range seedList.Items {
-					var sm SeedMeta
-					sm.Seed = seed.Name
-					seeds.Seeds = append(seeds.Seeds, sm)
-				}
-				return PrintoutObject(seeds, ioStreams.Out, outputFormat)
+				return printSeeds(target, configReader, m, ioStreams.Out, outputFormatOf(cmd, outputFormatTable))
 			case "shoots":
-				if len(target.Stack()) == 1 {
-					return printProjectsWithShoots(target, ioStreams.Out, outputFormat)
-				} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "seed" {
-					return printProjectsWithShootsForSeed(ioStreams.Out, outputFormat)
-				} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "project" {
-					return printSeedsWithShootsForProject(ioStreams.Out, outputFormat)
-				}
+				return printShoots(target, m, ioStreams.Out, outputFormatOf(cmd, outputFormatTable))
 			case "issues":
-				return printIssues(target, ioStreams.Out, outputFormat)
+				return printIssues(target, m, ioStreams.Out, outputFormat)
 			case "namespaces":
-				return printNamespaces(ioStreams.Out)
+				return printNamespaces(configReader, m, ioStreams.Out, outputFormatOf(cmd, outputFormatTable))
 			}
 
 			return errors.New("command must be in the format: " + cmd.Use)
 		},
 		ValidArgs: []string{"issues", "projects", "gardens", "seeds", "shoots", "namespaces"},
 	}
+	addShootFilterFlags(cmd)
+	addIssueFilterFlags(cmd)
+	addSeedFlags(cmd)
 
 	return cmd
 }
 
-// printProjectsWithShoots lists list of projects with shoots
-func printProjectsWithShoots(target TargetInterface, writer io.Writer, outFormat string) error {
+// matchName returns true if m is nil or name matches m
+func matchName(m *gardenctl.Matcher, name string) bool {
+	return m == nil || m.Match(name)
+}
+
+// printProjectsWithShoots lists list of projects with shoots, filtered by the project and shoot matchers
+func printProjectsWithShoots(target TargetInterface, projectMatcher, shootMatcher *gardenctl.Matcher, writer io.Writer, outFormat string) error {
 	gardenClientset, err := target.GardenerClient()
 	if err != nil {
 		return err
@@ -96,16 +119,18 @@ func printProjectsWithShoots(target TargetInterface, writer io.Writer, outFormat
 
 	var projects Projects
 	for _, project := range projectList.Items {
+		if !matchName(projectMatcher, project.Name) {
+			continue
+		}
 		var pm ProjectMeta
 		for _, shoot := range shootList.Items {
-			if shoot.Namespace == *project.Spec.Namespace {
-				currentShoot := shoot.Name
-				if shoot.Status.IsHibernated {
-					currentShoot += " (Hibernated)"
-				}
-				pm.Shoots = append(pm.Shoots, currentShoot)
+			if shoot.Namespace == *project.Spec.Namespace && matchName(shootMatcher, shoot.Name) {
+				pm.Shoots = append(pm.Shoots, ProjectShootMeta{Name: shoot.Name, Hibernated: shoot.Status.IsHibernated})
 			}
 		}
+		if shootMatcher != nil && len(pm.Shoots) == 0 {
+			continue
+		}
 		pm.Project = project.Name
 		projects.Projects = append(projects.Projects, pm)
 	}
@@ -115,10 +140,18 @@ func printProjectsWithShoots(target TargetInterface, writer io.Writer, outFormat
 
 // PrintGardenClusters prints all Garden cluster in the Garden config
 func PrintGardenClusters(reader ConfigReader, writer io.Writer, outFormat string) error {
+	return printGardenClusters(reader, nil, writer, outFormat)
+}
+
+// printGardenClusters prints the Garden clusters in the Garden config matching m
+func printGardenClusters(reader ConfigReader, m *gardenctl.Matcher, writer io.Writer, outFormat string) error {
 	config := reader.ReadConfig(pathGardenConfig)
 
 	var gardens GardenClusters
 	for _, garden := range config.GardenClusters {
+		if !matchName(m, garden.Name) {
+			continue
+		}
 		var gm GardenClusterMeta
 		gm.Name = garden.Name
 		gardens.GardenClusters = append(gardens.GardenClusters, gm)
@@ -126,192 +159,88 @@ func PrintGardenClusters(reader ConfigReader, writer io.Writer, outFormat string
 	return PrintoutObject(gardens, writer, outFormat)
 }
 
-// getSeeds returns list of seeds
-func getSeeds(clientset gardencoreclientset.Interface) *gardencorev1beta1.SeedList {
-	seedList, err := clientset.CoreV1beta1().Seeds().List(metav1.ListOptions{})
-	checkError(err)
-	return seedList
-}
-
-// printProjectsWithShootsForSeed
-func printProjectsWithShootsForSeed(writer io.Writer, outFormat string) error {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	var projects Projects
-	gardenClientset, err := target.GardenerClient()
-	checkError(err)
-	projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
-	checkError(err)
-	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-	checkError(err)
-	for _, project := range projectList.Items {
-		var pm ProjectMeta
-		for _, shoot := range shootList.Items {
-			if shoot.Namespace == *project.Spec.Namespace && shoot.Spec.SeedName != nil && target.Target[1].Name == *shoot.Spec.SeedName {
-				currentShoot := shoot.Name
-				if shoot.Status.IsHibernated {
-					currentShoot += " (Hibernated)"
-				}
-				pm.Shoots = append(pm.Shoots, currentShoot)
-			}
-		}
-		if len(pm.Shoots) > 0 {
-			pm.Project = project.Name
-			projects.Projects = append(projects.Projects, pm)
-		}
+//printNamespaces get all namespaces matching m based on current kubeconfig
+func printNamespaces(configReader ConfigReader, m *gardenctl.Matcher, writer io.Writer, outFormat string) error {
+	currentConfig, err := getKubeConfigOfCurrentTarget(configReader)
+	if err != nil {
+		return err
 	}
-	if len(projects.Projects) == 0 {
-		fmt.Printf("No shoots for %s\n", target.Target[1].Name)
-		os.Exit(2)
+	kubeconfig, err := ioutil.ReadFile(currentConfig)
+	if err != nil {
+		return err
 	}
-	return PrintoutObject(projects, writer, outFormat)
-}
-
-// printIssues lists broken shoot clusters
-func printIssues(target TargetInterface, writer io.Writer, outFormat string) error {
-	gardenClientset, err := target.GardenerClient()
-	checkError(err)
-	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-	checkError(err)
-	var issues Issues
-	for _, item := range shootList.Items {
-		var im IssuesMeta
-		var statusMeta StatusMeta
-		var lastOperationMeta LastOperationMeta
-		state := ""
-		healthy := true
-		hasIssue := true
-		unknown := true
-		if item.Status.LastOperation != nil {
-			if len(item.Status.Conditions) > 0 {
-				for _, condition := range item.Status.Conditions {
-					if condition.Status == "True" {
-						unknown = false
-					}
-					if condition.Status == "False" {
-						unknown = false
-						healthy = false
-					}
-				}
-			}
-			if (item.Status.LastOperation.Progress == 100) && (item.Status.LastOperation.State == "Succeeded") && ((item.Status.LastOperation.Type == "Create") || (item.Status.LastOperation.Type == "Reconcile")) {
-				hasIssue = false
-			}
-			if unknown {
-				state = "Unknown"
-			} else if healthy {
-				state = "Ready"
-			} else {
-				state = "NotReady"
-			}
-			if !hasIssue && !healthy {
-				hasIssue = true
-			}
-			if hasIssue {
-				if item.Status.LastOperation != nil {
-					lastOperationMeta.Description = item.Status.LastOperation.Description
-					lastOperationMeta.LastUpdateTime = item.Status.LastOperation.LastUpdateTime.String()
-					lastOperationMeta.Progress = int(item.Status.LastOperation.Progress)
-					lastOperationMeta.State = string(item.Status.LastOperation.State)
-					lastOperationMeta.Type = string(item.Status.LastOperation.Type)
-				}
-				if item.Status.LastErrors != nil {
-					for _, lastError := range item.Status.LastErrors {
-						statusMeta.LastErrors = append(statusMeta.LastErrors, lastError.Description)
-					}
-				}
-				statusMeta.LastOperation = lastOperationMeta
-				im.Health = state
-				im.Project = getProjectForNamespace(item.Namespace)
-				im.Seed = *item.Spec.SeedName
-				im.Shoot = item.Name
-				im.Status = statusMeta
-				issues.Issues = append(issues.Issues, im)
-			}
-		} else {
-			lastOperationMeta.Description = "Not processed (!)"
-			statusMeta.LastOperation = lastOperationMeta
-			im.Status = statusMeta
-			im.Project = getProjectForNamespace(item.Namespace)
-			im.Seed = *item.Spec.SeedName
-			im.Shoot = item.Name
-			im.Health = "None"
-			issues.Issues = append(issues.Issues, im)
+	client, err := gardenctl.KubernetesFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	namespaceList, err := client.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var namespaces Namespaces
+	for _, namespace := range namespaceList.Items {
+		if !matchName(m, namespace.Name) {
+			continue
 		}
+		namespaces.Namespaces = append(namespaces.Namespaces, NamespaceMeta{
+			Name:              namespace.Name,
+			Status:            string(namespace.Status.Phase),
+			CreationTimestamp: namespace.CreationTimestamp.Time,
+		})
 	}
-	return PrintoutObject(issues, writer, outFormat)
+	return PrintoutObject(namespaces, writer, outFormat)
 }
 
-// printSeedsWithShootsForProject
-func printSeedsWithShootsForProject(writer io.Writer, outFormat string) error {
-	var target Target
-	ReadTarget(pathTarget, &target)
-
-	gardenClientset, err := target.GardenerClient()
-	checkError(err)
-
-	projectName := target.Target[1].Name
-	project, err := gardenClientset.CoreV1beta1().Projects().Get(projectName, metav1.GetOptions{})
-	checkError(err)
+// PrintTable prints the namespaces as table
+func (n Namespaces) PrintTable(w io.Writer, wide bool) error {
+	now := time.Now()
+	var rows [][]string
+	for _, namespace := range n.Namespaces {
+		rows = append(rows, []string{namespace.Name, orDash(namespace.Status), formatAge(namespace.CreationTimestamp, now)})
+	}
+	return printTable(w, []string{"NAME", "STATUS", "AGE"}, rows)
+}
 
-	projectNamespace := project.Spec.Namespace
-	shootList, err := gardenClientset.CoreV1beta1().Shoots(*projectNamespace).List(metav1.ListOptions{})
-	checkError(err)
+// PrintTable prints the gardens as table
+func (g GardenClusters) PrintTable(w io.Writer, wide bool) error {
+	var rows [][]string
+	for _, garden := range g.GardenClusters {
+		rows = append(rows, []string{garden.Name})
+	}
+	return printTable(w, []string{"NAME"}, rows)
+}
 
-	var seeds, seedsFiltered Seeds
-	seedList := getSeeds(gardenClientset)
-	for _, seed := range seedList.Items {
-		var sm SeedMeta
-		sm.Seed = seed.Name
-		seeds.Seeds = append(seeds.Seeds, sm)
+// PrintTable prints the projects with the number of their shoots and hibernated shoots as table, wide adds
+// the names of the shoots
+func (p Projects) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"PROJECT", "SHOOTS", "HIBERNATED"}
+	if wide {
+		header = append(header, "NAMES")
 	}
-	for _, shoot := range shootList.Items {
-		for index, seed := range seeds.Seeds {
-			if seed.Seed == *shoot.Spec.SeedName {
-				currentShoot := shoot.Name
-				if shoot.Status.IsHibernated {
-					currentShoot += " (Hibernated)"
-				}
-				seeds.Seeds[index].Shoots = append(seeds.Seeds[index].Shoots, currentShoot)
+	var rows [][]string
+	for _, project := range p.Projects {
+		var names []string
+		hibernated := 0
+		for _, shoot := range project.Shoots {
+			names = append(names, shoot.Name)
+			if shoot.Hibernated {
+				hibernated++
 			}
 		}
-	}
-	for _, seed := range seeds.Seeds {
-		if len(seed.Shoots) > 0 {
-			seedsFiltered.Seeds = append(seedsFiltered.Seeds, seed)
+		row := []string{project.Project, fmt.Sprint(len(project.Shoots)), fmt.Sprint(hibernated)}
+		if wide {
+			row = append(row, orDash(strings.Join(names, ",")))
 		}
+		rows = append(rows, row)
 	}
-	if len(seedsFiltered.Seeds) == 0 {
-		fmt.Printf("Project %s is empty\n", target.Target[1].Name)
-		os.Exit(2)
-	}
-	return PrintoutObject(seedsFiltered, writer, outFormat)
+	return printTable(w, header, rows)
 }
 
-//printNamespaces get all namespaces based on current kubeconfig
-func printNamespaces(writer io.Writer) error {
-	currentConfig := getKubeConfigOfCurrentTarget()
-	out, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+currentConfig, "get", "ns")
-	if err != nil {
-		return err
-	}
-	fmt.Fprint(writer, out)
-	return nil
-}
-
-// getProjectForNamespace returns name of project for a shoot
-func getProjectForNamespace(namespace string) string {
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	gardenClientset, err := gardencoreclientset.NewForConfig(NewConfigFromBytes(*kubeconfig))
-	checkError(err)
-	projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
-	checkError(err)
-	for _, project := range projectList.Items {
-		if namespace == *project.Spec.Namespace {
-			return project.Name
-		}
+// Names returns the names of the projects
+func (p Projects) Names() []string {
+	var names []string
+	for _, project := range p.Projects {
+		names = append(names, project.Project)
 	}
-	return ""
+	return names
 }
@@ -0,0 +1,301 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// noErrorCode is the root cause of the issues without error code
+const noErrorCode = "<none>"
+
+var (
+	// the filters of ls issues
+	issueProject     string
+	issueErrorCode   string
+	issueMinSeverity string
+	issueFailingFor  time.Duration
+	issueGroupByCode bool
+)
+
+// issueFilterFlags are the flags which are only supported by ls issues
+var issueFilterFlags = []string{"project", "error-code", "min-severity", "failing-for", "group-by-code"}
+
+// addIssueFilterFlags adds the flags of the filters of ls issues
+func addIssueFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&issueProject, "project", "", "list the issues of the shoots in a project matching the name or pattern")
+	cmd.Flags().StringVar(&issueErrorCode, "error-code", "", "list the issues with an error code matching the name or pattern, e.g. ERR_INFRA_*")
+	cmd.Flags().StringVar(&issueMinSeverity, "min-severity", "", "list the issues with at least the severity low, medium, high or critical")
+	cmd.Flags().DurationVar(&issueFailingFor, "failing-for", 0, "list the issues which exist at least the duration, e.g. 1h")
+	cmd.Flags().BoolVar(&issueGroupByCode, "group-by-code", false, "group the shoots with issues by the error code of their root cause")
+}
+
+// printIssues prints the shoots with issues of the targeted garden matching m and the filters of ls issues,
+// the most severe first
+func printIssues(target TargetInterface, m *gardenctl.Matcher, writer io.Writer, outFormat string) error {
+	filter, err := newShootFilter()
+	if err != nil {
+		return err
+	}
+	var projectMatcher, codeMatcher *gardenctl.Matcher
+	if issueProject != "" {
+		if projectMatcher, err = newMatcher(issueProject); err != nil {
+			return err
+		}
+	}
+	if issueErrorCode != "" {
+		if codeMatcher, err = newMatcher(issueErrorCode); err != nil {
+			return err
+		}
+	}
+	minSeverity := gardenctl.IssueSeverityLow
+	if issueMinSeverity != "" {
+		if minSeverity, err = gardenctl.ParseIssueSeverity(issueMinSeverity); err != nil {
+			return err
+		}
+	}
+
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return err
+	}
+	projects, err := projectsByNamespace(gardenClientset)
+	if err != nil {
+		return err
+	}
+	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{LabelSelector: shootSelector})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var issues Issues
+	var severities []gardenctl.IssueSeverity
+	for i := range shootList.Items {
+		shoot := &shootList.Items[i]
+		if !matchName(m, shoot.Name) || !filter.Match(shoot, now) || projectMatcher != nil && !projectMatcher.Match(projects[shoot.Namespace]) {
+			continue
+		}
+		issue := gardenctl.ShootIssueOf(shoot)
+		if issue == nil || issue.Severity < minSeverity || codeMatcher != nil && !issue.HasCode(codeMatcher) {
+			continue
+		}
+		if issueFailingFor > 0 && now.Sub(issue.Since) < issueFailingFor {
+			continue
+		}
+		issues.Issues = append(issues.Issues, toIssuesMeta(shoot, issue, projects[shoot.Namespace], now))
+		severities = append(severities, issue.Severity)
+	}
+	sort.Sort(bySeverity{issues.Issues, severities})
+
+	if issueGroupByCode {
+		return PrintoutObject(groupIssuesByCode(issues, severities), writer, outFormat)
+	}
+	return PrintoutObject(issues, writer, outFormat)
+}
+
+// bySeverity sorts the issues by their severity, the most severe first, then by project and shoot
+type bySeverity struct {
+	issues     []IssuesMeta
+	severities []gardenctl.IssueSeverity
+}
+
+func (s bySeverity) Len() int { return len(s.issues) }
+
+func (s bySeverity) Swap(i, j int) {
+	s.issues[i], s.issues[j] = s.issues[j], s.issues[i]
+	s.severities[i], s.severities[j] = s.severities[j], s.severities[i]
+}
+
+func (s bySeverity) Less(i, j int) bool {
+	if s.severities[i] != s.severities[j] {
+		return s.severities[i] > s.severities[j]
+	}
+	if s.issues[i].Project != s.issues[j].Project {
+		return s.issues[i].Project < s.issues[j].Project
+	}
+	return s.issues[i].Shoot < s.issues[j].Shoot
+}
+
+// groupIssuesByCode groups the sorted issues by the error code of their root cause, the groups are
+// sorted by their severity and size
+func groupIssuesByCode(issues Issues, severities []gardenctl.IssueSeverity) IssueGroups {
+	var groups []*IssueGroupMeta
+	groupSeverities := map[string]gardenctl.IssueSeverity{}
+	byCode := map[string]*IssueGroupMeta{}
+	for i, issue := range issues.Issues {
+		code := noErrorCode
+		if len(issue.Codes) > 0 {
+			code = issue.Codes[0]
+		}
+		group, ok := byCode[code]
+		if !ok {
+			group = &IssueGroupMeta{Code: code}
+			byCode[code] = group
+			groups = append(groups, group)
+		}
+		group.Count++
+		group.Shoots = append(group.Shoots, issue.Project+"/"+issue.Shoot)
+		if severity, ok := groupSeverities[code]; !ok || severities[i] > severity {
+			groupSeverities[code] = severities[i]
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		si, sj := groupSeverities[groups[i].Code], groupSeverities[groups[j].Code]
+		if si != sj {
+			return si > sj
+		}
+		return groups[i].Count > groups[j].Count
+	})
+
+	var issueGroups IssueGroups
+	for _, group := range groups {
+		group.Severity = groupSeverities[group.Code].String()
+		issueGroups.Groups = append(issueGroups.Groups, *group)
+	}
+	return issueGroups
+}
+
+// toIssuesMeta returns the details of the issue of a shoot in the project
+func toIssuesMeta(shoot *gardencorev1beta1.Shoot, issue *gardenctl.ShootIssue, project string, now time.Time) IssuesMeta {
+	im := IssuesMeta{
+		Project:  project,
+		Shoot:    shoot.Name,
+		Provider: shoot.Spec.Provider.Type,
+		Health:   gardenctl.ShootHealth(shoot),
+		Severity: issue.Severity.String(),
+		Since:    issue.Since,
+	}
+	if !issue.Since.IsZero() {
+		im.Duration = now.Sub(issue.Since).Round(time.Second).String()
+	}
+	if shoot.Spec.SeedName != nil {
+		im.Seed = *shoot.Spec.SeedName
+	}
+	for _, code := range issue.Codes {
+		im.Codes = append(im.Codes, string(code))
+	}
+	for _, condition := range issue.Conditions {
+		im.Conditions = append(im.Conditions, toConditionMeta(condition, now))
+	}
+	if op := shoot.Status.LastOperation; op != nil {
+		im.Status.LastOperation = LastOperationMeta{
+			Description:    op.Description,
+			LastUpdateTime: op.LastUpdateTime.String(),
+			Progress:       int(op.Progress),
+			State:          string(op.State),
+			Type:           string(op.Type),
+		}
+	} else {
+		im.Health = "None"
+		im.Status.LastOperation.Description = "Not processed (!)"
+	}
+	for _, lastError := range shoot.Status.LastErrors {
+		im.Status.LastErrors = append(im.Status.LastErrors, lastError.Description)
+	}
+	return im
+}
+
+// toConditionMeta returns the details of a condition at time now
+func toConditionMeta(condition gardencorev1beta1.Condition, now time.Time) ConditionMeta {
+	cm := ConditionMeta{
+		Type:               string(condition.Type),
+		Status:             string(condition.Status),
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: condition.LastTransitionTime.Time,
+	}
+	if !cm.LastTransitionTime.IsZero() {
+		cm.Duration = now.Sub(cm.LastTransitionTime).Round(time.Second).String()
+	}
+	for _, code := range condition.Codes {
+		cm.Codes = append(cm.Codes, string(code))
+	}
+	return cm
+}
+
+// PrintTable prints the shoots with issues as table, wide adds the conditions which are not true and the last errors
+func (i Issues) PrintTable(w io.Writer, wide bool) error {
+	now := time.Now()
+	header := []string{"PROJECT", "SEED", "SHOOT", "SEVERITY", "HEALTH", "ROOT CAUSE", "DURATION", "LAST OPERATION"}
+	if wide {
+		header = append(header, "CONDITIONS", "LAST ERRORS")
+	}
+	var rows [][]string
+	for _, issue := range i.Issues {
+		rootCause := "-"
+		if len(issue.Codes) > 0 {
+			rootCause = issue.Codes[0]
+		}
+		lastOperation := formatLastOperation(&issue.Status.LastOperation)
+		if issue.Status.LastOperation.Type == "" && issue.Status.LastOperation.Description != "" {
+			lastOperation = issue.Status.LastOperation.Description
+		}
+		row := []string{orDash(issue.Project), orDash(issue.Seed), issue.Shoot, issue.Severity, issue.Health, rootCause, formatAge(issue.Since, now), lastOperation}
+		if wide {
+			var conditions []string
+			for _, condition := range issue.Conditions {
+				conditions = append(conditions, condition.Type+"="+condition.Status)
+			}
+			row = append(row, orDash(strings.Join(conditions, ",")), orDash(strings.Join(issue.Status.LastErrors, "; ")))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// Names returns the names of the shoots with issues
+func (i Issues) Names() []string {
+	var names []string
+	for _, issue := range i.Issues {
+		names = append(names, issue.Shoot)
+	}
+	return names
+}
+
+// PrintTable prints the number of shoots per root cause as table, wide adds the shoots
+func (g IssueGroups) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"ROOT CAUSE", "SEVERITY", "SHOOTS"}
+	if wide {
+		header = append(header, "NAMES")
+	}
+	var rows [][]string
+	for _, group := range g.Groups {
+		row := []string{group.Code, group.Severity, fmt.Sprint(group.Count)}
+		if wide {
+			row = append(row, strings.Join(group.Shoots, ","))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// Names returns the root causes of the groups
+func (g IssueGroups) Names() []string {
+	var names []string
+	for _, group := range g.Groups {
+		names = append(names, group.Code)
+	}
+	return names
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// seedSortOrders are the orders of ls seeds, the seeds are sorted by name or by the number of allocated
+// shoots, free shoots or shoots with issues in descending order
+var seedSortOrders = []string{"name", "allocated", "free", "issues"}
+
+var (
+	// the flags of ls seeds
+	seedSortBy string
+)
+
+// seedFlags are the flags which are only supported by ls seeds
+var seedFlags = []string{"sort-by"}
+
+// addSeedFlags adds the flags of ls seeds
+func addSeedFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&seedSortBy, "sort-by", "name", "sort the seeds by "+strings.Join(seedSortOrders, ", "))
+}
+
+// printSeeds prints the overview of the seeds of the targeted garden matching m
+func printSeeds(target TargetInterface, configReader ConfigReader, m *gardenctl.Matcher, writer io.Writer, outFormat string) error {
+	less, err := seedLess(seedSortBy)
+	if err != nil {
+		return err
+	}
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return err
+	}
+	seedList, err := gardenClientset.CoreV1beta1().Seeds().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	capacity := 0
+	if garden := configReader.ReadConfig(pathGardenConfig).Garden(target.Stack()[0].Name); garden != nil {
+		capacity = garden.SeedCapacity
+	}
+
+	now := time.Now()
+	var overview Seeds
+	for i := range seedList.Items {
+		if seed := &seedList.Items[i]; matchName(m, seed.Name) {
+			overview.Seeds = append(overview.Seeds, toSeedMeta(seed, capacity, now))
+		}
+	}
+	seeds := map[string]*SeedMeta{}
+	for i := range overview.Seeds {
+		seeds[overview.Seeds[i].Seed] = &overview.Seeds[i]
+	}
+	for i := range shootList.Items {
+		shoot := &shootList.Items[i]
+		if shoot.Spec.SeedName == nil || seeds[*shoot.Spec.SeedName] == nil {
+			continue
+		}
+		sm := seeds[*shoot.Spec.SeedName]
+		sm.Allocated++
+		if shoot.Status.IsHibernated {
+			sm.Hibernated++
+		}
+		if gardenctl.ShootIssueOf(shoot) != nil {
+			sm.Issues++
+		}
+	}
+	for i := range overview.Seeds {
+		if sm := &overview.Seeds[i]; sm.Capacity > 0 {
+			free := sm.Capacity - sm.Allocated
+			if free < 0 {
+				free = 0
+			}
+			sm.Free = &free
+		}
+	}
+	sort.SliceStable(overview.Seeds, func(i, j int) bool { return less(&overview.Seeds[i], &overview.Seeds[j]) })
+
+	return PrintoutObject(overview, writer, outFormat)
+}
+
+// seedLess returns the order of the seeds sorted by sortBy, see seedSortOrders
+func seedLess(sortBy string) (func(a, b *SeedMeta) bool, error) {
+	byName := func(a, b *SeedMeta) bool { return a.Seed < b.Seed }
+	descending := func(value func(s *SeedMeta) int) func(a, b *SeedMeta) bool {
+		return func(a, b *SeedMeta) bool {
+			if va, vb := value(a), value(b); va != vb {
+				return va > vb
+			}
+			return byName(a, b)
+		}
+	}
+	switch sortBy {
+	case "name":
+		return byName, nil
+	case "allocated":
+		return descending(func(s *SeedMeta) int { return s.Allocated }), nil
+	case "free":
+		// seeds with unknown capacity are sorted last
+		return descending(func(s *SeedMeta) int {
+			if s.Free == nil {
+				return -1
+			}
+			return *s.Free
+		}), nil
+	case "issues":
+		return descending(func(s *SeedMeta) int { return s.Issues }), nil
+	}
+	return nil, fmt.Errorf("invalid sort order %q, must be one of %s", sortBy, strings.Join(seedSortOrders, ", "))
+}
+
+// toSeedMeta returns the overview of a seed without its shoots
+func toSeedMeta(seed *gardencorev1beta1.Seed, capacity int, now time.Time) SeedMeta {
+	sm := SeedMeta{
+		Seed:     seed.Name,
+		Provider: seed.Spec.Provider.Type,
+		Region:   seed.Spec.Provider.Region,
+		Health:   gardenctl.ConditionsHealth(seed.Status.Conditions),
+		Visible:  true,
+		Capacity: capacity,
+	}
+	if seed.Status.KubernetesVersion != nil {
+		sm.KubernetesVersion = *seed.Status.KubernetesVersion
+	}
+	if settings := seed.Spec.Settings; settings != nil && settings.Scheduling != nil {
+		sm.Visible = settings.Scheduling.Visible
+	}
+	for _, taint := range seed.Spec.Taints {
+		switch taint.Key {
+		case gardencorev1beta1.DeprecatedSeedTaintInvisible:
+			sm.Visible = false
+		case gardencorev1beta1.SeedTaintProtected:
+			sm.Protected = true
+		}
+		if taint.Value != nil {
+			sm.Taints = append(sm.Taints, taint.Key+"="+*taint.Value)
+		} else {
+			sm.Taints = append(sm.Taints, taint.Key)
+		}
+	}
+	if backup := seed.Spec.Backup; backup != nil {
+		sm.Backup = &SeedBackupMeta{Provider: backup.Provider}
+		if backup.Region != nil {
+			sm.Backup.Region = *backup.Region
+		}
+	}
+	for _, condition := range seed.Status.Conditions {
+		sm.Conditions = append(sm.Conditions, toConditionMeta(condition, now))
+	}
+	return sm
+}
+
+// conditionStatus returns the status of the condition of the seed or "-" if it has no such condition
+func (s *SeedMeta) conditionStatus(conditionType gardencorev1beta1.ConditionType) string {
+	for _, condition := range s.Conditions {
+		if condition.Type == string(conditionType) {
+			return condition.Status
+		}
+	}
+	return "-"
+}
+
+// PrintTable prints the overview of the seeds as table, wide adds the taints, the conditions, the number of
+// hibernated shoots and the Kubernetes version
+func (s Seeds) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"SEED", "PROVIDER", "REGION", "HEALTH", "VISIBLE", "PROTECTED", "SHOOTS", "FREE", "ISSUES", "BACKUP"}
+	if wide {
+		header = append(header, "TAINTS", "GARDENLET READY", "BOOTSTRAPPED", "HIBERNATED", "VERSION")
+	}
+	var rows [][]string
+	for i := range s.Seeds {
+		seed := &s.Seeds[i]
+		shoots, free := fmt.Sprint(seed.Allocated), "-"
+		if seed.Free != nil {
+			shoots, free = fmt.Sprintf("%d/%d", seed.Allocated, seed.Capacity), fmt.Sprint(*seed.Free)
+		}
+		backup := "-"
+		if seed.Backup != nil {
+			backup = strings.TrimSuffix(seed.Backup.Provider+"/"+seed.Backup.Region, "/")
+		}
+		row := []string{seed.Seed, seed.Provider, seed.Region, orDash(seed.Health), fmt.Sprint(seed.Visible), fmt.Sprint(seed.Protected),
+			shoots, free, fmt.Sprint(seed.Issues), backup}
+		if wide {
+			row = append(row, orDash(strings.Join(seed.Taints, ",")), seed.conditionStatus(gardencorev1beta1.SeedGardenletReady),
+				seed.conditionStatus(gardencorev1beta1.SeedBootstrapped), fmt.Sprint(seed.Hibernated), orDash(seed.KubernetesVersion))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// Names returns the names of the seeds
+func (s Seeds) Names() []string {
+	var names []string
+	for _, seed := range s.Seeds {
+		names = append(names, seed.Seed)
+	}
+	return names
+}
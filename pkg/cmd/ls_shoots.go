@@ -0,0 +1,276 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// the filters of ls shoots and ls issues
+	shootSelector          string
+	shootProvider          string
+	shootRegion            string
+	shootKubernetesVersion string
+	shootPurpose           string
+	shootSeed              string
+	shootCreatedBy         string
+	shootHibernated        bool
+	shootAwake             bool
+	shootMinAge            time.Duration
+	shootMaxAge            time.Duration
+)
+
+// shootFilterFlags are the flags which are only supported by ls shoots and ls issues
+var shootFilterFlags = []string{"selector", "provider", "region", "kubernetes-version", "purpose", "seed", "created-by", "hibernated", "awake", "min-age", "max-age"}
+
+// addShootFilterFlags adds the flags of the filters of ls shoots and ls issues
+func addShootFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&shootSelector, "selector", "l", "", "list the shoots matching the label selector, e.g. purpose=infra,team!=core")
+	cmd.Flags().StringVar(&shootProvider, "provider", "", "list the shoots with a provider type matching the name or pattern, e.g. aws")
+	cmd.Flags().StringVar(&shootRegion, "region", "", "list the shoots in a region matching the name or pattern, e.g. eu-*")
+	cmd.Flags().StringVar(&shootKubernetesVersion, "kubernetes-version", "", "list the shoots with a Kubernetes version in the range, e.g. \">= 1.17, < 1.19\"")
+	cmd.Flags().StringVar(&shootPurpose, "purpose", "", "list the shoots with a purpose matching the name or pattern, e.g. production")
+	cmd.Flags().StringVar(&shootSeed, "seed", "", "list the shoots scheduled on a seed matching the name or pattern")
+	cmd.Flags().StringVar(&shootCreatedBy, "created-by", "", "list the shoots created by a user matching the name or pattern")
+	cmd.Flags().BoolVar(&shootHibernated, "hibernated", false, "list the hibernated shoots")
+	cmd.Flags().BoolVar(&shootAwake, "awake", false, "list the shoots which are not hibernated")
+	cmd.Flags().DurationVar(&shootMinAge, "min-age", 0, "list the shoots created at least the duration ago, e.g. 720h")
+	cmd.Flags().DurationVar(&shootMaxAge, "max-age", 0, "list the shoots created at most the duration ago, e.g. 24h")
+}
+
+// checkFilterFlags returns an error if a flag of ls shoots, ls issues or ls seeds is set for another kind
+func checkFilterFlags(cmd *cobra.Command, kind string) error {
+	if kind != "shoots" && kind != "issues" {
+		for _, name := range shootFilterFlags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("flag --%s is only supported by \"gardenctl ls shoots\" and \"gardenctl ls issues\"", name)
+			}
+		}
+	}
+	if kind != "issues" {
+		for _, name := range issueFilterFlags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("flag --%s is only supported by \"gardenctl ls issues\"", name)
+			}
+		}
+	}
+	if kind != "seeds" {
+		for _, name := range seedFlags {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("flag --%s is only supported by \"gardenctl ls seeds\"", name)
+			}
+		}
+	}
+	return nil
+}
+
+// newShootFilter returns the filter of ls shoots and ls issues set by the flags
+func newShootFilter() (*gardenctl.ShootFilter, error) {
+	f := &gardenctl.ShootFilter{MinAge: shootMinAge, MaxAge: shootMaxAge}
+	for _, m := range []struct {
+		pattern string
+		matcher **gardenctl.Matcher
+	}{
+		{shootProvider, &f.Provider},
+		{shootRegion, &f.Region},
+		{shootSeed, &f.Seed},
+		{shootPurpose, &f.Purpose},
+		{shootCreatedBy, &f.CreatedBy},
+	} {
+		if m.pattern == "" {
+			continue
+		}
+		matcher, err := newMatcher(m.pattern)
+		if err != nil {
+			return nil, err
+		}
+		*m.matcher = matcher
+	}
+	if shootKubernetesVersion != "" {
+		constraints, err := semver.NewConstraint(shootKubernetesVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes version range %q: %v", shootKubernetesVersion, err)
+		}
+		f.KubernetesVersion = constraints
+	}
+	if shootHibernated && shootAwake {
+		return nil, errors.New("flags --hibernated and --awake cannot be combined")
+	}
+	if shootHibernated || shootAwake {
+		f.Hibernated = &shootHibernated
+	}
+	if shootMinAge > 0 && shootMaxAge > 0 && shootMinAge > shootMaxAge {
+		return nil, errors.New("flag --min-age must not be greater than --max-age")
+	}
+	return f, nil
+}
+
+// printShoots prints the shoots of the targeted garden, project or seed matching m and the filters of ls shoots
+func printShoots(target TargetInterface, m *gardenctl.Matcher, writer io.Writer, outFormat string) error {
+	filter, err := newShootFilter()
+	if err != nil {
+		return err
+	}
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return err
+	}
+	projects, err := projectsByNamespace(gardenClientset)
+	if err != nil {
+		return err
+	}
+
+	namespace := ""
+	var seed string
+	if stack := target.Stack(); len(stack) > 1 {
+		switch stack[1].Kind {
+		case TargetKindProject:
+			for ns, name := range projects {
+				if name == stack[1].Name {
+					namespace = ns
+				}
+			}
+			if namespace == "" {
+				return NewNotFoundError("project %q not found", stack[1].Name)
+			}
+		case TargetKindSeed:
+			seed = stack[1].Name
+		}
+	}
+	shootList, err := gardenClientset.CoreV1beta1().Shoots(namespace).List(metav1.ListOptions{LabelSelector: shootSelector})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var shoots Shoots
+	for i := range shootList.Items {
+		shoot := &shootList.Items[i]
+		if !matchName(m, shoot.Name) || !filter.Match(shoot, now) {
+			continue
+		}
+		if seed != "" && (shoot.Spec.SeedName == nil || *shoot.Spec.SeedName != seed) {
+			continue
+		}
+		shoots.Shoots = append(shoots.Shoots, toShootMeta(shoot, projects[shoot.Namespace]))
+	}
+	sort.SliceStable(shoots.Shoots, func(i, j int) bool {
+		if shoots.Shoots[i].Project != shoots.Shoots[j].Project {
+			return shoots.Shoots[i].Project < shoots.Shoots[j].Project
+		}
+		return shoots.Shoots[i].Name < shoots.Shoots[j].Name
+	})
+
+	return PrintoutObject(shoots, writer, outFormat)
+}
+
+// projectsByNamespace returns the names of the projects by their namespace
+func projectsByNamespace(gardenClientset gardencoreclientset.Interface) (map[string]string, error) {
+	projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	projects := map[string]string{}
+	for _, project := range projectList.Items {
+		if project.Spec.Namespace != nil {
+			projects[*project.Spec.Namespace] = project.Name
+		}
+	}
+	return projects, nil
+}
+
+// toShootMeta returns the details of a shoot in the project
+func toShootMeta(shoot *gardencorev1beta1.Shoot, project string) ShootMeta {
+	sm := ShootMeta{
+		Name:              shoot.Name,
+		Project:           project,
+		Namespace:         shoot.Namespace,
+		Provider:          shoot.Spec.Provider.Type,
+		Region:            shoot.Spec.Region,
+		KubernetesVersion: shoot.Spec.Kubernetes.Version,
+		Hibernated:        shoot.Status.IsHibernated,
+		CreatedBy:         shoot.Annotations[gardenctl.CreatedByAnnotation],
+		CreationTimestamp: shoot.CreationTimestamp.Time,
+		Health:            gardenctl.ShootHealth(shoot),
+	}
+	if shoot.Spec.SeedName != nil {
+		sm.Seed = *shoot.Spec.SeedName
+	}
+	if shoot.Spec.Purpose != nil {
+		sm.Purpose = string(*shoot.Spec.Purpose)
+	}
+	for _, worker := range shoot.Spec.Provider.Workers {
+		sm.Workers = append(sm.Workers, WorkerMeta{
+			Name:        worker.Name,
+			MachineType: worker.Machine.Type,
+			Minimum:     worker.Minimum,
+			Maximum:     worker.Maximum,
+		})
+	}
+	if op := shoot.Status.LastOperation; op != nil {
+		sm.LastOperation = &LastOperationMeta{
+			Description:    op.Description,
+			LastUpdateTime: op.LastUpdateTime.String(),
+			Progress:       int(op.Progress),
+			State:          string(op.State),
+			Type:           string(op.Type),
+		}
+	}
+	return sm
+}
+
+// PrintTable prints the shoots as table, wide adds the purpose, the creator and the age
+func (s Shoots) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"NAME", "PROJECT", "SEED", "PROVIDER", "REGION", "VERSION", "WORKERS", "HIBERNATED", "HEALTH", "LAST OPERATION"}
+	if wide {
+		header = append(header, "PURPOSE", "CREATED BY", "AGE")
+	}
+	now := time.Now()
+	var rows [][]string
+	for _, shoot := range s.Shoots {
+		var minimum, maximum int32
+		for _, worker := range shoot.Workers {
+			minimum += worker.Minimum
+			maximum += worker.Maximum
+		}
+		row := []string{shoot.Name, orDash(shoot.Project), orDash(shoot.Seed), shoot.Provider, shoot.Region, shoot.KubernetesVersion,
+			fmt.Sprintf("%d-%d", minimum, maximum), fmt.Sprint(shoot.Hibernated), shoot.Health, formatLastOperation(shoot.LastOperation)}
+		if wide {
+			row = append(row, orDash(shoot.Purpose), orDash(shoot.CreatedBy), formatAge(shoot.CreationTimestamp, now))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// formatLastOperation returns the type, state and progress of the last operation for a table cell
+func formatLastOperation(op *LastOperationMeta) string {
+	if op == nil || op.Type == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s %s (%d%%)", op.Type, op.State, op.Progress)
+}
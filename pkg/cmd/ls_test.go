@@ -17,8 +17,11 @@ package cmd_test
 import (
 	"github.com/gardener/gardenctl/pkg/cmd"
 	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorefake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
 	"github.com/golang/mock/gomock"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -58,6 +61,37 @@ var _ = Describe("Ls command", func() {
 			})
 		})
 
+		Context("list gardens", func() {
+			It("should only list the gardens matching the pattern", func() {
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+				target.EXPECT().Stack().Return([]cmd.TargetMeta{})
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+					GardenClusters: []cmd.GardenClusterMeta{
+						{Name: "prod-aws"},
+						{Name: "dev-aws"},
+						{Name: "prod-gcp"},
+					},
+				})
+
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"gardens", "prod-*"})
+				err := command.Execute()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("gardenClusters:\n- name: prod-aws\n- name: prod-gcp\n"))
+			})
+
+			It("should return error for an invalid pattern", func() {
+				ioStreams, _, _, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"gardens", "re:prod-("})
+				err := command.Execute()
+
+				Expect(err).To(MatchError(ContainSubstring("invalid regular expression")))
+			})
+		})
+
 		Context("list shoots", func() {
 			It("should return error for empty target", func() {
 				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
@@ -71,6 +105,243 @@ var _ = Describe("Ls command", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(Equal("target stack is empty"))
 			})
+
+			Context("with shoots in the garden", func() {
+				var (
+					namespace = "garden-core"
+					seed      = "aws-eu1"
+				)
+
+				BeforeEach(func() {
+					shoot := func(name, provider, version string, hibernated bool) *gardencorev1beta1.Shoot {
+						return &gardencorev1beta1.Shoot{
+							ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"team": "core"}},
+							Spec: gardencorev1beta1.ShootSpec{
+								Provider: gardencorev1beta1.Provider{
+									Type:    provider,
+									Workers: []gardencorev1beta1.Worker{{Name: "pool", Machine: gardencorev1beta1.Machine{Type: "m5.large"}, Minimum: 1, Maximum: 3}},
+								},
+								Region:     "eu-west-1",
+								SeedName:   &seed,
+								Kubernetes: gardencorev1beta1.Kubernetes{Version: version},
+							},
+							Status: gardencorev1beta1.ShootStatus{
+								IsHibernated: hibernated,
+								Conditions:   []gardencorev1beta1.Condition{{Type: "APIServerAvailable", Status: gardencorev1beta1.ConditionTrue}},
+								LastOperation: &gardencorev1beta1.LastOperation{
+									Type:     gardencorev1beta1.LastOperationTypeReconcile,
+									State:    gardencorev1beta1.LastOperationStateSucceeded,
+									Progress: 100,
+								},
+							},
+						}
+					}
+					client := gardencorefake.NewSimpleClientset(
+						&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "core"}, Spec: gardencorev1beta1.ProjectSpec{Namespace: &namespace}},
+						shoot("api", "aws", "1.17.5", false),
+						shoot("batch", "aws", "1.18.2", true),
+						shoot("web", "gcp", "1.17.5", false),
+					)
+					targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+					target.EXPECT().Stack().Return([]cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}}).AnyTimes()
+					target.EXPECT().GardenerClient().Return(client, nil)
+				})
+
+				It("should print the filtered shoots as table", func() {
+					ioStreams, _, out, _ := cmd.NewTestIOStreams()
+					command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+					command.SetArgs([]string{"shoots", "--provider", "aws", "--kubernetes-version", "< 1.18"})
+					err := command.Execute()
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.String()).To(Equal("" +
+						"NAME   PROJECT   SEED      PROVIDER   REGION      VERSION   WORKERS   HIBERNATED   HEALTH   LAST OPERATION\n" +
+						"api    core      aws-eu1   aws        eu-west-1   1.17.5    1-3       false        Ready    Reconcile Succeeded (100%)\n"))
+				})
+
+				It("should print the shoots with their fields if an output format is set", func() {
+					ioStreams, _, out, _ := cmd.NewTestIOStreams()
+					command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+					command.Flags().StringP("output", "o", "yaml", "")
+					command.SetArgs([]string{"shoots", "-l", "team=core", "--hibernated", "-o", "yaml"})
+					err := command.Execute()
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.String()).To(HavePrefix("shoots:\n- name: batch\n  project: core\n"))
+					Expect(out.String()).To(ContainSubstring("  hibernated: true\n"))
+					Expect(out.String()).To(ContainSubstring("    machineType: m5.large\n"))
+					Expect(out.String()).NotTo(ContainSubstring("name: api"))
+				})
+			})
+
+			It("should refuse filters for other kinds", func() {
+				ioStreams, _, _, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"projects", "--provider", "aws"})
+				err := command.Execute()
+
+				Expect(err).To(MatchError(`flag --provider is only supported by "gardenctl ls shoots" and "gardenctl ls issues"`))
+			})
+		})
+
+		Context("list issues", func() {
+			BeforeEach(func() {
+				namespace := "garden-core"
+				seed := "aws-eu1"
+				shoot := func(name string, seedName *string, op *gardencorev1beta1.LastOperation, conditions ...gardencorev1beta1.Condition) *gardencorev1beta1.Shoot {
+					return &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+						Spec: gardencorev1beta1.ShootSpec{
+							Provider: gardencorev1beta1.Provider{Type: "aws"},
+							SeedName: seedName,
+						},
+						Status: gardencorev1beta1.ShootStatus{LastOperation: op, Conditions: conditions},
+					}
+				}
+				succeeded := &gardencorev1beta1.LastOperation{
+					Type:     gardencorev1beta1.LastOperationTypeReconcile,
+					State:    gardencorev1beta1.LastOperationStateSucceeded,
+					Progress: 100,
+				}
+				client := gardencorefake.NewSimpleClientset(
+					&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "core"}, Spec: gardencorev1beta1.ProjectSpec{Namespace: &namespace}},
+					shoot("healthy", &seed, succeeded, gardencorev1beta1.Condition{Type: gardencorev1beta1.ShootAPIServerAvailable, Status: gardencorev1beta1.ConditionTrue}),
+					shoot("unscheduled", nil, nil),
+					shoot("nodes", &seed, succeeded, gardencorev1beta1.Condition{
+						Type:    gardencorev1beta1.ShootEveryNodeReady,
+						Status:  gardencorev1beta1.ConditionFalse,
+						Reason:  "NodesUnhealthy",
+						Message: "Machine quota exceeded",
+						Codes:   []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded},
+					}),
+					shoot("quota", &seed, succeeded, gardencorev1beta1.Condition{
+						Type:   gardencorev1beta1.ShootEveryNodeReady,
+						Status: gardencorev1beta1.ConditionFalse,
+						Codes:  []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded},
+					}),
+				)
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+				target.EXPECT().Stack().Return([]cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}}).AnyTimes()
+				target.EXPECT().GardenerClient().Return(client, nil)
+			})
+
+			It("should list the issues with their conditions, the most severe first", func() {
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"issues"})
+				err := command.Execute()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(HavePrefix("issues:\n- project: core\n  seed: aws-eu1\n  shoot: nodes\n  provider: aws\n  health: NotReady\n  severity: high\n  codes:\n  - ERR_INFRA_QUOTA_EXCEEDED\n"))
+				Expect(out.String()).To(ContainSubstring("  - type: EveryNodeReady\n    status: \"False\"\n    reason: NodesUnhealthy\n    message: Machine quota exceeded\n"))
+				Expect(out.String()).To(ContainSubstring("- project: core\n  shoot: unscheduled\n  provider: aws\n  health: None\n  severity: low\n"))
+				Expect(out.String()).NotTo(ContainSubstring("shoot: healthy"))
+			})
+
+			It("should filter the issues by error code and severity", func() {
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"issues", "--error-code", "ERR_INFRA_*", "--min-severity", "high", "--seed", "aws-*"})
+				err := command.Execute()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(ContainSubstring("shoot: nodes\n"))
+				Expect(out.String()).To(ContainSubstring("shoot: quota\n"))
+				Expect(out.String()).NotTo(ContainSubstring("shoot: unscheduled"))
+			})
+
+			It("should group the issues by root cause", func() {
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"issues", "--group-by-code"})
+				err := command.Execute()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("" +
+					"groups:\n" +
+					"- code: ERR_INFRA_QUOTA_EXCEEDED\n  severity: high\n  count: 2\n  shoots:\n  - core/nodes\n  - core/quota\n" +
+					"- code: <none>\n  severity: low\n  count: 1\n  shoots:\n  - core/unscheduled\n"))
+			})
+		})
+
+		Context("list seeds", func() {
+			BeforeEach(func() {
+				namespace := "garden-core"
+				awsSeed, gcpSeed := "aws-eu1", "gcp-eu1"
+				region := "eu-central-1"
+				seed := func(name, provider string, ready gardencorev1beta1.ConditionStatus, taints ...gardencorev1beta1.SeedTaint) *gardencorev1beta1.Seed {
+					return &gardencorev1beta1.Seed{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec: gardencorev1beta1.SeedSpec{
+							Provider: gardencorev1beta1.SeedProvider{Type: provider, Region: "eu-west-1"},
+							Taints:   taints,
+						},
+						Status: gardencorev1beta1.SeedStatus{Conditions: []gardencorev1beta1.Condition{
+							{Type: gardencorev1beta1.SeedGardenletReady, Status: ready},
+							{Type: gardencorev1beta1.SeedBootstrapped, Status: gardencorev1beta1.ConditionTrue},
+						}},
+					}
+				}
+				shoot := func(name string, seedName *string, op *gardencorev1beta1.LastOperation) *gardencorev1beta1.Shoot {
+					return &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+						Spec:       gardencorev1beta1.ShootSpec{SeedName: seedName},
+						Status:     gardencorev1beta1.ShootStatus{LastOperation: op},
+					}
+				}
+				succeeded := &gardencorev1beta1.LastOperation{
+					Type:     gardencorev1beta1.LastOperationTypeReconcile,
+					State:    gardencorev1beta1.LastOperationStateSucceeded,
+					Progress: 100,
+				}
+				aws := seed(awsSeed, "aws", gardencorev1beta1.ConditionTrue)
+				aws.Spec.Backup = &gardencorev1beta1.SeedBackup{Provider: "aws", Region: &region}
+				client := gardencorefake.NewSimpleClientset(
+					aws,
+					seed(gcpSeed, "gcp", gardencorev1beta1.ConditionFalse, gardencorev1beta1.SeedTaint{Key: gardencorev1beta1.DeprecatedSeedTaintInvisible}),
+					shoot("api", &awsSeed, succeeded),
+					shoot("web", &awsSeed, nil),
+					shoot("batch", &gcpSeed, succeeded),
+					shoot("unscheduled", nil, nil),
+				)
+				targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+				target.EXPECT().Stack().Return([]cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}}).AnyTimes()
+				target.EXPECT().GardenerClient().Return(client, nil).AnyTimes()
+				configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+					GardenClusters: []cmd.GardenClusterMeta{{Name: "prod", SeedCapacity: 3}},
+				}).AnyTimes()
+			})
+
+			It("should print the overview of the seeds sorted by free capacity", func() {
+				ioStreams, _, out, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"seeds", "--sort-by", "free"})
+				err := command.Execute()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("" +
+					"SEED      PROVIDER   REGION      HEALTH     VISIBLE   PROTECTED   SHOOTS   FREE   ISSUES   BACKUP\n" +
+					"gcp-eu1   gcp        eu-west-1   NotReady   false     false       1/3      2      0        -\n" +
+					"aws-eu1   aws        eu-west-1   Ready      true      false       2/3      1      1        aws/eu-central-1\n"))
+			})
+
+			It("should refuse an invalid sort order", func() {
+				ioStreams, _, _, _ := cmd.NewTestIOStreams()
+				command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+				command.SetArgs([]string{"seeds", "--sort-by", "region"})
+				err := command.Execute()
+
+				Expect(err).To(MatchError(`invalid sort order "region", must be one of name, allocated, free, issues`))
+			})
+		})
+
+		It("should refuse the filters of issues for other kinds", func() {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewLsCmd(targetReader, configReader, ioStreams)
+			command.SetArgs([]string{"shoots", "--min-severity", "high"})
+			err := command.Execute()
+
+			Expect(err).To(MatchError(`flag --min-severity is only supported by "gardenctl ls issues"`))
 		})
 	})
 
@@ -16,10 +16,8 @@ package cmd
 
 import (
 	"errors"
-	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
@@ -28,132 +26,99 @@ import (
 
 	authorizationv1 "k8s.io/api/authorization/v1"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	"github.com/gardener/gardener/pkg/apis/core"
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	k8s "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 // GetGardenConfig sets GardenConfig struct
-func GetGardenConfig(pathGardenConfig string, gardenConfig *GardenConfig) {
-	yamlGardenConfig, err := ioutil.ReadFile(pathGardenConfig)
-	checkError(err)
-	err = yaml.Unmarshal(yamlGardenConfig, &gardenConfig)
+func GetGardenConfig(pathGardenConfig string, gardenConfig *GardenConfig) error {
+	config, err := gardenctl.LoadConfig(pathGardenConfig)
 	if err != nil {
-		fmt.Println("Invalid gardenctl configuration")
-		os.Exit(2)
+		return err
 	}
+	*gardenConfig = *config
+	return nil
 }
 
 // GetGardenClusterKubeConfigFromConfig return kubeconfig of garden cluster if exists
-func GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget string) {
+func GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget string) error {
 	var gardenConfig GardenConfig
-	var target Target
 	i, err := os.Stat(pathTarget)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	if i.Size() == 0 {
 		// if no garden cluster is selected take the first as default cluster
 		i, err := os.Stat(pathGardenConfig)
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if i.Size() == 0 {
-			fmt.Println("Please provide a gardenctl configuration before usage")
-			return
+			fmt.Fprintln(os.Stderr, "Please provide a gardenctl configuration before usage")
+			return nil
 		}
-		GetGardenConfig(pathGardenConfig, &gardenConfig)
-		target.Target = []TargetMeta{{"garden", gardenConfig.GardenClusters[0].Name}}
-		file, err := os.OpenFile(pathTarget, os.O_WRONLY|os.O_CREATE, 0644)
-		checkError(err)
-		defer file.Close()
-		content, err := yaml.Marshal(target)
-		checkError(err)
-		_, err = file.Write(content)
-		checkError(err)
-	}
-}
-
-// clientToTarget returns the client to target e.g. garden, seed
-// DEPRECATED: Use `target.K8SClientToKind()` instead.
-func clientToTarget(target TargetKind) (*k8s.Clientset, error) {
-	switch target {
-	case TargetKindGarden:
-		KUBECONFIG = getKubeConfigOfClusterType("garden")
-	case TargetKindSeed:
-		KUBECONFIG = getKubeConfigOfClusterType("seed")
-	case TargetKindShoot:
-		KUBECONFIG = getKubeConfigOfClusterType("shoot")
-	}
-	var pathToKubeconfig string
-	if kubeconfig == nil {
-		if home := HomeDir(); home != "" {
-			if target == TargetKindSeed || target == TargetKindShoot {
-				kubeconfig = flag.String("kubeconfig", getKubeConfigOfCurrentTarget(), "(optional) absolute path to the kubeconfig file")
-			} else {
-				pathToKubeconfig = TidyKubeconfigWithHomeDir(getGardenKubeConfig())
-				kubeconfig = flag.String("kubeconfig", pathToKubeconfig, "(optional) absolute path to the kubeconfig file")
-			}
-		} else {
-			kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
+		if err := GetGardenConfig(pathGardenConfig, &gardenConfig); err != nil {
+			return err
 		}
-		flag.Parse()
-	} else {
-		err := flag.Set("kubeconfig", KUBECONFIG)
-		checkError(err)
-	}
-	kubeconfig, err := ioutil.ReadFile(*kubeconfig)
-	checkError(err)
-	clientConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
-	checkError(err)
-	rawConfig, err := clientConfig.RawConfig()
-	checkError(err)
-	if err := ValidateClientConfig(rawConfig); err != nil {
-		return nil, err
+		if len(gardenConfig.GardenClusters) == 0 {
+			fmt.Fprintln(os.Stderr, "Please provide a gardenctl configuration before usage")
+			return nil
+		}
+		return gardenctl.WriteTarget(pathTarget, []TargetMeta{{Kind: TargetKindGarden, Name: gardenConfig.GardenClusters[0].Name}})
 	}
-	config, err := clientConfig.ClientConfig()
-	checkError(err)
-	clientset, err := k8s.NewForConfig(config)
-	return clientset, err
+	return nil
 }
 
 // getMonitoringCredentials returns username and password required for url login to the montiring tools
-func getMonitoringCredentials() (username, password string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	shootName := target.Target[2].Name
-	shootNamespace := getSeedNamespaceNameForShoot(shootName)
-	var err error
-	Client, err = clientToTarget("seed")
-	checkError(err)
+func getMonitoringCredentials(targetReader TargetReader) (username, password string, err error) {
+	target := targetReader.ReadTarget(pathTarget)
+	shootName := target.Stack()[2].Name
+	shootNamespace, err := getSeedNamespaceNameForShoot(targetReader, shootName)
+	if err != nil {
+		return "", "", err
+	}
+	client, err := target.K8SClientToKind(TargetKindSeed)
+	if err != nil {
+		return "", "", err
+	}
 	secretName := "monitoring-ingress-credentials"
-	monitoringSecret, err := Client.CoreV1().Secrets(shootNamespace).Get((secretName), metav1.GetOptions{})
-	checkError(err)
+	monitoringSecret, err := client.CoreV1().Secrets(shootNamespace).Get((secretName), metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
 	username = string(monitoringSecret.Data["username"][:])
 	password = string(monitoringSecret.Data["password"][:])
-	return username, password
+	return username, password, nil
 }
 
 // getSeedNamespaceNameForShoot returns namespace name
-func getSeedNamespaceNameForShoot(shootName string) (namespaceSeed string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	gardenClientset, err := gardencoreclientset.NewForConfig(NewConfigFromBytes(*kubeconfig))
-	checkError(err)
+func getSeedNamespaceNameForShoot(targetReader TargetReader, shootName string) (string, error) {
+	target := targetReader.ReadTarget(pathTarget)
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return "", err
+	}
 	var shoot *gardencorev1beta1.Shoot
 	if target.Stack()[1].Kind == "project" {
 		project, err := gardenClientset.CoreV1beta1().Projects().Get(target.Stack()[1].Name, metav1.GetOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		shoot, err = gardenClientset.CoreV1beta1().Shoots(*project.Spec.Namespace).Get(target.Stack()[2].Name, metav1.GetOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 	} else {
 		shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		for index, s := range shootList.Items {
 			if s.Name == target.Stack()[2].Name && *s.Spec.SeedName == target.Stack()[1].Name {
 				shoot = &shootList.Items[index]
@@ -161,19 +126,35 @@ func getSeedNamespaceNameForShoot(shootName string) (namespaceSeed string) {
 			}
 		}
 	}
-	return shoot.Status.TechnicalID
+	if shoot == nil {
+		return "", NewNotFoundError("shoot %s not found on seed %s", target.Stack()[2].Name, target.Stack()[1].Name)
+	}
+	return shoot.Status.TechnicalID, nil
+}
+
+// clusterOfCurrentTarget returns a client and the kubeconfig path for the cluster of the given kind in the current target
+func clusterOfCurrentTarget(targetReader TargetReader, kind TargetKind) (k8s.Interface, string, error) {
+	target := targetReader.ReadTarget(pathTarget)
+	kubeconfig, err := target.KubeconfigPathToKind(kind)
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := target.K8SClientToKind(kind)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, kubeconfig, nil
 }
 
 // getTargetType returns error and name of type
-func getTargetType() (TargetKind, error) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	length := len(target.Target)
+func getTargetType(targetReader TargetReader) (TargetKind, error) {
+	stack := targetReader.ReadTarget(pathTarget).Stack()
+	length := len(stack)
 	switch length {
 	case 1:
 		return TargetKindGarden, nil
 	case 2:
-		if target.Target[1].Kind == "seed" {
+		if stack[1].Kind == "seed" {
 			return TargetKindSeed, nil
 		}
 
@@ -185,67 +166,89 @@ func getTargetType() (TargetKind, error) {
 	}
 }
 
-func getEmail(githubURL string) string {
+func getEmail(githubURL string) (string, error) {
 	if githubURL == "" {
-		return "null"
+		return "null", nil
 	}
 	baseURL, err := url.Parse(githubURL)
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	baseURL.Path += "/api/v3/users/"
 	baseURL.Path += url.PathEscape(os.Getenv("USER"))
 	resp, err := http.Get(baseURL.String())
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	defer resp.Body.Close()
 	userInfo, err := ioutil.ReadAll(resp.Body)
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	var yamlOut map[string]interface{}
 	err = yaml.Unmarshal(userInfo, &yamlOut)
-	checkError(err)
-	githubEmail := yamlOut["email"].(string)
+	if err != nil {
+		return "", err
+	}
+	githubEmail, ok := yamlOut["email"].(string)
+	if !ok {
+		return "null", nil
+	}
 	fmt.Printf("used GitHub email: %s\n", githubEmail)
-	return githubEmail
+	return githubEmail, nil
 }
 
-func getEmailFromConfig() string {
+func getEmailFromConfig() (string, error) {
 	var gardenConfig GardenConfig
-	GetGardenConfig(pathGardenConfig, &gardenConfig)
-	return gardenConfig.Email
+	if err := GetGardenConfig(pathGardenConfig, &gardenConfig); err != nil {
+		return "", err
+	}
+	return gardenConfig.Email, nil
 }
 
-func getGithubURL() string {
+func getGithubURL() (string, error) {
 	var gardenConfig GardenConfig
-	GetGardenConfig(pathGardenConfig, &gardenConfig)
-	return gardenConfig.GithubURL
+	if err := GetGardenConfig(pathGardenConfig, &gardenConfig); err != nil {
+		return "", err
+	}
+	return gardenConfig.GithubURL, nil
 }
 
 func isIPv4(host string) bool {
 	return net.ParseIP(host) != nil && net.ParseIP(host).To4() != nil
 }
 
-func getPublicIP() string {
+func getPublicIP() (string, error) {
 	ipURL, err := url.Parse("https://api.ipify.org")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	params := url.Values{}
 	params.Add("format", "text")
 	ipURL.RawQuery = params.Encode()
 	resp, err := http.Get(ipURL.String())
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	defer resp.Body.Close()
 	ip, err := ioutil.ReadAll(resp.Body)
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	if net.ParseIP(string(ip)) == nil {
-		fmt.Printf("IP not valid:" + string(ip))
-		os.Exit(0)
+		return "", NewRemoteError(nil, "public IP not valid: %s", string(ip))
 	}
-	return string(ip)
+	return string(ip), nil
 }
 
 // get role either user or operator
-func getRole(targetReader TargetReader) string {
+func getRole(targetReader TargetReader) (string, error) {
 	var role string
 	target := targetReader.ReadTarget(pathTarget)
 	clientset, err := target.K8SClientToKind("garden")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	ssar := &authorizationv1.SelfSubjectAccessReview{
 		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authorizationv1.ResourceAttributes{
@@ -255,23 +258,25 @@ func getRole(targetReader TargetReader) string {
 		},
 	}
 	ssar, err = clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ssar)
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	if ssar.Status.Allowed {
 		role = "operator"
 	} else {
 		role = "user"
 	}
-	return role
+	return role, nil
 }
 
 /*
 getTargetMapInfo retun garden,project,seed,shoot,shootTechnicalID to global targetInfo
 Use `getFromTargetInfo()` instead
 */
-func getTargetMapInfo(targetReader TargetReader) {
+func getTargetMapInfo(targetReader TargetReader) error {
 	target := targetReader.ReadTarget(pathTarget)
 	if len(targetInfo) > 0 {
-		return
+		return nil
 	}
 
 	for _, t := range target.Stack() {
@@ -280,7 +285,9 @@ func getTargetMapInfo(targetReader TargetReader) {
 
 	if IsTargeted(targetReader, "shoot") {
 		shoot, err := GetTargetedShootObject(targetReader)
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		targetInfo["shootTechnicalID"] = shoot.Status.TechnicalID
 
 		if targetInfo["seed"] == "" {
@@ -290,9 +297,12 @@ func getTargetMapInfo(targetReader TargetReader) {
 
 	if targetInfo["project"] == "" {
 		projectObj, err := GetTargetedProjectObject(targetReader)
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		targetInfo["project"] = projectObj.Name
 	}
+	return nil
 }
 
 /*
@@ -349,13 +359,15 @@ func IsControlPlaneTargeted(targetReader TargetReader) bool {
 }
 
 //GetFromTargetInfo validation value from global map targetInfo garden/project/shoot/seed/shootTechnicalID/....
-func GetFromTargetInfo(targetReader TargetReader, key string) string {
-	getTargetMapInfo(targetReader)
+func GetFromTargetInfo(targetReader TargetReader, key string) (string, error) {
+	if err := getTargetMapInfo(targetReader); err != nil {
+		return "", err
+	}
 	value := targetInfo[key]
 	if value == "" {
-		log.Fatalf("value %s not found in targetInfo\n", key)
+		return "", fmt.Errorf("value %s not found in targetInfo", key)
 	}
-	return value
+	return value, nil
 }
 
 // GetShootObject return shoot object and error
@@ -385,16 +397,24 @@ func GetTargetedShootObject(targetReader TargetReader) (*v1beta1.Shoot, error) {
 func GetTargetedProjectObject(targetReader TargetReader) (*v1beta1.Project, error) {
 	target := targetReader.ReadTarget(pathTarget)
 	Client, err := target.GardenerClient()
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 	if IsTargeted(targetReader, "project") {
 		name, err := GetTargetName(targetReader, "project")
-		checkError(err)
+		if err != nil {
+			return nil, err
+		}
 		return GetProjectObject(targetReader, name)
 	} else if IsTargeted(targetReader, "seed", "shoot") {
 		seedName, err := GetTargetName(targetReader, "seed")
-		checkError(err)
+		if err != nil {
+			return nil, err
+		}
 		shootName, err := GetTargetName(targetReader, "shoot")
-		checkError(err)
+		if err != nil {
+			return nil, err
+		}
 		shootList, err := Client.CoreV1beta1().Shoots("").List(metav1.ListOptions{
 			FieldSelector: fields.SelectorFromSet(
 				fields.Set{
@@ -72,14 +72,14 @@ gardenClusters:
 	}
 	Context("After calling GetGardenClusterKubeConfigFromConfig", func() {
 		It("First Garden Cluster should be set as default target Name if no garden cluster is specified", func() {
-			GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget)
-			ReadTarget(pathTarget, &target)
+			Expect(GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget)).To(Succeed())
+			Expect(ReadTarget(pathTarget, &target)).To(Succeed())
 			Expect(target.Target[0].Name).To(Equal("dev"))
 		})
 	})
 	Context("After calling GetGardenClusters", func() {
 		It("GardenCluster Name should be dev ", func() {
-			GetGardenConfig(pathGardenConfig, &gardenConf)
+			Expect(GetGardenConfig(pathGardenConfig, &gardenConf)).To(Succeed())
 			Expect(gardenConf.GardenClusters[0].Name).To(Equal("dev"))
 			Expect(gardenConf.GardenClusters[1].Name).To(Equal("prod"))
 		})
@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -24,7 +23,7 @@ import (
 )
 
 // NewOpenstackCmd returns a new openstack cmd.
-func NewOpenstackCmd(targetReader TargetReader) *cobra.Command {
+func NewOpenstackCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                "openstack <args>",
 		Short:              "e.g. \"gardenctl openstack floating ip delete ip_address\"",
@@ -33,14 +32,24 @@ func NewOpenstackCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "openstack"); err != nil {
+				return err
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 			if !CheckToolInstalled("openstack") {
 				fmt.Println("Please go to https://docs.openstack.org/newton/user-guide/common/cli-install-openstack-command-line-clients.html for how to install openstack cli")
-				os.Exit(2)
+				return NewToolMissingError("openstack")
 			}
 			arguments := strings.Join(os.Args[2:], " ")
-			fmt.Println(operate("openstack", arguments))
+			output, err := operate(targetReader, "openstack", arguments)
+			if err != nil {
+				return err
+			}
+			fmt.Println(output)
 
 			return nil
 		},
@@ -18,7 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"os/exec"
 	"strings"
@@ -34,36 +33,50 @@ import (
 )
 
 // operate executes a command on specified cli with pulled credentials for target
-func operate(provider, arguments string) string {
+func operate(targetReader TargetReader, provider, arguments string) (string, error) {
 	secretName, region, namespaceSecret, profile := "", "", "", ""
-	var target Target
-	ReadTarget(pathTarget, &target)
+	target := targetReader.ReadTarget(pathTarget)
 	var err error
 	var secret *v1.Secret
-	Client, err = clientToTarget("garden")
-	checkError(err)
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
 
 	gardenClientset, err := target.GardenerClient()
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	var shoot *gardencorev1beta1.Shoot
 	if target.Stack()[1].Kind == "project" {
 		project, err := gardenClientset.CoreV1beta1().Projects().Get(target.Stack()[1].Name, metav1.GetOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		shoot, err = gardenClientset.CoreV1beta1().Shoots(*project.Spec.Namespace).Get(target.Stack()[2].Name, metav1.GetOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 	} else if target.Stack()[1].Kind == "seed" {
 		shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		var filteredShoots []gardencorev1beta1.Shoot
 		for _, s := range shootList.Items {
-			if s.Name == target.Stack()[2].Name && *s.Spec.SeedName == target.Stack()[1].Name {
+			if s.Name == target.Stack()[2].Name && s.Spec.SeedName != nil && *s.Spec.SeedName == target.Stack()[1].Name {
 				filteredShoots = append(filteredShoots, s)
 			}
 		}
 		if len(filteredShoots) > 1 {
-			fmt.Println("There's more than one shoot with same name " + target.Stack()[2].Name + " in seed " + target.Stack()[1].Name)
-			fmt.Println("Please target the shoot using project")
-			os.Exit(2)
+			var matches []string
+			for _, s := range filteredShoots {
+				matches = append(matches, s.Namespace+"/"+s.Name)
+			}
+			return "", NewAmbiguousMatchError(TargetKindShoot, target.Stack()[2].Name, matches)
+		}
+		if len(filteredShoots) == 0 {
+			return "", NewNotFoundError("shoot %s not found on seed %s", target.Stack()[2].Name, target.Stack()[1].Name)
 		}
 		shoot = &(filteredShoots[0])
 	}
@@ -74,13 +87,17 @@ func operate(provider, arguments string) string {
 	profile = shoot.Spec.CloudProfileName
 
 	secretBinding, err := gardenClientset.CoreV1beta1().SecretBindings(namespaceSecretBinding).Get((secretBindingName), metav1.GetOptions{})
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 
 	secretName = secretBinding.SecretRef.Name
 	namespaceSecret = secretBinding.SecretRef.Namespace
 
-	secret, err = Client.CoreV1().Secrets(namespaceSecret).Get((secretName), metav1.GetOptions{})
-	checkError(err)
+	secret, err = gardenClient.CoreV1().Secrets(namespaceSecret).Get((secretName), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
 
 	var out []byte
 	switch provider {
@@ -93,7 +110,7 @@ func operate(provider, arguments string) string {
 		cmd.Env = newEnv
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			log.Fatalf("AWS CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "AWS CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 
 	case "gcp":
@@ -102,40 +119,49 @@ func operate(provider, arguments string) string {
 		var tmpAccount string
 
 		tmpFile, err := ioutil.TempFile(os.TempDir(), "tmpFile-")
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		defer os.Remove(tmpFile.Name())
 		_, err = tmpFile.Write(serviceaccount)
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		err = tmpFile.Close()
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		tmpAccount, err = ExecCmdReturnOutput("gcloud", "config", "list", "account", "--format", "json")
 		if err != nil {
-			fmt.Println("Cmd was unsuccessful")
-			os.Exit(2)
+			return "", err
 		}
 		dec := json.NewDecoder(strings.NewReader(tmpAccount))
 		err = dec.Decode(&data)
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		jq := jsonq.NewQuery(data)
 		tmpAccount, err = jq.String("core", "account")
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 		err = ExecCmd(nil, "gcloud auth activate-service-account --key-file="+tmpFile.Name(), false)
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 		dec = json.NewDecoder(strings.NewReader(string([]byte(secret.Data["serviceaccount.json"]))))
 		err = dec.Decode(&data)
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		jq = jsonq.NewQuery(data)
 		account, err := jq.String("client_email")
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 		project, err := jq.String("project_id")
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 
 		arguments := arguments + " --account=" + account + " --project=" + project
@@ -143,12 +169,12 @@ func operate(provider, arguments string) string {
 		cmd := exec.Command("gcloud", args...)
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			log.Fatalf("gcloud CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "gcloud CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 
 		err = ExecCmd(nil, "gcloud config set account "+tmpAccount, false)
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 
 	case "az":
@@ -159,7 +185,7 @@ func operate(provider, arguments string) string {
 
 		err := ExecCmd(nil, "az login --service-principal -u "+string(clientID[:])+" -p "+string(clientSecret[:])+" --tenant "+string(tenantID[:]), true)
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 
 		arguments := arguments + " --subscription " + string(subscriptionID[:])
@@ -167,19 +193,25 @@ func operate(provider, arguments string) string {
 		cmd := exec.Command("az", args...)
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			log.Fatalf("az CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "az CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 
 	case "openstack":
 		authURL := ""
 		cloudProfileList, err := gardenClientset.CoreV1beta1().CloudProfiles().List(metav1.ListOptions{})
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		for _, cp := range cloudProfileList.Items {
 			if cp.Name == profile {
 				cloudProfileConfig, err := getOpenstackCloudProfileConfig(&cp)
-				checkError(err)
+				if err != nil {
+					return "", err
+				}
 				authURL, err = getKeyStoneURL(cloudProfileConfig, region)
-				checkError(err)
+				if err != nil {
+					return "", err
+				}
 			}
 		}
 		domainName := []byte(secret.Data["domainName"])
@@ -193,8 +225,7 @@ func operate(provider, arguments string) string {
 		cmd.Env = newEnv
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			fmt.Println(err)
-			log.Fatalf("Openstack CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "Openstack CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 
 	case "aliyun":
@@ -202,18 +233,17 @@ func operate(provider, arguments string) string {
 		accessKeySecret := secret.Data["accessKeySecret"]
 		err = ExecCmd(nil, "aliyun configure set --access-key-id="+string(accessKeyID[:])+" --access-key-secret="+string(accessKeySecret[:])+" --region="+region, true)
 		if err != nil {
-			os.Exit(2)
+			return "", err
 		}
 
 		if arguments == "" {
-			return ""
+			return "", nil
 		}
 		args := strings.Fields(arguments)
 		cmd := exec.Command("aliyun", args...)
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			fmt.Println(err)
-			log.Fatalf("Aliyun CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "Aliyun CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 	case "hcloud":
 		token := []byte(secret.Data["hcloudToken"])
@@ -223,11 +253,11 @@ func operate(provider, arguments string) string {
 		cmd.Env = newEnv
 		out, err = cmd.CombinedOutput()
 		if err != nil {
-			log.Fatalf("hcloud CLI failed with %s\n%s\n", out, err)
+			return "", NewRemoteError(err, "hcloud CLI failed with %s", strings.TrimSpace(string(out)))
 		}
 	}
 
-	return (strings.TrimSpace(string(out[:])))
+	return strings.TrimSpace(string(out[:])), nil
 }
 
 func getOpenstackCloudProfileConfig(in *gardencorev1beta1.CloudProfile) (*openstackv1alpha1.CloudProfileConfig, error) {
@@ -237,7 +267,9 @@ func getOpenstackCloudProfileConfig(in *gardencorev1beta1.CloudProfile) (*openst
 
 	extensionsScheme := runtime.NewScheme()
 	err := openstackinstall.AddToScheme(extensionsScheme)
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 	decoder := serializer.NewCodecFactory(extensionsScheme).UniversalDecoder()
 
 	out := &openstackv1alpha1.CloudProfileConfig{
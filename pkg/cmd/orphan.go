@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -36,38 +35,47 @@ func NewOrphanCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
 			}
 			var rs []string
-			pathTerraformState := filepath.Join(downloadTerraformFiles("infra", targetReader), "terraform.tfstate")
+			pathTerraformFiles, err := downloadTerraformFiles("infra", targetReader)
+			if err != nil {
+				return err
+			}
+			pathTerraformState := filepath.Join(pathTerraformFiles, "terraform.tfstate")
 			buf, err := ioutil.ReadFile(pathTerraformState)
 			if err != nil || len(buf) < 64 {
-				fmt.Println("Could not read terraform.tfstate: " + pathTerraformState)
-				os.Exit(2)
+				return NewNotFoundError("could not read terraform.tfstate: %s", pathTerraformState)
 			}
 			terraformstate := string(buf)
 
 			shoot, err := FetchShootFromTarget(target)
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			infraType := shoot.Spec.Provider.Type
 
 			switch infraType {
 			case "aws":
-				rs = getAWSInfraResources(targetReader)
+				rs, err = getAWSInfraResources(targetReader)
 			case "azure":
-				rs = getAzureInfraResources(targetReader)
+				rs, err = getAzureInfraResources(targetReader)
 			case "gcp":
-				rs = getGCPInfraResources(targetReader)
+				rs, err = getGCPInfraResources(targetReader)
 			case "openstack":
-				rs = getOstackInfraResources(targetReader)
+				rs, err = getOstackInfraResources(targetReader)
 			case "alicloud":
-				rs = getAliCloudInfraResources(targetReader)
+				rs, err = getAliCloudInfraResources(targetReader)
 			default:
 				return errors.New("infra type not found")
 			}
+			if err != nil {
+				return err
+			}
 
-			err = GetOrphanInfraResources(rs, terraformstate)
-			checkError(err)
+			if err := GetOrphanInfraResources(rs, terraformstate); err != nil {
+				return err
+			}
 			fmt.Printf("\n\nsearched %s\n", pathTerraformState)
 
 			return nil
@@ -99,68 +107,113 @@ func GetOrphanInfraResources(rs []string, terraformstate string) error {
 	return nil
 }
 
-func getAWSInfraResources(targetReader TargetReader) []string {
+func getAWSInfraResources(targetReader TargetReader) ([]string, error) {
 	rs := make([]string, 0)
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return nil, err
+	}
 
 	// fetch shoot vpc resources
-	capturedOutput := execInfraOperator("aws", "ec2 describe-vpcs --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err := execInfraOperator(targetReader, "aws", "ec2 describe-vpcs --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`VPCS.*(vpc-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot subnet resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-subnets --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-subnets --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`SUBNETS.*:subnet\/(subnet-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot dhcp options resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-dhcp-options --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-dhcp-options --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`DHCPOPTIONS.*(dopt-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot ip address resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-addresses --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-addresses --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`ADDRESSES.*(eipalloc-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot nat gateway resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-nat-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-nat-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`NATGATEWAYS.*(nat-[a-z0-9]*)`, capturedOutput, rs)
 	rs = findInfraResourcesMatch(`NATGATEWAYADDRESSES.*(eni-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot internet gateway resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-internet-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-internet-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`INTERNETGATEWAYS.*(igw-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot security group resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-security-groups --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-security-groups --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`SECURITYGROUPS.*(sg-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot route table resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-route-tables --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-route-tables --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`ROUTETABLES.*(rtb-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot instance resources
-	capturedOutput = execInfraOperator("aws", "ec2 describe-instances --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-instances --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`IAMINSTANCEPROFILE.*:instance-profile\/(shoot--[a-z0-9-]*-nodes)`, capturedOutput, rs)
 
 	// fetch shoot bastion instance resource
-	capturedOutput = execInfraOperator("aws", "ec2 describe-instances --filter Name=tag:Name,Values="+shoottag+"-bastions")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-instances --filter Name=tag:Name,Values="+shoottag+"-bastions")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`INSTANCES.*(i-[a-z0-9]*)`, capturedOutput, rs)
 
 	// fetch shoot bastion security group
-	capturedOutput = execInfraOperator("aws", "ec2 describe-security-groups --filter Name=tag:component,Values=gardenctl")
+	capturedOutput, err = execInfraOperator(targetReader, "aws", "ec2 describe-security-groups --filter Name=tag:component,Values=gardenctl")
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch("SECURITYGROUPS.*(sg-[a-z0-9]*).*"+shoottag, capturedOutput, rs)
 
-	return unique(rs)
+	return unique(rs), nil
 }
 
-func getAzureInfraResources(targetReader TargetReader) []string {
+func getAzureInfraResources(targetReader TargetReader) ([]string, error) {
 	rs := make([]string, 0)
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return nil, err
+	}
 
 	// fetch shoot resource group
-	capturedOutput := execInfraOperator("az", "group show --name "+shoottag)
+	capturedOutput, err := execInfraOperator(targetReader, "az", "group show --name "+shoottag)
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`\"id\".*(resourceGroups\/[a-z0-9-]*)\"`, capturedOutput, rs)
 
 	// fetch shoot vnet resources
-	capturedOutput = execInfraOperator("az", "network vnet list -g "+shoottag)
+	capturedOutput, err = execInfraOperator(targetReader, "az", "network vnet list -g "+shoottag)
+	if err != nil {
+		return nil, err
+	}
 	vnets := make([]string, 0)
 	vnets = findInfraResourcesMatch(`\"id\".*(virtualNetworks\/[a-z0-9-]*)\"`, capturedOutput, vnets)
 	rs = findInfraResourcesMatch(`\"id\".*(virtualNetworks\/[a-z0-9-]*)\"`, capturedOutput, rs)
@@ -170,32 +223,50 @@ func getAzureInfraResources(targetReader TargetReader) []string {
 		for _, vnet := range vnets {
 			s := strings.Split(vnet, "/")
 			vnetName := s[1]
-			capturedOutput = execInfraOperator("az", "network vnet subnet list -g "+shoottag+" --vnet-name "+vnetName)
+			capturedOutput, err = execInfraOperator(targetReader, "az", "network vnet subnet list -g "+shoottag+" --vnet-name "+vnetName)
+			if err != nil {
+				return nil, err
+			}
 			rs = findInfraResourcesMatch(`\"id\".*(subnets\/[a-z0-9-]*)\"`, capturedOutput, rs)
 		}
 	}
 
 	// fetch shoot nic resources
-	capturedOutput = execInfraOperator("az", "network nic list -g "+shoottag)
+	capturedOutput, err = execInfraOperator(targetReader, "az", "network nic list -g "+shoottag)
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`\"id\".*(networkInterfaces\/[a-z0-9-]*)\"`, capturedOutput, rs)
 
 	// fetch shoot security group resources
-	capturedOutput = execInfraOperator("az", "network nsg list -g "+shoottag)
+	capturedOutput, err = execInfraOperator(targetReader, "az", "network nsg list -g "+shoottag)
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`\"id\".*(networkSecurityGroups\/[a-z0-9-]*)\"`, capturedOutput, rs)
 
 	// fetch shoot route resources
-	capturedOutput = execInfraOperator("az", "network route-table list -g "+shoottag)
+	capturedOutput, err = execInfraOperator(targetReader, "az", "network route-table list -g "+shoottag)
+	if err != nil {
+		return nil, err
+	}
 	rs = findInfraResourcesMatch(`\"id\".*routes\/([a-z0-9-]*)\"`, capturedOutput, rs)
 
-	return unique(rs)
+	return unique(rs), nil
 }
 
-func getGCPInfraResources(targetReader TargetReader) []string {
+func getGCPInfraResources(targetReader TargetReader) ([]string, error) {
 	rs := make([]string, 0)
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return nil, err
+	}
 
 	// fetch shoot subnet resource
-	capturedOutput := execInfraOperator("gcp", "compute networks subnets list")
+	capturedOutput, err := execInfraOperator(targetReader, "gcp", "compute networks subnets list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag+"-nodes") {
 		rsShootSubnet := make([]string, 0)
 		rsShootSubnet = findInfraResourcesMatch(shoottag+"-nodes(.*)", capturedOutput, rsShootSubnet)
@@ -205,13 +276,19 @@ func getGCPInfraResources(targetReader TargetReader) []string {
 			rs = append(rs, shoottag+"-nodes")
 
 			// fetch shoot vpc resource
-			capturedOutput = execInfraOperator("gcp", "compute networks list")
+			capturedOutput, err = execInfraOperator(targetReader, "gcp", "compute networks list")
+			if err != nil {
+				return nil, err
+			}
 			if strings.Contains(capturedOutput, shootVpc) {
 				rs = append(rs, shootVpc)
 			}
 
 			// fetch shoot cloud router resource
-			capturedOutput = execInfraOperator("gcp", "compute routers list")
+			capturedOutput, err = execInfraOperator(targetReader, "gcp", "compute routers list")
+			if err != nil {
+				return nil, err
+			}
 			if strings.Contains(capturedOutput, shootVpc) {
 				rsShootRouter := make([]string, 0)
 				rsShootRouter = findInfraResourcesMatch("(.*)"+shootVpc, capturedOutput, rsShootRouter)
@@ -223,7 +300,10 @@ func getGCPInfraResources(targetReader TargetReader) []string {
 						rs = append(rs, shootRouter)
 
 						// fetch shoot cloud nat resource
-						capturedOutput = execInfraOperator("gcp", "compute routers nats list --router="+shootRouter+" --router-region="+shootRouterRegion)
+						capturedOutput, err = execInfraOperator(targetReader, "gcp", "compute routers nats list --router="+shootRouter+" --router-region="+shootRouterRegion)
+						if err != nil {
+							return nil, err
+						}
 						if strings.Contains(capturedOutput, shoottag+"-cloud-nat") {
 							rs = append(rs, shoottag+"-cloud-nat")
 						}
@@ -234,7 +314,10 @@ func getGCPInfraResources(targetReader TargetReader) []string {
 	}
 
 	// fetch shoot service account
-	capturedOutput = execInfraOperator("gcp", "iam service-accounts list")
+	capturedOutput, err = execInfraOperator(targetReader, "gcp", "iam service-accounts list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag) {
 		rsserviceAccount := make([]string, 0)
 		rsserviceAccount = findInfraResourcesMatch(shoottag+"(.*)False", capturedOutput, rsserviceAccount)
@@ -246,15 +329,21 @@ func getGCPInfraResources(targetReader TargetReader) []string {
 		}
 	}
 
-	return unique(rs)
+	return unique(rs), nil
 }
 
-func getOstackInfraResources(targetReader TargetReader) []string {
+func getOstackInfraResources(targetReader TargetReader) ([]string, error) {
 	rs := make([]string, 0)
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return nil, err
+	}
 
 	// fetch shoot network id
-	capturedOutput := execInfraOperator("openstack", "openstack network list")
+	capturedOutput, err := execInfraOperator(targetReader, "openstack", "openstack network list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag) {
 		rsShootNetwork := make([]string, 0)
 		rsShootNetwork = findInfraResourcesMatch("(.*)"+shoottag, capturedOutput, rsShootNetwork)
@@ -266,7 +355,10 @@ func getOstackInfraResources(targetReader TargetReader) []string {
 	}
 
 	// fetch shoot subnet id
-	capturedOutput = execInfraOperator("openstack", "openstack subnet list")
+	capturedOutput, err = execInfraOperator(targetReader, "openstack", "openstack subnet list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag) {
 		rsShootSubnet := make([]string, 0)
 		rsShootSubnet = findInfraResourcesMatch("(.*)"+shoottag, capturedOutput, rsShootSubnet)
@@ -278,7 +370,10 @@ func getOstackInfraResources(targetReader TargetReader) []string {
 	}
 
 	// fetch shoot router id
-	capturedOutput = execInfraOperator("openstack", "openstack router list")
+	capturedOutput, err = execInfraOperator(targetReader, "openstack", "openstack router list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag) {
 		rsShootRouter := make([]string, 0)
 		rsShootRouter = findInfraResourcesMatch("(.*)"+shoottag, capturedOutput, rsShootRouter)
@@ -288,7 +383,10 @@ func getOstackInfraResources(targetReader TargetReader) []string {
 			rs = append(rs, rsRouter)
 
 			// fetch shoot floating network id
-			capturedOutput = execInfraOperator("openstack", "openstack floating ip list --router "+rsRouter+" -f value")
+			capturedOutput, err = execInfraOperator(targetReader, "openstack", "openstack floating ip list --router "+rsRouter+" -f value")
+			if err != nil {
+				return nil, err
+			}
 			rsShootFloatingNetwork := make([]string, 0)
 			rsShootFloatingNetwork = findInfraResourcesMatch(`([a-z0-9-]{36})`, capturedOutput, rsShootFloatingNetwork)
 			if len(rsShootFloatingNetwork) > 0 {
@@ -299,7 +397,10 @@ func getOstackInfraResources(targetReader TargetReader) []string {
 	}
 
 	// fetch shoot security group id
-	capturedOutput = execInfraOperator("openstack", "openstack security group list")
+	capturedOutput, err = execInfraOperator(targetReader, "openstack", "openstack security group list")
+	if err != nil {
+		return nil, err
+	}
 	if strings.Contains(capturedOutput, shoottag) {
 		rsShootSecurityGroup := make([]string, 0)
 		rsShootSecurityGroup = findInfraResourcesMatch("(.*)"+shoottag, capturedOutput, rsShootSecurityGroup)
@@ -310,17 +411,26 @@ func getOstackInfraResources(targetReader TargetReader) []string {
 		}
 	}
 
-	return unique(rs)
+	return unique(rs), nil
 }
 
-func getAliCloudInfraResources(targetReader TargetReader) []string {
+func getAliCloudInfraResources(targetReader TargetReader) ([]string, error) {
 	rs := make([]string, 0)
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return nil, err
+	}
 
 	// fetch shoot vpc id
-	capturedOutput := execInfraOperator("aliyun", "aliyun vpc DescribeVpcs --VpcName "+shoottag+"-vpc")
+	capturedOutput, err := execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeVpcs --VpcName "+shoottag+"-vpc")
+	if err != nil {
+		return nil, err
+	}
 	if !strings.Contains(capturedOutput, "VpcId") {
-		capturedOutput = execInfraOperator("aliyun", "aliyun ecs DescribeInstances --InstanceName "+shoottag+"*")
+		capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun ecs DescribeInstances --InstanceName "+shoottag+"*")
+		if err != nil {
+			return nil, err
+		}
 	}
 	rs = findInfraResourcesMatch(`\"VpcId\": \"(.*)\"`, capturedOutput, rs)
 	if len(rs) > 0 && string(rs[0][0]) == "v" && string(rs[0][2]) == "c" {
@@ -329,12 +439,17 @@ func getAliCloudInfraResources(targetReader TargetReader) []string {
 		// fetch shoot router table id
 		rs = findInfraResourcesMatch(`\"(vtb\-[a-z0-9]*)\"`, capturedOutput, rs)
 		// fetch shoot vswitch id
-		capturedOutput = execInfraOperator("aliyun", "aliyun vpc DescribeVSwitches --VpcId "+rs[0])
+		capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeVSwitches --VpcId "+rs[0])
+		if err != nil {
+			return nil, err
+		}
 		if strings.Contains(capturedOutput, shoottag) {
 			capturedOutput = capturedOutput[strings.Index(capturedOutput, "{"):]
 			var jsonOut map[string]interface{}
 			err := json.Unmarshal([]byte(capturedOutput), &jsonOut)
-			checkError(err)
+			if err != nil {
+				return nil, err
+			}
 			data := jsonOut["VSwitches"].(map[string]interface{})
 			for _, v := range data {
 				switch v := v.(type) {
@@ -350,24 +465,38 @@ func getAliCloudInfraResources(targetReader TargetReader) []string {
 			}
 		}
 		// fetch shoot nat gateway id
-		capturedOutput = execInfraOperator("aliyun", "aliyun vpc DescribeNatGateways --VpcId "+rs[0])
+		capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeNatGateways --VpcId "+rs[0])
+		if err != nil {
+			return nil, err
+		}
 		rs = findInfraResourcesMatch(`\"(ngw\-[a-z0-9]*)\"`, capturedOutput, rs)
 		// fetch shoot security group id
-		capturedOutput = execInfraOperator("aliyun", "aliyun ecs DescribeSecurityGroups --SecurityGroupName "+shoottag+"-sg")
+		capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun ecs DescribeSecurityGroups --SecurityGroupName "+shoottag+"-sg")
+		if err != nil {
+			return nil, err
+		}
 		rs = findInfraResourcesMatch(`\"(sg\-[a-z0-9]*)\"`, capturedOutput, rs)
 		for _, rsid := range rs {
 			if strings.HasPrefix(rsid, "ngw") {
 				// fetch shoot snat table id
-				capturedOutput = execInfraOperator("aliyun", "aliyun vpc DescribeNatGateways --NatGatewayId "+rsid)
+				capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeNatGateways --NatGatewayId "+rsid)
+				if err != nil {
+					return nil, err
+				}
 				rs = findInfraResourcesMatch(`\"(stb\-[a-z0-9]*)\"`, capturedOutput, rs)
 				// fetch shoot snat entry
 				for _, rsid := range rs {
 					if strings.HasPrefix(rsid, "stb") {
-						capturedOutput = execInfraOperator("aliyun", "aliyun vpc DescribeSnatTableEntries --SnatTableId "+rsid)
+						capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeSnatTableEntries --SnatTableId "+rsid)
+						if err != nil {
+							return nil, err
+						}
 						capturedOutput = capturedOutput[strings.Index(capturedOutput, "{"):]
 						var jsonOut map[string]interface{}
 						err := json.Unmarshal([]byte(capturedOutput), &jsonOut)
-						checkError(err)
+						if err != nil {
+							return nil, err
+						}
 						data := jsonOut["SnatTableEntries"].(map[string]interface{})
 						for _, v := range data {
 							switch v := v.(type) {
@@ -388,12 +517,17 @@ func getAliCloudInfraResources(targetReader TargetReader) []string {
 					}
 				}
 				// fetch shoot elastic ip address
-				capturedOutput = execInfraOperator("aliyun", "aliyun vpc DescribeEipAddresses --AssociatedInstanceId "+rsid+" --AssociatedInstanceType Nat")
+				capturedOutput, err = execInfraOperator(targetReader, "aliyun", "aliyun vpc DescribeEipAddresses --AssociatedInstanceId "+rsid+" --AssociatedInstanceType Nat")
+				if err != nil {
+					return nil, err
+				}
 				if strings.Contains(capturedOutput, shoottag) {
 					capturedOutput = capturedOutput[strings.Index(capturedOutput, "{"):]
 					var jsonOut map[string]interface{}
 					err := json.Unmarshal([]byte(capturedOutput), &jsonOut)
-					checkError(err)
+					if err != nil {
+						return nil, err
+					}
 					data := jsonOut["EipAddresses"].(map[string]interface{})
 					for _, v := range data {
 						switch v := v.(type) {
@@ -412,12 +546,11 @@ func getAliCloudInfraResources(targetReader TargetReader) []string {
 		}
 	}
 
-	return unique(rs)
+	return unique(rs), nil
 }
 
-func execInfraOperator(provider string, arguments string) string {
-	capturedOutput := operate(provider, arguments)
-	return capturedOutput
+func execInfraOperator(targetReader TargetReader, provider string, arguments string) (string, error) {
+	return operate(targetReader, provider, arguments)
 }
 
 func findInfraResourcesMatch(pattern string, out string, rs []string) []string {
@@ -0,0 +1,327 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// These are the output formats. The jsonpath, go-template and custom-columns formats are followed by
+// "=" and their template, e.g. jsonpath={.shoots[*].name} or custom-columns=NAME:.name,SEED:.seed.
+const (
+	outputFormatYAML          = "yaml"
+	outputFormatJSON          = "json"
+	outputFormatTable         = "table"
+	outputFormatWide          = "wide"
+	outputFormatName          = "name"
+	outputFormatJSONPath      = "jsonpath"
+	outputFormatGoTemplate    = "go-template"
+	outputFormatCustomColumns = "custom-columns"
+)
+
+// outputFormats is the description of the output formats in the help of the output flag
+const outputFormats = "yaml, json, table, wide, name, jsonpath=TEMPLATE, go-template=TEMPLATE or custom-columns=NAME:.PATH,..."
+
+// TablePrinter is implemented by the objects which can be printed with the table and wide output formats
+type TablePrinter interface {
+	// PrintTable prints the object as table, wide adds further columns
+	PrintTable(w io.Writer, wide bool) error
+}
+
+// NameLister is implemented by the objects whose items have no name or metadata.name field
+type NameLister interface {
+	// Names returns the names of the items printed with the name output format
+	Names() []string
+}
+
+// PrintoutObject prints the object in the output format, see outputFormats. Pass os.Stdout if desired
+func PrintoutObject(objectToPrint interface{}, writer io.Writer, outputFormat string) error {
+	format, tmpl := outputFormat, ""
+	if i := strings.Index(outputFormat, "="); i >= 0 {
+		format, tmpl = outputFormat[:i], outputFormat[i+1:]
+		if tmpl == "" {
+			return fmt.Errorf("output format %s requires a template, e.g. %s=%s", format, format, templateExample(format))
+		}
+	}
+
+	switch format {
+	case outputFormatYAML:
+		yaml, err := yaml.Marshal(objectToPrint)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(writer, string(yaml))
+	case outputFormatJSON:
+		json, err := json.MarshalIndent(objectToPrint, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(writer, string(json))
+	case outputFormatTable, outputFormatWide:
+		if t, ok := objectToPrint.(TablePrinter); ok {
+			return t.PrintTable(writer, format == outputFormatWide)
+		}
+		names, err := objectNames(objectToPrint)
+		if err != nil {
+			return fmt.Errorf("output format %s is not supported for this object", format)
+		}
+		var rows [][]string
+		for _, name := range names {
+			rows = append(rows, []string{name})
+		}
+		return printTable(writer, []string{"NAME"}, rows)
+	case outputFormatName:
+		names, err := objectNames(objectToPrint)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Fprintln(writer, name)
+		}
+	case outputFormatJSONPath, outputFormatGoTemplate, outputFormatCustomColumns:
+		if tmpl == "" {
+			return fmt.Errorf("output format %s requires a template, e.g. %s=%s", format, format, templateExample(format))
+		}
+		data, err := genericObject(objectToPrint)
+		if err != nil {
+			return err
+		}
+		switch format {
+		case outputFormatJSONPath:
+			return printJSONPath(writer, tmpl, data)
+		case outputFormatGoTemplate:
+			return printGoTemplate(writer, tmpl, data)
+		default:
+			return printCustomColumns(writer, tmpl, data)
+		}
+	default:
+		return errors.New("output format not supported: '" + outputFormat + "'")
+	}
+	return nil
+}
+
+// outputFormatOf returns the output format set by the output flag of cmd, or defaultFormat if it is not set
+func outputFormatOf(cmd *cobra.Command, defaultFormat string) string {
+	if f := cmd.Flags().Lookup("output"); f != nil && f.Changed {
+		return outputFormat
+	}
+	return defaultFormat
+}
+
+// templateExample returns an example of the template of the output format
+func templateExample(format string) string {
+	switch format {
+	case outputFormatJSONPath:
+		return "{.metadata.name}"
+	case outputFormatGoTemplate:
+		return "{{.metadata.name}}"
+	default:
+		return "NAME:.metadata.name"
+	}
+}
+
+// genericObject converts obj into maps, slices and values by its json representation,
+// so that templates refer to the fields by their json names
+func genericObject(obj interface{}) (interface{}, error) {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// genericItems returns the items of a generic list object, that is the value of its items field or of its
+// only field if that is a list. Any other object is returned as the only item.
+func genericItems(data interface{}) []interface{} {
+	switch d := data.(type) {
+	case []interface{}:
+		return d
+	case map[string]interface{}:
+		if items, ok := d["items"].([]interface{}); ok {
+			return items
+		}
+		if len(d) == 1 {
+			for _, v := range d {
+				if items, ok := v.([]interface{}); ok {
+					return items
+				}
+			}
+		}
+		if len(d) == 0 {
+			return nil
+		}
+	}
+	return []interface{}{data}
+}
+
+// objectNames returns the names of the items of obj, see NameLister
+func objectNames(obj interface{}) ([]string, error) {
+	if l, ok := obj.(NameLister); ok {
+		return l.Names(), nil
+	}
+	data, err := genericObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, item := range genericItems(data) {
+		m, _ := item.(map[string]interface{})
+		name, ok := m["name"].(string)
+		if !ok {
+			metadata, _ := m["metadata"].(map[string]interface{})
+			if name, ok = metadata["name"].(string); !ok {
+				return nil, errors.New("output format name is not supported for this object")
+			}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// relaxedJSONPath accepts a jsonpath template without braces like kubectl, e.g. .metadata.name
+func relaxedJSONPath(tmpl string) string {
+	if strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+	if !strings.HasPrefix(tmpl, ".") {
+		tmpl = "." + tmpl
+	}
+	return "{" + tmpl + "}"
+}
+
+// printJSONPath prints the data with the jsonpath template
+func printJSONPath(w io.Writer, tmpl string, data interface{}) error {
+	j := jsonpath.New("output").AllowMissingKeys(true)
+	if err := j.Parse(relaxedJSONPath(tmpl)); err != nil {
+		return fmt.Errorf("invalid jsonpath template %q: %v", tmpl, err)
+	}
+	return j.Execute(w, data)
+}
+
+// printGoTemplate prints the data with the go template
+func printGoTemplate(w io.Writer, tmpl string, data interface{}) error {
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid go-template %q: %v", tmpl, err)
+	}
+	return t.Execute(w, data)
+}
+
+// printCustomColumns prints a column for every HEADER:PATH pair of the spec, the path is a jsonpath
+// evaluated for every item of the data
+func printCustomColumns(w io.Writer, spec string, data interface{}) error {
+	var header []string
+	var paths []*jsonpath.JSONPath
+	for _, column := range strings.Split(spec, ",") {
+		parts := strings.SplitN(column, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid custom column %q, expected HEADER:PATH, e.g. NAME:.metadata.name", column)
+		}
+		j := jsonpath.New(parts[0]).AllowMissingKeys(true)
+		if err := j.Parse(relaxedJSONPath(parts[1])); err != nil {
+			return fmt.Errorf("invalid path of custom column %q: %v", parts[0], err)
+		}
+		header = append(header, parts[0])
+		paths = append(paths, j)
+	}
+
+	var rows [][]string
+	for _, item := range genericItems(data) {
+		var row []string
+		for _, j := range paths {
+			results, err := j.FindResults(item)
+			if err != nil {
+				return err
+			}
+			var values []string
+			for _, result := range results {
+				for _, v := range result {
+					values = append(values, fmt.Sprint(v.Interface()))
+				}
+			}
+			if len(values) == 0 {
+				values = []string{"<none>"}
+			}
+			row = append(row, strings.Join(values, ","))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// printTable prints the header and the rows aligned in columns
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printSection prints a titled section of a table of a composite object
+func printSection(w io.Writer, title string, print func(w io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := print(&buf); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s:\n\n", title)
+	_, err := fmt.Fprintln(w, buf.String())
+	return err
+}
+
+// formatAge returns the time since t like kubectl, e.g. 45s, 10m, 5h or 12d
+func formatAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < 0:
+		return "0s"
+	case d < 2*time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < 2*time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// orDash returns "-" for an empty value of a table cell
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/gardener/gardenctl/pkg/cmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Printer", func() {
+
+	type item struct {
+		Name   string `json:"name"`
+		Region string `json:"region,omitempty"`
+	}
+	type list struct {
+		Items []item `json:"items"`
+	}
+
+	var (
+		writer   *bytes.Buffer
+		projects = Projects{Projects: []ProjectMeta{
+			{Project: "core", Shoots: []ProjectShootMeta{{Name: "a"}, {Name: "b", Hibernated: true}}},
+			{Project: "dev"},
+		}}
+		items = list{Items: []item{{Name: "a", Region: "eu"}, {Name: "b"}}}
+	)
+
+	BeforeEach(func() {
+		writer = &bytes.Buffer{}
+	})
+
+	It("should print the table of a table printer", func() {
+		Expect(PrintoutObject(projects, writer, "table")).To(Succeed())
+		Expect(writer.String()).To(Equal("PROJECT   SHOOTS   HIBERNATED\ncore      2        1\ndev       0        0\n"))
+	})
+
+	It("should print the wide table of a table printer", func() {
+		Expect(PrintoutObject(projects, writer, "wide")).To(Succeed())
+		Expect(writer.String()).To(Equal("PROJECT   SHOOTS   HIBERNATED   NAMES\ncore      2        1            a,b\ndev       0        0            -\n"))
+	})
+
+	It("should print a name column for other objects", func() {
+		Expect(PrintoutObject(items, writer, "table")).To(Succeed())
+		Expect(writer.String()).To(Equal("NAME\na\nb\n"))
+	})
+
+	It("should print the names", func() {
+		Expect(PrintoutObject(projects, writer, "name")).To(Succeed())
+		Expect(writer.String()).To(Equal("core\ndev\n"))
+	})
+
+	It("should print the names of the items", func() {
+		Expect(PrintoutObject(items, writer, "name")).To(Succeed())
+		Expect(writer.String()).To(Equal("a\nb\n"))
+	})
+
+	It("should print the shoot names used by the completion", func() {
+		shoots := Shoots{Shoots: []ShootMeta{{Name: "api", Project: "core"}, {Name: "web", Project: "dev"}}}
+		Expect(PrintoutObject(shoots, writer, "name")).To(Succeed())
+		Expect(writer.String()).To(Equal("api\nweb\n"))
+	})
+
+	It("should print the seed names used by the completion", func() {
+		seeds := Seeds{Seeds: []SeedMeta{{Seed: "aws-eu1"}, {Seed: "gcp-eu1"}}}
+		Expect(PrintoutObject(seeds, writer, "name")).To(Succeed())
+		Expect(writer.String()).To(Equal("aws-eu1\ngcp-eu1\n"))
+	})
+
+	It("should print a jsonpath", func() {
+		Expect(PrintoutObject(projects, writer, "jsonpath={.projects[*].project}")).To(Succeed())
+		Expect(writer.String()).To(Equal("core dev"))
+	})
+
+	It("should print a jsonpath without braces", func() {
+		Expect(PrintoutObject(projects, writer, "jsonpath=.projects[0].shoots[1].name")).To(Succeed())
+		Expect(writer.String()).To(Equal("b"))
+	})
+
+	It("should print the hibernation of the shoots as field", func() {
+		Expect(PrintoutObject(projects, writer, "jsonpath={.projects[0].shoots[*].hibernated}")).To(Succeed())
+		Expect(writer.String()).To(Equal("false true"))
+	})
+
+	It("should print a go template", func() {
+		Expect(PrintoutObject(projects, writer, "go-template={{range .projects}}{{.project}};{{end}}")).To(Succeed())
+		Expect(writer.String()).To(Equal("core;dev;"))
+	})
+
+	It("should print custom columns of the items", func() {
+		Expect(PrintoutObject(items, writer, "custom-columns=NAME:.name,REGION:.region")).To(Succeed())
+		Expect(writer.String()).To(Equal("NAME   REGION\na      eu\nb      <none>\n"))
+	})
+
+	It("should print custom columns of the only list field", func() {
+		Expect(PrintoutObject(projects, writer, "custom-columns=PROJECT:.project,SHOOTS:.shoots[*].name")).To(Succeed())
+		Expect(writer.String()).To(Equal("PROJECT   SHOOTS\ncore      a,b\ndev       <none>\n"))
+	})
+
+	It("should return an error if the template is missing", func() {
+		err := PrintoutObject(projects, writer, "jsonpath=")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("output format jsonpath requires a template, e.g. jsonpath={.metadata.name}"))
+
+		err = PrintoutObject(projects, writer, "custom-columns")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("output format custom-columns requires a template, e.g. custom-columns=NAME:.metadata.name"))
+	})
+
+	It("should print the URLs of an endpoint as field", func() {
+		endpoint := Endpoint{URLs: []string{"https://p.example.com"}, Username: "admin"}
+		Expect(PrintoutObject(endpoint, writer, "json")).To(Succeed())
+		Expect(writer.String()).To(Equal("{\n  \"pods\": null,\n  \"urls\": [\n    \"https://p.example.com\"\n  ],\n  \"username\": \"admin\"\n}"))
+	})
+
+	It("should print the URLs of an endpoint after its pods", func() {
+		endpoint := Endpoint{URLs: []string{"https://p.example.com"}, Username: "admin"}
+		Expect(PrintoutObject(endpoint, writer, "table")).To(Succeed())
+		Expect(writer.String()).To(Equal("NAMESPACE   NAME   READY   STATUS   RESTARTS   AGE\n\nURL                     USERNAME\nhttps://p.example.com   admin\n"))
+	})
+
+	It("should return an error for an invalid custom column", func() {
+		err := PrintoutObject(items, writer, "custom-columns=NAME")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(`invalid custom column "NAME", expected HEADER:PATH, e.g. NAME:.metadata.name`))
+	})
+
+	It("should return an error if the names are unknown", func() {
+		err := PrintoutObject(struct{ Value int }{1}, writer, "name")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("output format name is not supported for this object"))
+	})
+})
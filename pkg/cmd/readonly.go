@@ -0,0 +1,162 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// readOnlyKubectlCommands are the kubectl commands which do not mutate a cluster. If not all of its
+// subcommands are read-only, the read-only subcommands of a command are listed.
+var readOnlyKubectlCommands = map[string][]string{
+	"":              nil,
+	"api-resources": nil,
+	"api-versions":  nil,
+	"auth":          {"can-i", "whoami"},
+	"cluster-info":  nil,
+	"config":        {"current-context", "get-clusters", "get-contexts", "view"},
+	"describe":      nil,
+	"diff":          nil,
+	"events":        nil,
+	"explain":       nil,
+	"get":           nil,
+	"logs":          nil,
+	"rollout":       {"history", "status"},
+	"top":           nil,
+	"version":       nil,
+	"wait":          nil,
+}
+
+// kubectlValueFlags are the global kubectl flags which take their value as separate argument
+var kubectlValueFlags = map[string]bool{
+	"-n": true, "--namespace": true, "--context": true, "--cluster": true, "--user": true,
+	"--kubeconfig": true, "-s": true, "--server": true, "--token": true, "--as": true,
+	"--as-group": true, "--request-timeout": true, "--cache-dir": true, "--certificate-authority": true,
+	"--client-certificate": true, "--client-key": true, "-v": true, "--v": true,
+}
+
+// IsReadOnlyKubectlCommand returns true if kubectl called with args does not mutate a cluster.
+// Commands which are not known to be read-only are considered mutating.
+func IsReadOnlyKubectlCommand(args []string) bool {
+	command, subcommand := kubectlCommand(args)
+	subcommands, ok := readOnlyKubectlCommands[command]
+	if !ok {
+		return false
+	}
+	if subcommands == nil {
+		return true
+	}
+	for _, s := range subcommands {
+		if s == subcommand {
+			return true
+		}
+	}
+	return false
+}
+
+// kubectlCommand returns the command and the subcommand of the kubectl arguments
+func kubectlCommand(args []string) (string, string) {
+	var commands []string
+	for i := 0; i < len(args) && len(commands) < 2; i++ {
+		switch {
+		case kubectlValueFlags[args[i]]:
+			i++
+		case strings.HasPrefix(args[i], "-"):
+		default:
+			commands = append(commands, args[i])
+		}
+	}
+	for len(commands) < 2 {
+		commands = append(commands, "")
+	}
+	return commands[0], commands[1]
+}
+
+// IsReadOnlyTerraformCommand returns true if terraform called with args does not change the
+// infrastructure. init is allowed, it only prepares the local working directory.
+func IsReadOnlyTerraformCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "init", "plan", "show":
+		return true
+	case "state":
+		return len(args) > 1 && args[1] == "list"
+	}
+	return false
+}
+
+// checkGardenReadOnly refuses operation with a forbidden error if the targeted garden is read-only
+func checkGardenReadOnly(target TargetInterface, reader ConfigReader, operation string) error {
+	stack := target.Stack()
+	if len(stack) == 0 {
+		return nil
+	}
+	return checkReadOnly(&Target{Target: stack[:1]}, reader, operation)
+}
+
+// checkReadOnly refuses operation with a forbidden error if the target is read-only
+func checkReadOnly(target TargetInterface, reader ConfigReader, operation string) error {
+	stack := target.Stack()
+	if len(stack) == 0 {
+		return nil
+	}
+	garden := reader.ReadConfig(pathGardenConfig).Garden(stack[0].Name)
+	if garden == nil || (!garden.ReadOnly && len(garden.ReadOnlyTargets) == 0) {
+		return nil
+	}
+
+	tp := TargetPathFromStack(stack)
+	tp.Namespace = ""
+	project := tp.Project
+	if project == "" && tp.Shoot != "" && !garden.ReadOnly {
+		shoot, err := FetchShootFromTarget(target)
+		if err != nil {
+			return err
+		}
+		if project, err = projectOfShoot(target, shoot); err != nil {
+			return err
+		}
+	}
+	readOnly, err := garden.IsReadOnly(project, tp.Shoot)
+	if err != nil {
+		return err
+	}
+	if readOnly {
+		return NewForbiddenError("%s is read-only, %s is refused", tp, operation)
+	}
+	return nil
+}
+
+// checkShootReadOnly refuses operation with a forbidden error if shoot is read-only, the shoot does not
+// have to be targeted
+func checkShootReadOnly(target TargetInterface, shoot *gardencorev1beta1.Shoot, reader ConfigReader, operation string) error {
+	stack := target.Stack()
+	if len(stack) == 0 {
+		return nil
+	}
+	garden := reader.ReadConfig(pathGardenConfig).Garden(stack[0].Name)
+	if garden == nil || (!garden.ReadOnly && len(garden.ReadOnlyTargets) == 0) {
+		return nil
+	}
+	project, err := projectOfShoot(target, shoot)
+	if err != nil {
+		return err
+	}
+	stack = []TargetMeta{stack[0], {Kind: TargetKindProject, Name: project}, {Kind: TargetKindShoot, Name: shoot.Name}}
+	return checkReadOnly(&Target{Target: stack}, reader, operation)
+}
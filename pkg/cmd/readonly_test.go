@@ -0,0 +1,60 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd_test
+
+import (
+	"strings"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Read-only", func() {
+	DescribeTable("#IsReadOnlyKubectlCommand",
+		func(args string, expected bool) {
+			Expect(cmd.IsReadOnlyKubectlCommand(strings.Fields(args))).To(Equal(expected))
+		},
+		Entry("no command", "", true),
+		Entry("get", "get pods -o wide", true),
+		Entry("get with namespace before the command", "-n kube-system get pods", true),
+		Entry("get with namespace flag", "--namespace=garden get pods", true),
+		Entry("logs", "logs -f my-pod", true),
+		Entry("read-only subcommand", "rollout status deployment/app", true),
+		Entry("apply", "apply -f manifest.yaml", false),
+		Entry("delete with namespace before the command", "-n get delete pod get", false),
+		Entry("edit", "edit deployment app", false),
+		Entry("scale", "scale deployment app --replicas=0", false),
+		Entry("drain", "drain node-1", false),
+		Entry("exec", "exec -it my-pod -- sh", false),
+		Entry("mutating subcommand", "rollout restart deployment/app", false),
+		Entry("config subcommand", "config set-context --current", false),
+	)
+
+	DescribeTable("#IsReadOnlyTerraformCommand",
+		func(args string, expected bool) {
+			Expect(cmd.IsReadOnlyTerraformCommand(strings.Fields(args))).To(Equal(expected))
+		},
+		Entry("init", "init", true),
+		Entry("plan", "plan", true),
+		Entry("show", "show", true),
+		Entry("state list", "state list", true),
+		Entry("state rm", "state rm aws_vpc.vpc", false),
+		Entry("apply", "apply", false),
+		Entry("destroy", "destroy", false),
+		Entry("no command", "", false),
+	)
+})
@@ -49,7 +49,7 @@ var (
 var registerAll bool
 
 // NewRegisterCmd returns a new register command.
-func NewRegisterCmd() *cobra.Command {
+func NewRegisterCmd(targetReader TargetReader, configReader ConfigReader) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "register (e-mail)",
 		Short:        "Register as cluster admin for the operator shift, e.g. \"gardenctl register john.doe@example.com\"",
@@ -64,47 +64,80 @@ func NewRegisterCmd() *cobra.Command {
 				email = args[0]
 			}
 			if len(args) < 1 {
-				email = getEmailFromConfig()
-				githubURL := getGithubURL()
+				var err error
+				email, err = getEmailFromConfig()
+				if err != nil {
+					return err
+				}
+				githubURL, err := getGithubURL()
+				if err != nil {
+					return err
+				}
 				if email == "" {
 					if githubURL == "" {
 						return errors.New("no email specified and no GitHub url configured in garden config")
 					}
-					email = getEmail(githubURL)
+					email, err = getEmail(githubURL)
+					if err != nil {
+						return err
+					}
 					if email == "null" {
 						return errors.New("could not read GitHub email address")
 					}
 				}
 			}
-			err := checkmail.ValidateFormat(email)
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(2)
+			if err := checkmail.ValidateFormat(email); err != nil {
+				return err
 			}
 			fmt.Println("Format Validated")
 			if !registerAll {
-				config, err := clientcmd.BuildConfigFromFlags("", getKubeConfigOfClusterType("garden"))
-				checkError(err)
+				if err := checkGardenReadOnly(targetReader.ReadTarget(pathTarget), configReader, "register"); err != nil {
+					return err
+				}
+				pathToKubeconfig, err := getKubeConfigOfClusterType(targetReader, "garden")
+				if err != nil {
+					return err
+				}
+				config, err := clientcmd.BuildConfigFromFlags("", pathToKubeconfig)
+				if err != nil {
+					return err
+				}
 				clientset, err := k8s.NewForConfig(config)
-				checkError(err)
+				if err != nil {
+					return err
+				}
 				clusterRoleBinding, err := clientset.RbacV1().ClusterRoleBindings().Get(AdminClusterRoleBindingName, metav1.GetOptions{})
 				if err != nil && strings.Contains(err.Error(), AdminClusterRoleBindingName) {
 					kubeSecret, err := clientset.CoreV1().Secrets("garden").Get("virtual-garden-kubeconfig-for-admin", metav1.GetOptions{})
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					virtualPath := filepath.Join(pathDefault, "virtual")
 					err = os.MkdirAll(virtualPath, os.ModePerm)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					virtualPathKubeConfig := filepath.Join(virtualPath, "virtualKubeConfig.yaml")
-					err = ioutil.WriteFile(virtualPathKubeConfig, kubeSecret.Data["kubeconfig"], 0644)
-					checkError(err)
+					err = ioutil.WriteFile(virtualPathKubeConfig, kubeSecret.Data["kubeconfig"], 0600)
+					if err != nil {
+						return err
+					}
 					config, err := clientcmd.BuildConfigFromFlags("", virtualPathKubeConfig)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					clientset, err = k8s.NewForConfig(config)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					clusterRoleBinding, err = clientset.RbacV1().ClusterRoleBindings().Get(AdminClusterRoleBindingName, metav1.GetOptions{})
-					checkError(err)
+					if err != nil {
+						return err
+					}
 				} else {
-					checkError(err)
+					if err != nil {
+						return err
+					}
 				}
 				registerUser := true
 				for _, subject := range clusterRoleBinding.Subjects {
@@ -117,38 +150,63 @@ func NewRegisterCmd() *cobra.Command {
 				if registerUser {
 					clusterRoleBinding.Subjects = append(clusterRoleBinding.Subjects, rbacv1.Subject{Kind: "User", Name: email})
 					_, err = clientset.RbacV1().ClusterRoleBindings().Update(clusterRoleBinding)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					fmt.Printf("User %s registered \n", email)
 				}
 			} else {
-				currentKubeConfig := getGardenKubeConfig()
-				var gardenConfig GardenConfig
-				GetGardenConfig(pathGardenConfig, &gardenConfig)
-				for _, cluster := range gardenConfig.GardenClusters {
+				for _, cluster := range configReader.ReadConfig(pathGardenConfig).GardenClusters {
+					readOnly, err := cluster.IsReadOnly("", "")
+					if err != nil {
+						return err
+					}
+					if readOnly {
+						fmt.Printf("Skipping read-only garden %s \n", cluster.Name)
+						continue
+					}
 					gardenKubeConfig := cluster.KubeConfig
 					gardenKubeConfig = TidyKubeconfigWithHomeDir(gardenKubeConfig)
 					config, err := clientcmd.BuildConfigFromFlags("", gardenKubeConfig)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					clientset, err := k8s.NewForConfig(config)
-					checkError(err)
+					if err != nil {
+						return err
+					}
 					clusterRoleBinding, err := clientset.RbacV1().ClusterRoleBindings().Get(AdminClusterRoleBindingName, metav1.GetOptions{})
 					if err != nil && strings.Contains(err.Error(), AdminClusterRoleBindingName) {
 						kubeSecret, err := clientset.CoreV1().Secrets("garden").Get("virtual-garden-kubeconfig-for-admin", metav1.GetOptions{})
-						checkError(err)
+						if err != nil {
+							return err
+						}
 						virtualPath := filepath.Join(pathDefault, "virtual")
 						err = os.MkdirAll(virtualPath, os.ModePerm)
-						checkError(err)
+						if err != nil {
+							return err
+						}
 						virtualPathKubeConfig := filepath.Join(virtualPath, "virtualKubeConfig.yaml")
-						err = ioutil.WriteFile(virtualPathKubeConfig, kubeSecret.Data["kubeconfig"], 0644)
-						checkError(err)
+						err = ioutil.WriteFile(virtualPathKubeConfig, kubeSecret.Data["kubeconfig"], 0600)
+						if err != nil {
+							return err
+						}
 						config, err = clientcmd.BuildConfigFromFlags("", virtualPathKubeConfig)
-						checkError(err)
+						if err != nil {
+							return err
+						}
 						clientset, err = k8s.NewForConfig(config)
-						checkError(err)
+						if err != nil {
+							return err
+						}
 						clusterRoleBinding, err = clientset.RbacV1().ClusterRoleBindings().Get(AdminClusterRoleBindingName, metav1.GetOptions{})
-						checkError(err)
+						if err != nil {
+							return err
+						}
 					} else {
-						checkError(err)
+						if err != nil {
+							return err
+						}
 					}
 					registerUser := true
 					for _, subject := range clusterRoleBinding.Subjects {
@@ -161,11 +219,12 @@ func NewRegisterCmd() *cobra.Command {
 					if registerUser {
 						clusterRoleBinding.Subjects = append(clusterRoleBinding.Subjects, rbacv1.Subject{Kind: "User", Name: email})
 						_, err = clientset.RbacV1().ClusterRoleBindings().Update(clusterRoleBinding)
-						checkError(err)
+						if err != nil {
+							return err
+						}
 						fmt.Printf("User %s registered on %s \n", email, cluster.Name)
 					}
 				}
-				kubeconfig = &currentKubeConfig
 			}
 
 			return nil
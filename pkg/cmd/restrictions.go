@@ -0,0 +1,105 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// confirmedRestrictions are the keys of the access restrictions confirmed with --confirm-restriction
+var confirmedRestrictions []string
+
+// newRestrictionGate returns the gate enforcing the access restrictions. The confirmation is only
+// read from a terminal, so input piped to the wrapped tools is left untouched.
+func newRestrictionGate(ioStreams IOStreams) *gardenctl.RestrictionGate {
+	gate := &gardenctl.RestrictionGate{
+		Confirmed: confirmedRestrictions,
+		Out:       ioStreams.ErrOut,
+	}
+	if in, ok := ioStreams.In.(*os.File); ok && terminal.IsTerminal(int(in.Fd())) {
+		gate.In = in
+	}
+	return gate
+}
+
+// enforceAccessRestrictions enforces the access restrictions of the targeted garden on shoot
+func enforceAccessRestrictions(target TargetInterface, shoot *gardencorev1beta1.Shoot, reader ConfigReader, ioStreams IOStreams) error {
+	restrictions := reader.ReadConfig(pathGardenConfig).AccessRestrictions(target.Stack()[0].Name)
+	return enforceRestrictions(target, shoot, restrictions, ioStreams)
+}
+
+// enforceTargetAccessRestrictions enforces the access restrictions on the targeted shoot, nothing
+// is enforced if no shoot is targeted.
+func enforceTargetAccessRestrictions(target TargetInterface, reader ConfigReader, ioStreams IOStreams) error {
+	if !CheckShootIsTargeted(target) {
+		return nil
+	}
+	restrictions := reader.ReadConfig(pathGardenConfig).AccessRestrictions(target.Stack()[0].Name)
+	if len(restrictions) == 0 {
+		return nil
+	}
+	shoot, err := FetchShootFromTarget(target)
+	if err != nil {
+		return err
+	}
+	return enforceRestrictions(target, shoot, restrictions, ioStreams)
+}
+
+// enforceRestrictions enforces restrictions on shoot, the project of the shoot is only looked up
+// if there are restrictions
+func enforceRestrictions(target TargetInterface, shoot *gardencorev1beta1.Shoot, restrictions []AccessRestriction, ioStreams IOStreams) error {
+	if len(restrictions) == 0 {
+		return nil
+	}
+	project, err := projectOfShoot(target, shoot)
+	if err != nil {
+		return err
+	}
+	return newRestrictionGate(ioStreams).Enforce(restrictions, shoot, project)
+}
+
+// projectOfShoot returns the project of shoot, it is looked up in the garden if the shoot is
+// not targeted via its project
+func projectOfShoot(target TargetInterface, shoot *gardencorev1beta1.Shoot) (string, error) {
+	if stack := target.Stack(); len(stack) > 1 && stack[1].Kind == TargetKindProject {
+		return stack[1].Name, nil
+	}
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
+	return gardenctl.ProjectOfNamespace(gardenClient, shoot.Namespace)
+}
+
+// withoutConfirmRestrictionFlags removes the --confirm-restriction flags of gardenctl from the
+// arguments passed on to a wrapped tool
+func withoutConfirmRestrictionFlags(args []string) []string {
+	var result []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--confirm-restriction":
+			i++
+		case strings.HasPrefix(args[i], "--confirm-restriction="):
+		default:
+			result = append(result, args[i])
+		}
+	}
+	return result
+}
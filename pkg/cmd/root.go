@@ -15,11 +15,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	"github.com/spf13/cobra"
 
 	// Register clients
@@ -33,12 +33,19 @@ var gardenConfig string
 var pathGardenHome string
 var sessionID string
 var debugSwitch bool
+var ignoreCase bool
 var targetInfo = make(map[string]string)
 
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   "gardenctl",
 	Short: "g",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// the config command reports the warnings itself
+		if cmd.Name() != "config" {
+			warnConfig(os.Stderr)
+		}
+	},
 }
 
 const (
@@ -53,7 +60,7 @@ __gardenctl_parse() {
 	fi
 	;;
 	seed)
-	if gardenctl_out=$(gardenctl ls seeds | grep -w "\- seed:" | awk '{print $3}' 2>/dev/null); then
+	if gardenctl_out=$(gardenctl ls seeds -o name 2>/dev/null); then
 		COMPREPLY+=( $( compgen -W "${gardenctl_out[*]}" -- "$cur" ) )
 	fi
 	;;
@@ -63,7 +70,7 @@ __gardenctl_parse() {
 	fi
 	;;
 	shoot)
-	if gardenctl_out=$(gardenctl ls shoots | grep -v "projects:" | grep -v "\- project: " | grep -v "seeds:" | grep -v "\- seed: " | grep -v "shoots:" | awk '{print $2}' 2>/dev/null); then
+	if gardenctl_out=$(gardenctl ls shoots -o name 2>/dev/null); then
 		COMPREPLY+=( $( compgen -W "${gardenctl_out[*]}" -- "$cur" ) )
 	fi
 	;;
@@ -87,58 +94,75 @@ __gardenctl_custom_func() {
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	pathGardenHome = os.Getenv("GARDENCTL_HOME")
-	if pathGardenHome == "" {
-		pathGardenHome = pathDefault
-	} else {
-		pathGardenHome = strings.Replace(pathGardenHome, "~", HomeDir(), 1)
+	if err := setup(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(ExitCode(err))
+	}
+	if err := RootCmd.Execute(); err != nil {
+		os.Exit(ExitCode(err))
+	}
+}
+
+// setup prepares the gardenctl home and session directories and the credentials cache
+func setup() error {
+	session := gardenctl.SessionFromEnv()
+	pathGardenHome = session.Home
+	if err := CreateDir(pathGardenHome, 0751); err != nil {
+		return err
+	}
+	sessionID = session.ID
+	if err := CreateDir(session.Dir, 0751); err != nil {
+		return err
+	}
+	if err := session.MarkUsed(); err != nil {
+		return err
 	}
-	pathGardenConfig = filepath.Join(pathGardenHome, "config")
-	CreateDir(pathGardenHome, 0751)
-	sessionID = os.Getenv("GARDEN_SESSION_ID")
-	if sessionID == "" {
-		sessionID = "plantingSession"
+	pathTarget = session.TargetPath()
+	if err := CreateFileIfNotExists(pathTarget, 0644); err != nil {
+		return err
 	}
-	pathSessionID := filepath.Join(pathDefaultSession, sessionID)
-	CreateDir(pathSessionID, 0751)
-	pathTarget = filepath.Join(pathSessionID, "target")
-	CreateFileIfNotExists(pathTarget, 0644)
-	pathHistory = filepath.Join(pathSessionID, "history")
-	CreateFileIfNotExists(pathHistory, 0644)
-	gardenConfig = os.Getenv("GARDENCONFIG")
-	if gardenConfig != "" {
+	pathHistory = session.HistoryPath()
+	if err := CreateFileIfNotExists(pathHistory, 0644); err != nil {
+		return err
+	}
+	pathPushedTargets = session.PushedTargetsPath()
+	pathGardenConfig = session.ConfigPath
+	if gardenConfig = os.Getenv(gardenctl.ConfigEnvVar); gardenConfig != "" {
 		if _, err := os.Stat(gardenConfig); err != nil {
-			fmt.Println("gardenctl configuration set in environment does not exist")
-			os.Exit(1)
+			return errors.New("gardenctl configuration set in environment does not exist")
 		}
-		pathGardenConfig = gardenConfig
 	} else if _, err := os.Stat(pathGardenConfig); err != nil {
-		CreateFileIfNotExists(pathGardenConfig, 0644)
+		if err := CreateFileIfNotExists(pathGardenConfig, 0644); err != nil {
+			return err
+		}
 	}
-	GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget)
-	if err := RootCmd.Execute(); err != nil {
-		os.Exit(1)
+	if err := initCredentialCache((&GardenConfigReader{}).ReadConfig(pathGardenConfig).Cache); err != nil {
+		return err
 	}
+	return GetGardenClusterKubeConfigFromConfig(pathGardenConfig, pathTarget)
 }
 
 func init() {
 	var (
+		ioStreams = IOStreams{
+			In:     os.Stdin,
+			Out:    os.Stdout,
+			ErrOut: os.Stderr,
+		}
 		configReader     = &GardenConfigReader{}
-		targetReader     = &GardenctlTargetReader{}
+		clientFactory    = NewClientFactory(configReader, ioStreams.ErrOut)
+		targetReader     = &GardenctlTargetReader{ClientFactory: clientFactory}
 		targetWriter     = &GardenctlTargetWriter{}
 		kubeconfigReader = &GardenctlKubeconfigReader{}
 		kubeconfigWriter = &GardenctlKubeconfigWriter{}
 		historyWriter    = &GardenctlHistoryWriter{}
-		ioStreams        = IOStreams{
-			In:     os.Stdin,
-			Out:    os.Stdout,
-			ErrOut: os.Stderr,
-		}
 	)
 
 	RootCmd.PersistentFlags().BoolVarP(&cachevar, "no-cache", "c", false, "no caching")
-	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "yaml", "output format yaml or json")
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "yaml", "output format: "+outputFormats)
 	RootCmd.PersistentFlags().BoolVarP(&debugSwitch, "verbose", "d", false, "enable verbose output")
+	RootCmd.PersistentFlags().StringSliceVar(&confirmedRestrictions, "confirm-restriction", nil, "confirm the access restriction with the given key in advance, e.g. for automation")
+	RootCmd.PersistentFlags().BoolVar(&ignoreCase, "ignore-case", false, "match names and patterns case-insensitively")
 
 	cobra.EnableCommandSorting = false
 	cobra.EnablePrefixMatching = prefixMatching
@@ -147,23 +171,32 @@ func init() {
 	RootCmd.AddCommand(
 		NewLsCmd(targetReader, configReader, ioStreams),
 		NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kubeconfigReader, historyWriter),
-		NewDropCmd(targetReader, targetWriter, ioStreams),
+		NewDropCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams),
 		NewGetCmd(targetReader, configReader, kubeconfigReader, kubeconfigWriter, ioStreams))
-	RootCmd.AddCommand(NewDownloadCmd(targetReader), NewShowCmd(targetReader), NewLogsCmd(targetReader))
-	RootCmd.AddCommand(NewRegisterCmd(), NewUnregisterCmd())
+	RootCmd.AddCommand(NewDownloadCmd(targetReader, configReader, ioStreams), NewShowCmd(targetReader, configReader, ioStreams),
+		NewLogsCmd(targetReader, configReader, ioStreams))
+	RootCmd.AddCommand(NewRegisterCmd(targetReader, configReader), NewUnregisterCmd(targetReader, configReader))
 	RootCmd.AddCommand(NewCompletionCmd())
-	RootCmd.AddCommand(NewShellCmd(targetReader, ioStreams))
-	RootCmd.AddCommand(NewSSHCmd(targetReader, ioStreams))
-	RootCmd.AddCommand(NewKubectlCmd(), NewKaCmd(), NewKsCmd(), NewKgCmd(), NewKnCmd())
-	RootCmd.AddCommand(NewKubectxCmd())
-	RootCmd.AddCommand(NewTerraformCmd(targetReader))
+	RootCmd.AddCommand(NewShellCmd(targetReader, configReader, ioStreams))
+	RootCmd.AddCommand(NewSSHCmd(targetReader, configReader, ioStreams))
+	RootCmd.AddCommand(NewKubectlCmd(targetReader, configReader, ioStreams), NewKaCmd(targetReader, configReader, ioStreams),
+		NewKsCmd(targetReader, configReader, ioStreams), NewKgCmd(targetReader, configReader, ioStreams), NewKnCmd(targetReader, configReader, ioStreams))
+	RootCmd.AddCommand(NewKubectxCmd(configReader))
+	RootCmd.AddCommand(NewTerraformCmd(targetReader, configReader, ioStreams))
 	RootCmd.AddCommand(NewOrphanCmd(targetReader))
-	RootCmd.AddCommand(NewAliyunCmd(targetReader), NewAwsCmd(targetReader), NewAzCmd(targetReader),
-		NewGcloudCmd(targetReader), NewOpenstackCmd(targetReader), NewHcloudCmd(targetReader))
+	RootCmd.AddCommand(NewAliyunCmd(targetReader, configReader, ioStreams), NewAwsCmd(targetReader, configReader, ioStreams),
+		NewAzCmd(targetReader, configReader, ioStreams), NewGcloudCmd(targetReader, configReader, ioStreams),
+		NewOpenstackCmd(targetReader, configReader, ioStreams), NewHcloudCmd(targetReader, configReader, ioStreams))
 	RootCmd.AddCommand(NewInfoCmd(targetReader, ioStreams))
 	RootCmd.AddCommand(NewVersionCmd(), NewUpdateCheckCmd())
 	RootCmd.AddCommand(NewDiagCmd(targetReader, ioStreams))
-	RootCmd.AddCommand(NewHistoryCmd(targetWriter, historyWriter))
+	RootCmd.AddCommand(NewHistoryCmd(targetReader, targetWriter, configReader, historyWriter, ioStreams))
+	RootCmd.AddCommand(NewBookmarkCmd(ioStreams))
+	RootCmd.AddCommand(NewSessionCmd(targetReader, ioStreams))
+	RootCmd.AddCommand(NewEnvCmd(targetReader, configReader, ioStreams))
+	RootCmd.AddCommand(NewCacheCmd(ioStreams))
+	RootCmd.AddCommand(NewConfigCmd(ioStreams))
+	RootCmd.AddCommand(NewRevokeCmd(targetReader, ioStreams))
 
 	RootCmd.SuggestionsMinimumDistance = suggestionsMinimumDistance
 	RootCmd.BashCompletionFunction = bashCompletionFunc
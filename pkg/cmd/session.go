@@ -0,0 +1,375 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/spf13/cobra"
+)
+
+// sessionIDEnvVar is the environment variable holding the ID of the current session
+const sessionIDEnvVar = gardenctl.SessionIDEnvVar
+
+var (
+	sessionExample = `
+	# Use a new session in the current shell.
+	eval $(gardenctl session new)
+
+	# Switch the current shell to the existing session 3f2a9c1b7d4e.
+	eval $(gardenctl session use 3f2a9c1b7d4e)
+
+	# Remove all sessions which have not been used for a week.
+	gardenctl session gc --older-than 7`
+
+	// sessionOlderThan is the number of days after which unused sessions are garbage collected
+	sessionOlderThan int
+
+	validSessionID = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// NewSessionCmd returns a new session command.
+func NewSessionCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "session (new|use|ls|rm|gc)",
+		Short:        "Create, list and remove sessions, e.g. \"eval $(gardenctl session new)\" to use a new session in the current shell",
+		Example:      sessionExample,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("command must be in the format: session (new|use|ls|rm|gc)")
+			}
+			switch args[0] {
+			case "new":
+				if len(args) > 2 {
+					return errors.New("command must be in the format: session new [bash|zsh|fish|powershell]")
+				}
+				sh := detectShell()
+				if len(args) == 2 {
+					var err error
+					if sh, err = parseShell(args[1]); err != nil {
+						return err
+					}
+				}
+				return newSession(ioStreams.Out, sh)
+			case "use":
+				if len(args) < 2 || len(args) > 3 {
+					return errors.New("command must be in the format: session use ID [bash|zsh|fish|powershell]")
+				}
+				sh := detectShell()
+				if len(args) == 3 {
+					var err error
+					if sh, err = parseShell(args[2]); err != nil {
+						return err
+					}
+				}
+				return useSession(ioStreams.Out, sh, args[1])
+			case "ls":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: session ls")
+				}
+				return printSessions(targetReader, ioStreams.Out, outputFormat)
+			case "rm":
+				if len(args) < 2 {
+					return errors.New("command must be in the format: session rm ID...")
+				}
+				return removeSessions(targetReader, ioStreams.Out, args[1:])
+			case "gc":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: session gc [--older-than DAYS]")
+				}
+				return gcSessions(targetReader, ioStreams.Out, time.Duration(sessionOlderThan)*24*time.Hour)
+			}
+
+			return errors.New("command must be in the format: session (new|use|ls|rm|gc)")
+		},
+		ValidArgs: []string{"new", "use", "ls", "rm", "gc"},
+	}
+
+	cmd.PersistentFlags().IntVar(&sessionOlderThan, "older-than", 30, "garbage collect sessions which have not been used for the given number of days")
+
+	return cmd
+}
+
+// newSession creates a session with a generated ID and prints the shell statement to use it
+func newSession(writer io.Writer, sh shell) error {
+	id, err := generateSessionID()
+	if err != nil {
+		return err
+	}
+	if err := initSession(id); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(writer, sh.export(sessionIDEnvVar, id))
+	fmt.Fprintln(writer, "# Run this command to use the new session in the current shell:")
+	fmt.Fprintln(writer, "# "+sh.evalCommand("gardenctl session new "+string(sh)))
+	return nil
+}
+
+// useSession prints the shell statement to use the existing session with the given ID
+func useSession(writer io.Writer, sh shell, id string) error {
+	if err := validateSessionID(id); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(writer, sh.export(sessionIDEnvVar, id))
+	fmt.Fprintln(writer, "# Run this command to use the session in the current shell:")
+	fmt.Fprintln(writer, "# "+sh.evalCommand("gardenctl session use "+id+" "+string(sh)))
+	return nil
+}
+
+// validateSessionID returns an error if id is not the ID of an existing session
+func validateSessionID(id string) error {
+	if !validSessionID.MatchString(id) || id == "." || id == ".." {
+		return fmt.Errorf("invalid session ID %q", id)
+	}
+	if exists, err := FileExists(sessionDir(id)); err != nil {
+		return err
+	} else if !exists {
+		return NewNotFoundError("session %q not found", id)
+	}
+	return nil
+}
+
+// generateSessionID returns a random session ID
+func generateSessionID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// initSession creates the directory and the files of the session with the given ID
+func initSession(id string) error {
+	dir := sessionDir(id)
+	if err := os.MkdirAll(dir, 0751); err != nil {
+		return err
+	}
+	for _, name := range []string{"target", "history"} {
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionDir returns the directory of the session with the given ID
+func sessionDir(id string) string {
+	return filepath.Join(gardenctl.SessionsDir(), id)
+}
+
+// listSessions returns all sessions sorted by last use, most recent first
+func listSessions(targetReader TargetReader) ([]SessionMeta, error) {
+	entries, err := ioutil.ReadDir(gardenctl.SessionsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sessions []SessionMeta
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		lastUsed, err := sessionLastUsed(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		session := SessionMeta{
+			ID:       entry.Name(),
+			Current:  entry.Name() == sessionID,
+			LastUsed: lastUsed,
+		}
+		if exists, _ := FileExists(filepath.Join(sessionDir(entry.Name()), "target")); exists {
+			target := targetReader.ReadTarget(filepath.Join(sessionDir(entry.Name()), "target"))
+			if len(target.Stack()) > 0 {
+				session.Target = TargetPathFromStack(target.Stack()).String()
+			}
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastUsed.After(sessions[j].LastUsed)
+	})
+	return sessions, nil
+}
+
+// sessionLastUsed returns the time of the last command run in the session. Sessions of former versions
+// without marker were last used at the most recent modification time of the session directory and its files.
+func sessionLastUsed(id string) (time.Time, error) {
+	dir := sessionDir(id)
+	if info, err := os.Stat((&gardenctl.Session{Dir: dir}).LastUsedPath()); err == nil {
+		return info.ModTime(), nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	lastUsed := info.ModTime()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, f := range files {
+		if f.ModTime().After(lastUsed) {
+			lastUsed = f.ModTime()
+		}
+	}
+	return lastUsed, nil
+}
+
+// printSessions prints all sessions with their current target and last use
+func printSessions(targetReader TargetReader, writer io.Writer, outFormat string) error {
+	sessions, err := listSessions(targetReader)
+	if err != nil {
+		return err
+	}
+	return PrintoutObject(Sessions{Sessions: sessions}, writer, outFormat)
+}
+
+// removeSessions removes the sessions with the given IDs together with their cached data
+func removeSessions(targetReader TargetReader, writer io.Writer, ids []string) error {
+	for _, id := range ids {
+		if err := validateSessionID(id); err != nil {
+			return err
+		}
+		if id == sessionID {
+			return fmt.Errorf("session %q is the current session and can't be removed", id)
+		}
+	}
+
+	return deleteSessions(targetReader, writer, ids)
+}
+
+// gcSessions removes all sessions except the current one which have not been used for longer than maxAge
+func gcSessions(targetReader TargetReader, writer io.Writer, maxAge time.Duration) error {
+	sessions, err := listSessions(targetReader)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, session := range sessions {
+		if !session.Current && time.Since(session.LastUsed) > maxAge {
+			stale = append(stale, session.ID)
+		}
+	}
+	if len(stale) == 0 {
+		fmt.Fprintln(writer, "No stale sessions found")
+		return nil
+	}
+
+	return deleteSessions(targetReader, writer, stale)
+}
+
+// deleteSessions deletes the session directories, their kubeconfigs and the cached shoot data only referenced by them
+func deleteSessions(targetReader TargetReader, writer io.Writer, ids []string) error {
+	removed := make(map[string]bool)
+	cacheDirs := make(map[string]bool)
+	for _, id := range ids {
+		removed[id] = true
+		target := targetReader.ReadTarget(filepath.Join(sessionDir(id), "target"))
+		if dir := shootCacheDir(target.Stack()); dir != "" {
+			cacheDirs[dir] = true
+		}
+	}
+
+	// keep cached data which is still referenced by a remaining session
+	sessions, err := listSessions(targetReader)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if removed[session.ID] {
+			continue
+		}
+		target := targetReader.ReadTarget(filepath.Join(sessionDir(session.ID), "target"))
+		delete(cacheDirs, shootCacheDir(target.Stack()))
+	}
+
+	for _, id := range ids {
+		if err := os.RemoveAll(sessionDir(id)); err != nil {
+			return err
+		}
+		if err := credentialCache().RemoveAll(filepath.Dir(sessionKubeconfigPath(id))); err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "Removed session %s\n", id)
+	}
+	for dir := range cacheDirs {
+		if err := credentialCache().RemoveAll(dir); err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "Removed cached data %s\n", dir)
+	}
+	return nil
+}
+
+// shootCacheDir returns the cache directory of the shoot in the target stack, if a shoot is targeted
+func shootCacheDir(stack []TargetMeta) string {
+	if len(stack) < 3 || stack[2].Kind != TargetKindShoot {
+		return ""
+	}
+	switch stack[1].Kind {
+	case TargetKindProject:
+		return filepath.Join(pathGardenHome, "cache", stack[0].Name, "projects", stack[1].Name, stack[2].Name)
+	case TargetKindSeed:
+		return filepath.Join(pathGardenHome, "cache", stack[0].Name, "seeds", stack[1].Name, stack[2].Name)
+	}
+	return ""
+}
+
+// PrintTable prints the sessions as table
+func (s Sessions) PrintTable(w io.Writer, wide bool) error {
+	now := time.Now()
+	var rows [][]string
+	for _, session := range s.Sessions {
+		current := ""
+		if session.Current {
+			current = "*"
+		}
+		rows = append(rows, []string{current, session.ID, orDash(session.Target), formatAge(session.LastUsed, now)})
+	}
+	return printTable(w, []string{"CURRENT", "ID", "TARGET", "LAST USED"}, rows)
+}
+
+// Names returns the IDs of the sessions
+func (s Sessions) Names() []string {
+	var names []string
+	for _, session := range s.Sessions {
+		names = append(names, session.ID)
+	}
+	return names
+}
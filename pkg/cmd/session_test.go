@@ -0,0 +1,187 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Session command", func() {
+	var (
+		ctrl         *gomock.Controller
+		targetReader *mockcmd.MockTargetReader
+		home         string
+		sessionsDir  string
+		oldHome      string
+
+		createSession = func(id string, lastUsed time.Time) {
+			dir := filepath.Join(sessionsDir, id)
+			Expect(os.MkdirAll(dir, 0751)).To(Succeed())
+			for _, name := range []string{"target", "history"} {
+				Expect(ioutil.WriteFile(filepath.Join(dir, name), nil, 0644)).To(Succeed())
+				Expect(os.Chtimes(filepath.Join(dir, name), lastUsed, lastUsed)).To(Succeed())
+			}
+			Expect(os.Chtimes(dir, lastUsed, lastUsed)).To(Succeed())
+		}
+		execute = func(args ...string) (string, error) {
+			ioStreams, _, out, _ := cmd.NewTestIOStreams()
+			command := cmd.NewSessionCmd(targetReader, ioStreams)
+			command.SetArgs(args)
+			err := command.Execute()
+			return out.String(), err
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		targetReader = mockcmd.NewMockTargetReader(ctrl)
+
+		var err error
+		home, err = ioutil.TempDir("", "gardenctl-session")
+		Expect(err).NotTo(HaveOccurred())
+		oldHome = os.Getenv("HOME")
+		os.Setenv("HOME", home)
+		sessionsDir = filepath.Join(home, ".garden", "sessions")
+	})
+
+	AfterEach(func() {
+		os.Setenv("HOME", oldHome)
+		os.RemoveAll(home)
+		ctrl.Finish()
+	})
+
+	Context("with sessions", func() {
+		BeforeEach(func() {
+			createSession("recent", time.Now())
+			createSession("stale", time.Now().Add(-48*time.Hour))
+
+			target := mockcmd.NewMockTargetInterface(ctrl)
+			target.EXPECT().Stack().Return([]cmd.TargetMeta{{Kind: cmd.TargetKindGarden, Name: "prod"}, {Kind: cmd.TargetKindProject, Name: "core"}}).AnyTimes()
+			targetReader.EXPECT().ReadTarget(filepath.Join(sessionsDir, "recent", "target")).Return(target).AnyTimes()
+			empty := mockcmd.NewMockTargetInterface(ctrl)
+			empty.EXPECT().Stack().Return(nil).AnyTimes()
+			targetReader.EXPECT().ReadTarget(filepath.Join(sessionsDir, "stale", "target")).Return(empty).AnyTimes()
+		})
+
+		It("should list the sessions with their target, most recently used first", func() {
+			out, err := execute("ls")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(HavePrefix("sessions:\n- id: recent\n  target: prod/core\n"))
+			Expect(out).To(ContainSubstring("- id: stale\n  lastUsed:"))
+		})
+
+		It("should remove the given sessions", func() {
+			out, err := execute("rm", "stale")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Removed session stale\n"))
+			Expect(filepath.Join(sessionsDir, "stale")).NotTo(BeADirectory())
+			Expect(filepath.Join(sessionsDir, "recent")).To(BeADirectory())
+		})
+
+		It("should refuse to remove an unknown session", func() {
+			_, err := execute("rm", "stale", "unknown")
+
+			Expect(err).To(MatchError(`session "unknown" not found`))
+			Expect(filepath.Join(sessionsDir, "stale")).To(BeADirectory())
+		})
+
+		It("should garbage collect the sessions which have not been used for the given number of days", func() {
+			out, err := execute("gc", "--older-than", "1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Removed session stale\n"))
+			Expect(filepath.Join(sessionsDir, "stale")).NotTo(BeADirectory())
+			Expect(filepath.Join(sessionsDir, "recent")).To(BeADirectory())
+
+			out, err = execute("gc", "--older-than", "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("No stale sessions found\n"))
+		})
+
+		It("should keep the sessions in which a command ran recently", func() {
+			session := &gardenctl.Session{ID: "stale", Dir: filepath.Join(sessionsDir, "stale")}
+			Expect(session.MarkUsed()).To(Succeed())
+
+			out, err := execute("gc", "--older-than", "1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("No stale sessions found\n"))
+			Expect(filepath.Join(sessionsDir, "stale")).To(BeADirectory())
+		})
+
+		It("should garbage collect the sessions in which no command ran recently", func() {
+			lastUsed := time.Now().Add(-48 * time.Hour)
+			marker := filepath.Join(sessionsDir, "recent", "lastUsed")
+			Expect(ioutil.WriteFile(marker, nil, 0644)).To(Succeed())
+			Expect(os.Chtimes(marker, lastUsed, lastUsed)).To(Succeed())
+
+			out, err := execute("gc", "--older-than", "1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Removed session recent\nRemoved session stale\n"))
+		})
+
+		It("should print the statement to use an existing session", func() {
+			out, err := execute("use", "stale", "bash")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(HavePrefix("export GARDEN_SESSION_ID='stale';\n"))
+			Expect(out).To(ContainSubstring("# eval $(gardenctl session use stale bash)\n"))
+		})
+
+		It("should refuse to use an unknown session", func() {
+			_, err := execute("use", "unknown")
+
+			Expect(err).To(MatchError(`session "unknown" not found`))
+		})
+	})
+
+	DescribeTable("validation",
+		func(args []string, expectedErr string) {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command := cmd.NewSessionCmd(targetReader, ioStreams)
+			command.SetArgs(args)
+
+			err := command.Execute()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(expectedErr))
+		},
+		Entry("without args", []string{}, "command must be in the format: session (new|use|ls|rm|gc)"),
+		Entry("with unknown action", []string{"foo"}, "command must be in the format: session (new|use|ls|rm|gc)"),
+		Entry("new with too many args", []string{"new", "bash", "foo"}, "command must be in the format: session new [bash|zsh|fish|powershell]"),
+		Entry("new with unknown shell", []string{"new", "foo"}, "unsupported shell \"foo\", must be one of: bash, zsh, fish, powershell"),
+		Entry("use without ID", []string{"use"}, "command must be in the format: session use ID [bash|zsh|fish|powershell]"),
+		Entry("use with unknown shell", []string{"use", "recent", "foo"}, "unsupported shell \"foo\", must be one of: bash, zsh, fish, powershell"),
+		Entry("use with path as ID", []string{"use", "../target"}, "invalid session ID \"../target\""),
+		Entry("rm without ID", []string{"rm"}, "command must be in the format: session rm ID..."),
+		Entry("rm with path as ID", []string{"rm", "../target"}, "invalid session ID \"../target\""),
+		Entry("rm with relative ID", []string{"rm", ".."}, "invalid session ID \"..\""),
+	)
+})
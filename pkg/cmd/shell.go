@@ -33,7 +33,7 @@ import (
 var imageFlag string
 
 // NewShellCmd returns a new shell command.
-func NewShellCmd(reader TargetReader, ioStreams IOStreams) *cobra.Command {
+func NewShellCmd(reader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "shell (node|pod)",
 		Short:        "Shell to a node, e.g. \"gardenctl shell node_name\"",
@@ -56,6 +56,9 @@ func NewShellCmd(reader TargetReader, ioStreams IOStreams) *cobra.Command {
 			if targetKind == TargetKindProject {
 				return errors.New("project targeted")
 			}
+			if err = checkReadOnly(target, configReader, "shell"); err != nil {
+				return err
+			}
 
 			var shoot *gardencorev1beta1.Shoot
 			if len(target.Stack()) == 1 {
@@ -69,6 +72,11 @@ func NewShellCmd(reader TargetReader, ioStreams IOStreams) *cobra.Command {
 			if shoot != nil && shoot.Status.IsHibernated {
 				return fmt.Errorf("shoot %q is hibernated", shoot.Name)
 			}
+			if shoot != nil {
+				if err = enforceAccessRestrictions(target, shoot, configReader, ioStreams); err != nil {
+					return err
+				}
+			}
 
 			var client kubernetes.Interface
 			if client, err = target.K8SClient(); err != nil {
@@ -79,7 +87,7 @@ func NewShellCmd(reader TargetReader, ioStreams IOStreams) *cobra.Command {
 				return printNodes(client, ioStreams)
 			}
 
-			return shellToNode(client, targetKind, args[0], ioStreams)
+			return shellToNode(reader, client, targetKind, args[0], ioStreams)
 		},
 	}
 
@@ -103,7 +111,7 @@ func printNodes(client kubernetes.Interface, ioStreams IOStreams) (err error) {
 }
 
 // shellToNode creates a root pod on node
-func shellToNode(client kubernetes.Interface, targetKind TargetKind, nodeName string, ioStreams IOStreams) (err error) {
+func shellToNode(targetReader TargetReader, client kubernetes.Interface, targetKind TargetKind, nodeName string, ioStreams IOStreams) (err error) {
 	// Check if the node name was a pod name and we should actually identify the node from the pod (node that runs the pod)
 	var pods *corev1.PodList
 	if pods, err = client.CoreV1().Pods("").List(metav1.ListOptions{}); err != nil {
@@ -170,8 +178,13 @@ func shellToNode(client kubernetes.Interface, targetKind TargetKind, nodeName st
 		return err
 	}
 
+	pathToKubeconfig, err := getKubeConfigOfClusterType(targetReader, targetKind)
+	if err != nil {
+		return err
+	}
+
 	time.Sleep(1000)
-	err = ExecCmd(nil, "kubectl -n "+namespace+" exec -it "+podName+" -- chroot /hostroot /bin/bash", false, "KUBECONFIG="+getKubeConfigOfClusterType(targetKind))
+	err = ExecCmd(nil, "kubectl -n "+namespace+" exec -it "+podName+" -- chroot /hostroot /bin/bash", false, "KUBECONFIG="+pathToKubeconfig)
 	if err != nil {
 		return err
 	}
@@ -32,10 +32,11 @@ import (
 var _ = Describe("Shell command", func() {
 
 	var (
-		ctrl    *gomock.Controller
-		reader  *mockcmd.MockTargetReader
-		target  *mockcmd.MockTargetInterface
-		command *cobra.Command
+		ctrl         *gomock.Controller
+		reader       *mockcmd.MockTargetReader
+		configReader *mockcmd.MockConfigReader
+		target       *mockcmd.MockTargetInterface
+		command      *cobra.Command
 
 		execute = func(command *cobra.Command, args []string) error {
 			command.SetArgs(args)
@@ -84,6 +85,7 @@ var _ = Describe("Shell command", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		reader = mockcmd.NewMockTargetReader(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
 		target = mockcmd.NewMockTargetInterface(ctrl)
 	})
 
@@ -103,9 +105,10 @@ var _ = Describe("Shell command", func() {
 			target.EXPECT().Stack().Return(targetMeta).AnyTimes()
 			target.EXPECT().GardenerClient().Return(gardenClientSet, nil)
 			target.EXPECT().K8SClient().Return(k8sClientSet, nil)
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{}).Times(2)
 
 			ioStreams, _, out, _ := cmd.NewTestIOStreams()
-			command = cmd.NewShellCmd(reader, ioStreams)
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
 			err := execute(command, []string{})
 
 			Expect(err).NotTo(HaveOccurred())
@@ -120,7 +123,7 @@ var _ = Describe("Shell command", func() {
 				)
 
 				ioStreams, _, _, _ := cmd.NewTestIOStreams()
-				command = cmd.NewShellCmd(reader, ioStreams)
+				command = cmd.NewShellCmd(reader, configReader, ioStreams)
 				err := execute(command, []string{})
 
 				Expect(err).To(HaveOccurred())
@@ -139,9 +142,10 @@ var _ = Describe("Shell command", func() {
 			target.EXPECT().K8SClient().Return(k8sClientSet, nil)
 			target.EXPECT().GardenerClient().Return(gardenClientSet, nil)
 			target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{}).Times(2)
 
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewShellCmd(reader, ioStreams)
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
 			err := execute(command, []string{"minikube"})
 
 			Expect(err).To(HaveOccurred())
@@ -157,7 +161,7 @@ var _ = Describe("Shell command", func() {
 			)
 
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewShellCmd(reader, ioStreams)
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
 			err := execute(command, []string{"minikube"})
 
 			Expect(err).To(HaveOccurred())
@@ -168,7 +172,7 @@ var _ = Describe("Shell command", func() {
 	Context("with >= 2 args", func() {
 		It("should return error", func() {
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewShellCmd(reader, ioStreams)
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
 			err := execute(command, []string{"minikube", "docker-for-mac"})
 
 			Expect(err).To(HaveOccurred())
@@ -176,6 +180,100 @@ var _ = Describe("Shell command", func() {
 		})
 	})
 
+	Context("with access restrictions", func() {
+		var gardenConfig *cmd.GardenConfig
+
+		BeforeEach(func() {
+			gardenConfig = &cmd.GardenConfig{
+				GardenClusters: []cmd.GardenClusterMeta{{
+					Name: "test-garden",
+					AccessRestrictions: []cmd.AccessRestriction{{
+						Key:         "seed.gardener.cloud/eu-access",
+						NotifyIf:    true,
+						Msg:         "EU access only",
+						Enforcement: cmd.RestrictionEnforcementDeny,
+					}},
+				}},
+			}
+			gardenClientSet := gardencorefake.NewSimpleClientset(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: shootName, Namespace: "garden-prod"},
+				Spec: gardencorev1beta1.ShootSpec{
+					SeedName:     &seedName,
+					SeedSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"seed.gardener.cloud/eu-access": "true"}},
+				},
+			})
+			gardenClient := fake.NewSimpleClientset(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "garden-prod", Labels: map[string]string{"project.gardener.cloud/name": "prod"}},
+			})
+
+			reader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+			target.EXPECT().Kind().Return(cmd.TargetKindShoot, nil)
+			target.EXPECT().GardenerClient().Return(gardenClientSet, nil)
+			target.EXPECT().K8SClientToKind(cmd.TargetKindGarden).Return(gardenClient, nil)
+			target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+		})
+
+		It("should not open a shell if a restriction denies the access", func() {
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig).Times(2)
+
+			ioStreams, _, _, errOut := cmd.NewTestIOStreams()
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
+			err := execute(command, []string{"minikube"})
+
+			Expect(cmd.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`access to shoot "test-shoot" is denied by access restriction "seed.gardener.cloud/eu-access"`))
+			Expect(errOut.String()).To(Equal("EU access only\n"))
+		})
+
+		It("should not open a shell if a selector of a restriction selects the project of the shoot", func() {
+			gardenConfig.GardenClusters[0].AccessRestrictions[0] = cmd.AccessRestriction{
+				Key:         "production",
+				Msg:         "production project",
+				Enforcement: cmd.RestrictionEnforcementDeny,
+				Selector:    &cmd.AccessRestrictionSelector{Projects: []string{"prod"}},
+			}
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig).Times(2)
+
+			ioStreams, _, _, errOut := cmd.NewTestIOStreams()
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
+			err := execute(command, []string{"minikube"})
+
+			Expect(cmd.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`access to shoot "test-shoot" is denied by access restriction "production"`))
+			Expect(errOut.String()).To(Equal("production project\n"))
+		})
+
+		It("should not open a shell without a confirmation if a restriction requires one", func() {
+			gardenConfig.GardenClusters[0].AccessRestrictions[0].Enforcement = cmd.RestrictionEnforcementConfirm
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig).Times(2)
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
+			err := execute(command, []string{"minikube"})
+
+			Expect(cmd.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`access to shoot "test-shoot" requires confirming access restriction(s) seed.gardener.cloud/eu-access`))
+		})
+	})
+
+	Context("with read-only garden", func() {
+		It("should not open a shell", func() {
+			reader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+			target.EXPECT().Kind().Return(cmd.TargetKindShoot, nil)
+			target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{
+				GardenClusters: []cmd.GardenClusterMeta{{Name: "test-garden", ReadOnly: true}},
+			})
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
+			err := execute(command, []string{})
+
+			Expect(cmd.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("test-garden/@test-name/test-shoot is read-only, shell is refused"))
+		})
+	})
+
 	Context("with hibernated shoot", func() {
 		It("should not list nodes", func() {
 			gardenClientSet := createGardenClientSet(true)
@@ -184,9 +282,10 @@ var _ = Describe("Shell command", func() {
 			target.EXPECT().Kind().Return(cmd.TargetKindShoot, nil)
 			target.EXPECT().GardenerClient().Return(gardenClientSet, nil)
 			target.EXPECT().Stack().Return(targetMeta).AnyTimes()
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(&cmd.GardenConfig{})
 
 			ioStreams, _, _, _ := cmd.NewTestIOStreams()
-			command = cmd.NewShellCmd(reader, ioStreams)
+			command = cmd.NewShellCmd(reader, configReader, ioStreams)
 			err := execute(command, []string{})
 
 			Expect(err).To(HaveOccurred())
@@ -0,0 +1,233 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// kubeconfigTTL is the time to live of the short-lived kubeconfig returned by get shoot
+	kubeconfigTTL time.Duration
+	// kubeconfigRole is the role of the short-lived kubeconfig returned by get shoot
+	kubeconfigRole string
+	// revokeAll revokes the short-lived kubeconfigs of all shoots
+	revokeAll bool
+)
+
+const (
+	defaultKubeconfigTTL  = time.Hour
+	defaultKubeconfigRole = "view"
+)
+
+// NewRevokeCmd returns a new revoke command.
+func NewRevokeCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [SERVICE_ACCOUNT] [--all]",
+		Short: "Revoke the short-lived kubeconfigs created with \"gardenctl get shoot --kubeconfig-ttl\" by deleting their service accounts, e.g. \"gardenctl revoke\" for the targeted shoot",
+		Example: `
+	# Revoke the short-lived kubeconfigs of the targeted shoot.
+	gardenctl revoke
+
+	# Revoke a single kubeconfig by the name of its service account.
+	gardenctl revoke gardenctl-x7k2m9qd
+
+	# Revoke the short-lived kubeconfigs of all shoots.
+	gardenctl revoke --all`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 || (revokeAll && len(args) > 0) {
+				return errors.New("command must be in the format: revoke [SERVICE_ACCOUNT] [--all]")
+			}
+			accesses, err := gardenctl.ReadShootAccesses(shootAccessPath())
+			if err != nil {
+				return err
+			}
+
+			var selected []gardenctl.ShootAccess
+			switch {
+			case revokeAll:
+				selected = accesses
+			case len(args) == 1:
+				for _, access := range accesses {
+					if access.ServiceAccount == args[0] {
+						selected = append(selected, access)
+					}
+				}
+				if len(selected) == 0 {
+					return NewNotFoundError("no kubeconfig with service account %q has been created by gardenctl", args[0])
+				}
+			default:
+				target := targetReader.ReadTarget(pathTarget)
+				if !CheckShootIsTargeted(target) {
+					return NewNotTargetedError(TargetKindShoot)
+				}
+				shoot, err := FetchShootFromTarget(target)
+				if err != nil {
+					return err
+				}
+				garden := target.Stack()[0].Name
+				for _, access := range accesses {
+					if access.Garden == garden && access.Namespace == shoot.Namespace && access.Shoot == shoot.Name {
+						selected = append(selected, access)
+					}
+				}
+			}
+			if len(selected) == 0 {
+				fmt.Fprintln(ioStreams.Out, "No short-lived kubeconfigs to revoke")
+				return nil
+			}
+			return revokeShootAccesses(selected, ioStreams)
+		},
+	}
+
+	cmd.Flags().BoolVar(&revokeAll, "all", false, "revoke the short-lived kubeconfigs of all shoots")
+
+	return cmd
+}
+
+// printShootAccessKubeconfig creates a service account with kubeconfigRole in the shoot with the given
+// name, or the targeted shoot, and prints a kubeconfig with a token which expires after kubeconfigTTL
+func printShootAccessKubeconfig(name string, targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams, outFormat string) error {
+	role, ttl := kubeconfigRole, kubeconfigTTL
+	if role == "" {
+		role = defaultKubeconfigRole
+	}
+	if ttl == 0 {
+		ttl = defaultKubeconfigTTL
+	}
+	if _, ok := gardenctl.ShootAccessRoles[role]; !ok {
+		return fmt.Errorf("unknown role %q, must be one of: %s", role, strings.Join(gardenctl.ShootAccessRoleNames(), ", "))
+	}
+	if ttl < gardenctl.MinShootAccessTTL {
+		return fmt.Errorf("kubeconfig ttl %s is too short, it must be at least %s", ttl, gardenctl.MinShootAccessTTL)
+	}
+
+	target := targetReader.ReadTarget(pathTarget)
+	shoot, err := GetTargetedShootObject(targetReader)
+	if name != "" {
+		shoot, err = GetShootObject(targetReader, name)
+	}
+	if err != nil {
+		return err
+	}
+	if err := enforceAccessRestrictions(target, shoot, configReader, ioStreams); err != nil {
+		return err
+	}
+	if role != defaultKubeconfigRole {
+		if err := checkShootReadOnly(target, shoot, configReader, fmt.Sprintf("a kubeconfig with role %s", role)); err != nil {
+			return err
+		}
+	}
+
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	adminKubeconfig, err := gardenctl.ShootAdminKubeconfig(gardenClient, shoot.Namespace, shoot.Name, ioStreams.ErrOut)
+	if err != nil {
+		return err
+	}
+	shootClient, err := gardenctl.KubernetesFromKubeconfig(adminKubeconfig)
+	if err != nil {
+		return err
+	}
+	access, token, err := gardenctl.CreateShootAccess(shootClient, role, ttl)
+	if err != nil {
+		return err
+	}
+	access.Garden, access.Namespace, access.Shoot = target.Stack()[0].Name, shoot.Namespace, shoot.Name
+	if err := gardenctl.UpdateShootAccesses(shootAccessPath(), func(accesses []gardenctl.ShootAccess) []gardenctl.ShootAccess {
+		return append(accesses, *access)
+	}); err != nil {
+		return err
+	}
+
+	kubeconfig, err := gardenctl.ShootAccessKubeconfig(adminKubeconfig, token)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.ErrOut, "Created service account %s/%s with role %s in shoot %s, the kubeconfig expires at %s. Revoke it with \"gardenctl revoke %s\".\n",
+		gardenctl.ShootAccessNamespace, access.ServiceAccount, role, shoot.Name, access.ExpirationTimestamp.Local().Format(time.RFC3339), access.ServiceAccount)
+	return PrintoutObject(fmt.Sprintf("%s\n", kubeconfig), ioStreams.Out, outFormat)
+}
+
+// revokeShootAccesses deletes the service accounts of accesses from their shoots and forgets them. The
+// accesses of shoots which do not exist anymore are forgotten as well.
+func revokeShootAccesses(accesses []gardenctl.ShootAccess, ioStreams IOStreams) error {
+	var failed int
+	revoked := make(map[gardenctl.ShootAccess]bool)
+	for _, access := range accesses {
+		if err := revokeShootAccess(access, ioStreams); err != nil {
+			fmt.Fprintf(ioStreams.ErrOut, "Failed to revoke service account %s of shoot %s/%s: %v\n", access.ServiceAccount, access.Garden, access.Shoot, err)
+			failed++
+			continue
+		}
+		revoked[access] = true
+	}
+
+	if err := gardenctl.UpdateShootAccesses(shootAccessPath(), func(current []gardenctl.ShootAccess) []gardenctl.ShootAccess {
+		var kept []gardenctl.ShootAccess
+		for _, access := range current {
+			if !revoked[access] {
+				kept = append(kept, access)
+			}
+		}
+		return kept
+	}); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d kubeconfig(s) could not be revoked", failed, len(accesses))
+	}
+	return nil
+}
+
+// revokeShootAccess deletes the service account of access from its shoot
+func revokeShootAccess(access gardenctl.ShootAccess, ioStreams IOStreams) error {
+	garden := &Target{Target: []TargetMeta{{Kind: TargetKindGarden, Name: access.Garden}}}
+	gardenClient, err := garden.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	adminKubeconfig, err := gardenctl.ShootAdminKubeconfig(gardenClient, access.Namespace, access.Shoot, ioStreams.ErrOut)
+	if IsNotFound(err) {
+		fmt.Fprintf(ioStreams.Out, "Forgot service account %s, shoot %s/%s does not exist anymore\n", access.ServiceAccount, access.Garden, access.Shoot)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	shootClient, err := gardenctl.KubernetesFromKubeconfig(adminKubeconfig)
+	if err != nil {
+		return err
+	}
+	if err := gardenctl.RevokeShootAccess(shootClient, access.ServiceAccount); err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Revoked service account %s/%s of shoot %s/%s\n", gardenctl.ShootAccessNamespace, access.ServiceAccount, access.Garden, access.Shoot)
+	return nil
+}
+
+// shootAccessPath returns the path of the file recording the short-lived kubeconfigs
+func shootAccessPath() string {
+	return filepath.Join(pathGardenHome, "shoot-access.yaml")
+}
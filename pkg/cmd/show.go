@@ -17,103 +17,101 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"strconv"
+	"io"
 	"strings"
 
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 var (
 	flagoutput string
+	// showOutputFormat is the output format of the shown pods
+	showOutputFormat string
 )
 
 // NewShowCmd returns a new show command.
-func NewShowCmd(targetReader TargetReader) *cobra.Command {
+func NewShowCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)",
-		Short: `Show details about endpoint/service and open in default browser if applicable`,
+		Use:          "show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)",
+		Short:        `Show details about endpoint/service and open in default browser if applicable`,
+		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 || len(args) > 2 {
 				return errors.New("Command must be in the format: show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
 			}
 			t := targetReader.ReadTarget(pathTarget)
 			if (len(t.Stack()) < 3 || (len(t.Stack()) == 3 && t.Stack()[2].Kind == "namespace")) && (args[0] != "operator") && (args[0] != "tf") && (args[0] != "kubernetes-dashboard") && (args[0] != "etcd-operator") {
-				fmt.Println("No shoot targeted")
-				os.Exit(2)
+				return NewNotTargetedError(TargetKindShoot)
 			} else if (len(t.Stack()) < 2 && (args[0] == "tf")) || len(t.Stack()) < 3 && (args[0] == "tf") && (t.Stack()[1].Kind != "seed") {
-				fmt.Println("No seed or shoot targeted")
-				os.Exit(2)
+				return NewNotTargetedError(TargetKindSeed)
 			} else if len(t.Stack()) == 0 {
-				fmt.Println("Target stack is empty")
-				os.Exit(2)
+				return NewNotTargetedError(TargetKindGarden)
+			}
+			if err := enforceTargetAccessRestrictions(t, configReader, ioStreams); err != nil {
+				return err
 			}
 
 			// Set up global map variable targetInfo and key validation check
+			showOutputFormat = outputFormatOf(cmd, outputFormatWide)
 
 			switch args[0] {
 			case "infra":
 				if flagoutput == "" {
 					flagoutput = "json"
 				}
-				showCloudInfra(targetReader, flagoutput)
+				return showCloudInfra(targetReader, ioStreams, flagoutput)
 			case "operator":
-				showOperator()
+				return showOperator(targetReader, ioStreams)
 			case "gardener-dashboard":
-				showGardenerDashboard()
+				return showGardenerDashboard(targetReader, ioStreams)
 			case "api":
-				showAPIServer(targetReader)
+				return showAPIServer(targetReader, ioStreams)
 			case "scheduler":
-				showScheduler(targetReader)
+				return showScheduler(targetReader, ioStreams)
 			case "controller-manager":
-				showControllerManager(targetReader)
+				return showControllerManager(targetReader, ioStreams)
 			case "etcd-operator":
-				showEtcdOperator()
+				return showEtcdOperator(targetReader, ioStreams)
 			case "etcd-main":
-				showEtcdMain(targetReader)
+				return showEtcdMain(targetReader, ioStreams)
 			case "etcd-events":
-				showEtcdEvents(targetReader)
+				return showEtcdEvents(targetReader, ioStreams)
 			case "addon-manager":
-				showAddonManager(targetReader)
+				return showAddonManager(targetReader, ioStreams)
 			case "vpn-seed":
-				showVpnSeed(targetReader)
+				return showVpnSeed(targetReader, ioStreams)
 			case "vpn-shoot":
-				showVpnShoot(targetReader)
+				return showVpnShoot(targetReader, ioStreams)
 			case "machine-controller-manager":
-				showMachineControllerManager(targetReader)
+				return showMachineControllerManager(targetReader, ioStreams)
 			case "kubernetes-dashboard":
-				showKubernetesDashboard(targetReader)
+				return showKubernetesDashboard(targetReader, ioStreams)
 			case "prometheus":
-				showPrometheus(targetReader)
+				return showPrometheus(targetReader, ioStreams)
 			case "grafana":
-				showGrafana(targetReader)
+				return showGrafana(targetReader, ioStreams)
 			case "tf":
 				if len(args) == 1 {
-					showTf()
-					break
+					return showTf(targetReader, ioStreams)
 				}
 				switch args[1] {
 				case "infra":
-					showInfra()
+					return showInfra(targetReader, ioStreams)
 				case "dns":
-					showDNS()
+					return showDNS(targetReader, ioStreams)
 				case "ingress":
-					showIngress()
+					return showIngress(targetReader, ioStreams)
 				default:
-					fmt.Println("Command must be in the format: show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
+					return errors.New("Command must be in the format: show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
 				}
 			case "cluster-autoscaler":
-				showClusterAutoscaler(targetReader)
+				return showClusterAutoscaler(targetReader, ioStreams)
 			default:
-				fmt.Println("Command must be in the format: show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
+				return errors.New("Command must be in the format: show (infra|operator|gardener-dashboard|api|scheduler|controller-manager|etcd-operator|etcd-main|etcd-events|addon-manager|vpn-seed|vpn-shoot|machine-controller-manager|kubernetes-dashboard|prometheus|grafana|tf (infra|dns|ingress)|cluster-autoscaler)")
 			}
-			return nil
 		},
 		ValidArgs: []string{"operator", "gardener-dashboard", "api", "scheduler", "controller-manager", "etcd-operator", "etcd-main", "etcd-events", "addon-manager", "vpn-seed", "vpn-shoot", "machine-controller-manager", "kubernetes-dashboard", "prometheus", "grafana", "tf", "cluster-autoscaler"},
 	}
@@ -122,396 +120,458 @@ func NewShowCmd(targetReader TargetReader) *cobra.Command {
 	return cmd
 }
 
-// showPodGarden
-func showPodGarden(podName string, namespace string) {
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
-	for _, pod := range pods.Items {
-		if strings.Contains(pod.Name, podName) {
-			err := ExecCmd(nil, "kubectl get pods "+pod.Name+" -o wide -n "+namespace, false, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+// PrintTable prints the pods of the endpoint followed by its URLs
+func (e Endpoint) PrintTable(w io.Writer, wide bool) error {
+	if err := (Pods{Pods: e.Pods}).PrintTable(w, wide); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	var rows [][]string
+	for _, url := range e.URLs {
+		rows = append(rows, []string{url, orDash(e.Username)})
+	}
+	return printTable(w, []string{"URL", "USERNAME"}, rows)
+}
+
+// PrintTable prints the output of the CLI commands as is
+func (r InfraResources) PrintTable(w io.Writer, wide bool) error {
+	for _, resource := range r.Resources {
+		if _, err := fmt.Fprintln(w, resource.Output); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-// showOperator shows the garden operator pod in the garden cluster
-func showOperator() {
-	showPodGarden("gardener-apiserver", "garden")
-	showPodGarden("gardener-controller-manager", "garden")
+// matchPods returns the pods matched by match
+func matchPods(pods []corev1.Pod, match func(pod corev1.Pod) bool) Pods {
+	var matched Pods
+	for _, pod := range pods {
+		if match(pod) {
+			matched.Pods = append(matched.Pods, toPodMeta(pod))
+		}
+	}
+	return matched
 }
 
-// showUI opens the gardener landing page
-func showGardenerDashboard() {
-	showPodGarden("gardener-dashboard", "garden")
-	output, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+KUBECONFIG, "get", "ingress", "gardener-dashboard-ingress", "-n", "garden")
+// podsOfGarden returns the pods in namespace of the garden cluster whose names contain podName
+func podsOfGarden(targetReader TargetReader, podName string, namespace string) (Pods, error) {
+	client, _, err := clusterOfCurrentTarget(targetReader, TargetKindGarden)
 	if err != nil {
-		fmt.Println("Cmd was unsuccessful")
-		os.Exit(2)
+		return Pods{}, err
 	}
-	list := strings.Split(output, " ")
-	url := "-"
-	for _, val := range list {
-		if strings.HasPrefix(val, "dashboard.") {
-			url = val
-		}
-	}
-	urls := strings.Split(url, ",")
-	var filteredUrls []string
-	match := false
-	opened := false
-	for index, url := range urls {
-		for _, u := range filteredUrls {
-			if url == u {
-				match = true
-			}
-		}
-		if !match {
-			filteredUrls = append(filteredUrls, url)
-			fmt.Println("URL-" + strconv.Itoa(index+1) + ": " + "https://" + url)
-			if !opened {
-				err := browser.OpenURL("https://" + url)
-				checkError(err)
-				opened = true
-			}
-		}
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return Pods{}, err
 	}
+	return matchPods(pods.Items, func(pod corev1.Pod) bool { return strings.Contains(pod.Name, podName) }), nil
 }
 
-// showPod is an abstraction to show pods in seed cluster controlplane or kube-system namespace of shoot
-func showPod(toMatch string, toTarget TargetKind, targetReader TargetReader) {
+// podsOf returns the pods whose names contain toMatch in the control plane of the targeted shoot in the seed,
+// or in the kube-system namespace of the shoot if toTarget is shoot
+func podsOf(targetReader TargetReader, toMatch string, toTarget TargetKind) (Pods, error) {
 	target := targetReader.ReadTarget(pathTarget)
 
 	var namespace string
+	var err error
 	if len(target.Stack()) == 2 {
 		namespace = "garden"
 	} else if len(target.Stack()) == 3 {
-		namespace = getSeedNamespaceNameForShoot(target.Stack()[2].Name)
+		namespace, err = getSeedNamespaceNameForShoot(targetReader, target.Stack()[2].Name)
+		if err != nil {
+			return Pods{}, err
+		}
 	}
 
-	var err error
-	Client, err = clientToTarget("seed")
-	checkError(err)
+	client, _, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return Pods{}, err
+	}
 	if toTarget == TargetKindShoot {
 		namespace = "kube-system"
-		Client, err = clientToTarget(TargetKindShoot)
-		checkError(err)
-	}
-	pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-	checkError(err)
-	for _, pod := range pods.Items {
-		if strings.Contains(pod.Name, toMatch) {
-			err := ExecCmd(nil, "kubectl get pods "+pod.Name+" -o wide -n "+namespace, false, "KUBECONFIG="+KUBECONFIG)
-			checkError(err)
+		client, _, err = clusterOfCurrentTarget(targetReader, TargetKindShoot)
+		if err != nil {
+			return Pods{}, err
 		}
 	}
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return Pods{}, err
+	}
+	return matchPods(pods.Items, func(pod corev1.Pod) bool { return strings.Contains(pod.Name, toMatch) }), nil
+}
+
+// showPodGarden prints the pods in namespace of the garden cluster whose names contain podName
+func showPodGarden(targetReader TargetReader, ioStreams IOStreams, podName string, namespace string) error {
+	pods, err := podsOfGarden(targetReader, podName, namespace)
+	if err != nil {
+		return err
+	}
+	return PrintoutObject(pods, ioStreams.Out, showOutputFormat)
+}
+
+// showPod prints the pods whose names contain toMatch in the seed or shoot cluster of the targeted shoot
+func showPod(targetReader TargetReader, ioStreams IOStreams, toMatch string, toTarget TargetKind) error {
+	pods, err := podsOf(targetReader, toMatch, toTarget)
+	if err != nil {
+		return err
+	}
+	return PrintoutObject(pods, ioStreams.Out, showOutputFormat)
+}
+
+// showOperator shows the gardener apiserver and controller manager pods in the garden cluster
+func showOperator(targetReader TargetReader, ioStreams IOStreams) error {
+	pods, err := podsOfGarden(targetReader, "gardener-apiserver", "garden")
+	if err != nil {
+		return err
+	}
+	controllerManager, err := podsOfGarden(targetReader, "gardener-controller-manager", "garden")
+	if err != nil {
+		return err
+	}
+	pods.Pods = append(pods.Pods, controllerManager.Pods...)
+	return PrintoutObject(pods, ioStreams.Out, showOutputFormat)
 }
 
-// showCloudInfra shows the infra resources for the targeted shoot cluster
-func showCloudInfra(targetReader TargetReader, output string) {
+// showVpnSeed shows the kube-apiserver and prometheus pods of the targeted shoot, which run the vpn-seed container
+func showVpnSeed(targetReader TargetReader, ioStreams IOStreams) error {
+	pods, err := podsOf(targetReader, "kube-apiserver", TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	prometheus, err := podsOf(targetReader, "prometheus-0", TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	pods.Pods = append(pods.Pods, prometheus.Pods...)
+	return PrintoutObject(pods, ioStreams.Out, showOutputFormat)
+}
+
+// showGardenerDashboard shows the gardener dashboard pods and URLs and opens the first URL in the browser
+func showGardenerDashboard(targetReader TargetReader, ioStreams IOStreams) error {
+	pods, err := podsOfGarden(targetReader, "gardener-dashboard", "garden")
+	if err != nil {
+		return err
+	}
+	pathToKubeconfig, err := getKubeConfigOfClusterType(targetReader, TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	output, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+pathToKubeconfig, "get", "ingress", "gardener-dashboard-ingress", "-n", "garden")
+	if err != nil {
+		return err
+	}
+	list := strings.Split(output, " ")
+	url := "-"
+	for _, val := range list {
+		if strings.HasPrefix(val, "dashboard.") {
+			url = val
+		}
+	}
+	endpoint := Endpoint{Pods: pods.Pods}
+	seen := map[string]bool{}
+	for _, host := range strings.Split(url, ",") {
+		if !seen[host] {
+			seen[host] = true
+			endpoint.URLs = append(endpoint.URLs, "https://"+host)
+		}
+	}
+	if err := PrintoutObject(endpoint, ioStreams.Out, showOutputFormat); err != nil {
+		return err
+	}
+	return browser.OpenURL(endpoint.URLs[0])
+}
+
+// showCloudInfra shows the infra resources for the targeted shoot cluster, output is the output format of the
+// CLI of the infrastructure provider
+func showCloudInfra(targetReader TargetReader, ioStreams IOStreams, output string) error {
 	target := targetReader.ReadTarget(pathTarget)
 	shoot, err := FetchShootFromTarget(target)
-	checkError(err)
-	infraType := shoot.Spec.Provider.Type
+	if err != nil {
+		return err
+	}
+	shoottag, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 
-	switch infraType {
+	var resources InfraResources
+	switch shoot.Spec.Provider.Type {
 	case "aws":
-		showCloudInfraTypeAWS(targetReader, output)
+		resources, err = listInfraResources(targetReader, "aws", awsInfraCommands(shoottag, output))
 	case "azure":
-		showCloudInfraTypeAzure(targetReader, output)
+		resources, err = listAzureInfraResources(targetReader, shoottag, output)
 	case "gcp":
-		showCloudInfraTypeGCP(targetReader, output)
+		resources, err = listInfraResources(targetReader, "gcp", gcpInfraCommands(shoottag, output))
 	case "openstack":
-		showCloudInfraTypeOpenstack(targetReader, output)
+		resources, err = listInfraResources(targetReader, "openstack", openstackInfraCommands(shoottag, output))
 	case "alicloud":
-		showCloudInfraTypeAlicloud(targetReader)
+		resources, err = listInfraResources(targetReader, "aliyun", alicloudInfraCommands(shoottag))
 	default:
-		fmt.Println("infra type not found")
+		return errors.New("infra type not found")
 	}
+	if err != nil {
+		return err
+	}
+	return PrintoutObject(resources, ioStreams.Out, showOutputFormat)
 }
 
-// showCloudInfraTypeAWS shows the AWS infra resources for the targeted shoot cluster
-func showCloudInfraTypeAWS(targetReader TargetReader, output string) {
-
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
-
-	capturedOutput := execInfraOperator("aws", "ec2 describe-instances --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-volumes --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-vpcs --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-subnets --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-route-tables --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-security-groups --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-internet-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-nat-gateways --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aws", "ec2 describe-addresses --filter Name=tag:kubernetes.io/cluster/"+shoottag+",Values=1 --output "+output)
-	fmt.Println(capturedOutput)
-}
-
-// showCloudInfraTypeAzure shows the Azure infra resources for the targeted shoot cluster
-func showCloudInfraTypeAzure(targetReader TargetReader, output string) {
-
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
-
-	capturedOutput := execInfraOperator("az", "vm list -d -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "disk list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "network vnet list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	vnets := make([]string, 0)
-	vnets = findInfraResourcesMatch(`\"id\".*(virtualNetworks\/[a-z0-9-]*)\"`, capturedOutput, vnets)
-	if len(vnets) > 0 {
-		for _, vnet := range vnets {
-			s := strings.Split(vnet, "/")
-			vnetName := s[1]
-			capturedOutput = execInfraOperator("az", "network vnet subnet list -g "+shoottag+" --vnet-name "+vnetName+" --output "+output)
-			fmt.Println(capturedOutput)
+// listInfraResources runs the given commands with the CLI of provider and returns their output
+func listInfraResources(targetReader TargetReader, provider string, commands []string) (InfraResources, error) {
+	resources := InfraResources{Provider: provider}
+	for _, command := range commands {
+		output, err := execInfraOperator(targetReader, provider, command)
+		if err != nil {
+			return resources, err
 		}
+		resources.Resources = append(resources.Resources, InfraResource{Command: command, Output: output})
 	}
-
-	capturedOutput = execInfraOperator("az", "network route-table list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "network nsg list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "network lb list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "network nic list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("az", "network public-ip list -g "+shoottag+" --output "+output)
-	fmt.Println(capturedOutput)
+	return resources, nil
 }
 
-// showCloudInfraTypeGCP shows the GCP infra resources for the targeted shoot cluster
-func showCloudInfraTypeGCP(targetReader TargetReader, output string) {
-
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
-
-	capturedOutput := execInfraOperator("gcp", "compute instances list --filter=name~"+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute disks list --filter=name~"+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute networks list --filter=name="+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute networks subnets list --filter=name~"+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute routers list --filter=name~"+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute routes list --filter=network="+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("gcp", "compute firewall-rules list --filter=network="+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
+// awsInfraCommands returns the aws commands listing the infra resources of the shoot with technical id shoottag
+func awsInfraCommands(shoottag, output string) []string {
+	filter := " --filter Name=tag:kubernetes.io/cluster/" + shoottag + ",Values=1 --output " + output
+	return []string{
+		"ec2 describe-instances" + filter,
+		"ec2 describe-volumes" + filter,
+		"ec2 describe-vpcs" + filter,
+		"ec2 describe-subnets" + filter,
+		"ec2 describe-route-tables" + filter,
+		"ec2 describe-security-groups" + filter,
+		"ec2 describe-internet-gateways" + filter,
+		"ec2 describe-nat-gateways" + filter,
+		"ec2 describe-addresses" + filter,
+	}
 }
 
-// showCloudInfraTypeOpenstack shows the Openstack infra resources for the targeted shoot cluster
-func showCloudInfraTypeOpenstack(targetReader TargetReader, output string) {
-
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
-
-	capturedOutput := execInfraOperator("openstack", "server list --name "+shoottag+".* --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "volume list --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "network list --name "+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "subnet list --name "+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "router list --name "+shoottag+" --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "floating ip list --format "+output)
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("openstack", "security group list --format "+output)
-	fmt.Println(capturedOutput)
+// listAzureInfraResources lists the Azure infra resources of the shoot with technical id shoottag, the subnets
+// are listed per virtual network
+func listAzureInfraResources(targetReader TargetReader, shoottag, output string) (InfraResources, error) {
+	group := " -g " + shoottag + " --output " + output
+	resources, err := listInfraResources(targetReader, "az", []string{
+		"vm list -d" + group,
+		"disk list" + group,
+		"network vnet list" + group,
+	})
+	if err != nil {
+		return resources, err
+	}
+	var commands []string
+	vnets := findInfraResourcesMatch(`\"id\".*(virtualNetworks\/[a-z0-9-]*)\"`, resources.Resources[len(resources.Resources)-1].Output, nil)
+	for _, vnet := range vnets {
+		commands = append(commands, "network vnet subnet list -g "+shoottag+" --vnet-name "+strings.Split(vnet, "/")[1]+" --output "+output)
+	}
+	commands = append(commands,
+		"network route-table list"+group,
+		"network nsg list"+group,
+		"network lb list"+group,
+		"network nic list"+group,
+		"network public-ip list"+group,
+	)
+	more, err := listInfraResources(targetReader, "az", commands)
+	resources.Resources = append(resources.Resources, more.Resources...)
+	return resources, err
 }
 
-// showCloudInfraTypeAlicloud shows the Alicloud infra resources for the targeted shoot cluster
-func showCloudInfraTypeAlicloud(targetReader TargetReader) {
-
-	shoottag := GetFromTargetInfo(targetReader, "shootTechnicalID")
-
-	capturedOutput := execInfraOperator("aliyun", "ecs DescribeInstances --InstanceName "+shoottag+"*")
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeDisks")
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aliyun", "vpc DescribeVpcs --VpcName "+shoottag+"-vpc")
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeVSwitches")
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeVRouters")
-	fmt.Println(capturedOutput)
-
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeRouteTables")
-	fmt.Println(capturedOutput)
+// gcpInfraCommands returns the gcloud commands listing the infra resources of the shoot with technical id shoottag
+func gcpInfraCommands(shoottag, output string) []string {
+	format := " --format " + output
+	return []string{
+		"compute instances list --filter=name~" + shoottag + format,
+		"compute disks list --filter=name~" + shoottag + format,
+		"compute networks list --filter=name=" + shoottag + format,
+		"compute networks subnets list --filter=name~" + shoottag + format,
+		"compute routers list --filter=name~" + shoottag + format,
+		"compute routes list --filter=network=" + shoottag + format,
+		"compute firewall-rules list --filter=network=" + shoottag + format,
+	}
+}
 
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeEipAddresses")
-	fmt.Println(capturedOutput)
+// openstackInfraCommands returns the openstack commands listing the infra resources of the shoot with technical id shoottag
+func openstackInfraCommands(shoottag, output string) []string {
+	format := " --format " + output
+	return []string{
+		"server list --name " + shoottag + ".*" + format,
+		"volume list" + format,
+		"network list --name " + shoottag + format,
+		"subnet list --name " + shoottag + format,
+		"router list --name " + shoottag + format,
+		"floating ip list" + format,
+		"security group list" + format,
+	}
+}
 
-	capturedOutput = execInfraOperator("aliyun", "ecs DescribeSecurityGroups --SecurityGroupName "+shoottag+"-sg")
-	fmt.Println(capturedOutput)
+// alicloudInfraCommands returns the aliyun commands listing the infra resources of the shoot with technical id shoottag
+func alicloudInfraCommands(shoottag string) []string {
+	return []string{
+		"ecs DescribeInstances --InstanceName " + shoottag + "*",
+		"ecs DescribeDisks",
+		"vpc DescribeVpcs --VpcName " + shoottag + "-vpc",
+		"ecs DescribeVSwitches",
+		"ecs DescribeVRouters",
+		"ecs DescribeRouteTables",
+		"ecs DescribeEipAddresses",
+		"ecs DescribeSecurityGroups --SecurityGroupName " + shoottag + "-sg",
+	}
 }
 
 // showAPIServer shows the pod for the api-server running in the targeted seed cluster
-func showAPIServer(targetReader TargetReader) {
-	showPod("kube-apiserver", "seed", targetReader)
+func showAPIServer(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "kube-apiserver", TargetKindSeed)
 }
 
 // showScheduler shows the pod for the running scheduler in the targeted seed cluster
-func showScheduler(targetReader TargetReader) {
-	showPod("kube-scheduler", "seed", targetReader)
+func showScheduler(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "kube-scheduler", TargetKindSeed)
 }
 
 // showControllerManager shows the pod for the running controller-manager in the targeted seed cluster
-func showControllerManager(targetReader TargetReader) {
-	showPod("kube-controller-manager", "seed", targetReader)
+func showControllerManager(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "kube-controller-manager", TargetKindSeed)
 }
 
 // showEtcdOperator shows the pod for the running etcd-operator in the targeted garden cluster
-func showEtcdOperator() {
-	showPodGarden("etcd-operator", "kube-system")
+func showEtcdOperator(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPodGarden(targetReader, ioStreams, "etcd-operator", "kube-system")
 }
 
 // showEtcdMain shows the pod for the running etcd-main in the targeted seed cluster
-func showEtcdMain(targetReader TargetReader) {
-	showPod("etcd-main", "seed", targetReader)
+func showEtcdMain(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "etcd-main", TargetKindSeed)
 }
 
 // showEtcdEvents shows the pod for the running etcd-events in the targeted seed cluster
-func showEtcdEvents(targetReader TargetReader) {
-	showPod("etcd-events", "seed", targetReader)
+func showEtcdEvents(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "etcd-events", TargetKindSeed)
 }
 
 // showAddonManager shows the pod for the running addon-manager in the targeted seed cluster
-func showAddonManager(targetReader TargetReader) {
-	showPod("kube-addon-manager", "seed", targetReader)
-}
-
-// showVpnSeed shows the pod for the running vpn-seed in the targeted seed cluster
-func showVpnSeed(targetReader TargetReader) {
-	showPod("kube-apiserver", "seed", targetReader)
-	showPod("prometheus-0", "seed", targetReader)
+func showAddonManager(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "kube-addon-manager", TargetKindSeed)
 }
 
 // showVpnShoot shows the pod for the running vpn-shoot in the targeted shoot cluster
-func showVpnShoot(targetReader TargetReader) {
-	showPod("vpn-shoot", "shoot", targetReader)
+func showVpnShoot(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "vpn-shoot", TargetKindShoot)
 }
 
 // showPrometheus shows the prometheus pod in the targeted seed cluster
-func showPrometheus(targetReader TargetReader) {
-	username, password = getMonitoringCredentials()
-	showPod("prometheus", "seed", targetReader)
-	KUBECONFIG := getKubeConfigOfClusterType("seed")
-	url, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+KUBECONFIG, "get", "ingress", "prometheus", "-n", GetFromTargetInfo(targetReader, "shootTechnicalID"), "--no-headers", "-o", "custom-columns=:spec.rules[].host")
+func showPrometheus(targetReader TargetReader, ioStreams IOStreams) error {
+	username, _, err := getMonitoringCredentials(targetReader)
+	if err != nil {
+		return err
+	}
+	pods, err := podsOf(targetReader, "prometheus", TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	KUBECONFIG, err := getKubeConfigOfClusterType(targetReader, "seed")
+	if err != nil {
+		return err
+	}
+	shootTechnicalID, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
+	host, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+KUBECONFIG, "get", "ingress", "prometheus", "-n", shootTechnicalID, "--no-headers", "-o", "custom-columns=:spec.rules[].host")
 	if err != nil {
-		log.Fatalf("Cmd was unsuccessful")
+		return err
 	}
-	url = "https://" + username + ":" + password + "@" + url
-	fmt.Println("URL: " + url)
-	err = browser.OpenURL(url)
-	checkError(err)
+	url := "https://" + strings.TrimSpace(host)
+	if err := PrintoutObject(Endpoint{Pods: pods.Pods, URLs: []string{url}, Username: username}, ioStreams.Out, showOutputFormat); err != nil {
+		return err
+	}
+	return browser.OpenURL(url)
 }
 
 // showMachineControllerManager shows the prometheus pods in the targeted seed cluster
-func showMachineControllerManager(targetReader TargetReader) {
-	showPod("machine-controller-manager", "seed", targetReader)
+func showMachineControllerManager(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "machine-controller-manager", TargetKindSeed)
 }
 
 // showKubernetesDashboard shows the kubernetes dashboard for the targeted cluster
-func showKubernetesDashboard(targetReader TargetReader) {
+func showKubernetesDashboard(targetReader TargetReader, ioStreams IOStreams) error {
 	target := targetReader.ReadTarget(pathTarget)
-	gardenName := target.Stack()[0].Name
+	var kubeconfig string
+	var matched Pods
 	if len(target.Stack()) == 1 {
-		var err error
-		Client, err = clientToTarget("garden")
-		checkError(err)
-		pods, err := Client.CoreV1().Pods("kube-system").List(metav1.ListOptions{})
-		checkError(err)
-		for _, pod := range pods.Items {
-			if strings.Contains(pod.Name, "kubernetes-dashboard") {
-				err := ExecCmd(nil, "kubectl get pods "+pod.Name+" -o wide -n kube-system", false, "KUBECONFIG="+KUBECONFIG)
-				checkError(err)
-			}
+		client, err := target.K8SClientToKind(TargetKindGarden)
+		if err != nil {
+			return err
+		}
+		kubeconfig, err = target.KubeconfigPathToKind(TargetKindGarden)
+		if err != nil {
+			return err
+		}
+		pods, err := client.CoreV1().Pods("kube-system").List(metav1.ListOptions{})
+		if err != nil {
+			return err
 		}
+		matched = matchPods(pods.Items, func(pod corev1.Pod) bool { return strings.Contains(pod.Name, "kubernetes-dashboard") })
 	} else if len(target.Stack()) == 2 {
 		namespace := "kube-system"
-		if len(target.Stack()) == 2 && target.Stack()[1].Kind == "seed" {
-			KUBECONFIG = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Stack()[1].Name, "kubeconfig.yaml")
-		} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == "project" {
-			fmt.Println("Project targeted")
-			os.Exit(2)
+		if len(target.Stack()) == 2 && target.Stack()[1].Kind == "project" {
+			return NewNotTargetedError(TargetKindSeed)
 		}
-		config, err := clientcmd.BuildConfigFromFlags("", KUBECONFIG)
-		checkError(err)
-		Client, err := kubernetes.NewForConfig(config)
-		checkError(err)
-		pods, err := Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
-		checkError(err)
-		for _, pod := range pods.Items {
-			if strings.Contains(pod.Name, "kubernetes-dashboard") {
-				err := ExecCmd(nil, "kubectl get pods "+pod.Name+" -o wide -n "+namespace, false, "KUBECONFIG="+KUBECONFIG)
-				checkError(err)
-			}
+		client, err := target.K8SClientToKind(TargetKindSeed)
+		if err != nil {
+			return err
+		}
+		kubeconfig, err = target.KubeconfigPathToKind(TargetKindSeed)
+		if err != nil {
+			return err
+		}
+		pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return err
 		}
+		matched = matchPods(pods.Items, func(pod corev1.Pod) bool { return strings.Contains(pod.Name, "kubernetes-dashboard") })
 	} else if len(target.Stack()) == 3 {
-		showPod("kubernetes-dashboard", "shoot", targetReader)
+		var err error
+		matched, err = podsOf(targetReader, "kubernetes-dashboard", TargetKindShoot)
+		if err != nil {
+			return err
+		}
+		kubeconfig, err = target.KubeconfigPathToKind(TargetKindShoot)
+		if err != nil {
+			return err
+		}
 	} else if len(target.Stack()) == 0 {
-		fmt.Println("No target")
-		os.Exit(2)
+		return NewNotTargetedError(TargetKindGarden)
 	}
 	url := "http://127.0.0.1:8002/api/v1/namespaces/kube-system/services/https:kubernetes-dashboard:/proxy/"
+	if err := PrintoutObject(Endpoint{Pods: matched.Pods, URLs: []string{url}}, ioStreams.Out, showOutputFormat); err != nil {
+		return err
+	}
 	err := browser.OpenURL(url)
-	checkError(err)
-	err = ExecCmd(nil, "kubectl proxy -p 8002", false, "KUBECONFIG="+KUBECONFIG)
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	return ExecCmd(nil, "kubectl proxy -p 8002", false, "KUBECONFIG="+kubeconfig)
 }
 
 // showGrafana shows the grafana dashboard for the targeted cluster
-func showGrafana(targetReader TargetReader) {
-	username, password = getMonitoringCredentials()
-	showPod("grafana", "seed", targetReader)
-	output, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+KUBECONFIG, "get", "ingress", "grafana-operators", "-n", GetFromTargetInfo(targetReader, "shootTechnicalID"))
+func showGrafana(targetReader TargetReader, ioStreams IOStreams) error {
+	username, _, err := getMonitoringCredentials(targetReader)
+	if err != nil {
+		return err
+	}
+	pods, err := podsOf(targetReader, "grafana", TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	pathToKubeconfig, err := getKubeConfigOfClusterType(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	shootTechnicalID, err := GetFromTargetInfo(targetReader, "shootTechnicalID")
 	if err != nil {
-		log.Fatalf("Cmd was unsuccessful")
+		return err
+	}
+	output, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+pathToKubeconfig, "get", "ingress", "grafana-operators", "-n", shootTechnicalID)
+	if err != nil {
+		return err
 	}
 	list := strings.Split(output, " ")
 	url := "-"
@@ -521,59 +581,49 @@ func showGrafana(targetReader TargetReader) {
 			url = formattedURL[0]
 		}
 	}
-	url = "https://" + username + ":" + password + "@" + url
-	fmt.Println("URL: " + url)
-	err = browser.OpenURL(url)
-	checkError(err)
+	url = "https://" + url
+	if err := PrintoutObject(Endpoint{Pods: pods.Pods, URLs: []string{url}, Username: username}, ioStreams.Out, showOutputFormat); err != nil {
+		return err
+	}
+	return browser.OpenURL(url)
 }
 
 // showTerraform pods for specified name
-func showTerraform(name string) {
-	var err error
-	Client, err = clientToTarget("seed")
-	checkError(err)
-	pods, err := Client.CoreV1().Pods("").List(metav1.ListOptions{})
-	checkError(err)
-	output := ""
-	count := 0
-	for _, pod := range pods.Items {
-		if strings.Contains(pod.Name, name) && pod.Status.Phase == "Running" {
-			output, err = ExecCmdReturnOutput("kubectl", "--kubeconfig="+KUBECONFIG, "get", "pods", pod.Name, "-o", "wide", "-n", pod.Namespace)
-			if err != nil {
-				fmt.Println("Cmd was unsuccessful")
-				os.Exit(2)
-			}
-			if count != 0 {
-				fmt.Printf("%s\n", strings.Split(output, "\n")[1])
-			} else {
-				fmt.Printf("%s", output)
-				count++
-			}
-		}
+func showTerraform(targetReader TargetReader, ioStreams IOStreams, name string) error {
+	client, _, err := clusterOfCurrentTarget(targetReader, TargetKindSeed)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods("").List(metav1.ListOptions{})
+	if err != nil {
+		return err
 	}
+	return PrintoutObject(matchPods(pods.Items, func(pod corev1.Pod) bool {
+		return strings.Contains(pod.Name, name) && pod.Status.Phase == corev1.PodRunning
+	}), ioStreams.Out, showOutputFormat)
 }
 
 // showTf shows the currently running infra tf-pods
-func showTf() {
-	showTerraform(".tf-job")
+func showTf(targetReader TargetReader, ioStreams IOStreams) error {
+	return showTerraform(targetReader, ioStreams, ".tf-job")
 }
 
 // showInfra shows the currently running infra tf-pods
-func showInfra() {
-	showTerraform(".infra.tf-job")
+func showInfra(targetReader TargetReader, ioStreams IOStreams) error {
+	return showTerraform(targetReader, ioStreams, ".infra.tf-job")
 }
 
 // showDNS shows the currently running dns tf-pods
-func showDNS() {
-	showTerraform(".dns.tf-job")
+func showDNS(targetReader TargetReader, ioStreams IOStreams) error {
+	return showTerraform(targetReader, ioStreams, ".dns.tf-job")
 }
 
 // showIngress shows the currently running ingress tf-pods
-func showIngress() {
-	showTerraform(".ingress.tf-job")
+func showIngress(targetReader TargetReader, ioStreams IOStreams) error {
+	return showTerraform(targetReader, ioStreams, ".ingress.tf-job")
 }
 
 // showClusterAutoscaler shows the pod for the running cluster-autoscaler in the targeted seed cluster
-func showClusterAutoscaler(targetReader TargetReader) {
-	showPod("cluster-autoscaler", "seed", targetReader)
+func showClusterAutoscaler(targetReader TargetReader, ioStreams IOStreams) error {
+	return showPod(targetReader, ioStreams, "cluster-autoscaler", TargetKindSeed)
 }
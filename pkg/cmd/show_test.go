@@ -29,6 +29,7 @@ var _ = Describe("Show command", func() {
 		ctrl         *gomock.Controller
 		targetReader *mockcmd.MockTargetReader
 		target       *mockcmd.MockTargetInterface
+		configReader *mockcmd.MockConfigReader
 		command      *cobra.Command
 	)
 
@@ -36,6 +37,7 @@ var _ = Describe("Show command", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		targetReader = mockcmd.NewMockTargetReader(ctrl)
 		target = mockcmd.NewMockTargetInterface(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
 	})
 
 	AfterEach(func() {
@@ -46,7 +48,8 @@ var _ = Describe("Show command", func() {
 		It("should return error", func() {
 			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target).AnyTimes()
 			target.EXPECT().Stack().Return([]cmd.TargetMeta{}).AnyTimes()
-			command = cmd.NewShowCmd(targetReader)
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewShowCmd(targetReader, configReader, ioStreams)
 			command.SetArgs([]string{})
 			err := command.Execute()
 
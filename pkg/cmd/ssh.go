@@ -15,10 +15,7 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
 	"path/filepath"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -42,7 +39,7 @@ const (
 )
 
 // NewSSHCmd returns a new ssh command.
-func NewSSHCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
+func NewSSHCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "ssh",
 		Short:        "SSH to a node, .e.g. \"gardenctl ssh node_name\"",
@@ -50,17 +47,28 @@ func NewSSHCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if err := checkReadOnly(target, configReader, "ssh"); err != nil {
+				return err
 			}
 
 			shoot, err := FetchShootFromTarget(target)
-			checkError(err)
+			if err != nil {
+				return err
+			}
+			if err = enforceAccessRestrictions(target, shoot, configReader, ioStreams); err != nil {
+				return err
+			}
 
 			if len(args) == 0 && flagproviderid == "" {
 				return printNodeNames(targetReader, shoot.Name)
 			}
 
-			path := downloadTerraformFiles("infra", targetReader)
+			path, err := downloadTerraformFiles("infra", targetReader)
+			if err != nil {
+				return err
+			}
 			if path != "" {
 				path = filepath.Join(path, "terraform.tfstate")
 			}
@@ -80,53 +88,43 @@ func NewSSHCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
 				pathSSKeypair = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", seedName, shootName)
 			}
 
-			sshKeypairSecret := getSSHKeypair(shoot)
-			checkError(err)
-			err = ioutil.WriteFile(filepath.Join(pathSSKeypair, "key"), sshKeypairSecret.Data["id_rsa"], 0600)
-			checkError(err)
+			sshKeypairSecret, err := getSSHKeypair(targetReader, shoot)
+			if err != nil {
+				return err
+			}
+			pathKey, err := writeCacheFile(filepath.Join(pathSSKeypair, "key"), sshKeypairSecret.Data["id_rsa"])
+			if err != nil {
+				return err
+			}
+			pathSSKeypair = filepath.Dir(pathKey)
 			fmt.Println("Downloaded id_rsa key")
 
 			fmt.Println("Check Public IP")
-			myPublicIP := getPublicIP()
+			myPublicIP, err := getPublicIP()
+			if err != nil {
+				return err
+			}
 
+			nodeName := ""
+			if flagproviderid == "" {
+				nodeName = args[0]
+			}
 			sshPublicKey := sshKeypairSecret.Data["id_rsa.pub"]
 			infraType := shoot.Spec.Provider.Type
 			switch infraType {
 			case "aws":
-				if flagproviderid != "" {
-					sshToAWSNode(targetReader, "", path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				} else {
-					sshToAWSNode(targetReader, args[0], path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				}
+				return sshToAWSNode(targetReader, nodeName, path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
 			case "gcp":
-				if flagproviderid != "" {
-					sshToGCPNode(targetReader, "", path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				} else {
-					sshToGCPNode(targetReader, args[0], path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				}
+				return sshToGCPNode(targetReader, nodeName, path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
 			case "azure":
-				if flagproviderid != "" {
-					sshToAZNode(targetReader, "", path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				} else {
-					sshToAZNode(targetReader, args[0], path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				}
+				return sshToAZNode(targetReader, nodeName, path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
 			case "alicloud":
-				if flagproviderid != "" {
-					sshToAlicloudNode(targetReader, "", path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				} else {
-					sshToAlicloudNode(targetReader, args[0], path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				}
+				return sshToAlicloudNode(targetReader, nodeName, path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
 			case "openstack":
-				if flagproviderid != "" {
-					sshToOpenstackNode("", path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				} else {
-					sshToOpenstackNode(args[0], path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
-				}
+				return sshToOpenstackNode(targetReader, nodeName, path, user, pathSSKeypair, sshPublicKey, myPublicIP, flagproviderid)
 			default:
 				return fmt.Errorf("infrastructure type %q not found", infraType)
 			}
-
-			return nil
 		},
 	}
 
@@ -136,18 +134,28 @@ func NewSSHCmd(targetReader TargetReader, ioStreams IOStreams) *cobra.Command {
 }
 
 // getSSHKeypair downloads ssh keypair for a shoot cluster
-func getSSHKeypair(shoot *gardencorev1beta1.Shoot) *v1.Secret {
-	Client, err := clientToTarget("garden")
-	checkError(err)
-	secret, err := Client.CoreV1().Secrets(shoot.Namespace).Get(shoot.Name+".ssh-keypair", metav1.GetOptions{})
-	checkError(err)
-	return secret
+func getSSHKeypair(targetReader TargetReader, shoot *gardencorev1beta1.Shoot) (*v1.Secret, error) {
+	client, _, err := clusterOfCurrentTarget(targetReader, TargetKindGarden)
+	if err != nil {
+		return nil, err
+	}
+	return client.CoreV1().Secrets(shoot.Namespace).Get(shoot.Name+".ssh-keypair", metav1.GetOptions{})
 }
 
-// printNodeNames print all nodes in k8s cluster
+// printNodeNames print all nodes in k8s cluster, users which cannot read the machines get the nodes of the shoot
 func printNodeNames(targetReader TargetReader, shootName string) error {
+	role, err := getRole(targetReader)
+	if err != nil {
+		return err
+	}
+	if role == "user" {
+		return printShootNodeNames(targetReader)
+	}
+
 	machineList, err := getMachineList(targetReader, shootName)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println("Nodes:")
 	for _, machine := range machineList.Items {
@@ -170,28 +178,41 @@ echo "gardener ALL=(ALL) NOPASSWD:ALL" >/etc/sudoers.d/99-gardener-user
 	return []byte(userData)
 }
 
-func getMachineList(targetReader TargetReader, shootName string) (*v1alpha1.MachineList, error) {
-	if getRole(targetReader) == "user" {
-		var target Target
-		ReadTarget(pathTarget, &target)
-		clientset, err := target.K8SClientToKind("shoot")
-		checkError(err)
-		fmt.Printf("%s\n", "Nodes")
-		list, _ := clientset.CoreV1().Nodes().List(metav1.ListOptions{})
-		for _, node := range list.Items {
-			fmt.Printf("%s\n", node.Name)
-		}
-		os.Exit(0)
+// printShootNodeNames prints the nodes of the targeted shoot
+func printShootNodeNames(targetReader TargetReader) error {
+	target := targetReader.ReadTarget(pathTarget)
+	clientset, err := target.K8SClientToKind("shoot")
+	if err != nil {
+		return err
+	}
+	list, err := clientset.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return err
 	}
+	fmt.Printf("%s\n", "Nodes")
+	for _, node := range list.Items {
+		fmt.Printf("%s\n", node.Name)
+	}
+	return nil
+}
 
-	config, err := clientcmd.BuildConfigFromFlags("", getKubeConfigOfClusterType("seed"))
-	checkError(err)
+func getMachineList(targetReader TargetReader, shootName string) (*v1alpha1.MachineList, error) {
+	pathToKubeconfig, err := getKubeConfigOfClusterType(targetReader, "seed")
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", pathToKubeconfig)
+	if err != nil {
+		return nil, err
+	}
 	client, err := mcmv1alpha1.NewForConfig(config)
-	checkError(err)
-
-	shootNamespace := getSeedNamespaceNameForShoot(shootName)
-	machines, err := client.MachineV1alpha1().Machines(shootNamespace).List(metav1.ListOptions{})
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return machines, nil
+	shootNamespace, err := getSeedNamespaceNameForShoot(targetReader, shootName)
+	if err != nil {
+		return nil, err
+	}
+	return client.MachineV1alpha1().Machines(shootNamespace).List(metav1.ListOptions{})
 }
@@ -53,6 +53,8 @@ type AliyunInstanceAttribute struct {
 	BastionSSHUser           string
 	MyPublicIP               string
 	FlagProviderID           string
+
+	targetReader TargetReader
 }
 
 // AliyunInstanceTypeSpec stores all the critical information for choosing a instance type on Alicloud.
@@ -70,42 +72,53 @@ type AliyunInstanceTypeSpec struct {
 }
 
 // sshToAlicloudNode provides cmds to ssh to alicloud via a public ip and clean it up afterwards.
-func sshToAlicloudNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) {
+func sshToAlicloudNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) (err error) {
 	// Check if this is a cleanup command
 	if nodeName == "cleanup" {
-		cleanupAliyunBastionHost(targetReader)
-		return
+		return cleanupAliyunBastionHost(targetReader)
 	}
 
 	fmt.Println("(1/5) Configuring aliyun cli")
-	configureAliyunCLI()
-	var target Target
-	ReadTarget(pathTarget, &target)
+	if err := configureAliyunCLI(targetReader); err != nil {
+		return err
+	}
 	fmt.Println("Aliyun cli configured.")
 
-	a := &AliyunInstanceAttribute{}
+	a := &AliyunInstanceAttribute{targetReader: targetReader}
 	a.MyPublicIP = myPublicIP + "/32"
 	a.FlagProviderID = flagProviderID
 	fmt.Println("")
 	fmt.Println("(2/5) Fetching data from target shoot cluster")
-	a.fetchAttributes(targetReader, nodeName)
+	if err := a.fetchAttributes(targetReader, nodeName); err != nil {
+		return err
+	}
 	fmt.Println("Data fetched from target shoot cluster.")
 
 	fmt.Println("")
 	fmt.Println("(3/5) Setting up bastion host security group")
-	a.createBastionHostSecurityGroup()
+	if err := a.createBastionHostSecurityGroup(); err != nil {
+		return err
+	}
 	fmt.Println("Bastion host security group set up.")
 
-	defer checkIsDeletionWanted(targetReader, a.BastionInstanceID)
+	defer func() {
+		if cleanupErr := checkIsDeletionWanted(targetReader, a.BastionInstanceID); err == nil {
+			err = cleanupErr
+		}
+	}()
 
 	fmt.Println("")
 	fmt.Println("(4/5) Setting up bastion host")
-	a.createBastionHostInstance(sshPublicKey)
+	if err := a.createBastionHostInstance(sshPublicKey); err != nil {
+		return err
+	}
 	fmt.Println("Bastion host set up.")
 
 	fmt.Println("")
 	fmt.Println("(5/5) Starting bastion host")
-	a.startBastionHostInstance()
+	if err := a.startBastionHostInstance(); err != nil {
+		return err
+	}
 	fmt.Println("Bastion host started.")
 
 	key := filepath.Join(pathSSKeypair, "key")
@@ -114,38 +127,58 @@ func sshToAlicloudNode(targetReader TargetReader, nodeName, path, user, pathSSKe
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Println(err)
-	}
+	return toolError("ssh", cmd.Run())
 }
 
 // fetchAttributes gets all the needed attributes for creating bastion host and its security group with given <nodeName>.
-func (a *AliyunInstanceAttribute) fetchAttributes(targetReader TargetReader, nodeName string) {
-	a.ShootName = GetFromTargetInfo(targetReader, "shootTechnicalID")
+func (a *AliyunInstanceAttribute) fetchAttributes(targetReader TargetReader, nodeName string) error {
 	var err error
+	a.ShootName, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	if a.FlagProviderID != "" {
 		a.InstanceID = a.FlagProviderID
 	} else {
-		a.InstanceID, err = fetchAlicloudInstanceIDByNodeName(nodeName)
-		checkError(err)
+		a.InstanceID, err = fetchAlicloudInstanceIDByNodeName(targetReader, nodeName)
+		if err != nil {
+			return err
+		}
 	}
 
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstanceAttribute --InstanceId="+a.InstanceID)
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 
 	a.RegionID, err = decodedQuery.String("RegionId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	a.ZoneID, err = decodedQuery.String("ZoneId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	a.VpcID, err = decodedQuery.String("VpcAttributes", "VpcId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	a.VSwitchID, err = decodedQuery.String("VpcAttributes", "VSwitchId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	a.ImageID, err = decodedQuery.String("ImageId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	ips, err := decodedQuery.ArrayOfStrings("VpcAttributes", "PrivateIpAddress", "IpAddress")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	a.PrivateIP = ips[0]
 	a.BastionSecurityGroupName = a.ShootName + "-bsg"
 	a.BastionInstanceName = a.ShootName + "-bastion"
@@ -155,30 +188,44 @@ func (a *AliyunInstanceAttribute) fetchAttributes(targetReader TargetReader, nod
 	a.InternetMaxBandwidthIn = "10"
 	a.InternetMaxBandwidthOut = "100"
 	a.IoOptimized = "optimized"
-	a.InstanceType = a.getMinimumInstanceSpec()
+	a.InstanceType, err = a.getMinimumInstanceSpec()
+	return err
 }
 
 // createBastionHostSecurityGroup finds the or creates a security group for the bastion host.
-func (a *AliyunInstanceAttribute) createBastionHostSecurityGroup() {
+func (a *AliyunInstanceAttribute) createBastionHostSecurityGroup() error {
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeSecurityGroups --VpcId="+a.VpcID)
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 
 	securityGroupNames, err := decodedQuery.Array("SecurityGroups", "SecurityGroup")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	securityGroupExists := false
 	for _, iter := range securityGroupNames {
 		securityGroup := jsonq.NewQuery(iter)
 		name, err := securityGroup.String("SecurityGroupName")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if name == a.BastionSecurityGroupName {
 			securityGroupExists = true
 			a.BastionSecurityGroupID, err = securityGroup.String("SecurityGroupId")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			fmt.Println("Configuring bastion host security group rules...")
 			createSGCmdString := "aliyun ecs AuthorizeSecurityGroup --Policy Accept --NicType intranet --Priority 1 --SourceCidrIp " + a.MyPublicIP + " --PortRange 22/22 --IpProtocol tcp --SecurityGroupId=" + a.BastionSecurityGroupID
 			_, err = ExecCmdReturnOutput("bash", "-c", createSGCmdString)
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			time.Sleep(time.Second * 10)
 			fmt.Println("Bastion host security group rules configured.")
 		}
@@ -186,17 +233,31 @@ func (a *AliyunInstanceAttribute) createBastionHostSecurityGroup() {
 
 	if !securityGroupExists {
 		res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs CreateSecurityGroup --RegionId="+a.RegionID+" --VpcId="+a.VpcID+" --SecurityGroupName="+a.BastionSecurityGroupName)
-		checkError(err)
-		decodedQuery = decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
+		decodedQuery, err = decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
 		a.BastionSecurityGroupID, err = decodedQuery.String("SecurityGroupId")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		attemptCnt := 0
 		for attemptCnt < 60 {
 			res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeSecurityGroups --SecurityGroupIds=\"['"+a.BastionSecurityGroupID+"']\"")
-			checkError(err)
-			decodedQuery = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
+			decodedQuery, err = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
 			totalCount, err := decodedQuery.Int("TotalCount")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			if totalCount == 1 {
 				time.Sleep(time.Second * 30)
 				fmt.Println("Bastion host security group created.")
@@ -207,36 +268,53 @@ func (a *AliyunInstanceAttribute) createBastionHostSecurityGroup() {
 			attemptCnt++
 		}
 		if attemptCnt == 60 {
-			fmt.Println("Bastion host security group creation time out. Please try again.")
-			os.Exit(2)
+			return NewRemoteError(nil, "bastion host security group creation time out, please try again")
 		}
 		fmt.Println("Configuring bastion host security group rules...")
 		createSGCmdString := "aliyun ecs AuthorizeSecurityGroup --Policy Accept --NicType intranet --Priority 1 --SourceCidrIp " + a.MyPublicIP + " --PortRange 22/22 --IpProtocol tcp --SecurityGroupId=" + a.BastionSecurityGroupID
 		_, err = ExecCmdReturnOutput("bash", "-c", createSGCmdString)
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		time.Sleep(time.Second * 10)
 		fmt.Println("Bastion host security group rules configured.")
 	}
+	return nil
 }
 
 // createBastionHostInstance finds the or creates a bastion host instance.
-func (a *AliyunInstanceAttribute) createBastionHostInstance(sshPublicKey []byte) {
+func (a *AliyunInstanceAttribute) createBastionHostInstance(sshPublicKey []byte) error {
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstances --VpcId="+a.VpcID)
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 
 	instances, err := decodedQuery.Array("Instances", "Instance")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	bastionServerExists := false
 	for _, iter := range instances {
 		instance := jsonq.NewQuery(iter)
 		instanceName, err := instance.String("InstanceName")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if instanceName == a.BastionInstanceName {
 			bastionServerExists = true
 			a.BastionInstanceID, err = instance.String("InstanceId")
-			checkError(err)
-			if checkIsThereGardenerUser(a.BastionInstanceID) {
+			if err != nil {
+				return err
+			}
+			gardenerUser, err := checkIsThereGardenerUser(a.targetReader, a.BastionInstanceID)
+			if err != nil {
+				return err
+			}
+			if gardenerUser {
 				a.BastionSSHUser = "gardener"
 			} else {
 				// The bastion is created before `gardener-user` change
@@ -252,18 +330,32 @@ func (a *AliyunInstanceAttribute) createBastionHostInstance(sshPublicKey []byte)
 
 		arguments := "aliyun ecs CreateInstance --ImageId=" + a.ImageID + " --InstanceType=" + a.InstanceType + " --RegionId=" + a.RegionID + " --ZoneId=" + a.ZoneID + " --VSwitchId=" + a.VSwitchID + " --InstanceChargeType=" + a.InstanceChargeType + " --InternetChargeType=" + a.InternetChargeType + " --InternetMaxBandwidthIn=" + a.InternetMaxBandwidthIn + " --InternetMaxBandwidthOut=" + a.InternetMaxBandwidthOut + " --IoOptimized=" + a.IoOptimized + " --InstanceName=" + a.BastionInstanceName + " --SecurityGroupId=" + a.BastionSecurityGroupID + " --UserData=" + encodedUserData
 		res, err = ExecCmdReturnOutput("bash", "-c", arguments)
-		checkError(err)
-		decodedQuery = decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
+		decodedQuery, err = decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
 		a.BastionInstanceID, err = decodedQuery.String("InstanceId")
 		a.BastionSSHUser = "gardener"
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		attemptCnt := 0
 		for attemptCnt < 60 {
 			res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstances --InstanceIds=\"['"+a.BastionInstanceID+"']\"")
-			checkError(err)
-			decodedQuery = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
+			decodedQuery, err = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
 			totalCount, err := decodedQuery.Int("TotalCount")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			if totalCount == 1 {
 				time.Sleep(time.Second * 30)
 				fmt.Println("Bastion host created.")
@@ -274,21 +366,28 @@ func (a *AliyunInstanceAttribute) createBastionHostInstance(sshPublicKey []byte)
 			attemptCnt++
 		}
 		if attemptCnt == 60 {
-			fmt.Println("Bastion host creation time out. Please try again.")
-			os.Exit(2)
+			return NewRemoteError(nil, "bastion host creation time out, please try again")
 		}
 	}
+	return nil
 }
 
 // startBastionHostInstances starts the bastion host and allocates a public ip for it.
-func (a *AliyunInstanceAttribute) startBastionHostInstance() {
+func (a *AliyunInstanceAttribute) startBastionHostInstance() error {
 	attemptCnt := 0
 	for attemptCnt < 60 {
 		res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstanceAttribute --InstanceId="+a.BastionInstanceID)
-		checkError(err)
-		decodedQuery := decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
+		decodedQuery, err := decodeAndQueryFromJSONString(res)
+		if err != nil {
+			return err
+		}
 		status, err := decodedQuery.String("Status")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if status == "Running" {
 			time.Sleep(time.Second * 30)
 			fmt.Println("Bastion host started.")
@@ -296,7 +395,9 @@ func (a *AliyunInstanceAttribute) startBastionHostInstance() {
 		} else if status == "Stopped" {
 			fmt.Println("Starting bastion host...")
 			_, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs StartInstance --InstanceId="+a.BastionInstanceID)
-			checkError(err)
+			if err != nil {
+				return err
+			}
 		} else if status == "Starting" {
 			fmt.Println("Waiting for bastion host to start...")
 		} else if status == "Stopping" {
@@ -306,36 +407,56 @@ func (a *AliyunInstanceAttribute) startBastionHostInstance() {
 		attemptCnt++
 	}
 	if attemptCnt == 60 {
-		fmt.Println("Bastion host starting time out. Please try again.")
-		os.Exit(2)
+		return NewRemoteError(nil, "bastion host starting time out, please try again")
 	}
 	fmt.Println("Allocating bastion host IP address...")
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs AllocatePublicIpAddress --InstanceId="+a.BastionInstanceID)
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 	a.BastionIP, err = decodedQuery.String("IpAddress")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	time.Sleep(time.Second * 10)
 	fmt.Println("Bastion host IP address allocated.")
+	return nil
 }
 
 // deleteBastionHostInstance stops the bastion host instance and deletes it.
-func (a *AliyunInstanceAttribute) deleteBastionHostInstance() {
+func (a *AliyunInstanceAttribute) deleteBastionHostInstance() error {
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstances")
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 	instances, err := decodedQuery.Array("Instances", "Instance")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	for _, iter := range instances {
 		instance := jsonq.NewQuery(iter)
 		instanceName, err := instance.String("InstanceName")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if instanceName == a.BastionInstanceName {
 			a.BastionInstanceID, err = instance.String("InstanceId")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			a.VpcID, err = instance.String("VpcAttributes", "VpcId")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			break
 		}
 	}
@@ -345,17 +466,26 @@ func (a *AliyunInstanceAttribute) deleteBastionHostInstance() {
 		attemptCnt := 0
 		for attemptCnt < 60 {
 			res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstanceAttribute --InstanceId="+a.BastionInstanceID)
-			checkError(err)
-			decodedQuery = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
+			decodedQuery, err = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
 			status, err := decodedQuery.String("Status")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			if status == "Stopped" {
 				time.Sleep(time.Second * 30)
 				break
 			} else if status == "Running" {
 				fmt.Println("Stopping bastion server instance...")
 				_, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs StopInstance --InstanceId="+a.BastionInstanceID)
-				checkError(err)
+				if err != nil {
+					return err
+				}
 			} else if status == "Starting" {
 				fmt.Println("Bastion server instance is currently starting...")
 			} else if status == "Stopping" {
@@ -365,40 +495,48 @@ func (a *AliyunInstanceAttribute) deleteBastionHostInstance() {
 			attemptCnt++
 		}
 		if attemptCnt == 60 {
-			fmt.Println("Bastion server instance stopping timeout. Please try again.")
-			os.Exit(2)
+			return NewRemoteError(nil, "bastion server instance stopping timeout, please try again")
 		}
 		fmt.Println("Bastion server instance stopped.")
 
 		attemptCnt = 0
 		for attemptCnt < 60 {
 			res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstances --InstanceIds=\"['"+a.BastionInstanceID+"']\"")
-			checkError(err)
-			decodedQuery = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
+			decodedQuery, err = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
 			totalCount, err := decodedQuery.Int("TotalCount")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			if totalCount == 0 {
 				time.Sleep(time.Second * 30)
 				break
 			} else if totalCount == 1 {
 				fmt.Println("Deleting bastion server instance...")
 				_, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DeleteInstance --Force true --InstanceId="+a.BastionInstanceID)
-				checkError(err)
+				if err != nil {
+					return err
+				}
 			}
 			fmt.Println("Waiting for bastion server instance to be deleted...")
 			time.Sleep(time.Second * 2)
 			attemptCnt++
 		}
 		if attemptCnt == 60 {
-			fmt.Println("Bastion server instance deletion timeout. Please try again.")
-			os.Exit(2)
+			return NewRemoteError(nil, "bastion server instance deletion timeout, please try again")
 		}
 		fmt.Println("Bastion server instance deleted.")
 	}
+	return nil
 }
 
 // deleteBastionHostSecurityGroup deletes the security group of the bastion host.
-func (a *AliyunInstanceAttribute) deleteBastionHostSecurityGroup() {
+func (a *AliyunInstanceAttribute) deleteBastionHostSecurityGroup() error {
 	var (
 		res string
 		err error
@@ -408,18 +546,29 @@ func (a *AliyunInstanceAttribute) deleteBastionHostSecurityGroup() {
 	} else {
 		res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeSecurityGroups")
 	}
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return err
+	}
 	securityGroups, err := decodedQuery.Array("SecurityGroups", "SecurityGroup")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	for _, iter := range securityGroups {
 		securityGroup := jsonq.NewQuery(iter)
 		sgName, err := securityGroup.String("SecurityGroupName")
-		checkError(err)
+		if err != nil {
+			return err
+		}
 		if sgName == a.BastionSecurityGroupName {
 			a.BastionSecurityGroupID, err = securityGroup.String("SecurityGroupId")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			break
 		}
 	}
@@ -429,54 +578,71 @@ func (a *AliyunInstanceAttribute) deleteBastionHostSecurityGroup() {
 		attemptCnt := 0
 		for attemptCnt < 60 {
 			res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeSecurityGroups --SecurityGroupIds=\"['"+a.BastionSecurityGroupID+"']\"")
-			checkError(err)
-			decodedQuery = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
+			decodedQuery, err = decodeAndQueryFromJSONString(res)
+			if err != nil {
+				return err
+			}
 			totalCount, err := decodedQuery.Int("TotalCount")
-			checkError(err)
+			if err != nil {
+				return err
+			}
 			if totalCount == 0 {
 				time.Sleep(time.Second * 2)
 				break
 			} else if totalCount == 1 {
 				fmt.Println("Deleting bastion server security group...")
 				_, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DeleteSecurityGroup --SecurityGroupId="+a.BastionSecurityGroupID)
-				checkError(err)
+				if err != nil {
+					return err
+				}
 			}
 			fmt.Println("Waiting for bastion server security group to be deleted...")
 			time.Sleep(time.Second * 2)
 			attemptCnt++
 		}
 		if attemptCnt == 60 {
-			fmt.Println("Bastion server security group deletion time out. Please try again.")
-			os.Exit(2)
+			return NewRemoteError(nil, "bastion server security group deletion time out, please try again")
 		}
 		fmt.Println("SecurityGroup " + a.BastionSecurityGroupName + " deleted.")
 	}
+	return nil
 }
 
 // configureAliyunCLI sets up user credential configurations for aliyuncli.
-func configureAliyunCLI() {
+func configureAliyunCLI(targetReader TargetReader) error {
 	fmt.Println("Configuring aliyun cli...")
-	operate("aliyun", "")
+	_, err := operate(targetReader, "aliyun", "")
+	return err
 }
 
 // decodeAndQueryFromJSONString returns the decoded JsonQuery with the given json string.
-func decodeAndQueryFromJSONString(jsonString string) *jsonq.JsonQuery {
+func decodeAndQueryFromJSONString(jsonString string) (*jsonq.JsonQuery, error) {
 	data := map[string]interface{}{}
 	decoder := json.NewDecoder(strings.NewReader(jsonString))
-	err := decoder.Decode(&data)
-	checkError(err)
-	return jsonq.NewQuery(data)
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return jsonq.NewQuery(data), nil
 }
 
 // fetchAlicloudInstanceIDByNodeName returns the instance ID for node for given <nodeName>.
-func fetchAlicloudInstanceIDByNodeName(nodeName string) (string, error) {
-	typeName, err := getTargetType()
-	checkError(err)
-	Client, err = clientToTarget(typeName)
-	checkError(err)
-
-	nodes, err := Client.CoreV1().Nodes().List(metav1.ListOptions{})
-	checkError(err)
+func fetchAlicloudInstanceIDByNodeName(targetReader TargetReader, nodeName string) (string, error) {
+	typeName, err := getTargetType(targetReader)
+	if err != nil {
+		return "", err
+	}
+	client, _, err := clusterOfCurrentTarget(targetReader, typeName)
+	if err != nil {
+		return "", err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
 	for _, node := range nodes.Items {
 		if nodeName == node.Name {
 			if node.Spec.ProviderID != "" {
@@ -493,40 +659,68 @@ func fetchAlicloudInstanceIDByNodeName(nodeName string) (string, error) {
 }
 
 // checkIsThereGardenerUser checks if the bastion contains gardener user
-func checkIsThereGardenerUser(instanceID string) bool {
+func checkIsThereGardenerUser(targetReader TargetReader, instanceID string) (bool, error) {
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeUserData --InstanceId="+instanceID)
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return false, err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return false, err
+	}
 	userData, err := decodedQuery.String("UserData")
-	checkError(err)
+	if err != nil {
+		return false, err
+	}
 
-	return userData != ""
+	return userData != "", nil
 }
 
 // parseAliyunInstanceTypeSpec parses instance type spec with given interface <data>.
-func (spec *AliyunInstanceTypeSpec) parseAliyunInstanceTypeSpec(data interface{}) {
+func (spec *AliyunInstanceTypeSpec) parseAliyunInstanceTypeSpec(data interface{}) error {
 	instanceType := jsonq.NewQuery(data)
 	var err error
 	spec.CPUCoreCount, err = instanceType.Int("CpuCoreCount")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.InstanceTypeFamily, err = instanceType.String("InstanceTypeFamily")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.EniQuantity, err = instanceType.Int("EniQuantity")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.InstanceTypeID, err = instanceType.String("InstanceTypeId")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.InstanceFamilyLevel, err = instanceType.String("InstanceFamilyLevel")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.GPUSpec, err = instanceType.String("GPUSpec")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.MemorySize, err = instanceType.Float("MemorySize")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.GPUAmount, err = instanceType.Int("GPUAmount")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.LocalStorageCategory, err = instanceType.String("LocalStorageCategory")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	spec.EniPrivateIPAddressQuantity, err = instanceType.Int("EniPrivateIpAddressQuantity")
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
 // getInstanceTypeSpecScore calculates the score of an instance type, the smaller it is, the smaller the instance is.
@@ -562,56 +756,82 @@ func (spec *AliyunInstanceTypeSpec) compareAndGetMinimumInstanceTypeSpec(rival A
 }
 
 // getMinimumInstanceSpec returns the name of the instance type with minimum specifications (such as minimum cpu).
-func (a *AliyunInstanceAttribute) getMinimumInstanceSpec() string {
+func (a *AliyunInstanceAttribute) getMinimumInstanceSpec() (string, error) {
 	res, err := ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeInstanceTypes")
-	checkError(err)
-	decodedQuery := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return "", err
+	}
+	decodedQuery, err := decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return "", err
+	}
 
 	specs := map[string]AliyunInstanceTypeSpec{}
 	instanceTypes, err := decodedQuery.Array("InstanceTypes", "InstanceType")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	for _, iter := range instanceTypes {
 		spec := &AliyunInstanceTypeSpec{}
-		spec.parseAliyunInstanceTypeSpec(iter)
+		if err := spec.parseAliyunInstanceTypeSpec(iter); err != nil {
+			return "", err
+		}
 		specs[spec.InstanceTypeID] = *spec
 	}
 
 	res, err = ExecCmdReturnOutput("bash", "-c", "aliyun ecs DescribeAvailableResource --ZoneId="+a.ZoneID+" --DestinationResource=InstanceType --IoOptimized optimized")
-	checkError(err)
-	decodedQuery = decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return "", err
+	}
+	decodedQuery, err = decodeAndQueryFromJSONString(res)
+	if err != nil {
+		return "", err
+	}
 
 	zones, err := decodedQuery.Array("AvailableZones", "AvailableZone")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	decodedQuery = jsonq.NewQuery(zones[0])
 	availableResources, err := decodedQuery.Array("AvailableResources", "AvailableResource")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 	decodedQuery = jsonq.NewQuery(availableResources[0])
 	supportedResources, err := decodedQuery.Array("SupportedResources", "SupportedResource")
-	checkError(err)
+	if err != nil {
+		return "", err
+	}
 
 	currentMinimumSpec := &AliyunInstanceTypeSpec{}
 	for _, iter := range supportedResources {
 		resource := jsonq.NewQuery(iter)
 		name, err := resource.String("Value")
-		checkError(err)
+		if err != nil {
+			return "", err
+		}
 		currentMinimumSpec.compareAndGetMinimumInstanceTypeSpec(specs[name])
 	}
 
-	return currentMinimumSpec.InstanceTypeID
+	return currentMinimumSpec.InstanceTypeID, nil
 }
 
 //checkIsDeletionWanted checks if the user wants to delete the created IAS resources
-func checkIsDeletionWanted(targetReader TargetReader, bastionInstanceID string) {
+func checkIsDeletionWanted(targetReader TargetReader, bastionInstanceID string) error {
 	fmt.Println("Would you like to cleanup the created bastion? (y/n)")
 
 	reader := bufio.NewReader(os.Stdin)
 	char, _, err := reader.ReadRune()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	switch char {
 	case 'y', 'Y':
 		fmt.Println("Cleanup")
-		cleanupAliyunBastionHost(targetReader)
+		if err := cleanupAliyunBastionHost(targetReader); err != nil {
+			return err
+		}
 	case 'n', 'N':
 		fmt.Println("- Run following command to hibernate bastion host:")
 		fmt.Println("gardenctl aliyun ecs StopInstance -- --InstanceId=" + bastionInstanceID)
@@ -621,35 +841,47 @@ func checkIsDeletionWanted(targetReader TargetReader, bastionInstanceID string)
 	default:
 		fmt.Println("Unknown option")
 	}
+	return nil
 }
 
 // cleanupAlicloudBastionHost cleans up the bastion host for the targeted cluster.
-func cleanupAliyunBastionHost(targetReader TargetReader) {
+func cleanupAliyunBastionHost(targetReader TargetReader) error {
 	fmt.Println("Cleaning up bastion host configurations...")
 
 	fmt.Println("")
 	fmt.Println("(1/4) Configuring aliyun cli")
-	configureAliyunCLI()
+	if err := configureAliyunCLI(targetReader); err != nil {
+		return err
+	}
 	fmt.Println("Aliyun cli configured.")
 
-	a := &AliyunInstanceAttribute{}
+	a := &AliyunInstanceAttribute{targetReader: targetReader}
 
 	fmt.Println("")
 	fmt.Println("(2/4) Fetching data from target shoot cluster")
-	a.ShootName = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	var err error
+	a.ShootName, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	a.BastionInstanceName = a.ShootName + "-bastion"
 	a.BastionSecurityGroupName = a.ShootName + "-bsg"
 	fmt.Println("Data fetched from target shoot cluster.")
 
 	fmt.Println("")
 	fmt.Println("(3/4) Cleaning up bastion host instance")
-	a.deleteBastionHostInstance()
+	if err := a.deleteBastionHostInstance(); err != nil {
+		return err
+	}
 
 	// Clean up bastion security group
 	fmt.Println("")
 	fmt.Println("(4/4) Clean up bastion server security group")
-	a.deleteBastionHostSecurityGroup()
+	if err := a.deleteBastionHostSecurityGroup(); err != nil {
+		return err
+	}
 
 	fmt.Println("")
 	fmt.Println("Bastion server settings cleaned up.")
+	return nil
 }
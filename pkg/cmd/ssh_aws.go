@@ -44,11 +44,13 @@ type AwsInstanceAttribute struct {
 	SSHPublicKey             []byte
 	MyPublicIP               string
 	FlagProviderID           string
+
+	targetReader TargetReader
 }
 
 // sshToAWSNode provides cmds to ssh to aws via a bastions host and clean it up afterwards
-func sshToAWSNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) {
-	a := &AwsInstanceAttribute{}
+func sshToAWSNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) (err error) {
+	a := &AwsInstanceAttribute{targetReader: targetReader}
 	a.SSHPublicKey = sshPublicKey
 	a.MyPublicIP = myPublicIP
 	a.FlagProviderID = flagProviderID
@@ -57,25 +59,38 @@ func sshToAWSNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 
 	fmt.Println("(1/4) Fetching data from target shoot cluster")
 
-	a.fetchAwsAttributes(targetReader, nodeName, path)
+	if err := a.fetchAwsAttributes(targetReader, nodeName, path); err != nil {
+		return err
+	}
 
 	fmt.Println("Data fetched from target shoot cluster.")
 	fmt.Println("")
 
 	fmt.Println("(2/4) Setting up bastion host security group")
 
-	a.createBastionHostSecurityGroup()
+	if err := a.createBastionHostSecurityGroup(); err != nil {
+		return err
+	}
 	fmt.Println("")
 
-	defer a.cleanupAwsBastionHost()
+	defer func() {
+		if cleanupErr := a.cleanupAwsBastionHost(); err == nil {
+			err = cleanupErr
+		}
+	}()
 
 	fmt.Println("(3/4) Creating bastion host and node host security group")
-	a.createBastionHostInstance()
+	if err := a.createBastionHostInstance(); err != nil {
+		return err
+	}
 
-	a.createNodeHostSecurityGroup()
+	if err := a.createNodeHostSecurityGroup(); err != nil {
+		return err
+	}
 
-	err := CheckIPPortReachable(a.BastionIP, "22")
-	checkError(err)
+	if err := CheckIPPortReachable(a.BastionIP, "22"); err != nil {
+		return err
+	}
 
 	bastionNode := user + "@" + a.BastionIP
 	node := ""
@@ -109,77 +124,106 @@ func sshToAWSNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Println(err)
-	}
+	return toolError("ssh", cmd.Run())
 }
 
 // fetchAwsAttributes gets all the needed attributes for creating bastion host and its security group with given <nodeName>.
-func (a *AwsInstanceAttribute) fetchAwsAttributes(targetReader TargetReader, nodeName, path string) {
-	a.ShootName = GetFromTargetInfo(targetReader, "shootTechnicalID")
+func (a *AwsInstanceAttribute) fetchAwsAttributes(targetReader TargetReader, nodeName, path string) error {
+	var err error
+	a.ShootName, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	publicUtility := a.ShootName + "-public-utility-z0"
 	arguments := fmt.Sprintf("ec2 describe-subnets --filters Name=tag:Name,Values=" + publicUtility + " --query Subnets[*].SubnetId")
-	a.SubnetID = strings.Trim(operate("aws", arguments), "\n")
+	subnetID, err := operate(targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	a.SubnetID = strings.Trim(subnetID, "\n")
 
 	if a.FlagProviderID != "" {
 		arguments = fmt.Sprintf("ec2 describe-instances --filters Name=instance-id,Values=" + a.FlagProviderID + " --query Reservations[*].Instances[*].{VpcId:VpcId}")
 	} else {
 		arguments = fmt.Sprintf("ec2 describe-subnets --filters Name=subnet-id,Values=" + a.SubnetID + " --query Subnets[*].{VpcId:VpcId}")
 	}
-	a.VpcID = strings.Trim(operate("aws", arguments), "\n")
+	vpcID, err := operate(targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	a.VpcID = strings.Trim(vpcID, "\n")
 
 	a.SecurityGroupName = a.ShootName + "-nodes"
-	a.getSecurityGroupID()
+	if err := a.getSecurityGroupID(); err != nil {
+		return err
+	}
 	a.BastionInstanceName = a.ShootName + "-bastions"
 	a.BastionSecurityGroupName = a.ShootName + "-bsg"
 
 	if a.FlagProviderID != "" {
 		arguments = fmt.Sprintf("ec2 describe-instances --filters Name=instance-id,Values=" + a.FlagProviderID + " --query Reservations[*].Instances[*].{ImageId:ImageId}")
-		a.ImageID = strings.Trim(operate("aws", arguments), "\n")
 	} else {
 		arguments = fmt.Sprintf("ec2 describe-instances --filters Name=network-interface.private-dns-name,Values=" + nodeName + " --query Reservations[*].Instances[*].{ImageId:ImageId}")
-		imageIDList := strings.Split(strings.TrimSuffix(strings.Trim(operate("aws", arguments), "\n"), "\n"), "\n")
-		if len(imageIDList) < 1 {
-			fmt.Println("there's no Image in this instance")
-			os.Exit(1)
-		}
-		a.ImageID = imageIDList[0]
 	}
+	imageIDs, err := operate(targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	imageIDList := strings.Fields(imageIDs)
+	if len(imageIDList) < 1 {
+		return NewNotFoundError("there's no Image in this instance")
+	}
+	a.ImageID = imageIDList[0]
 
 	a.KeyName = a.ShootName + "-ssh-publickey"
 	a.UserData = getBastionUserData(a.SSHPublicKey)
+	return nil
 }
 
 // createBastionHostSecurityGroup finds the or creates a security group for the bastion host.
-func (a *AwsInstanceAttribute) createBastionHostSecurityGroup() {
+func (a *AwsInstanceAttribute) createBastionHostSecurityGroup() error {
 	// check if security group exists
-	a.getBastionSecurityGroupID()
+	if err := a.getBastionSecurityGroupID(); err != nil {
+		return err
+	}
 	if a.BastionSecurityGroupID != "" {
 		fmt.Println("Security Group exists " + a.BastionSecurityGroupID + " skipping creation.")
-		return
+		return nil
 	}
 
 	// create security group for bastion host
 	arguments := fmt.Sprintf("ec2 create-security-group --group-name %s --description ssh-access --vpc-id %s", a.BastionSecurityGroupName, a.VpcID)
-	a.BastionSecurityGroupID = operate("aws", arguments)
+	var err error
+	if a.BastionSecurityGroupID, err = operate(a.targetReader, "aws", arguments); err != nil {
+		return err
+	}
 
 	arguments = fmt.Sprintf("ec2 create-tags --resources %s  --tags Key=component,Value=gardenctl", a.BastionSecurityGroupID)
-	operate("aws", arguments)
+	if _, err := operate(a.targetReader, "aws", arguments); err != nil {
+		return err
+	}
 
 	if net.ParseIP(a.MyPublicIP).To4() != nil {
 		arguments = fmt.Sprintf("ec2 authorize-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr %s/32", a.BastionSecurityGroupID, a.MyPublicIP)
 	} else if net.ParseIP(a.MyPublicIP).To16() != nil {
 		arguments = fmt.Sprintf("ec2 authorize-security-group-ingress --group-id %s --ip-permissions IpProtocol=tcp,FromPort=22,ToPort=22,Ipv6Ranges=[{CidrIpv6=%s/64}]", a.BastionSecurityGroupID, a.MyPublicIP)
 	}
-	operate("aws", arguments)
+	if _, err := operate(a.targetReader, "aws", arguments); err != nil {
+		return err
+	}
 	fmt.Println("Bastion host security group set up.")
+	return nil
 }
 
-func (a *AwsInstanceAttribute) createNodeHostSecurityGroup() {
+func (a *AwsInstanceAttribute) createNodeHostSecurityGroup() error {
 	//check whether the SG rules exist before adding it
 	ingressRuleExist := false
 	arguments := fmt.Sprintf("ec2 describe-security-groups --group-ids %s --query SecurityGroups[].IpPermissions[][].{IP:IpRanges,Port:FromPort}", a.SecurityGroupID)
-	ingressRulesList := strings.Split(strings.TrimSuffix(strings.Trim(operate("aws", arguments), "\n"), "\n"), "\n")
+	ingressRules, err := operate(a.targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	ingressRulesList := strings.Split(strings.TrimSuffix(strings.Trim(ingressRules, "\n"), "\n"), "\n")
 	if len(ingressRulesList) > 0 {
 		for i := 0; i < len(ingressRulesList)-1; i++ {
 			if ingressRulesList[i] == "22" && strings.Contains(ingressRulesList[i+1], a.BastionPrivIP+"/32") {
@@ -191,54 +235,86 @@ func (a *AwsInstanceAttribute) createNodeHostSecurityGroup() {
 	//add ingress rule when not found existing ingress rule
 	if !ingressRuleExist {
 		arguments = fmt.Sprintf("ec2 authorize-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr %s/32", a.SecurityGroupID, a.BastionPrivIP)
-		operate("aws", arguments)
+		if _, err := operate(a.targetReader, "aws", arguments); err != nil {
+			return err
+		}
 		fmt.Println("Opened SSH Port on Node.")
 	} else {
 		fmt.Println("SSH Port already opened on Node")
 	}
+	return nil
 }
 
 // getSecurityGroupID extracts security group id of ec2 instance
-func (a *AwsInstanceAttribute) getSecurityGroupID() {
+func (a *AwsInstanceAttribute) getSecurityGroupID() error {
 	arguments := fmt.Sprintf("ec2 describe-security-groups --filters Name=vpc-id,Values=%s Name=group-name,Values=%s --query SecurityGroups[*].{ID:GroupId}", a.VpcID, a.SecurityGroupName)
-	a.SecurityGroupID = operate("aws", arguments)
+	var err error
+	a.SecurityGroupID, err = operate(a.targetReader, "aws", arguments)
+	return err
 }
 
 // getBastionSecurityGroupID extracts security group id for bastion security group
-func (a *AwsInstanceAttribute) getBastionSecurityGroupID() {
+func (a *AwsInstanceAttribute) getBastionSecurityGroupID() error {
 	arguments := fmt.Sprintf("ec2 describe-security-groups --filters Name=vpc-id,Values=%s Name=group-name,Values=%s --query SecurityGroups[*].{ID:GroupId}", a.VpcID, a.BastionSecurityGroupName)
-	a.BastionSecurityGroupID = operate("aws", arguments)
+	var err error
+	a.BastionSecurityGroupID, err = operate(a.targetReader, "aws", arguments)
+	return err
 }
 
 // getBastionHostInstance gets bastion host instance if it exists
-func (a *AwsInstanceAttribute) getBastionHostInstance() {
+func (a *AwsInstanceAttribute) getBastionHostInstance() error {
 	arguments := fmt.Sprintf("ec2 describe-instances --filter Name=vpc-id,Values=%s Name=tag:Name,Values=%s Name=instance-state-name,Values=running --query Reservations[*].Instances[].{Instance:InstanceId} --output text", a.VpcID, a.BastionInstanceName)
-	a.BastionInstanceID = operate("aws", arguments)
+	var err error
+	a.BastionInstanceID, err = operate(a.targetReader, "aws", arguments)
+	return err
+}
+
+// getBastionHostIPs gets the public and private IP of the bastion host instance
+func (a *AwsInstanceAttribute) getBastionHostIPs() error {
+	arguments := "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PublicIpAddress"
+	bastionIP, err := operate(a.targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	a.BastionIP = strings.Trim(bastionIP, "\n")
+
+	arguments = "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PrivateIpAddress"
+	bastionPrivIP, err := operate(a.targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	a.BastionPrivIP = strings.Trim(bastionPrivIP, "\n")
+	return nil
 }
 
 // createBastionHostInstance find or creates a bastion host instance.
-func (a *AwsInstanceAttribute) createBastionHostInstance() {
+func (a *AwsInstanceAttribute) createBastionHostInstance() error {
 
 	// check if bastion host exists
-	a.getBastionHostInstance()
+	if err := a.getBastionHostInstance(); err != nil {
+		return err
+	}
 	if a.BastionInstanceID != "" {
 		fmt.Println("Bastion Host exists, skipping creation.")
-		arguments := "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PrivateIpAddress"
-		a.BastionPrivIP = strings.Trim(operate("aws", arguments), "\n")
-		arguments = "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PublicIpAddress"
-		a.BastionIP = strings.Trim(operate("aws", arguments), "\n")
-		return
+		return a.getBastionHostIPs()
 	}
 
 	tmpfile, err := ioutil.TempFile(os.TempDir(), "gardener-user.sh")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	defer os.Remove(tmpfile.Name())
-	_, err = tmpfile.Write(a.UserData)
-	checkError(err)
+	if _, err = tmpfile.Write(a.UserData); err != nil {
+		return err
+	}
 
 	instanceType := ""
 	arguments := fmt.Sprintf("ec2 describe-instance-type-offerings --query %s", "InstanceTypeOfferings[].InstanceType")
-	words := strings.Fields(operate("aws", arguments))
+	instanceTypes, err := operate(a.targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	words := strings.Fields(instanceTypes)
 	for _, value := range words {
 		if value == "t2.nano" {
 			instanceType = "t2.nano"
@@ -255,8 +331,11 @@ func (a *AwsInstanceAttribute) createBastionHostInstance() {
 
 	// create bastion host
 	arguments = fmt.Sprintf("ec2 run-instances --image-id %s --count 1 --instance-type %s --key-name %s --security-group-ids %s --subnet-id %s --associate-public-ip-address --user-data file://%s --tag-specifications ResourceType=instance,Tags=[{Key=Name,Value=%s},{Key=component,Value=gardenctl}] ResourceType=volume,Tags=[{Key=component,Value=gardenctl}]", a.ImageID, instanceType, a.KeyName, a.BastionSecurityGroupID, a.SubnetID, tmpfile.Name(), a.BastionInstanceName)
-	words = strings.Fields(operate("aws", arguments))
-	for _, value := range words {
+	instances, err := operate(a.targetReader, "aws", arguments)
+	if err != nil {
+		return err
+	}
+	for _, value := range strings.Fields(instances) {
 		if strings.HasPrefix(value, "i-") {
 			a.BastionInstanceID = value
 		}
@@ -266,20 +345,27 @@ func (a *AwsInstanceAttribute) createBastionHostInstance() {
 
 	// waiting instance running
 	arguments = "ec2 wait instance-running --instance-ids " + a.BastionInstanceID
-	operate("aws", arguments)
+	if _, err := operate(a.targetReader, "aws", arguments); err != nil {
+		return err
+	}
 	fmt.Println("Bastion host instance running.")
 
-	// fetch BastionInstanceID
-	arguments = "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PublicIpAddress"
-	a.BastionIP = strings.Trim(operate("aws", arguments), "\n")
-
-	// get bastion private IP
-	arguments = "ec2 describe-instances --instance-id " + a.BastionInstanceID + " --query Reservations[*].Instances[*].PrivateIpAddress"
-	a.BastionPrivIP = strings.Trim(operate("aws", arguments), "\n")
+	return a.getBastionHostIPs()
 }
 
-// cleanupAwsBastionHost cleans up the bastion host for the targeted cluster.
-func (a *AwsInstanceAttribute) cleanupAwsBastionHost() {
+// cleanupAwsBastionHost cleans up the bastion host for the targeted cluster. It continues on errors
+// to clean up as much as possible and returns the first one.
+func (a *AwsInstanceAttribute) cleanupAwsBastionHost() error {
+	var errs []error
+	run := func(arguments string) string {
+		out, err := operate(a.targetReader, "aws", arguments)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			errs = append(errs, err)
+		}
+		return out
+	}
+
 	fmt.Println("(4/4) Cleanup")
 	fmt.Println("Cleaning up bastion host configurations...")
 	fmt.Println("")
@@ -288,22 +374,30 @@ func (a *AwsInstanceAttribute) cleanupAwsBastionHost() {
 
 	// clean up bastion host instance
 	fmt.Println("  (1/3) Cleaning up bastion host instance")
-	arguments := fmt.Sprintf("ec2 terminate-instances --instance-ids %s", a.BastionInstanceID)
-	fmt.Println(operate("aws", arguments))
+	if a.BastionInstanceID != "" {
+		arguments := fmt.Sprintf("ec2 terminate-instances --instance-ids %s", a.BastionInstanceID)
+		fmt.Println(run(arguments))
+	}
 
 	// remove shh rule from ec2 instance
 	fmt.Println("  (2/3) Close SSH Port on Node.")
-	arguments = fmt.Sprintf("ec2 revoke-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr %s/32", a.SecurityGroupID, a.BastionPrivIP)
-	fmt.Println("  Closed SSH Port on Node.")
-	fmt.Println(operate("aws", arguments))
+	if a.BastionPrivIP != "" {
+		arguments := fmt.Sprintf("ec2 revoke-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr %s/32", a.SecurityGroupID, a.BastionPrivIP)
+		fmt.Println("  Closed SSH Port on Node.")
+		fmt.Println(run(arguments))
+	}
 
 	// clean up bastion security group
 	fmt.Println("  (3/3) Clean up bastion host security group")
 	fmt.Println("")
-	arguments = "ec2 wait instance-terminated --instance-ids " + a.BastionInstanceID
-	operate("aws", arguments)
-	arguments = fmt.Sprintf("ec2 delete-security-group --group-id %s", a.BastionSecurityGroupID)
-	operate("aws", arguments)
+	if a.BastionInstanceID != "" {
+		run("ec2 wait instance-terminated --instance-ids " + a.BastionInstanceID)
+	}
+	run(fmt.Sprintf("ec2 delete-security-group --group-id %s", a.BastionSecurityGroupID))
 	fmt.Println("")
+	if len(errs) > 0 {
+		return fmt.Errorf("bastion host configurations could not be cleaned up completely: %w", errs[0])
+	}
 	fmt.Println("Bastion host configurations successfully cleaned up.")
+	return nil
 }
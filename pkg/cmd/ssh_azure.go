@@ -33,20 +33,25 @@ type AzureInstanceAttribute struct {
 	SecurityGroupName  string
 	SkuType            string
 	MyPublicIP         string
+
+	targetReader TargetReader
 }
 
 // sshToAZNode provides cmds to ssh to az via a node name and clean it up afterwards
-func sshToAZNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) {
-	a := &AzureInstanceAttribute{}
+func sshToAZNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) (err error) {
+	a := &AzureInstanceAttribute{targetReader: targetReader}
 	a.MyPublicIP = myPublicIP
 
 	fmt.Println("")
 	fmt.Println("(1/4) Fetching data from target shoot cluster")
 
 	if flagProviderID != "" {
-		a.fetchAzureAttributes(targetReader, flagProviderID, path)
+		err = a.fetchAzureAttributes(targetReader, flagProviderID, path)
 	} else {
-		a.fetchAzureAttributes(targetReader, nodeName, path)
+		err = a.fetchAzureAttributes(targetReader, nodeName, path)
+	}
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("Data fetched from target shoot cluster.")
@@ -55,19 +60,29 @@ func sshToAZNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 	fmt.Println("(2/4) Configuring Azure")
 
 	// add nsg rule
-	a.addNsgRule()
+	if err := a.addNsgRule(); err != nil {
+		return err
+	}
 	fmt.Println("")
 
-	defer a.cleanupAzure()
+	defer func() {
+		if cleanupErr := a.cleanupAzure(); err == nil {
+			err = cleanupErr
+		}
+	}()
 
 	// create public ip
-	a.createPublicIP()
+	if err := a.createPublicIP(); err != nil {
+		return err
+	}
 	fmt.Println("Waiting 5 s until public ip is available.")
 	fmt.Println("")
 	time.Sleep(5 * time.Second)
 
 	// update nic ip-config
-	a.configureNic()
+	if err := a.configureNic(); err != nil {
+		return err
+	}
 
 	node := user + "@" + a.PublicIP
 	fmt.Println("Waiting 30 seconds until ports are open.")
@@ -93,14 +108,16 @@ func sshToAZNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Println(err)
-	}
+	return toolError("ssh", cmd.Run())
 }
 
 // fetchAttributes gets all the needed attributes for creating bastion host and its security group with given <nodeName>.
-func (a *AzureInstanceAttribute) fetchAzureAttributes(targetReader TargetReader, nodeName, path string) {
-	a.ShootName = GetFromTargetInfo(targetReader, "shootTechnicalID")
+func (a *AzureInstanceAttribute) fetchAzureAttributes(targetReader TargetReader, nodeName, path string) error {
+	var err error
+	a.ShootName, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	a.NamePublicIP = "sshIP"
 
 	a.RescourceGroupName = a.ShootName
@@ -108,62 +125,84 @@ func (a *AzureInstanceAttribute) fetchAzureAttributes(targetReader TargetReader,
 	a.NicName = nodeName + "-nic"
 
 	arguments := fmt.Sprintf(" network lb list -g %s  --query [].sku.name -o tsv", a.RescourceGroupName)
-	a.SkuType = operate("az", arguments)
+	if a.SkuType, err = operate(targetReader, "az", arguments); err != nil {
+		return err
+	}
 	fmt.Println(a.SkuType)
+	return nil
 }
 
 // addNsgRule creates a nsg rule to open the ssh port
-func (a *AzureInstanceAttribute) addNsgRule() {
+func (a *AzureInstanceAttribute) addNsgRule() error {
 	fmt.Println("Opened SSH Port.")
 	if net.ParseIP(a.MyPublicIP).To4() != nil {
 		arguments := fmt.Sprintf(" network nsg rule create --resource-group %s  --nsg-name %s --name ssh --protocol Tcp --priority 1000 --source-address-prefixes %s/32 --destination-port-range 22", a.RescourceGroupName, a.SecurityGroupName, a.MyPublicIP)
-		operate("az", arguments)
+		if _, err := operate(a.targetReader, "az", arguments); err != nil {
+			return err
+		}
 	} else {
 		fmt.Println("IPv6 is currently not fully supported by gardenctl: " + a.MyPublicIP)
 	}
-
+	return nil
 }
 
 // createPublicIP creates the public ip for nic
-func (a *AzureInstanceAttribute) createPublicIP() {
+func (a *AzureInstanceAttribute) createPublicIP() error {
 	fmt.Println("Create public ip")
 	arguments := fmt.Sprintf(" network public-ip create -g %s -n %s --sku %s --allocation-method static --tags component=gardenctl", a.RescourceGroupName, a.NamePublicIP, a.SkuType)
-	operate("az", arguments)
+	if _, err := operate(a.targetReader, "az", arguments); err != nil {
+		return err
+	}
 	arguments = fmt.Sprintf(" network public-ip list -g %s --query [?tags.component=='gardenctl'].ipAddress --output tsv", a.RescourceGroupName)
-	a.PublicIP = operate("az", arguments)
+	var err error
+	if a.PublicIP, err = operate(a.targetReader, "az", arguments); err != nil {
+		return err
+	}
 	fmt.Println(a.PublicIP)
+	return nil
 }
 
 // configureNic attaches a public ip to the nic
-func (a *AzureInstanceAttribute) configureNic() {
+func (a *AzureInstanceAttribute) configureNic() error {
 	fmt.Println("Add public ip to nic")
 	fmt.Println("")
 	arguments := fmt.Sprintf(" network nic ip-config update -g %s --nic-name %s --public-ip-address %s -n %s", a.RescourceGroupName, a.NicName, a.NamePublicIP, a.NicName)
-	operate("az", arguments)
+	_, err := operate(a.targetReader, "az", arguments)
+	return err
 }
 
-// cleanupAzure cleans up all created azure resources required for ssh connection
-func (a *AzureInstanceAttribute) cleanupAzure() {
+// cleanupAzure cleans up all created azure resources required for ssh connection. It continues on errors
+// to clean up as much as possible and returns the first one.
+func (a *AzureInstanceAttribute) cleanupAzure() error {
+	var errs []error
+	run := func(arguments string) {
+		if _, err := operate(a.targetReader, "az", arguments); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			errs = append(errs, err)
+		}
+	}
+
 	fmt.Println("")
 	fmt.Println("(4/4) Cleanup")
 
 	// remove ssh rule
 	fmt.Println("")
 	fmt.Println("  (1/3) Remove SSH rule")
-	arguments := fmt.Sprintf(" network nsg rule delete --resource-group %s  --nsg-name %s --name ssh", a.RescourceGroupName, a.SecurityGroupName)
-	operate("az", arguments)
+	run(fmt.Sprintf(" network nsg rule delete --resource-group %s  --nsg-name %s --name ssh", a.RescourceGroupName, a.SecurityGroupName))
 
 	// remove public ip address from nic
 	fmt.Println("")
 	fmt.Println("  (2/3) Remove public ip from nic")
-	arguments = fmt.Sprintf(" network nic ip-config update -g %s --nic-name %s --public-ip-address %s -n %s --remove publicIPAddress", a.RescourceGroupName, a.NicName, a.NamePublicIP, a.NicName)
-	operate("az", arguments)
+	run(fmt.Sprintf(" network nic ip-config update -g %s --nic-name %s --public-ip-address %s -n %s --remove publicIPAddress", a.RescourceGroupName, a.NicName, a.NamePublicIP, a.NicName))
 
 	// delete ip
 	fmt.Println("")
 	fmt.Println("  (3/3) Delete public ip")
-	arguments = fmt.Sprintf(" network public-ip delete -g %s -n %s", a.RescourceGroupName, a.NamePublicIP)
-	operate("az", arguments)
+	run(fmt.Sprintf(" network public-ip delete -g %s -n %s", a.RescourceGroupName, a.NamePublicIP))
 	fmt.Println("")
+	if len(errs) > 0 {
+		return fmt.Errorf("configuration could not be cleaned up completely: %w", errs[0])
+	}
 	fmt.Println("Configuration successfully cleaned up.")
+	return nil
 }
@@ -37,11 +37,13 @@ type GCPInstanceAttribute struct {
 	UserData         []byte
 	SSHPublicKey     []byte
 	MyPublicIP       string
+
+	targetReader TargetReader
 }
 
 // sshToGCPNode provides cmds to ssh to gcp via a public ip and clean it up afterwards
-func sshToGCPNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) {
-	g := &GCPInstanceAttribute{}
+func sshToGCPNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) (err error) {
+	g := &GCPInstanceAttribute{targetReader: targetReader}
 	g.SSHPublicKey = sshPublicKey
 	g.MyPublicIP = myPublicIP
 	fmt.Println("")
@@ -49,21 +51,32 @@ func sshToGCPNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 	fmt.Println("(1/4) Fetching data from target shoot cluster")
 
 	if flagProviderID != "" {
-		g.fetchGCPAttributes(targetReader, flagProviderID, path)
+		err = g.fetchGCPAttributes(targetReader, flagProviderID, path)
 	} else {
-		g.fetchGCPAttributes(targetReader, nodeName, path)
+		err = g.fetchGCPAttributes(targetReader, nodeName, path)
+	}
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("Data fetched from target shoot cluster.")
 	fmt.Println("")
 
 	fmt.Println("(2/4) Setting up bastion host firewall rule")
-	g.createBastionHostFirewallRule()
+	if err := g.createBastionHostFirewallRule(); err != nil {
+		return err
+	}
 
-	defer g.cleanupGcpBastionHost()
+	defer func() {
+		if cleanupErr := g.cleanupGcpBastionHost(); err == nil {
+			err = cleanupErr
+		}
+	}()
 
 	fmt.Println("(3/4) Creating bastion host")
-	g.createBastionHostInstance()
+	if err := g.createBastionHostInstance(); err != nil {
+		return err
+	}
 
 	bastionNode := user + "@" + g.BastionIP
 	node := ""
@@ -98,86 +111,115 @@ func sshToGCPNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Println(err)
-	}
+	return toolError("ssh", cmd.Run())
 }
 
 // fetchAwsAttributes gets all the needed attributes for creating bastion host and its security group with given <nodeName> by using gcp cli for non-operator user
-func (g *GCPInstanceAttribute) fetchGCPAttributes(targetReader TargetReader, nodeName, path string) {
-	g.ShootName = GetFromTargetInfo(targetReader, "shootTechnicalID")
+func (g *GCPInstanceAttribute) fetchGCPAttributes(targetReader TargetReader, nodeName, path string) error {
+	var err error
+	g.ShootName, err = GetFromTargetInfo(targetReader, "shootTechnicalID")
+	if err != nil {
+		return err
+	}
 	g.BastionHostName = g.ShootName + "-bastions"
 	g.FirewallRuleName = g.ShootName + "-allow-ssh-access"
 	g.Subnetwork = g.ShootName + "-nodes"
 
 	arguments := ("compute instances list --filter=" + nodeName + " --format=value(zone)")
-	g.Zone = operate("gcp", arguments)
+	if g.Zone, err = operate(targetReader, "gcp", arguments); err != nil {
+		return err
+	}
 
 	arguments = fmt.Sprintf("compute instances describe %s --zone %s --format=value(networkInterfaces.network.scope(networks))", nodeName, g.Zone)
-	g.VpcName = strings.Trim(strings.Trim(operate("gcp", arguments), "\n"), "']")
+	vpcName, err := operate(targetReader, "gcp", arguments)
+	if err != nil {
+		return err
+	}
+	g.VpcName = strings.Trim(strings.Trim(vpcName, "\n"), "']")
 	g.UserData = getBastionUserData(g.SSHPublicKey)
+	return nil
 }
 
 // createBastionHostFirewallRule finds the or creates a security group for the bastion host.
-func (g *GCPInstanceAttribute) createBastionHostFirewallRule() {
+func (g *GCPInstanceAttribute) createBastionHostFirewallRule() error {
 	fmt.Println("Add ssh rule")
 	if net.ParseIP(g.MyPublicIP).To4() != nil {
 		arguments := fmt.Sprintf("compute firewall-rules create %s --network %s --allow tcp:22 --source-ranges=%s/32", g.FirewallRuleName, g.ShootName, g.MyPublicIP)
-		fmt.Println(operate("gcp", arguments))
+		out, err := operate(g.targetReader, "gcp", arguments)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
 	} else {
 		fmt.Println("IPv6 is currently not fully supported by gardenctl: " + g.MyPublicIP)
 	}
-
+	return nil
 }
 
 // createBastionHostInstance finds or creates a bastion host instance.
-func (g *GCPInstanceAttribute) createBastionHostInstance() {
+func (g *GCPInstanceAttribute) createBastionHostInstance() error {
 	fmt.Println("Create bastion host")
 	tmpfile, err := ioutil.TempFile(os.TempDir(), "gardener-user.sh")
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	defer os.Remove(tmpfile.Name())
-	_, err = tmpfile.Write(g.UserData)
-	checkError(err)
+	if _, err = tmpfile.Write(g.UserData); err != nil {
+		return err
+	}
 	arguments := fmt.Sprintf("compute instances create %s --network %s --subnet %s --zone %s --metadata-from-file startup-script=%s --labels component=gardenctl", g.BastionHostName, g.VpcName, g.Subnetwork, g.Zone, tmpfile.Name())
-	fmt.Println(operate("gcp", arguments))
+	out, err := operate(g.targetReader, "gcp", arguments)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
 	arguments = fmt.Sprintf("compute disks add-labels %s --labels component=gardenctl --zone=%s", g.BastionHostName, g.Zone)
-	operate("gcp", arguments)
+	if _, err := operate(g.targetReader, "gcp", arguments); err != nil {
+		return err
+	}
 
-	// check if bastion host is up and running, timeout after 3 minutes
-	attemptCnt := 0
-	for attemptCnt < 60 {
+	// check if bastion host is up and running, timeout after 2 minutes
+	for attemptCnt := 0; attemptCnt < 60; attemptCnt++ {
 		arguments = fmt.Sprintf("compute instances describe %s --zone %s --flatten=[status]", g.BastionHostName, g.Zone)
-
-		capturedOutput := strings.Trim(operate("gcp", arguments), "-\n ")
-		checkError(err)
+		status, err := operate(g.targetReader, "gcp", arguments)
+		if err != nil {
+			return err
+		}
+		capturedOutput := strings.Trim(status, "-\n ")
 		fmt.Println("Instance State: " + capturedOutput)
 		if strings.Trim(capturedOutput, "\n") == "RUNNING" {
 			arguments := fmt.Sprintf("compute instances describe %s --zone %s --flatten=networkInterfaces[0].accessConfigs[0].natIP", g.BastionHostName, g.Zone)
-			capturedOutput = strings.Trim(operate("gcp", arguments), "-\n ")
-			words := strings.Fields(capturedOutput)
-			checkError(err)
-			ip := ""
-			for _, value := range words {
+			natIP, err := operate(g.targetReader, "gcp", arguments)
+			if err != nil {
+				return err
+			}
+			for _, value := range strings.Fields(strings.Trim(natIP, "-\n ")) {
 				if isIPv4(value) && !strings.HasPrefix(value, "10.") {
-					ip = value
+					g.BastionIP = value
 					break
 				}
 			}
-			g.BastionIP = ip
-			return
+			return nil
 		}
 		time.Sleep(time.Second * 2)
-		attemptCnt++
-	}
-	if attemptCnt == 90 {
-		fmt.Println("Bastion server instance timeout. Please try again.")
-		os.Exit(2)
 	}
-
+	return NewRemoteError(nil, "bastion server instance timeout, please try again")
 }
 
-// cleanupGcpBastionHost cleans up the bastion host for the targeted cluster.
-func (g *GCPInstanceAttribute) cleanupGcpBastionHost() {
+// cleanupGcpBastionHost cleans up the bastion host for the targeted cluster. It continues on errors
+// to clean up as much as possible and returns the first one.
+func (g *GCPInstanceAttribute) cleanupGcpBastionHost() error {
+	var errs []error
+	run := func(arguments string) {
+		out, err := operate(g.targetReader, "gcp", arguments)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			errs = append(errs, err)
+			return
+		}
+		fmt.Println(out)
+	}
+
 	fmt.Println("(4/4) Cleanup")
 	fmt.Println("Cleaning up bastion host configurations...")
 	fmt.Println("")
@@ -186,13 +228,15 @@ func (g *GCPInstanceAttribute) cleanupGcpBastionHost() {
 
 	// clean up bastion host instance
 	fmt.Println("  (1/2) Cleaning up bastion host instance")
-	arguments := fmt.Sprintf(" --quiet compute instances delete %s --zone %s", g.BastionHostName, g.Zone)
-	fmt.Println(operate("gcp", arguments))
+	run(fmt.Sprintf(" --quiet compute instances delete %s --zone %s", g.BastionHostName, g.Zone))
 
 	// remove shh port from firewall rule
 	fmt.Println("  (2/2) Close SSH Port on Node.")
 	fmt.Println("Close SSH Port on Node.")
-	arguments = fmt.Sprintf("compute firewall-rules delete %s --quiet", g.FirewallRuleName)
-	fmt.Println(operate("gcp", arguments))
+	run(fmt.Sprintf("compute firewall-rules delete %s --quiet", g.FirewallRuleName))
+	if len(errs) > 0 {
+		return fmt.Errorf("bastion host configurations could not be cleaned up completely: %w", errs[0])
+	}
 	fmt.Println("Bastion host configurations successfully cleaned up.")
+	return nil
 }
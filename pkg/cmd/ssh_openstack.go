@@ -36,12 +36,13 @@ type OpenstackInstanceAttribute struct {
 	InstanceID string
 	networkID  string
 	FIP        string
+
+	targetReader TargetReader
 }
 
 //sshToOpenstackNode ssh to openstack node
-func sshToOpenstackNode(nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) {
-	sshStatus := 0
-	a := &OpenstackInstanceAttribute{}
+func sshToOpenstackNode(targetReader TargetReader, nodeName, path, user, pathSSKeypair string, sshPublicKey []byte, myPublicIP string, flagProviderID string) (err error) {
+	a := &OpenstackInstanceAttribute{targetReader: targetReader}
 
 	if flagProviderID != "" {
 		a.InstanceID = flagProviderID
@@ -49,38 +50,55 @@ func sshToOpenstackNode(nodeName, path, user, pathSSKeypair string, sshPublicKey
 		a.InstanceID = nodeName
 	}
 
-	var err error
-
 	fmt.Println("(1/5) Getting the external network for creating FIP")
-	resNetwork := operate("openstack", "network list --external -f json")
+	resNetwork, err := operate(targetReader, "openstack", "network list --external -f json")
+	if err != nil {
+		return err
+	}
 	if len(resNetwork) < 2 {
-		fmt.Println("External network not found!")
-		os.Exit(2)
+		return NewNotFoundError("external network not found")
 	}
 	resNetwork = resNetwork[1 : len(resNetwork)-2] // network returns with [], trim them before next step json decode
-	decodedQueryNetwork := decodeAndQueryFromJSONString(resNetwork)
+	decodedQueryNetwork, err := decodeAndQueryFromJSONString(resNetwork)
+	if err != nil {
+		return err
+	}
 	a.networkID, err = decodedQueryNetwork.String("ID")
 	fmt.Println("The external network ID is " + a.networkID)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	fmt.Println("(2/5) Creating floating IP from external network")
-	resFloatingIP := operate("openstack", "floating ip create "+a.networkID+"  -f json")
-	decodedQueryFIP := decodeAndQueryFromJSONString(resFloatingIP)
+	resFloatingIP, err := operate(targetReader, "openstack", "floating ip create "+a.networkID+"  -f json")
+	if err != nil {
+		return err
+	}
+	decodedQueryFIP, err := decodeAndQueryFromJSONString(resFloatingIP)
+	if err != nil {
+		return err
+	}
 	a.FIP, err = decodedQueryFIP.String("floating_ip_address")
 	fmt.Println("The floating IP created is " + a.FIP)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	time.Sleep(5000)
 
+	defer func() {
+		if cleanupErr := a.cleanUpOpenstack(); err == nil {
+			err = cleanupErr
+		}
+	}()
+
 	fmt.Println("(3/5) Add floating IP to openstack server node")
-	operate("openstack", "server add floating ip "+a.InstanceID+" "+a.FIP)
+	if _, err := operate(targetReader, "openstack", "server add floating ip "+a.InstanceID+" "+a.FIP); err != nil {
+		return err
+	}
 	time.Sleep(5000)
 
-	defer a.cleanUpOpenstack(&sshStatus)
-
-	err = CheckIPPortReachable(a.FIP, "22")
-
-	if err != nil {
-		sshStatus = 1
+	if err := CheckIPPortReachable(a.FIP, "22"); err != nil {
+		return err
 	}
 
 	node := user + "@" + a.FIP
@@ -105,23 +123,24 @@ func sshToOpenstackNode(nodeName, path, user, pathSSKeypair string, sshPublicKey
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		fmt.Println(err)
-		if exitError, ok := err.(*exec.ExitError); ok {
-			sshStatus = exitError.ExitCode()
-		}
-	}
+	return toolError("ssh", cmd.Run())
 }
 
-//cleanUpOpenstack clean the resource added to ssh to openstack node
-func (a *OpenstackInstanceAttribute) cleanUpOpenstack(exitStatus *int) {
+//cleanUpOpenstack clean the resource added to ssh to openstack node, it continues on errors and returns the first one
+func (a *OpenstackInstanceAttribute) cleanUpOpenstack() error {
 	fmt.Println("")
 	fmt.Println("(5/5) Cleanup")
 
 	fmt.Println("De-associate server with floating ip")
-	operate("openstack", "server remove floating ip "+a.InstanceID+" "+a.FIP)
+	_, removeErr := operate(a.targetReader, "openstack", "server remove floating ip "+a.InstanceID+" "+a.FIP)
+	if removeErr != nil {
+		fmt.Fprintln(os.Stderr, removeErr)
+	}
 
 	fmt.Println("Delete the floating IP")
-	operate("openstack", "floating ip delete "+a.FIP)
-	os.Exit(*exitStatus)
+	if _, err := operate(a.targetReader, "openstack", "floating ip delete "+a.FIP); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	return removeErr
 }
@@ -27,10 +27,11 @@ import (
 var _ = Describe("SSH command", func() {
 
 	var (
-		ctrl    *gomock.Controller
-		reader  *mockcmd.MockTargetReader
-		target  *mockcmd.MockTargetInterface
-		command *cobra.Command
+		ctrl         *gomock.Controller
+		reader       *mockcmd.MockTargetReader
+		configReader *mockcmd.MockConfigReader
+		target       *mockcmd.MockTargetInterface
+		command      *cobra.Command
 
 		execute = func(command *cobra.Command, args []string) error {
 			command.SetArgs(args)
@@ -41,6 +42,7 @@ var _ = Describe("SSH command", func() {
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		reader = mockcmd.NewMockTargetReader(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
 		target = mockcmd.NewMockTargetInterface(ctrl)
 	})
 
@@ -55,7 +57,7 @@ var _ = Describe("SSH command", func() {
 				target.EXPECT().Stack().Return([]cmd.TargetMeta{})
 
 				ioStreams, _, _, _ := cmd.NewTestIOStreams()
-				command = cmd.NewSSHCmd(reader, ioStreams)
+				command = cmd.NewSSHCmd(reader, configReader, ioStreams)
 				err := execute(command, []string{})
 
 				Expect(err).To(HaveOccurred())
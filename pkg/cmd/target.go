@@ -23,21 +23,44 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // ProjectName is they key of a label on namespaces whose value holds the project name.
-const ProjectName = "project.gardener.cloud/name"
+const ProjectName = gardenctl.ProjectNameLabel
+
+var (
+	targetExample = `
+	# Target a shoot via its project in one step.
+	gardenctl target prod/my-project/my-shoot
+
+	# Target a shoot via its seed and set the namespace.
+	gardenctl target prod/@seed-aws-eu1/my-shoot:kube-system
+
+	# Pick one of the shoots of the targeted garden or project interactively.
+	gardenctl target shoot
+
+	# Search a shoot by name or technical ID in all configured gardens.
+	gardenctl target shoot shoot--my-project--my-shoot --all-gardens
+
+	# Save the current target as bookmark and target it again later.
+	gardenctl target save api-prod
+	gardenctl target api-prod
+
+	# Toggle back to the previous target.
+	gardenctl target -
+
+	# Save the current target, target another shoot and return to the saved target.
+	gardenctl target push prod/other-project/other-shoot
+	gardenctl target pop`
+)
 
 var (
 	pgarden       string
@@ -47,61 +70,69 @@ var (
 	pnamespace    string
 	pserver       string
 	pdashboardurl string
+	allGardens    bool
 )
 
 // NewTargetCmd returns a new target command.
 func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, kubeconfigReader KubeconfigReader, historyWriter HistoryWriter) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "target <project|garden|seed|shoot|namespace|server|dashboardUrl> NAME",
+		Use:          "target <project|garden|seed|shoot|namespace|server|dashboardUrl|save|push> NAME | BOOKMARK | - | pop",
 		Short:        "Set scope for next operations, e.g. \"gardenctl target garden garden_name\" to target garden with name of garden_name",
+		Example:      targetExample,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if pgarden != "" || pproject != "" || pseed != "" || pshoot != "" || pnamespace != "" || pserver != "" || pdashboardurl != "" {
 				var arguments []string
 				if pgarden != "" && pserver != "" {
-					fmt.Println("server and garden values can't be specified at same time.")
-					os.Exit(2)
+					return errors.New("server and garden values can't be specified at same time")
 				}
 				if pserver != "" {
 					if !isValidURI(pserver) {
-						fmt.Println("the server must be a valid uri")
-						os.Exit(2)
+						return errors.New("the server must be a valid uri")
+					}
+					if err := serverWrapper(configReader, pserver, kubeconfigReader); err != nil {
+						return err
 					}
-					serverWrapper(configReader, pserver, kubeconfigReader)
 				}
 				if pgarden != "" {
 					arguments := append(arguments, "garden")
 					arguments = append(arguments, pgarden)
-					err := gardenWrapper(targetReader, targetWriter, configReader, ioStreams, arguments)
-					checkError(err)
+					if err := gardenWrapper(targetReader, targetWriter, configReader, ioStreams, arguments); err != nil {
+						return err
+					}
 				}
 				if pproject != "" {
 					arguments := append(arguments, "project")
 					arguments = append(arguments, pproject)
-					err := projectWrapper(targetReader, targetWriter, configReader, ioStreams, arguments)
-					checkError(err)
+					if err := projectWrapper(targetReader, targetWriter, configReader, ioStreams, arguments); err != nil {
+						return err
+					}
 				}
 				if pseed != "" {
 					arguments := append(arguments, "seed")
 					arguments = append(arguments, pseed)
-					err := seedWrapper(targetReader, targetWriter, configReader, ioStreams, arguments)
-					checkError(err)
+					if err := seedWrapper(targetReader, targetWriter, configReader, ioStreams, arguments); err != nil {
+						return err
+					}
 				}
 				if pshoot != "" {
 					arguments := append(arguments, "shoot")
 					arguments = append(arguments, pshoot)
-					err := shootWrapper(targetReader, targetWriter, configReader, ioStreams, arguments)
-					checkError(err)
+					if err := shootWrapper(targetReader, targetWriter, configReader, ioStreams, arguments); err != nil {
+						return err
+					}
 				}
 
 				if pnamespace != "" {
-					err := namespaceWrapper(targetReader, targetWriter, pnamespace)
-					checkError(err)
+					if err := namespaceWrapper(configReader, targetReader, targetWriter, pnamespace); err != nil {
+						return err
+					}
 				}
 
 				if pdashboardurl != "" {
-					err := urlWrapper(targetReader, targetWriter, configReader, ioStreams, pdashboardurl)
-					checkError(err)
+					if err := urlWrapper(targetReader, targetWriter, configReader, ioStreams, pdashboardurl); err != nil {
+						return err
+					}
 				}
 
 				err := historyWriter.WriteStringln(pathHistory, targetInfo)
@@ -114,6 +145,12 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 			if len(args) < 1 && pgarden == "" && pproject == "" && pseed == "" && pshoot == "" && pnamespace == "" && pserver == "" && pdashboardurl == "" || len(args) > 5 {
 				return errors.New("command must be in the format: target <project|garden|seed|shoot|namespace|server|dashboardUrl> NAME")
 			}
+			if len(args) == 1 && IsTargetPath(args[0]) {
+				if err := targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, args[0]); err != nil {
+					return err
+				}
+				return historyWriter.WriteStringln(pathHistory, targetInfo)
+			}
 			switch args[0] {
 			case "garden":
 				err := gardenWrapper(targetReader, targetWriter, configReader, ioStreams, args)
@@ -136,10 +173,17 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 					return err
 				}
 			case "namespace":
+				if len(args) == 1 && interactive() {
+					name, err := pickNamespace(targetReader)
+					if err != nil {
+						return err
+					}
+					args = append(args, name)
+				}
 				if len(args) != 2 || args[1] == "" {
 					return errors.New("command must be in the format: target namespace NAME")
 				}
-				err := namespaceWrapper(targetReader, targetWriter, args[1])
+				err := namespaceWrapper(configReader, targetReader, targetWriter, args[1])
 				if err != nil {
 					return err
 				}
@@ -148,10 +192,11 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 					return errors.New("command must be in the format: target server NAME")
 				}
 				if !isValidURI(args[1]) {
-					fmt.Println("the server name must be a valid uri")
-					os.Exit(2)
+					return errors.New("the server name must be a valid uri")
+				}
+				if err := serverWrapper(configReader, args[1], kubeconfigReader); err != nil {
+					return err
 				}
-				serverWrapper(configReader, args[1], kubeconfigReader)
 				argStr := []string{"garden", pgarden}
 				err := gardenWrapper(targetReader, targetWriter, configReader, ioStreams, argStr)
 				if err != nil {
@@ -162,8 +207,7 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 					return errors.New("command must be in the format: target dashboardUrl URL")
 				}
 				if !isValidURI(args[1]) {
-					fmt.Println("the server name must be a valid uri")
-					os.Exit(2)
+					return errors.New("the server name must be a valid uri")
 				}
 
 				err := urlWrapper(targetReader, targetWriter, configReader, ioStreams, args[1])
@@ -171,107 +215,38 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 					return err
 				}
 
-			default:
-				target := targetReader.ReadTarget(pathTarget)
-				if len(target.Stack()) < 1 {
-					return errors.New("no garden cluster targeted")
-				} else if garden && !seed && !project {
-					gardens := resolveNameGarden(configReader, args[0])
-					if len(gardens) == 0 {
-						fmt.Println("No match for " + args[0])
-						os.Exit(2)
-					} else if len(gardens) == 1 {
-						targetGarden(targetWriter, gardens[0])
-					} else if len(gardens) > 1 {
-						fmt.Println("gardens:")
-						for _, val := range gardens {
-							fmt.Println("- garden: " + val)
-						}
-						os.Exit(2)
-					}
-					break
-				} else if !garden && seed && !project {
-					seeds := resolveNameSeed(target, args[0])
-					if len(seeds) == 0 {
-						fmt.Println("No match for " + args[0])
-						os.Exit(2)
-					} else if len(seeds) == 1 {
-						targetSeed(targetReader, targetWriter, seeds[0], true)
-					} else if len(seeds) > 1 {
-						fmt.Println("seeds:")
-						for _, val := range seeds {
-							fmt.Println("- seed: " + val)
-						}
-						os.Exit(2)
-					}
-					break
-				} else if !garden && !seed && project {
-					projects := resolveNameProject(target, args[0])
-					if len(projects) == 0 {
-						fmt.Println("No match for " + args[0])
-						os.Exit(2)
-					} else if len(projects) == 1 {
-						targetProject(targetReader, targetWriter, projects[0])
-					} else if len(projects) > 1 {
-						fmt.Println("projects:")
-						for _, val := range projects {
-							fmt.Println("- project: " + val)
-						}
-						os.Exit(2)
-					}
-					break
+			case "-":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: target -")
 				}
-				tmp := KUBECONFIG
-				var err error
-				Client, err = clientToTarget("garden")
-				checkError(err)
-				clientset, err := target.GardenerClient()
-				checkError(err)
-				seedList := getSeeds(clientset)
-				for _, seed := range seedList.Items {
-					if args[0] == seed.Name {
-						targetSeed(targetReader, targetWriter, args[0], true)
-						os.Exit(0)
-					}
+				if err := targetPrevious(targetReader, targetWriter, configReader, ioStreams); err != nil {
+					return err
 				}
-				gardenClientset, err := target.GardenerClient()
-				checkError(err)
-				projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
-				checkError(err)
-				match := false
-				for _, project := range projectList.Items {
-					if args[0] == project.Name {
-						targetProject(targetReader, targetWriter, args[0])
-						match = true
-						break
-					}
+			case "push":
+				if len(args) > 2 {
+					return errors.New("command must be in the format: target push [TARGET_PATH|BOOKMARK|NAME]")
 				}
-				KUBECONFIG = tmp
-				if match {
-					break
+				if err := pushTarget(targetReader, targetWriter, configReader, ioStreams, args[1:]); err != nil {
+					return err
 				}
-				shoots := resolveNameShoot(target, args[0])
-				if len(shoots) == 0 {
-					fmt.Println("No match for " + args[0])
-				} else if len(shoots) == 1 {
-					targetShoot(targetReader, targetWriter, shoots[0], configReader)
-				} else if len(shoots) > 1 {
-					k8sClientToGarden, err := target.K8SClientToKind(TargetKindGarden)
-					checkError(err)
-					fmt.Fprintln(ioStreams.Out, "shoots:")
-					for _, shoot := range shoots {
-						projectName, err := getProjectNameByShootNamespace(k8sClientToGarden, shoot.Namespace)
-						checkError(err)
-
-						fmt.Fprintln(ioStreams.Out, "- project: "+projectName)
-						fmt.Fprintln(ioStreams.Out, "  shoot: "+shoot.Name)
-					}
+				if len(args) == 1 {
+					return nil
 				}
-				if pnamespace != "" {
-					err := namespaceWrapper(targetReader, targetWriter, pnamespace)
-					if err != nil {
-						checkError(err)
-					}
+			case "pop":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: target pop")
+				}
+				if err := popTarget(targetReader, targetWriter, configReader, ioStreams); err != nil {
+					return err
+				}
+			case "save":
+				if len(args) != 2 || args[1] == "" {
+					return errors.New("command must be in the format: target save BOOKMARK")
+				}
+				return saveBookmark(targetReader, ioStreams, args[1])
+			default:
+				if err := targetArgument(targetReader, targetWriter, configReader, ioStreams, args[0]); err != nil {
+					return err
 				}
 			}
 
@@ -282,7 +257,7 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 
 			return nil
 		},
-		ValidArgs: []string{"project", "garden", "seed", "shoot", "namespace", "server", "dashboardUrl"},
+		ValidArgs: []string{"project", "garden", "seed", "shoot", "namespace", "server", "dashboardUrl", "save", "push", "pop"},
 	}
 
 	cmd.PersistentFlags().StringVarP(&pgarden, "garden", "g", "", "garden name")
@@ -292,52 +267,89 @@ func NewTargetCmd(targetReader TargetReader, targetWriter TargetWriter, configRe
 	cmd.PersistentFlags().StringVarP(&pnamespace, "namespace", "n", "", "namespace name")
 	cmd.PersistentFlags().StringVarP(&pserver, "server", "r", "", "server name")
 	cmd.PersistentFlags().StringVarP(&pdashboardurl, "dashboardUrl", "u", "", "dashboard url name")
+	cmd.PersistentFlags().BoolVarP(&allGardens, "all-gardens", "A", false, "search the shoot in all configured gardens")
+	cmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "never prompt to pick a name if it is ambiguous or omitted")
 
 	return cmd
 }
 
-// resolveNameProject resolves name to project
-func resolveNameProject(target TargetInterface, name string) (matches []string) {
-	gardenClientset, err := target.GardenerClient()
-	checkError(err)
-	if !strings.Contains(name, "*") {
-		project, err := gardenClientset.CoreV1beta1().Projects().Get(name, metav1.GetOptions{})
-		if err != nil {
-			return []string{}
-		}
-		return []string{project.Name}
+// targetName targets the garden, seed, project or shoot with the given name, depending on the
+// --garden, --seed and --project switches
+func targetName(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, name string) error {
+	target := targetReader.ReadTarget(pathTarget)
+	if len(target.Stack()) < 1 {
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	if garden && !seed && !project {
+		return gardenWrapper(targetReader, targetWriter, configReader, ioStreams, []string{"garden", name})
+	} else if !garden && seed && !project {
+		return seedWrapper(targetReader, targetWriter, configReader, ioStreams, []string{"seed", name})
+	} else if !garden && !seed && project {
+		return projectWrapper(targetReader, targetWriter, configReader, ioStreams, []string{"project", name})
 	}
 
-	projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
-	checkError(err)
-	matcher := ""
-	for _, project := range projectList.Items {
-		if strings.HasPrefix(name, "*") && strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 2)
-			if strings.Contains(project.Name, matcher) {
-				matches = append(matches, project.Name)
-				continue
-			}
-		}
-		if strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasPrefix(project.Name, matcher) {
-				matches = append(matches, project.Name)
-				continue
-			}
+	clientset, err := target.GardenerClient()
+	if err != nil {
+		return err
+	}
+	seedList, err := clientset.CoreV1beta1().Seeds().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, seed := range seedList.Items {
+		if name == seed.Name {
+			return targetSeed(configReader, targetReader, targetWriter, name, true)
 		}
-		if strings.HasPrefix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasSuffix(project.Name, matcher) {
-				matches = append(matches, project.Name)
-			}
+	}
+	projectList, err := clientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, project := range projectList.Items {
+		if name == project.Name {
+			return targetProject(targetReader, targetWriter, name)
 		}
 	}
-	return matches
+
+	if err := shootWrapper(targetReader, targetWriter, configReader, ioStreams, []string{"shoot", name}); err != nil {
+		return err
+	}
+	if pnamespace != "" {
+		return namespaceWrapper(configReader, targetReader, targetWriter, pnamespace)
+	}
+	return nil
+}
+
+// newMatcher returns the matcher for a name or pattern given on the command line
+func newMatcher(pattern string) (*gardenctl.Matcher, error) {
+	return gardenctl.NewMatcher(pattern, ignoreCase)
+}
+
+// explainMatches logs which names were matched by m in verbose mode
+func explainMatches(m *gardenctl.Matcher, kind TargetKind, matches []string) {
+	GardenctlDebugLog(m.Explain(kind, matches))
+}
+
+// resolveNameProject resolves name to project
+func resolveNameProject(target TargetInterface, name string) ([]string, error) {
+	m, err := newMatcher(name)
+	if err != nil {
+		return nil, err
+	}
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return nil, err
+	}
+	projects, err := gardenctl.ResolveProjects(gardenClientset, m)
+	if err != nil {
+		return nil, err
+	}
+	explainMatches(m, TargetKindProject, projects)
+	return projects, nil
 }
 
 // targetProject targets a project
-func targetProject(targetReader TargetReader, targetWriter TargetWriter, name string) {
+func targetProject(targetReader TargetReader, targetWriter TargetWriter, name string) error {
 	target := targetReader.ReadTarget(pathTarget)
 	new := target.Stack()[:1]
 	new = append(new, TargetMeta{
@@ -345,58 +357,31 @@ func targetProject(targetReader TargetReader, targetWriter TargetWriter, name st
 		Name: name,
 	})
 	target.SetStack(new)
-	err := targetWriter.WriteTarget(pathTarget, target)
-	checkError(err)
+	if err := targetWriter.WriteTarget(pathTarget, target); err != nil {
+		return err
+	}
 	toTargetInfo(target)
+	return nil
 }
 
 // resolveNameGarden resolves name to garden
-func resolveNameGarden(reader ConfigReader, name string) (matches []string) {
-	config := reader.ReadConfig(pathGardenConfig)
-	matcher := ""
-	for _, garden := range config.GardenClusters {
-		if strings.HasPrefix(name, "*") && strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 2)
-			if strings.Contains(garden.Name, matcher) {
-				matches = append(matches, garden.Name)
-			}
-		} else if strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasPrefix(garden.Name, matcher) {
-				matches = append(matches, garden.Name)
-			}
-		} else if strings.HasPrefix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasSuffix(garden.Name, matcher) {
-				matches = append(matches, garden.Name)
-			}
-		} else {
-			if garden.Name == name {
-				matches = append(matches, garden.Name)
-			}
-		}
+func resolveNameGarden(reader ConfigReader, name string) ([]string, error) {
+	m, err := newMatcher(name)
+	if err != nil {
+		return nil, err
 	}
-	return matches
+	gardens := gardenctl.ResolveGardens(reader.ReadConfig(pathGardenConfig), m)
+	explainMatches(m, TargetKindGarden, gardens)
+	return gardens, nil
 }
 
 // resolveGardenNameFromURL resolve garden name from provided dashboard URL
-func resolveGardenNameFromURL(reader ConfigReader, dashboardURL string) string {
-	config := reader.ReadConfig(pathGardenConfig)
-	gardenName := ""
-	for _, garden := range config.GardenClusters {
-		if strings.Contains(garden.DashboardURL, dashboardURL) {
-			gardenName = garden.Name
-		}
-	}
-	if gardenName == "" {
-		fmt.Println("a garden could not be matched for the provided dashboard url")
-		os.Exit(2)
-	}
-	return gardenName
+func resolveGardenNameFromURL(reader ConfigReader, dashboardURL string) (string, error) {
+	return gardenctl.ResolveGardenByDashboardURL(reader.ReadConfig(pathGardenConfig), dashboardURL)
 }
 
 // targetGarden targets kubeconfig file of garden cluster
-func targetGarden(targetWriter TargetWriter, name string) {
+func targetGarden(configReader ConfigReader, targetWriter TargetWriter, name string) error {
 	target := &Target{
 		Target: []TargetMeta{
 			{
@@ -406,78 +391,71 @@ func targetGarden(targetWriter TargetWriter, name string) {
 		},
 	}
 
-	err := targetWriter.WriteTarget(pathTarget, target)
-	checkError(err)
+	if err := targetWriter.WriteTarget(pathTarget, target); err != nil {
+		return err
+	}
+	kubeconfig, err := getKubeConfigOfCurrentTarget(configReader)
+	if err != nil {
+		return err
+	}
 	fmt.Println("Garden:")
-	fmt.Println("KUBECONFIG=" + getKubeConfigOfCurrentTarget())
+	fmt.Println("KUBECONFIG=" + kubeconfig)
 	toTargetInfo(target)
+	return nil
 }
 
 // resolveNameSeed resolves name to seed
-func resolveNameSeed(target TargetInterface, name string) (matches []string) {
-	tmp := KUBECONFIG
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	matcher := ""
-	clientset, err := target.GardenerClient()
-	checkError(err)
-	seedList := getSeeds(clientset)
-	for _, seed := range seedList.Items {
-		if strings.HasPrefix(name, "*") && strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 2)
-			if strings.Contains(seed.Name, matcher) {
-				matches = append(matches, seed.Name)
-			}
-		} else if strings.HasSuffix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasPrefix(seed.Name, matcher) {
-				matches = append(matches, seed.Name)
-			}
-		} else if strings.HasPrefix(name, "*") {
-			matcher = strings.Replace(name, "*", "", 1)
-			if strings.HasSuffix(seed.Name, matcher) {
-				matches = append(matches, seed.Name)
-			}
-		} else {
-			if seed.Name == name {
-				matches = append(matches, seed.Name)
-			}
-		}
+func resolveNameSeed(target TargetInterface, name string) ([]string, error) {
+	m, err := newMatcher(name)
+	if err != nil {
+		return nil, err
+	}
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := gardenctl.ResolveSeeds(gardenClientset, m)
+	if err != nil {
+		return nil, err
 	}
-	KUBECONFIG = tmp
-	return matches
+	explainMatches(m, TargetKindSeed, seeds)
+	return seeds, nil
 }
 
 // targetSeed targets kubeconfig file of seed cluster and updates target
-func targetSeed(targetReader TargetReader, targetWriter TargetWriter, name string, cache bool) {
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
+func targetSeed(configReader ConfigReader, targetReader TargetReader, targetWriter TargetWriter, name string, cache bool) error {
 	target := targetReader.ReadTarget(pathTarget)
 	gardenName := target.Stack()[0].Name
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
 	gardenClientset, err := target.GardenerClient()
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	seed, err := gardenClientset.CoreV1beta1().Seeds().Get(name, metav1.GetOptions{})
 	if err != nil {
-		fmt.Println("Seed not found")
-		os.Exit(2)
+		if apierrors.IsNotFound(err) {
+			return NewNotFoundError("seed %q not found", name)
+		}
+		return err
 	}
 	if seed.Spec.SecretRef == nil {
-		fmt.Println("Spec.SecretRef is missing in this seed, seed not reachable")
-		os.Exit(2)
+		return NewRemoteError(nil, "spec.secretRef is missing in seed %q, seed not reachable", name)
+	}
+	kubeSecret, err := gardenClient.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
 	}
-	kubeSecret, err := Client.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
-	checkError(err)
 	pathSeed := filepath.Join(pathGardenHome, "cache", gardenName, "seeds", name)
-	err = os.MkdirAll(pathSeed, os.ModePerm)
-	checkError(err)
-	err = ioutil.WriteFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"], 0644)
-	checkError(err)
-	KUBECONFIG = filepath.Join(pathSeed, "kubeconfig.yaml")
+	if _, err = writeCacheFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"]); err != nil {
+		return err
+	}
 	if !cachevar && cache {
-		err = ioutil.WriteFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"], 0644)
-		checkError(err)
+		if _, err = writeCacheFile(filepath.Join(pathSeed, "kubeconfig.yaml"), kubeSecret.Data["kubeconfig"]); err != nil {
+			return err
+		}
 	}
 
 	new := target.Stack()[:1]
@@ -487,125 +465,92 @@ func targetSeed(targetReader TargetReader, targetWriter TargetWriter, name strin
 	})
 	target.SetStack(new)
 
-	err = targetWriter.WriteTarget(pathTarget, target)
-	checkError(err)
+	if err = targetWriter.WriteTarget(pathTarget, target); err != nil {
+		return err
+	}
 	toTargetInfo(target)
+	kubeconfig, err := getKubeConfigOfCurrentTarget(configReader)
+	if err != nil {
+		return err
+	}
 	fmt.Println("Seed:")
-	fmt.Println("KUBECONFIG=" + getKubeConfigOfCurrentTarget())
+	fmt.Println("KUBECONFIG=" + kubeconfig)
+	return nil
 }
 
 func toTargetInfo(target TargetInterface) {
 	targetInfo["Cmd"] = strings.Join(os.Args[0:], " ")
+	for _, key := range []string{targetInfoGarden, targetInfoProject, targetInfoSeed, targetInfoShoot, targetInfoNamespace, targetInfoTechnicalID, targetInfoBookmark} {
+		delete(targetInfo, key)
+	}
 	for _, k := range target.Stack() {
 		targetInfo[string(k.Kind)] = k.Name
 	}
 }
 
 // resolveNameShoot resolves name to shoot
-func resolveNameShoot(target TargetInterface, name string) []gardencorev1beta1.Shoot {
+func resolveNameShoot(target TargetInterface, name string) ([]gardencorev1beta1.Shoot, error) {
+	m, err := newMatcher(name)
+	if err != nil {
+		return nil, err
+	}
 	gardenClientset, err := target.GardenerClient()
-	checkError(err)
-
-	isRegexName := true
-	listOptions := metav1.ListOptions{}
-	if !strings.Contains(name, "*") {
-		isRegexName = false
-		fieldSelector := fields.OneTermEqualSelector("metadata.name", name)
-		listOptions.FieldSelector = fieldSelector.String()
-	}
-
-	var shootList *gardencorev1beta1.ShootList
-	if len(target.Stack()) == 2 && target.Stack()[1].Kind == TargetKindProject {
-		projectName := target.Stack()[1].Name
-		project, err := gardenClientset.CoreV1beta1().Projects().Get(projectName, metav1.GetOptions{})
-		checkError(err)
-
-		projectNamespace := project.Spec.Namespace
-		shootList, err = gardenClientset.CoreV1beta1().Shoots(*projectNamespace).List(listOptions)
-		checkError(err)
-	} else if len(target.Stack()) == 2 && target.Stack()[1].Kind == TargetKindSeed {
-		shootList, err = gardenClientset.CoreV1beta1().Shoots("").List(listOptions)
-		checkError(err)
-
-		var filteredShoots []gardencorev1beta1.Shoot
-		for _, shoot := range shootList.Items {
-			if *shoot.Spec.SeedName == target.Stack()[1].Name {
-				filteredShoots = append(filteredShoots, shoot)
-			}
-		}
-		shootList.Items = filteredShoots
-	} else {
-		shootList, err = gardenClientset.CoreV1beta1().Shoots("").List(listOptions)
-		checkError(err)
-	}
-
-	if isRegexName {
-		var (
-			matches []gardencorev1beta1.Shoot
-			matcher string
-		)
-		for _, shoot := range shootList.Items {
-			shootName := shoot.Name
-			if strings.HasPrefix(name, "*") && strings.HasSuffix(name, "*") {
-				matcher = strings.Replace(name, "*", "", 2)
-				if strings.Contains(shootName, matcher) {
-					matches = append(matches, shoot)
-				}
-			} else if strings.HasSuffix(name, "*") {
-				matcher = strings.Replace(name, "*", "", 1)
-				if strings.HasPrefix(shootName, matcher) {
-					matches = append(matches, shoot)
-				}
-			} else if strings.HasPrefix(name, "*") {
-				matcher = strings.Replace(name, "*", "", 1)
-				if strings.HasSuffix(shootName, matcher) {
-					matches = append(matches, shoot)
-				}
-			} else {
-				if shootName == name {
-					matches = append(matches, shoot)
-				}
-			}
-		}
-
-		return matches
+	if err != nil {
+		return nil, err
 	}
-
-	return shootList.Items
+	shoots, err := gardenctl.ResolveShoots(gardenClientset, target.Stack(), m)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, shoot := range shoots {
+		names = append(names, shoot.Namespace+"/"+shoot.Name)
+	}
+	explainMatches(m, TargetKindShoot, names)
+	return shoots, nil
 }
 
 // targetShoot targets shoot cluster with project as default value in stack
-func targetShoot(targetReader TargetReader, targetWriter TargetWriter, shoot gardencorev1beta1.Shoot, reader ConfigReader) {
-	var target Target
-	ReadTarget(pathTarget, &target)
+func targetShoot(targetReader TargetReader, targetWriter TargetWriter, shoot gardencorev1beta1.Shoot, reader ConfigReader) error {
+	current := targetReader.ReadTarget(pathTarget)
+	target := Target{Target: current.Stack()}
 
 	// Get and cache seed kubeconfig for future commands
 	gardenName := target.Stack()[0].Name
 	pathSeedCache := filepath.Join(pathGardenHome, "cache", gardenName, "seeds")
 	pathProjectCache := filepath.Join(pathGardenHome, "cache", gardenName, "projects")
 
-	gardenClientset, err := target.GardenerClient()
-	checkError(err)
+	if shoot.Spec.SeedName == nil {
+		return fmt.Errorf("shoot %q is not scheduled to a seed yet", shoot.Name)
+	}
+	if err := enforceAccessRestrictions(current, &shoot, reader, IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}); err != nil {
+		return err
+	}
+	gardenClientset, err := current.GardenerClient()
+	if err != nil {
+		return err
+	}
 	seed, err := gardenClientset.CoreV1beta1().Seeds().Get(*shoot.Spec.SeedName, metav1.GetOptions{})
-	checkError(err)
-	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	gardenClient, err := current.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
 	seedKubeconfigSecret, err := gardenClient.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
 	// temporary solution , will clean up code in ticket move get seed out of targetShoot method #269
 	if err != nil {
-		if strings.Contains(err.Error(), "forbidden") {
-			fmt.Printf(warningColor, "\nWarning:\nYou are user role!\n\n")
-		} else {
-			checkError(err)
+		if !apierrors.IsForbidden(err) {
+			return err
 		}
+		fmt.Printf(warningColor, "\nWarning:\nYou are user role!\n\n")
 	}
 
 	var seedCacheDir = filepath.Join(pathSeedCache, *shoot.Spec.SeedName)
-	err = os.MkdirAll(seedCacheDir, os.ModePerm)
-	checkError(err)
-	var seedKubeconfigPath = filepath.Join(seedCacheDir, "kubeconfig.yaml")
-	err = ioutil.WriteFile(seedKubeconfigPath, seedKubeconfigSecret.Data["kubeconfig"], 0644)
-	checkError(err)
+	if _, err = writeCacheFile(filepath.Join(seedCacheDir, "kubeconfig.yaml"), seedKubeconfigSecret.Data["kubeconfig"]); err != nil {
+		return err
+	}
 
 	// Get shoot kubeconfig
 	var shootKubeconfigSecretName = fmt.Sprintf("%s.kubeconfig", shoot.Name)
@@ -614,64 +559,75 @@ func targetShoot(targetReader TargetReader, targetWriter TargetWriter, shoot gar
 		fmt.Println("Kubeconfig not available, using empty one. Be aware only a limited number of cmds are available!")
 	}
 
-	k8sClientToGarden, err := target.K8SClientToKind(TargetKindGarden)
-	checkError(err)
-	projectName, err := getProjectNameByShootNamespace(k8sClientToGarden, shoot.Namespace)
-	checkError(err)
+	projectName, err := gardenctl.ProjectOfNamespace(gardenClient, shoot.Namespace)
+	if err != nil {
+		return err
+	}
 
+	errUserViaSeed := NewForbiddenError("you are user role and can't target shoot via seed, please target shoot via project")
+	operatorViaSeed := false
+	if len(target.Target) > 1 && target.Target[1].Kind == "seed" {
+		role, err := getRole(targetReader)
+		if err != nil {
+			return err
+		}
+		operatorViaSeed = role != "user"
+	}
 	if len(target.Target) == 1 {
-		target.Target = append(target.Target, TargetMeta{"project", projectName})
-		target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+		target.Target = append(target.Target, TargetMeta{Kind: "project", Name: projectName})
+		target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 	} else if len(target.Target) == 2 {
-		drop(targetWriter)
-		if target.Target[1].Kind == "seed" && getRole(targetReader) != "user" {
+		if err := drop(targetWriter, 1); err != nil {
+			return err
+		}
+		if operatorViaSeed {
 			target.Target[1].Kind = "seed"
 			target.Target[1].Name = *shoot.Spec.SeedName
 		} else if target.Target[1].Kind == "project" {
 			target.Target[1].Kind = "project"
 			target.Target[1].Name = projectName
 		} else {
-			fmt.Println("You are user role and can't target shoot via seed, please target shoot via project")
-			os.Exit(2)
+			return errUserViaSeed
 		}
-		target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+		target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 	} else if len(target.Target) == 3 {
-		drop(targetWriter)
-		drop(targetWriter)
-		if len(target.Target) > 2 && target.Target[1].Kind == "seed" && getRole(targetReader) != "user" {
+		if err := drop(targetWriter, 2); err != nil {
+			return err
+		}
+		if len(target.Target) > 2 && operatorViaSeed {
 			target.Target = target.Target[:len(target.Target)-2]
-			target.Target = append(target.Target, TargetMeta{"seed", *shoot.Spec.SeedName})
-			target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+			target.Target = append(target.Target, TargetMeta{Kind: "seed", Name: *shoot.Spec.SeedName})
+			target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 		} else if len(target.Target) > 2 && target.Target[1].Kind == "project" {
 			target.Target = target.Target[:len(target.Target)-2]
-			target.Target = append(target.Target, TargetMeta{"project", projectName})
-			target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+			target.Target = append(target.Target, TargetMeta{Kind: "project", Name: projectName})
+			target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 		} else {
-			fmt.Println("You are user role and can't target shoot via seed, please target shoot via project")
-			os.Exit(2)
+			return errUserViaSeed
 		}
 	} else if len(target.Target) == 4 {
-		drop(targetWriter)
-		drop(targetWriter)
-		drop(targetWriter)
-		if len(target.Target) > 3 && target.Target[1].Kind == "seed" && getRole(targetReader) != "user" {
+		if err := drop(targetWriter, 3); err != nil {
+			return err
+		}
+		if len(target.Target) > 3 && operatorViaSeed {
 			target.Target = target.Target[:len(target.Target)-3]
-			target.Target = append(target.Target, TargetMeta{"seed", *shoot.Spec.SeedName})
-			target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+			target.Target = append(target.Target, TargetMeta{Kind: "seed", Name: *shoot.Spec.SeedName})
+			target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 		} else if len(target.Target) > 3 && target.Target[1].Kind == "project" {
 			target.Target = target.Target[:len(target.Target)-3]
-			target.Target = append(target.Target, TargetMeta{"project", projectName})
-			target.Target = append(target.Target, TargetMeta{"shoot", shoot.Name})
+			target.Target = append(target.Target, TargetMeta{Kind: "project", Name: projectName})
+			target.Target = append(target.Target, TargetMeta{Kind: "shoot", Name: shoot.Name})
 		} else {
-			fmt.Println("You are user role and can't target shoot via seed, please target shoot via project")
-			os.Exit(2)
+			return errUserViaSeed
 		}
 	}
 
 	// Write target
-	err = targetWriter.WriteTarget(pathTarget, &target)
-	checkError(err)
+	if err = targetWriter.WriteTarget(pathTarget, &target); err != nil {
+		return err
+	}
 	toTargetInfo(&target)
+	targetInfo[targetInfoTechnicalID] = shoot.Status.TechnicalID
 
 	// Cache shoot kubeconfig
 	var shootCacheDir string
@@ -681,94 +637,31 @@ func targetShoot(targetReader TargetReader, targetWriter TargetWriter, shoot gar
 		shootCacheDir = filepath.Join(pathProjectCache, target.Target[1].Name, shoot.Name)
 	}
 
-	err = os.MkdirAll(shootCacheDir, os.ModePerm)
-	checkError(err)
-	var shootKubeconfigPath = filepath.Join(shootCacheDir, "kubeconfig.yaml")
-	err = ioutil.WriteFile(shootKubeconfigPath, shootKubeconfigSecret.Data["kubeconfig"], 0644)
-	checkError(err)
-
-	warningMsg := checkShootsRestriction(shoot, reader, gardenName)
-	if warningMsg != "" {
-		fmt.Println(warningMsg)
-	}
-
-	KUBECONFIG = shootKubeconfigPath
-	fmt.Println("Shoot:")
-	fmt.Println("KUBECONFIG=" + KUBECONFIG)
-}
-
-func getProjectNameByShootNamespace(k8sClientToGarden kubernetes.Interface, shootNamespace string) (string, error) {
-	namespace, err := k8sClientToGarden.CoreV1().Namespaces().Get(shootNamespace, metav1.GetOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	labelValue, ok := namespace.Labels[ProjectName]
-	if !ok {
-		return "", fmt.Errorf("label %q on namespace %q not found", ProjectName, namespace.Name)
-	}
-
-	return labelValue, nil
-}
-
-// getSeedForProject
-func getSeedForProject(shootName string) (seedName string) {
-	var err error
-	Client, err = clientToTarget("garden")
-	checkError(err)
-	gardenClientset, err := gardencoreclientset.NewForConfig(NewConfigFromBytes(*kubeconfig))
-	checkError(err)
-	shootList, err := gardenClientset.CoreV1beta1().Shoots("").List(metav1.ListOptions{})
-	// temporary solution , will clean up code in ticket move get seed out of targetShoot method #269
+	shootKubeconfigPath, err := writeCacheFile(filepath.Join(shootCacheDir, "kubeconfig.yaml"), shootKubeconfigSecret.Data["kubeconfig"])
 	if err != nil {
-		if strings.Contains(err.Error(), "forbidden") {
-			fmt.Printf(warningColor, "\nWarning:\nYou are user role!\n\n")
-		} else {
-			checkError(err)
-		}
+		return err
 	}
 
-	for _, item := range shootList.Items {
-		if item.Name == shootName {
-			seedName = *item.Spec.SeedName
-		}
-	}
-	return seedName
+	fmt.Println("Shoot:")
+	fmt.Println("KUBECONFIG=" + shootKubeconfigPath)
+	return nil
 }
 
 // getKubeConfigOfClusterType return config of specified type
-func getKubeConfigOfClusterType(clusterType TargetKind) (pathToKubeconfig string) {
-	var target Target
-	ReadTarget(pathTarget, &target)
-	gardenName := target.Stack()[0].Name
-	switch clusterType {
-	case TargetKindGarden:
-		pathToKubeconfig = TidyKubeconfigWithHomeDir(getGardenKubeConfig())
-	case TargetKindSeed:
-		if target.Target[1].Kind == "seed" {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Target[1].Name, "kubeconfig.yaml")
-		} else {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", getSeedForProject(target.Target[2].Name), "kubeconfig.yaml")
-		}
-	case TargetKindShoot:
-		if target.Target[1].Kind == "seed" {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", getSeedForProject(target.Target[2].Name), target.Target[2].Name, "kubeconfig.yaml")
-		} else if target.Target[1].Kind == "project" {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "projects", target.Target[1].Name, target.Target[2].Name, "kubeconfig.yaml")
-		}
-	}
-	return pathToKubeconfig
+func getKubeConfigOfClusterType(targetReader TargetReader, clusterType TargetKind) (pathToKubeconfig string, err error) {
+	return targetReader.ReadTarget(pathTarget).KubeconfigPathToKind(clusterType)
 }
 
 // getKubeConfigOfCurrentTarget returns the path to the kubeconfig of current target
-func getKubeConfigOfCurrentTarget() (pathToKubeconfig string) {
+func getKubeConfigOfCurrentTarget(configReader ConfigReader) (pathToKubeconfig string, err error) {
 	var targetReal Target
 	var target Target
-	ReadTarget(pathTarget, &targetReal)
+	if err := ReadTarget(pathTarget, &targetReal); err != nil {
+		return "", err
+	}
 
 	if len(targetReal.Target) == 1 && targetReal.Stack()[0].Kind == "namespace" {
-		fmt.Println("the target has only namespace, this is invalid, at least one garden needs to be targeted before using namespace")
-		os.Exit(2)
+		return "", errors.New("the target has only namespace, this is invalid, at least one garden needs to be targeted before using namespace")
 	} else if len(targetReal.Target) > 1 && len(targetReal.Target) <= 4 {
 		if targetReal.Stack()[len(targetReal.Target)-1].Kind == "namespace" {
 			target.Target = targetReal.Target[:len(targetReal.Target)-1]
@@ -778,84 +671,94 @@ func getKubeConfigOfCurrentTarget() (pathToKubeconfig string) {
 	} else if len(targetReal.Target) == 1 && targetReal.Stack()[0].Kind != "namespace" {
 		target.Target = targetReal.Target
 	} else {
-		fmt.Println("length of target.Stack is illegal")
-		os.Exit(2)
+		return "", NewNotTargetedError(TargetKindGarden)
 	}
 
-	gardenName := target.Stack()[0].Name
-	if len(target.Target) == 1 {
-		pathToKubeconfig = TidyKubeconfigWithHomeDir(getGardenKubeConfig())
-	} else if (len(target.Target) == 2) && (target.Target[1].Kind != "project") {
-		pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Target[1].Name, "kubeconfig.yaml")
-	} else if (len(target.Target) == 2) && (target.Target[1].Kind == "project") {
-		pathToKubeconfig = getGardenKubeConfigViaGardenName(target.Target[0].Name)
-	} else if len(target.Target) == 3 {
-		if target.Target[1].Kind == "seed" {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", target.Target[1].Name, target.Target[2].Name, "kubeconfig.yaml")
-		} else if target.Target[1].Kind == "project" {
-			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "projects", target.Target[1].Name, target.Target[2].Name, "kubeconfig.yaml")
-		}
+	kubeconfig, err := kubeconfigPathOfStack(configReader, target.Target)
+	if err != nil {
+		return "", err
+	}
+	// the kubeconfig of the session is written anew, so it follows the kubeconfig of the target
+	if top := targetReal.Target[len(targetReal.Target)-1]; top.Kind == TargetKindNamespace {
+		kubeconfig, _, err = writeSessionKubeconfig(kubeconfig, top.Name)
 	}
-	return pathToKubeconfig
+	return kubeconfig, err
 }
 
-// getGardenKubeConfig returns path to garden kubeconfig file
-func getGardenKubeConfig() (pathToGardenKubeConfig string) {
-	pathToGardenKubeConfig = ""
-	var gardenClusters GardenClusters
-	var target Target
-	yamlGardenConfig, err := ioutil.ReadFile(pathGardenConfig)
-	checkError(err)
-	err = yaml.Unmarshal(yamlGardenConfig, &gardenClusters)
-	checkError(err)
-	ReadTarget(pathTarget, &target)
-	for _, value := range gardenClusters.GardenClusters {
-		if value.Name == target.Target[0].Name {
-			pathToGardenKubeConfig = value.KubeConfig
+// kubeconfigPathOfStack returns the path to the kubeconfig of the cluster at the top of the target stack,
+// a trailing namespace is ignored
+func kubeconfigPathOfStack(reader ConfigReader, stack []TargetMeta) (pathToKubeconfig string, err error) {
+	if len(stack) > 0 && stack[len(stack)-1].Kind == TargetKindNamespace {
+		stack = stack[:len(stack)-1]
+	}
+	if len(stack) == 0 {
+		return "", nil
+	}
+
+	gardenName := stack[0].Name
+	if len(stack) == 1 || (len(stack) == 2 && stack[1].Kind == TargetKindProject) {
+		for _, garden := range reader.ReadConfig(pathGardenConfig).GardenClusters {
+			if garden.Name == gardenName {
+				pathToKubeconfig = TidyKubeconfigWithHomeDir(garden.KubeConfig)
+			}
+		}
+	} else if len(stack) == 2 {
+		pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", stack[1].Name, "kubeconfig.yaml")
+	} else if len(stack) == 3 {
+		if stack[1].Kind == TargetKindSeed {
+			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "seeds", stack[1].Name, stack[2].Name, "kubeconfig.yaml")
+		} else if stack[1].Kind == TargetKindProject {
+			pathToKubeconfig = filepath.Join(pathGardenHome, "cache", gardenName, "projects", stack[1].Name, stack[2].Name, "kubeconfig.yaml")
 		}
 	}
-	return pathToGardenKubeConfig
+	return cachedPath(pathToKubeconfig)
 }
 
 // getGardenKubeConfigViaGardenName returns path to garden kubeconfig file via garden name
-func getGardenKubeConfigViaGardenName(name string) (pathToGardenKubeConfig string) {
-	pathToGardenKubeConfig = ""
-	var gardenClusters GardenClusters
-	yamlGardenConfig, err := ioutil.ReadFile(pathGardenConfig)
-	checkError(err)
-	err = yaml.Unmarshal(yamlGardenConfig, &gardenClusters)
-	checkError(err)
-	for _, value := range gardenClusters.GardenClusters {
-		if value.Name == name {
-			pathToGardenKubeConfig = value.KubeConfig
+func getGardenKubeConfigViaGardenName(configReader ConfigReader, name string) string {
+	for _, garden := range configReader.ReadConfig(pathGardenConfig).GardenClusters {
+		if garden.Name == name {
+			return garden.KubeConfig
 		}
 	}
-	return pathToGardenKubeConfig
+	return ""
 }
 
 func gardenWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
 	if len(args) == 1 {
-		// Print Garden clusters
-		return PrintGardenClusters(configReader, ioStreams.Out, "yaml")
+		if !interactive() {
+			// Print Garden clusters
+			return PrintGardenClusters(configReader, ioStreams.Out, "yaml")
+		}
+		args = []string{args[0], "*"}
 	} else if len(args) > 2 {
 		return errors.New("command must be in the format: target garden NAME")
 	}
 
-	gardens := resolveNameGarden(configReader, args[1])
+	gardens, err := resolveNameGarden(configReader, args[1])
+	if err != nil {
+		return err
+	}
 	if len(gardens) == 0 {
-		return fmt.Errorf("no match for %q", args[1])
-	} else if len(gardens) == 1 {
-		targetGarden(targetWriter, gardens[0])
+		return NewNotFoundError("no match for %q", args[1])
 	} else if len(gardens) > 1 {
+		if interactive() {
+			name, err := pickName(TargetKindGarden, gardens)
+			if err != nil {
+				return err
+			}
+			return targetGarden(configReader, targetWriter, name)
+		}
 		fmt.Println("gardens:")
 		for _, val := range gardens {
 			fmt.Println("- garden: " + val)
 		}
+		return NewAmbiguousMatchError(TargetKindGarden, args[1], gardens)
 	}
-	return nil
+	return targetGarden(configReader, targetWriter, gardens[0])
 }
 
-func serverWrapper(reader ConfigReader, serverName string, kubeconfigReader KubeconfigReader) {
+func serverWrapper(reader ConfigReader, serverName string, kubeconfigReader KubeconfigReader) error {
 	config := reader.ReadConfig(pathGardenConfig)
 
 	errors := map[string]error{}
@@ -866,7 +769,7 @@ func serverWrapper(reader ConfigReader, serverName string, kubeconfigReader Kube
 			errors[kc] = err
 			continue
 		}
-		svr, err := getServerValueFromKubeconfig(kc, kubeconfigReader)
+		svr, err := getServerValueFromKubeconfig(reader, kc, kubeconfigReader)
 		if err != nil {
 			errors[kc] = err
 			continue // skip error
@@ -885,9 +788,9 @@ func serverWrapper(reader ConfigReader, serverName string, kubeconfigReader Kube
 		for kc, err := range errors {
 			fmt.Printf("Warning: matching kubeconfig file %s failed with: %s\n", kc, err)
 		}
-		fmt.Println("a garden could not be matched for the provided server address:", serverName)
-		os.Exit(2)
+		return NewNotFoundError("a garden could not be matched for the provided server address: %s", serverName)
 	}
+	return nil
 }
 
 func isServerEquals(server1 string, server2 string) (bool, error) {
@@ -984,7 +887,7 @@ func isValidURI(input string) bool {
 	return err == nil
 }
 
-func getServerValueFromKubeconfig(kubeconfigPath string, kubeconfigReader KubeconfigReader) (string, error) {
+func getServerValueFromKubeconfig(configReader ConfigReader, kubeconfigPath string, kubeconfigReader KubeconfigReader) (string, error) {
 	kubeconfig, err := kubeconfigReader.ReadKubeconfig(kubeconfigPath)
 	if err != nil {
 		return "", err
@@ -997,7 +900,7 @@ func getServerValueFromKubeconfig(kubeconfigPath string, kubeconfigReader Kubeco
 	if err != nil {
 		return "", err
 	}
-	if err := ValidateClientConfig(rawConfig); err != nil {
+	if err := ValidateClientConfig(configReader, rawConfig); err != nil {
 		return "", err
 	}
 	config, err := clientConfig.ClientConfig()
@@ -1008,164 +911,248 @@ func getServerValueFromKubeconfig(kubeconfigPath string, kubeconfigReader Kubeco
 }
 
 func projectWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
+	if len(args) == 1 && interactive() {
+		args = []string{args[0], "*"}
+	}
 	if len(args) != 2 {
 		return errors.New("command must be in the format: target project NAME")
 	}
 	target := targetReader.ReadTarget(pathTarget)
 	if len(target.Stack()) < 1 {
-		return errors.New("no garden cluster targeted")
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	projects, err := resolveNameProject(target, args[1])
+	if err != nil {
+		return err
 	}
-	projects := resolveNameProject(target, args[1])
 	if len(projects) == 0 {
-		return fmt.Errorf("no match for %q", args[1])
-	} else if len(projects) == 1 {
-		targetProject(targetReader, targetWriter, projects[0])
+		return NewNotFoundError("no match for %q", args[1])
 	} else if len(projects) > 1 {
+		if interactive() {
+			name, err := pickName(TargetKindProject, projects)
+			if err != nil {
+				return err
+			}
+			return targetProject(targetReader, targetWriter, name)
+		}
 		fmt.Println("projects:")
 		for _, val := range projects {
 			fmt.Println("- project: " + val)
 		}
+		return NewAmbiguousMatchError(TargetKindProject, args[1], projects)
 	}
-	return nil
+	return targetProject(targetReader, targetWriter, projects[0])
 }
 
 func seedWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
+	if len(args) == 1 && interactive() {
+		args = []string{args[0], "*"}
+	}
 	if len(args) != 2 {
 		return errors.New("command must be in the format: target seed NAME")
 	}
 	target := targetReader.ReadTarget(pathTarget)
 	if len(target.Stack()) < 1 {
-		return errors.New("no garden cluster targeted")
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	seeds, err := resolveNameSeed(target, args[1])
+	if err != nil {
+		return err
 	}
-	seeds := resolveNameSeed(target, args[1])
 	if len(seeds) == 0 {
-		return fmt.Errorf("no match for %q", args[1])
-	} else if len(seeds) == 1 {
-		targetSeed(targetReader, targetWriter, seeds[0], true)
+		return NewNotFoundError("no match for %q", args[1])
 	} else if len(seeds) > 1 {
+		if interactive() {
+			name, err := pickName(TargetKindSeed, seeds)
+			if err != nil {
+				return err
+			}
+			return targetSeed(configReader, targetReader, targetWriter, name, true)
+		}
 		fmt.Println("seeds:")
 		for _, val := range seeds {
 			fmt.Println("- seed: " + val)
 		}
+		return NewAmbiguousMatchError(TargetKindSeed, args[1], seeds)
 	}
-	return nil
+	return targetSeed(configReader, targetReader, targetWriter, seeds[0], true)
 }
 
-//getAccessRestrictionsFromGardenConfig returns current accessRestrictions from garden config with given garden name
-func getAccessRestrictionsFromGardenConfig(reader ConfigReader, gardenName string) []AccessRestriction {
-	var ars = []AccessRestriction{}
-	config := reader.ReadConfig(pathGardenConfig)
-	for _, garden := range config.GardenClusters {
-		if garden.Name == gardenName && len(garden.AccessRestrictions) > 0 {
-			ars = garden.AccessRestrictions
-			break
-		}
+func shootWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
+	if len(args) == 1 && interactive() {
+		args = []string{args[0], "*"}
+	}
+	if len(args) != 2 {
+		return errors.New("command must be in the format: target shoot NAME")
+	}
+	target := targetReader.ReadTarget(pathTarget)
+	if allGardens {
+		return searchShootWrapper(target, targetReader, targetWriter, configReader, ioStreams, args[1])
+	}
+	if len(target.Stack()) < 1 {
+		return NewNotTargetedError(TargetKindGarden)
 	}
-	return ars
-}
 
-//checkShootsRestriction returns warning message based on comparion between garden config and shoot lables/annotation
-func checkShootsRestriction(shoot gardencorev1beta1.Shoot, reader ConfigReader, gardenName string) string {
-	warningMsg := ""
-	var shootMatchLabels map[string]string
-	var shootAnnotations map[string]string
-	ars := getAccessRestrictionsFromGardenConfig(reader, gardenName)
-	if shoot.Spec.SeedSelector == nil || shoot.Spec.SeedSelector.MatchLabels == nil {
-		return warningMsg
+	shoots, err := resolveNameShoot(target, args[1])
+	if err != nil {
+		return err
 	}
-	shootMatchLabels = shoot.Spec.SeedSelector.MatchLabels
-	shootAnnotations = shoot.GetAnnotations()
 
-	if len(ars) == 0 {
-		return warningMsg
+	if len(shoots) == 0 {
+		return NewNotFoundError("no match for %q", args[1])
+	} else if len(shoots) == 1 {
+		return targetShoot(targetReader, targetWriter, shoots[0], configReader)
+	} else if interactive() {
+		shoot, err := pickShoot(target, shoots)
+		if err != nil {
+			return err
+		}
+		return targetShoot(targetReader, targetWriter, shoot, configReader)
 	}
 
-	for _, ar := range ars {
-		if _, ok := shootMatchLabels[ar.Key]; !ok {
-			continue
+	k8sClientToGarden, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ioStreams.Out, "shoots:")
+	var matches []string
+	for _, shoot := range shoots {
+		projectName, err := gardenctl.ProjectOfNamespace(k8sClientToGarden, shoot.Namespace)
+		if err != nil {
+			return err
 		}
-		if shootMatchLabels[ar.Key] != strconv.FormatBool(ar.NotifyIf) {
-			continue
+
+		fmt.Fprintln(ioStreams.Out, "- project: "+projectName)
+		fmt.Fprintln(ioStreams.Out, "  shoot: "+shoot.Name)
+		matches = append(matches, projectName+"/"+shoot.Name)
+	}
+	return NewAmbiguousMatchError(TargetKindShoot, args[1], matches)
+}
+
+// searchShootWrapper targets the shoot matching name in any of the configured gardens
+func searchShootWrapper(target TargetInterface, targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, name string) error {
+	m, err := newMatcher(name)
+	if err != nil {
+		return err
+	}
+	config := configReader.ReadConfig(pathGardenConfig)
+	matches, unreachable := gardenctl.SearchShoots(config, target.GardenerClientToGarden, m)
+	for _, garden := range config.GardenClusters {
+		if err, ok := unreachable[garden.Name]; ok {
+			fmt.Fprintf(ioStreams.ErrOut, "Warning: skipping garden %s, it could not be searched: %v\n", garden.Name, err)
 		}
-		warningMsg += ar.Msg
-		warningMsg += "\n"
-		//if upper level msg will not show, neither will lower level msg show
-		if len(ar.Options) == 0 {
-			continue
+	}
+	var paths []string
+	for _, match := range matches {
+		paths = append(paths, match.Path())
+	}
+	explainMatches(m, TargetKindShoot, paths)
+
+	var match gardenctl.ShootMatch
+	switch {
+	case len(matches) == 0:
+		return NewNotFoundError("no match for %q in any garden", name)
+	case len(matches) == 1:
+		match = matches[0]
+	case interactive():
+		if match, err = pickShootMatch(matches); err != nil {
+			return err
 		}
-		for _, option := range ar.Options {
-			if _, ok := shootAnnotations[option.Key]; !ok {
-				continue
-			}
-			if shootAnnotations[option.Key] == strconv.FormatBool(option.NotifyIf) {
-				warningMsg += option.Msg
-				warningMsg += "\n"
+	default:
+		fmt.Fprintln(ioStreams.Out, "gardens:")
+		for i, match := range matches {
+			if i == 0 || matches[i-1].Garden != match.Garden {
+				fmt.Fprintln(ioStreams.Out, "- garden: "+match.Garden)
+				fmt.Fprintln(ioStreams.Out, "  shoots:")
 			}
+			fmt.Fprintln(ioStreams.Out, "  - project: "+match.Project)
+			fmt.Fprintln(ioStreams.Out, "    shoot: "+match.Shoot.Name)
 		}
+		return NewAmbiguousMatchError(TargetKindShoot, name, paths)
 	}
 
-	return warningMsg
+	if err := targetGarden(configReader, targetWriter, match.Garden); err != nil {
+		return err
+	}
+	return targetShoot(targetReader, targetWriter, match.Shoot, configReader)
 }
 
-func shootWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
-	if len(args) != 2 {
-		return errors.New("command must be in the format: target shoot NAME")
+//set namespace for current kubectl ctx
+func namespaceWrapper(configReader ConfigReader, targetReader TargetReader, targetWriter TargetWriter, kubectlNameSpace string) error {
+	if kubectlNameSpace == "" {
+		return errors.New("Namespace must be provided")
 	}
+
 	target := targetReader.ReadTarget(pathTarget)
-	if len(target.Stack()) < 1 {
-		return errors.New("no garden cluster targeted")
+	stack := target.Stack()
+	if len(stack) > 0 && stack[len(stack)-1].Kind == TargetKindNamespace {
+		stack = stack[:len(stack)-1]
 	}
-
-	shoots := resolveNameShoot(target, args[1])
-
-	if len(shoots) == 0 {
-		return fmt.Errorf("no match for %q", args[1])
-	} else if len(shoots) == 1 {
-		targetShoot(targetReader, targetWriter, shoots[0], configReader)
-	} else if len(shoots) > 1 {
-		k8sClientToGarden, err := target.K8SClientToKind(TargetKindGarden)
-		checkError(err)
-		fmt.Fprintln(ioStreams.Out, "shoots:")
-		for _, shoot := range shoots {
-			projectName, err := getProjectNameByShootNamespace(k8sClientToGarden, shoot.Namespace)
-			checkError(err)
-
-			fmt.Fprintln(ioStreams.Out, "- project: "+projectName)
-			fmt.Fprintln(ioStreams.Out, "  shoot: "+shoot.Name)
-		}
+	if len(stack) == 0 {
+		return NewNotTargetedError(TargetKindGarden)
+	}
+	target.SetStack(append(stack[:len(stack):len(stack)], TargetMeta{Kind: TargetKindNamespace, Name: kubectlNameSpace}))
+	kubeconfig, context, err := setNamespace(target, configReader, IOStreams{Out: os.Stdout, ErrOut: os.Stderr})
+	if err != nil {
+		return err
+	}
+	if err = targetNamespace(targetWriter, kubectlNameSpace); err != nil {
+		return err
 	}
+
+	fmt.Println("Namespace:")
+	fmt.Printf("Set namespace to %s for current context %s \n", kubectlNameSpace, context)
+	fmt.Println("KUBECONFIG=" + kubeconfig)
 	return nil
 }
 
-//set namespace for current kubectl ctx
-func namespaceWrapper(targetReader TargetReader, targetWriter TargetWriter, kubectlNameSpace string) error {
+// setNamespace validates the namespace at the top of the target stack and writes the kubeconfig of
+// the session, a copy of the kubeconfig of the target with the namespace set in its current context.
+// The cached kubeconfig of the target is left untouched. It returns the path of the kubeconfig of the
+// session and the name of its current context.
+func setNamespace(target TargetInterface, reader ConfigReader, ioStreams IOStreams) (string, string, error) {
+	stack := target.Stack()
+	namespace := stack[len(stack)-1].Name
 
-	err := targetNamespace(targetWriter, kubectlNameSpace)
+	client, err := target.K8SClient()
 	if err != nil {
-		return err
+		return "", "", err
 	}
-
-	if kubectlNameSpace == "" {
-		return errors.New("Namespace must be provided")
+	if err = gardenctl.ValidateNamespace(client, namespace); err != nil {
+		if !apierrors.IsForbidden(err) {
+			return "", "", err
+		}
+		fmt.Fprintf(ioStreams.ErrOut, "Warning: namespace %q could not be validated: %v\n", namespace, err)
 	}
 
-	currentConfig := getKubeConfigOfCurrentTarget()
+	kubeconfig, err := kubeconfigPathOfStack(reader, stack)
+	if err != nil {
+		return "", "", err
+	}
+	return writeSessionKubeconfig(kubeconfig, namespace)
+}
 
-	out, err := ExecCmdReturnOutput("kubectl", "--kubeconfig="+currentConfig, "config", "current-context")
+// writeSessionKubeconfig writes the kubeconfig of the session, a copy of the kubeconfig at path with
+// namespace set in its current context. It returns the path of the copy and the name of its current context.
+func writeSessionKubeconfig(path, namespace string) (string, string, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		fmt.Println(err)
+		return "", "", err
 	}
-	currentConext := strings.TrimSuffix(string(out), "\n")
-	fmt.Println("Namespace:")
-	fmt.Printf("Set namespace to %s for current context %s \n", kubectlNameSpace, currentConext)
-	out, err = ExecCmdReturnOutput("kubectl", "--kubeconfig="+currentConfig, "config", "set-context", currentConext, "--namespace="+kubectlNameSpace)
+	data, context, err := gardenctl.SetKubeconfigNamespace(data, namespace)
 	if err != nil {
-		fmt.Println(err)
-		return err
+		return "", "", fmt.Errorf("could not set namespace %q in kubeconfig %s: %v", namespace, path, err)
 	}
-	fmt.Println(string(out))
+	sessionKubeconfig, err := writeCacheFile(sessionKubeconfigPath(sessionID), data)
+	if err != nil {
+		return "", "", err
+	}
+	return sessionKubeconfig, context, nil
+}
 
-	return nil
+// sessionKubeconfigPath returns the path of the kubeconfig of the session with the given ID in the cache
+func sessionKubeconfigPath(id string) string {
+	return gardenctl.SessionKubeconfigPath(filepath.Join(pathGardenHome, "cache"), id)
 }
 
 //urlWrapper function target garden and shoot in dashboard url
@@ -1174,11 +1161,13 @@ func urlWrapper(targetReader TargetReader, targetWriter TargetWriter, configRead
 	shootName := ""
 	u, err := url.Parse(urlString)
 	if err != nil {
-		fmt.Println("the URL enter is invalid")
-		os.Exit(2)
+		return fmt.Errorf("the URL entered is invalid: %v", err)
 	}
 
-	gardenName := resolveGardenNameFromURL(configReader, u.Host)
+	gardenName, err := resolveGardenNameFromURL(configReader, u.Host)
+	if err != nil {
+		return err
+	}
 
 	re, _ := regexp.Compile(`\/namespace\/[a-z0-9-]*\/shoots\/([a-z0-9-]*)`)
 	values := re.FindStringSubmatch(u.Path)
@@ -1187,24 +1176,23 @@ func urlWrapper(targetReader TargetReader, targetWriter TargetWriter, configRead
 	}
 
 	if shootName == "" {
-		fmt.Println("could not get an valid shoot name from provided URL")
-		os.Exit(2)
+		return errors.New("could not get a valid shoot name from provided URL")
 	}
 
 	gardenArgs := []string{"garden", gardenName}
-	err = gardenWrapper(targetReader, targetWriter, configReader, ioStreams, gardenArgs)
-	checkError(err)
+	if err = gardenWrapper(targetReader, targetWriter, configReader, ioStreams, gardenArgs); err != nil {
+		return err
+	}
 	shootArgs := []string{"shoot", shootName}
-	err = shootWrapper(targetReader, targetWriter, configReader, ioStreams, shootArgs)
-	checkError(err)
-
-	return nil
+	return shootWrapper(targetReader, targetWriter, configReader, ioStreams, shootArgs)
 }
 
 //write current namespace to target
 func targetNamespace(targetWriter TargetWriter, ns string) error {
 	var target Target
-	ReadTarget(pathTarget, &target)
+	if err := ReadTarget(pathTarget, &target); err != nil {
+		return err
+	}
 
 	if len(target.Target) > 4 {
 		return errors.New("the length is greater than 4 and illegal")
@@ -1216,27 +1204,27 @@ func targetNamespace(targetWriter TargetWriter, ns string) error {
 		if string(target.Target[0].Kind) != "garden" {
 			return errors.New("if one element in target, this needs to be garden")
 		}
-		target.Target = append(target.Target, TargetMeta{"namespace", ns})
+		target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 	}
 	if len(target.Target) == 2 {
 		if target.Target[1].Kind != "namespace" {
-			target.Target = append(target.Target, TargetMeta{"namespace", ns})
+			target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 		} else {
 			target.Target = target.Target[:len(target.Target)-1]
-			target.Target = append(target.Target, TargetMeta{"namespace", ns})
+			target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 		}
 	}
 	if len(target.Target) == 3 {
 		if target.Target[2].Kind != "namespace" {
-			target.Target = append(target.Target, TargetMeta{"namespace", ns})
+			target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 		} else {
 			target.Target = target.Target[:len(target.Target)-1]
-			target.Target = append(target.Target, TargetMeta{"namespace", ns})
+			target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 		}
 	}
 	if len(target.Target) == 4 {
 		target.Target = target.Target[:len(target.Target)-1]
-		target.Target = append(target.Target, TargetMeta{"namespace", ns})
+		target.Target = append(target.Target, TargetMeta{Kind: "namespace", Name: ns})
 	}
 
 	err := targetWriter.WriteTarget(pathTarget, &target)
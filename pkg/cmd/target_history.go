@@ -16,9 +16,13 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"strconv"
+	"time"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	"github.com/gardener/gardenctl/pkg/internal/history"
 	"github.com/spf13/cobra"
 )
@@ -29,100 +33,268 @@ const (
 	targetInfoSeed      = "seed"
 	targetInfoShoot     = "shoot"
 	targetInfoNamespace = "namespace"
+	// targetInfoTechnicalID is the technical ID of the targeted shoot
+	targetInfoTechnicalID = "shootTechnicalID"
+
+	defaultHistoryRetention = 30 * 24 * time.Hour
+)
+
+var (
+	// historySince lists the history entries since a duration ago or a point in time
+	historySince string
+	// historyGarden lists the history entries of the gardens matching the pattern
+	historyGarden string
+	// historyOlderThan prunes the history entries older than the duration
+	historyOlderThan time.Duration
+	// historyPruneAll prunes all history entries
+	historyPruneAll bool
 )
 
 //NewHistoryCmd use for list/search targting history
-func NewHistoryCmd(targetWriter TargetWriter, historyWriter HistoryWriter) *cobra.Command {
+func NewHistoryCmd(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "history",
-		Short:        "List/Search targeting history, e.g. \"gardenctl x\"",
-		SilenceUsage: true,
-		Aliases:      []string{"x"},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			h := history.SetPath(pathHistory)
+		Use:   "history [ls|prune|use N]",
+		Short: "List/Search targeting history, e.g. \"gardenctl x\"",
+		Example: `
+	# Select a former target interactively.
+	gardenctl history
 
-			if len(h.Load().Items) <= 0 {
-				fmt.Println("No Target History results")
-				os.Exit(0)
-			}
+	# List the targets of the last two days in the garden prod.
+	gardenctl history ls --since 48h --garden prod -o json
 
-			items := h.Load().Reverse().Select()
+	# Target the second most recent entry of the list again.
+	gardenctl history use 2
 
-			m, err := toMap(items.PromptItem)
-			if err != nil {
-				return err
-			}
-
-			var target Target
-			if val, ok := m[targetInfoGarden]; ok {
-				appendTarget(&target, targetInfoGarden, val)
+	# Remove the entries older than a week.
+	gardenctl history prune --older-than 168h`,
+		SilenceUsage: true,
+		Aliases:      []string{"x"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if !interactive() {
+					return printHistory(ioStreams, outputFormat)
+				}
+				return selectHistory(targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			}
-
-			if val, ok := m[targetInfoProject]; ok {
-				appendTarget(&target, targetInfoProject, val)
+			switch args[0] {
+			case "ls":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: history ls [--since DURATION|TIME] [--garden NAME]")
+				}
+				return printHistory(ioStreams, outputFormat)
+			case "prune":
+				if len(args) != 1 {
+					return errors.New("command must be in the format: history prune [--older-than DURATION] [--all]")
+				}
+				return pruneHistory(ioStreams)
+			case "use":
+				if len(args) != 2 {
+					return errors.New("command must be in the format: history use N")
+				}
+				n, err := strconv.Atoi(args[1])
+				if err != nil || n < 1 {
+					return fmt.Errorf("invalid history entry %q, must be a number as listed by \"gardenctl history ls\"", args[1])
+				}
+				return useHistory(n, targetReader, targetWriter, configReader, historyWriter, ioStreams)
 			}
+			return errors.New("command must be in the format: history [ls|prune|use N]")
+		},
+		ValidArgs: []string{"ls", "prune", "use"},
+	}
 
-			if val, ok := m[targetInfoSeed]; ok {
-				appendTarget(&target, targetInfoSeed, val)
-			}
+	cmd.Flags().StringVar(&historySince, "since", "", "list the entries since a duration ago, e.g. 48h, or a time, e.g. 2020-06-01 or 2020-06-01T08:00:00Z")
+	cmd.Flags().StringVar(&historyGarden, "garden", "", "list the entries of the gardens matching the name or pattern")
+	cmd.Flags().DurationVar(&historyOlderThan, "older-than", defaultHistoryRetention, "prune the entries older than the duration, entries without timestamp are always pruned")
+	cmd.Flags().BoolVar(&historyPruneAll, "all", false, "prune all entries")
 
-			if val, ok := m[targetInfoShoot]; ok {
-				appendTarget(&target, targetInfoShoot, val)
-			}
+	return cmd
+}
 
-			if val, ok := m[targetInfoNamespace]; ok {
-				appendTarget(&target, targetInfoNamespace, val)
+// numberedHistory returns the history entries with their numbers, the most recent entry first with number 1
+func numberedHistory() ([]HistoryEntry, error) {
+	entries, err := history.ReadEntries(pathHistory)
+	if err != nil {
+		return nil, err
+	}
+	numbered := make([]HistoryEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		numbered = append(numbered, HistoryEntry{
+			Number: len(numbered) + 1,
+			Target: historyTargetPath(entries[i]).String(),
+			Entry:  entries[i],
+		})
+	}
+	return numbered, nil
+}
 
-				err := namespaceWrapper(nil, targetWriter, val)
-				if err != nil {
-					return err
-				}
-			}
+// printHistory prints the history entries matching historySince and historyGarden, the most recent entry first
+func printHistory(ioStreams IOStreams, outFormat string) error {
+	var since time.Time
+	if historySince != "" {
+		var err error
+		if since, err = parseSince(historySince, time.Now()); err != nil {
+			return err
+		}
+	}
+	var m *gardenctl.Matcher
+	if historyGarden != "" {
+		var err error
+		if m, err = newMatcher(historyGarden); err != nil {
+			return err
+		}
+	}
 
-			err = targetWriter.WriteTarget(pathTarget, &target)
-			if err != nil {
-				return fmt.Errorf("error write target %s", err)
-			}
+	entries, err := numberedHistory()
+	if err != nil {
+		return err
+	}
+	var matching []HistoryEntry
+	for _, entry := range entries {
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
+		if m != nil && !m.Match(entry.Garden) {
+			continue
+		}
+		matching = append(matching, entry)
+	}
+	return PrintoutObject(HistoryEntries{Entries: matching}, ioStreams.Out, outFormat)
+}
 
-			kubeconfigPathOutput(&target)
+// parseSince parses a duration before now or a point in time
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid value %q of --since, must be a duration, e.g. 48h, or a time, e.g. 2020-06-01 or 2020-06-01T08:00:00Z", value)
+}
 
-			err = historyWriter.WriteStringln(pathHistory, items.Item)
-			if err != nil {
-				return fmt.Errorf("error write history %s", err)
-			}
+// pruneHistory removes the history entries older than historyOlderThan, or all of them with historyPruneAll
+func pruneHistory(ioStreams IOStreams) error {
+	limit := time.Now().Add(-historyOlderThan)
+	removed, err := history.Prune(pathHistory, func(entry history.Entry) bool {
+		return !historyPruneAll && !entry.Timestamp.IsZero() && entry.Timestamp.After(limit)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Pruned %d entries of the target history\n", removed)
+	return nil
+}
 
-			return nil
-		},
+// useHistory targets the history entry with number n again
+func useHistory(n int, targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) error {
+	entries, err := numberedHistory()
+	if err != nil {
+		return err
+	}
+	if n > len(entries) {
+		return NewNotFoundError("history entry %d not found, the history has %d entries", n, len(entries))
 	}
+	return useHistoryEntry(entries[n-1].Entry, targetReader, targetWriter, configReader, historyWriter, ioStreams)
+}
 
-	return cmd
+// selectHistory lets the user select a history entry or a bookmark interactively and targets it again
+func selectHistory(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) error {
+	h, err := history.SetPath(pathHistory).Load()
+	if err != nil {
+		return err
+	}
+	if h.Bookmarks, err = bookmarkPromptItems(); err != nil {
+		return err
+	}
+	if len(h.Items) == 0 && len(h.Bookmarks) == 0 {
+		fmt.Fprintln(ioStreams.Out, "No Target History results")
+		return nil
+	}
+	items, err := h.List().Reverse().Select()
+	if err == history.ErrAborted {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if items.Item == "" {
+		return useBookmark(items.PromptItem.Bookmark, targetReader, targetWriter, configReader, historyWriter, ioStreams)
+	}
+	return useHistoryEntry(items.PromptItem, targetReader, targetWriter, configReader, historyWriter, ioStreams)
 }
 
-func toMap(item history.PromptItem) (map[string]string, error) {
-	toMap := make(map[string]string)
-	tmp, err := json.Marshal(item)
+// useHistoryEntry targets the target of entry and records it as most recent entry
+func useHistoryEntry(entry history.Entry, targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) error {
+	if entry.Garden == "" {
+		return fmt.Errorf("history entry %q has no garden", entry.Cmd)
+	}
+	if err := targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, historyTargetPath(entry).String()); err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
 	if err != nil {
-		return nil, fmt.Errorf("convert error")
+		return err
+	}
+	if err = historyWriter.WriteStringln(pathHistory, string(line)); err != nil {
+		return fmt.Errorf("error write history %s", err)
 	}
+	return nil
+}
 
-	err = json.Unmarshal(tmp, &toMap)
+// useBookmark targets the bookmark with the given name and records it as most recent entry
+func useBookmark(name string, targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) error {
+	bookmark, err := findBookmark(name)
 	if err != nil {
-		return nil, fmt.Errorf("convert error")
+		return err
+	}
+	if bookmark == nil {
+		return NewNotFoundError("bookmark %q not found", name)
+	}
+	if err = targetBookmark(bookmark, targetReader, targetWriter, configReader, ioStreams); err != nil {
+		return err
 	}
-	return toMap, nil
+	return historyWriter.WriteStringln(pathHistory, targetInfo)
 }
 
-func appendTarget(target *Target, targetKind TargetKind, name string) *Target {
-	target.Target = append(target.Target, TargetMeta{targetKind, name})
-	return target
+// historyTargetPath returns the target path of a history entry
+func historyTargetPath(entry history.Entry) *TargetPath {
+	return &TargetPath{
+		Garden:    entry.Garden,
+		Project:   entry.Project,
+		Seed:      entry.Seed,
+		Shoot:     entry.Shoot,
+		Namespace: entry.Namespace,
+	}
 }
 
-func kubeconfigPathOutput(target *Target) {
-	for _, k := range target.Target {
-		if k.Kind != targetInfoProject && k.Kind != TargetKindNamespace {
-			fmt.Println(k.Kind + ":")
-			KUBECONFIG = getKubeConfigOfClusterType(k.Kind)
-			fmt.Println("KUBECONFIG=" + KUBECONFIG)
+// PrintTable prints the history entries as table, wide adds the command
+func (h HistoryEntries) PrintTable(w io.Writer, wide bool) error {
+	header := []string{"NUMBER", "TIME", "TARGET"}
+	if wide {
+		header = append(header, "BOOKMARK", "CMD")
+	}
+	var rows [][]string
+	for _, entry := range h.Entries {
+		timestamp := "-"
+		if !entry.Timestamp.IsZero() {
+			timestamp = entry.Timestamp.Local().Format(time.RFC3339)
 		}
+		row := []string{fmt.Sprint(entry.Number), timestamp, entry.Target}
+		if wide {
+			row = append(row, orDash(entry.Bookmark), orDash(entry.Cmd))
+		}
+		rows = append(rows, row)
+	}
+	return printTable(w, header, rows)
+}
+
+// Names returns the target paths of the history entries
+func (h HistoryEntries) Names() []string {
+	var names []string
+	for _, entry := range h.Entries {
+		names = append(names, entry.Target)
 	}
+	return names
 }
@@ -15,6 +15,14 @@
 package cmd_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/cmd"
 	. "github.com/gardener/gardenctl/pkg/internal/history"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -42,6 +50,19 @@ var _ = Describe("History", func() {
 		})
 	})
 
+	Context("List with more than MaxEntries items", func() {
+		It("should keep the most recent items", func() {
+			h := &History{}
+			for i := 0; i < MaxEntries+2; i++ {
+				h.Items = append(h.Items, fmt.Sprint(i))
+			}
+			out := h.List().Items
+			Expect(out).To(HaveLen(MaxEntries))
+			Expect(out[0]).To(Equal("2"))
+			Expect(out[MaxEntries-1]).To(Equal(fmt.Sprint(MaxEntries + 1)))
+		})
+	})
+
 	Context("Reverse", func() {
 		It("should be a return Reverse order by Descending", func() {
 			out := history.Reverse().Items
@@ -96,4 +117,90 @@ var _ = Describe("History", func() {
 			Expect(out[0].Shoot).To(Equal("shootA"))
 		})
 	})
+
+	Context("history file", func() {
+		var (
+			dir  string
+			path string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "gardenctl-history")
+			Expect(err).NotTo(HaveOccurred())
+			path = filepath.Join(dir, "history")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should read former entries without timestamp and skip invalid lines", func() {
+			Expect(ioutil.WriteFile(path, []byte(`{"Cmd":"gardenctl target shoot api","garden":"prod","project":"core","shoot":"api"}
+no entry
+`), 0644)).To(Succeed())
+			entries, err := ReadEntries(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(Equal([]Entry{{Cmd: "gardenctl target shoot api", Garden: "prod", Project: "core", Shoot: "api"}}))
+		})
+
+		It("should replace a consecutive entry with the same target", func() {
+			first := time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC)
+			Expect(Append(path, Entry{Timestamp: first, Garden: "prod", Project: "core"})).To(Succeed())
+			Expect(Append(path, Entry{Timestamp: first.Add(time.Minute), Garden: "prod", Project: "core", Shoot: "api"})).To(Succeed())
+			Expect(Append(path, Entry{Timestamp: first.Add(2 * time.Minute), Garden: "prod", Project: "core", Shoot: "api", TechnicalID: "shoot--core--api"})).To(Succeed())
+
+			entries, err := ReadEntries(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(Equal([]Entry{
+				{Timestamp: first, Garden: "prod", Project: "core"},
+				{Timestamp: first.Add(2 * time.Minute), Garden: "prod", Project: "core", Shoot: "api", TechnicalID: "shoot--core--api"},
+			}))
+		})
+
+		It("should rotate the file if it exceeds MaxEntries", func() {
+			var lines []string
+			for i := 0; i < MaxEntries; i++ {
+				lines = append(lines, fmt.Sprintf(`{"garden":"prod","shoot":"shoot-%d"}`, i))
+			}
+			Expect(ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)).To(Succeed())
+			Expect(Append(path, Entry{Garden: "prod", Shoot: "latest"})).To(Succeed())
+
+			entries, err := ReadEntries(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(MaxEntries / 2))
+			Expect(entries[len(entries)-1].Shoot).To(Equal("latest"))
+			rotated, err := ReadEntries(path + ".1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rotated).To(HaveLen(MaxEntries/2 + 1))
+			Expect(rotated[0].Shoot).To(Equal("shoot-0"))
+		})
+
+		It("should prune the entries which are not kept", func() {
+			now := time.Now()
+			Expect(ioutil.WriteFile(path, []byte(`{"garden":"prod"}`+"\n"), 0644)).To(Succeed())
+			Expect(Append(path, Entry{Timestamp: now.Add(-48 * time.Hour), Garden: "dev"})).To(Succeed())
+			Expect(Append(path, Entry{Timestamp: now, Garden: "prod"})).To(Succeed())
+
+			removed, err := Prune(path, func(entry Entry) bool {
+				return entry.Timestamp.After(now.Add(-24 * time.Hour))
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(2))
+			entries, err := ReadEntries(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Garden).To(Equal("prod"))
+		})
+	})
+
+	Context("history command", func() {
+		It("should refuse an invalid entry number", func() {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command := cmd.NewHistoryCmd(nil, nil, nil, nil, ioStreams)
+			command.SetArgs([]string{"use", "0"})
+			err := command.Execute()
+			Expect(err).To(MatchError(`invalid history entry "0", must be a number as listed by "gardenctl history ls"`))
+		})
+	})
 })
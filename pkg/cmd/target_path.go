@@ -0,0 +1,371 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// targetPathSeparator separates the garden, project/seed and shoot segments of a target path.
+	targetPathSeparator = "/"
+	// targetPathSeedPrefix marks the second segment of a target path as seed instead of project.
+	targetPathSeedPrefix = "@"
+	// targetPathNamespaceSeparator separates the optional namespace from the rest of a target path.
+	targetPathNamespaceSeparator = ":"
+)
+
+// TargetPath is a parsed target path expression, e.g. "prod/my-project/my-shoot" or "prod/@my-seed/my-shoot:kube-system".
+type TargetPath struct {
+	Garden    string
+	Project   string
+	Seed      string
+	Shoot     string
+	Namespace string
+}
+
+// IsTargetPath returns whether the argument is a target path expression rather than a plain name.
+// Regular expressions like re:^prod or /^prod-(aws|gcp)/ are patterns, not target paths.
+func IsTargetPath(arg string) bool {
+	if strings.Contains(arg, "://") || gardenctl.IsRegexpPattern(arg) {
+		return false
+	}
+	return strings.Contains(arg, targetPathSeparator) || strings.Contains(arg, targetPathNamespaceSeparator)
+}
+
+// ParseTargetPath parses a target path of the form <garden>[/<project>|/@<seed>[/<shoot>]][:<namespace>].
+// The namespace separator is only looked for in the last segment, after a leading re: of a pattern.
+func ParseTargetPath(path string) (*TargetPath, error) {
+	tp := &TargetPath{}
+	rest := path
+	lastSegment := strings.LastIndex(rest, targetPathSeparator) + 1
+	if strings.HasPrefix(rest[lastSegment:], gardenctl.RegexpPrefix) {
+		lastSegment += len(gardenctl.RegexpPrefix)
+	}
+	if i := strings.Index(rest[lastSegment:], targetPathNamespaceSeparator); i >= 0 {
+		i += lastSegment
+		tp.Namespace = rest[i+1:]
+		rest = rest[:i]
+		if tp.Namespace == "" {
+			return nil, fmt.Errorf("invalid target path %q: namespace must not be empty", path)
+		}
+	}
+
+	segments := strings.Split(rest, targetPathSeparator)
+	if len(segments) > 3 {
+		return nil, fmt.Errorf("invalid target path %q: expected <garden>[/<project>|/@<seed>[/<shoot>]][:<namespace>]", path)
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == targetPathSeedPrefix {
+			return nil, fmt.Errorf("invalid target path %q: empty segment", path)
+		}
+	}
+
+	tp.Garden = segments[0]
+	if len(segments) > 1 {
+		if strings.HasPrefix(segments[1], targetPathSeedPrefix) {
+			tp.Seed = strings.TrimPrefix(segments[1], targetPathSeedPrefix)
+		} else {
+			tp.Project = segments[1]
+		}
+	}
+	if len(segments) > 2 {
+		tp.Shoot = segments[2]
+	}
+
+	return tp, nil
+}
+
+// String returns the canonical form of the target path.
+func (tp *TargetPath) String() string {
+	path := tp.Garden
+	if tp.Project != "" {
+		path += targetPathSeparator + tp.Project
+	} else if tp.Seed != "" {
+		path += targetPathSeparator + targetPathSeedPrefix + tp.Seed
+	}
+	if tp.Shoot != "" {
+		path += targetPathSeparator + tp.Shoot
+	}
+	if tp.Namespace != "" {
+		path += targetPathNamespaceSeparator + tp.Namespace
+	}
+	return path
+}
+
+// TargetPathFromStack converts a target stack into a target path.
+func TargetPathFromStack(stack []TargetMeta) *TargetPath {
+	tp := &TargetPath{}
+	for _, t := range stack {
+		switch t.Kind {
+		case TargetKindGarden:
+			tp.Garden = t.Name
+		case TargetKindProject:
+			tp.Project = t.Name
+		case TargetKindSeed:
+			tp.Seed = t.Name
+		case TargetKindShoot:
+			tp.Shoot = t.Name
+		case TargetKindNamespace:
+			tp.Namespace = t.Name
+		}
+	}
+	return tp
+}
+
+// targetPathWrapper resolves a target path completely before the target is written exactly once,
+// so that a failure in any segment leaves the current target untouched.
+func targetPathWrapper(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, path string) error {
+	tp, err := ParseTargetPath(path)
+	if err != nil {
+		return err
+	}
+
+	target := targetReader.ReadTarget(pathTarget)
+	shoot, err := resolveTargetPath(target, configReader, tp)
+	if err != nil {
+		return err
+	}
+
+	var kubeconfigPath string
+	if tp.Shoot != "" {
+		if err = enforceAccessRestrictions(target, shoot, configReader, ioStreams); err != nil {
+			return err
+		}
+		if kubeconfigPath, err = cacheShootKubeconfigs(target, shoot, tp); err != nil {
+			return err
+		}
+	} else if tp.Seed != "" {
+		if kubeconfigPath, err = cacheSeedKubeconfigForPath(target, tp.Garden, tp.Seed); err != nil {
+			return err
+		}
+	}
+
+	var sessionKubeconfig, context string
+	if tp.Namespace != "" {
+		if sessionKubeconfig, context, err = setNamespace(target, configReader, ioStreams); err != nil {
+			return err
+		}
+	}
+
+	if err = targetWriter.WriteTarget(pathTarget, target); err != nil {
+		return err
+	}
+	toTargetInfo(target)
+	if shoot != nil {
+		targetInfo[targetInfoTechnicalID] = shoot.Status.TechnicalID
+	}
+
+	if tp.Namespace != "" {
+		fmt.Fprintln(ioStreams.Out, "Namespace:")
+		fmt.Fprintf(ioStreams.Out, "Set namespace to %s for current context %s \n", tp.Namespace, context)
+	}
+
+	if shoot != nil {
+		fmt.Fprintln(ioStreams.Out, "Shoot:")
+	} else if tp.Seed != "" {
+		fmt.Fprintln(ioStreams.Out, "Seed:")
+	} else if tp.Project == "" {
+		kubeconfigPath = TidyKubeconfigWithHomeDir(getGardenKubeConfigViaGardenName(configReader, tp.Garden))
+		fmt.Fprintln(ioStreams.Out, "Garden:")
+	}
+	if sessionKubeconfig != "" {
+		kubeconfigPath = sessionKubeconfig
+	}
+	if kubeconfigPath != "" {
+		fmt.Fprintln(ioStreams.Out, "KUBECONFIG="+kubeconfigPath)
+	}
+
+	return nil
+}
+
+// resolveTargetPath resolves every segment of the target path and sets the resulting stack on target.
+// The resolved shoot is returned if the path contains one.
+func resolveTargetPath(target TargetInterface, configReader ConfigReader, tp *TargetPath) (*gardencorev1beta1.Shoot, error) {
+	gardens, err := resolveNameGarden(configReader, tp.Garden)
+	if err != nil {
+		return nil, err
+	}
+	if tp.Garden, err = pickSingleMatch(TargetKindGarden, tp.Garden, gardens); err != nil {
+		return nil, err
+	}
+	stack := []TargetMeta{{Kind: TargetKindGarden, Name: tp.Garden}}
+
+	// All further lookups go against the garden of the path, not against the currently targeted one.
+	target.SetStack(stack)
+
+	if tp.Project != "" {
+		projects, err := resolveNameProject(target, tp.Project)
+		if err != nil {
+			return nil, err
+		}
+		if tp.Project, err = pickSingleMatch(TargetKindProject, tp.Project, projects); err != nil {
+			return nil, err
+		}
+		stack = append(stack, TargetMeta{Kind: TargetKindProject, Name: tp.Project})
+	} else if tp.Seed != "" {
+		seeds, err := resolveNameSeed(target, tp.Seed)
+		if err != nil {
+			return nil, err
+		}
+		if tp.Seed, err = pickSingleMatch(TargetKindSeed, tp.Seed, seeds); err != nil {
+			return nil, err
+		}
+		stack = append(stack, TargetMeta{Kind: TargetKindSeed, Name: tp.Seed})
+	}
+
+	var shoot *gardencorev1beta1.Shoot
+	if tp.Shoot != "" {
+		target.SetStack(stack)
+		shoots, err := resolveNameShoot(target, tp.Shoot)
+		if err != nil {
+			return nil, err
+		}
+		if len(shoots) > 1 && interactive() {
+			picked, err := pickShoot(target, shoots)
+			if err != nil {
+				return nil, err
+			}
+			shoots = []gardencorev1beta1.Shoot{picked}
+		}
+		var names []string
+		for _, s := range shoots {
+			names = append(names, s.Namespace+"/"+s.Name)
+		}
+		if err := checkSingleMatch(TargetKindShoot, tp.Shoot, names); err != nil {
+			return nil, err
+		}
+		shoot = &shoots[0]
+		tp.Shoot = shoot.Name
+		stack = append(stack, TargetMeta{Kind: TargetKindShoot, Name: tp.Shoot})
+	}
+
+	if tp.Namespace != "" {
+		stack = append(stack, TargetMeta{Kind: TargetKindNamespace, Name: tp.Namespace})
+	}
+
+	target.SetStack(stack)
+	return shoot, nil
+}
+
+// pickSingleMatch returns the only name of matches. Several matches are picked interactively if the
+// session is interactive and are an error otherwise.
+func pickSingleMatch(kind TargetKind, name string, matches []string) (string, error) {
+	if len(matches) > 1 && interactive() {
+		return pickName(kind, matches)
+	}
+	if err := checkSingleMatch(kind, name, matches); err != nil {
+		return "", err
+	}
+	return matches[0], nil
+}
+
+// checkSingleMatch returns an error unless exactly one name matched.
+func checkSingleMatch(kind TargetKind, name string, matches []string) error {
+	switch len(matches) {
+	case 0:
+		return NewNotFoundError("no %s matches %q", kind, name)
+	case 1:
+		return nil
+	default:
+		return NewAmbiguousMatchError(kind, name, matches)
+	}
+}
+
+// cacheSeedKubeconfigForPath caches the kubeconfig of the seed of a target path and returns its path.
+func cacheSeedKubeconfigForPath(target TargetInterface, gardenName, seedName string) (string, error) {
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return "", err
+	}
+	seed, err := gardenClientset.CoreV1beta1().Seeds().Get(seedName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
+	return cacheSeedKubeconfig(gardenClient, gardenName, seed)
+}
+
+// cacheShootKubeconfigs caches the kubeconfigs of the shoot and its seed and returns the path of the shoot kubeconfig.
+func cacheShootKubeconfigs(target TargetInterface, shoot *gardencorev1beta1.Shoot, tp *TargetPath) (string, error) {
+	if shoot.Spec.SeedName == nil {
+		return "", fmt.Errorf("shoot %q is not scheduled to a seed yet", shoot.Name)
+	}
+
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return "", err
+	}
+	seed, err := gardenClientset.CoreV1beta1().Seeds().Get(*shoot.Spec.SeedName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	gardenClient, err := target.K8SClientToKind(TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := cacheSeedKubeconfig(gardenClient, tp.Garden, seed); err != nil {
+		if !apierrors.IsForbidden(err) {
+			return "", err
+		}
+		if tp.Seed != "" {
+			return "", fmt.Errorf("you are user role and can't target shoot via seed, please target shoot via project")
+		}
+		fmt.Printf(warningColor, "\nWarning:\nYou are user role!\n\n")
+	}
+
+	var shootCacheDir string
+	if tp.Seed != "" {
+		shootCacheDir = filepath.Join(pathGardenHome, "cache", tp.Garden, "seeds", tp.Seed, shoot.Name)
+	} else {
+		shootCacheDir = filepath.Join(pathGardenHome, "cache", tp.Garden, "projects", tp.Project, shoot.Name)
+	}
+
+	shootKubeconfigSecret, err := gardenClient.CoreV1().Secrets(shoot.Namespace).Get(fmt.Sprintf("%s.kubeconfig", shoot.Name), metav1.GetOptions{})
+	if err != nil {
+		fmt.Println("Kubeconfig not available, using empty one. Be aware only a limited number of cmds are available!")
+		return writeCachedKubeconfig(shootCacheDir, nil)
+	}
+	return writeCachedKubeconfig(shootCacheDir, shootKubeconfigSecret.Data["kubeconfig"])
+}
+
+// cacheSeedKubeconfig fetches the kubeconfig secret of the seed and stores it in the cache of the garden.
+func cacheSeedKubeconfig(gardenClient kubernetes.Interface, gardenName string, seed *gardencorev1beta1.Seed) (string, error) {
+	if seed.Spec.SecretRef == nil {
+		return "", fmt.Errorf("Spec.SecretRef is missing in seed %q, seed not reachable", seed.Name)
+	}
+	secret, err := gardenClient.CoreV1().Secrets(seed.Spec.SecretRef.Namespace).Get(seed.Spec.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return writeCachedKubeconfig(filepath.Join(pathGardenHome, "cache", gardenName, "seeds", seed.Name), secret.Data["kubeconfig"])
+}
+
+// writeCachedKubeconfig writes kubeconfig.yaml into the given cache directory and returns its path.
+func writeCachedKubeconfig(dir string, kubeconfig []byte) (string, error) {
+	return writeCacheFile(filepath.Join(dir, "kubeconfig.yaml"), kubeconfig)
+}
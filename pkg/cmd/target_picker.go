@@ -0,0 +1,205 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/manifoldco/promptui"
+	"golang.org/x/crypto/ssh/terminal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// noInteractive disables the interactive picker for ambiguous or omitted names
+var noInteractive bool
+
+// pickItem is a candidate offered by the interactive picker
+type pickItem struct {
+	Name       string
+	Garden     string
+	Project    string
+	Seed       string
+	Hibernated bool
+	Health     string
+}
+
+// Label returns the name shown for the item in the picker
+func (i pickItem) Label() string {
+	var segments []string
+	for _, segment := range []string{i.Garden, i.Project, i.Name} {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// interactive returns true if ambiguous or omitted names are picked interactively
+func interactive() bool {
+	return !noInteractive && terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// pickTarget lets the user select one of items with a fuzzy search and returns its index
+func pickTarget(kind TargetKind, items []pickItem) (int, error) {
+	templates := &promptui.SelectTemplates{
+		Label:    "{{ . }}?",
+		Active:   "\U0001F4CC {{ .Label | cyan }} ",
+		Inactive: "  {{ .Label | cyan }}",
+		Selected: "\U0001F4CC {{ .Label | red | cyan }} ",
+	}
+	if kind == TargetKindShoot {
+		templates.Details = `
+--------- Shoot Info ----------
+{{ if .Garden }}{{ "Garden:" | faint }}	{{ .Garden }}
+{{ end }}{{ "Project:" | faint }}	{{ .Project }}
+{{ "Seed:" | faint }}	{{ .Seed }}
+{{ "Hibernated:" | faint }}	{{ .Hibernated }}
+{{ "Health:" | faint }}	{{ if eq .Health "` + gardenctl.ShootHealthReady + `" }}{{ .Health | green }}{{ else }}{{ .Health | red }}{{ end }}
+`
+	}
+	searcher := func(input string, index int) bool {
+		return fuzzyMatch(input, items[index].Label())
+	}
+
+	prompt := promptui.Select{
+		Label:     fmt.Sprintf("Select %s", kind),
+		Items:     items,
+		Templates: templates,
+		Size:      10,
+		Searcher:  searcher,
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return -1, fmt.Errorf("no %s selected: %w", kind, err)
+	}
+	return i, nil
+}
+
+// fuzzyMatch returns true if all characters of input occur in name in the same order
+func fuzzyMatch(input, name string) bool {
+	name = strings.ToLower(name)
+	for _, c := range strings.ToLower(strings.Replace(input, " ", "", -1)) {
+		i := strings.IndexRune(name, c)
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+	}
+	return true
+}
+
+// pickName lets the user select one of names and returns it
+func pickName(kind TargetKind, names []string) (string, error) {
+	items := make([]pickItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, pickItem{Name: name})
+	}
+	i, err := pickTarget(kind, items)
+	if err != nil {
+		return "", err
+	}
+	return names[i], nil
+}
+
+// pickShoot lets the user select one of shoots and returns it
+func pickShoot(target TargetInterface, shoots []gardencorev1beta1.Shoot) (gardencorev1beta1.Shoot, error) {
+	gardenClientset, err := target.GardenerClient()
+	if err != nil {
+		return gardencorev1beta1.Shoot{}, err
+	}
+	projectList, err := gardenClientset.CoreV1beta1().Projects().List(metav1.ListOptions{})
+	if err != nil {
+		return gardencorev1beta1.Shoot{}, err
+	}
+	projectOfNamespace := map[string]string{}
+	for _, project := range projectList.Items {
+		if project.Spec.Namespace != nil {
+			projectOfNamespace[*project.Spec.Namespace] = project.Name
+		}
+	}
+
+	items := make([]pickItem, 0, len(shoots))
+	for _, shoot := range shoots {
+		items = append(items, shootPickItem(shoot, projectOfNamespace[shoot.Namespace]))
+	}
+	i, err := pickTarget(TargetKindShoot, items)
+	if err != nil {
+		return gardencorev1beta1.Shoot{}, err
+	}
+	return shoots[i], nil
+}
+
+// pickShootMatch lets the user select one of the shoots found in several gardens and returns it
+func pickShootMatch(matches []gardenctl.ShootMatch) (gardenctl.ShootMatch, error) {
+	items := make([]pickItem, 0, len(matches))
+	for _, match := range matches {
+		item := shootPickItem(match.Shoot, match.Project)
+		item.Garden = match.Garden
+		items = append(items, item)
+	}
+	i, err := pickTarget(TargetKindShoot, items)
+	if err != nil {
+		return gardenctl.ShootMatch{}, err
+	}
+	return matches[i], nil
+}
+
+// shootPickItem returns the picker item of shoot in the given project
+func shootPickItem(shoot gardencorev1beta1.Shoot, project string) pickItem {
+	item := pickItem{
+		Name:       shoot.Name,
+		Project:    project,
+		Hibernated: shoot.Status.IsHibernated,
+		Health:     gardenctl.ShootHealth(&shoot),
+	}
+	if shoot.Spec.SeedName != nil {
+		item.Seed = *shoot.Spec.SeedName
+	}
+	return item
+}
+
+// pickNamespace lets the user select one of the namespaces of the cluster of the current target
+func pickNamespace(targetReader TargetReader) (string, error) {
+	target := targetReader.ReadTarget(pathTarget)
+	if len(target.Stack()) < 1 {
+		return "", NewNotTargetedError(TargetKindGarden)
+	}
+	kind := TargetKindGarden
+	for _, meta := range target.Stack() {
+		if meta.Kind == TargetKindSeed || meta.Kind == TargetKindShoot {
+			kind = meta.Kind
+		}
+	}
+	client, err := target.K8SClientToKind(kind)
+	if err != nil {
+		return "", err
+	}
+	namespaceList, err := client.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, namespace := range namespaceList.Items {
+		names = append(names, namespace.Name)
+	}
+	if len(names) == 0 {
+		return "", NewNotFoundError("no namespaces found")
+	}
+	return pickName(TargetKindNamespace, names)
+}
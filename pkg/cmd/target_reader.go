@@ -16,15 +16,23 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"os"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
-// ReadTarget returns the current target.
+// ReadTarget returns the current target, which is empty if the target file cannot be read.
 func (r *GardenctlTargetReader) ReadTarget(targetPath string) TargetInterface {
 	var target Target
-	ReadTarget(targetPath, &target)
+	if err := ReadTarget(targetPath, &target); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read target file %s: %v\n", targetPath, err)
+		target.Target = nil
+	}
+	target.clients = r.ClientFactory
 	return &target
 }
 
@@ -40,21 +48,7 @@ func (t *Target) SetStack(stack []TargetMeta) {
 
 // Kind returns the current target kind.
 func (t *Target) Kind() (TargetKind, error) {
-	length := len(t.Target)
-	switch length {
-	case 1:
-		return TargetKindGarden, nil
-	case 2:
-		if t.Target[1].Kind == "seed" {
-			return TargetKindSeed, nil
-		}
-
-		return TargetKindProject, nil
-	case 3:
-		return TargetKindShoot, nil
-	default:
-		return "", errors.New("no target selected")
-	}
+	return gardenctl.KindOfStack(t.Target)
 }
 
 // K8SClient returns a kubernetes client configured against the current target.
@@ -64,15 +58,58 @@ func (t *Target) K8SClient() (kubernetes.Interface, error) {
 		return nil, err
 	}
 
-	return clientToTarget(kind)
+	return t.K8SClientToKind(kind)
 }
 
 // K8SClientToKind returns a kubernetes client configured against the given target <kind>.
 func (t *Target) K8SClientToKind(kind TargetKind) (kubernetes.Interface, error) {
-	return clientToTarget(kind)
+	clients, err := t.clientFactory()
+	if err != nil {
+		return nil, err
+	}
+	return clients.Kubernetes(t.Target, kind)
+}
+
+// RESTConfigToKind returns the rest config of the cluster of the given target <kind>.
+func (t *Target) RESTConfigToKind(kind TargetKind) (*rest.Config, error) {
+	clients, err := t.clientFactory()
+	if err != nil {
+		return nil, err
+	}
+	return clients.RESTConfig(t.Target, kind)
+}
+
+// KubeconfigPathToKind returns the path to the kubeconfig of the cluster of the given target <kind>.
+func (t *Target) KubeconfigPathToKind(kind TargetKind) (string, error) {
+	clients, err := t.clientFactory()
+	if err != nil {
+		return "", err
+	}
+	return clients.KubeconfigPath(t.Target, kind)
 }
 
-// GardenerClient returns a gardener client
+// GardenerClient returns a gardener client for the garden of the target stack
 func (t *Target) GardenerClient() (gardencoreclientset.Interface, error) {
-	return gardencoreclientset.NewForConfig(NewConfigFromBytes(getKubeConfigOfClusterType(TargetKindGarden)))
+	clients, err := t.clientFactory()
+	if err != nil {
+		return nil, err
+	}
+	return clients.Gardener(t.Target)
+}
+
+// GardenerClientToGarden returns a gardener client for the garden with the given name
+func (t *Target) GardenerClientToGarden(name string) (gardencoreclientset.Interface, error) {
+	clients, err := t.clientFactory()
+	if err != nil {
+		return nil, err
+	}
+	return clients.Gardener([]TargetMeta{{Kind: TargetKindGarden, Name: name}})
+}
+
+// clientFactory returns the client factory of the target, which is only set if the target was read via a TargetReader
+func (t *Target) clientFactory() (ClientFactory, error) {
+	if t.clients == nil {
+		return nil, errors.New("target has no client factory, it must be read via a TargetReader")
+	}
+	return t.clients, nil
 }
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/gardener/gardenctl/pkg/internal/history"
+)
+
+// targetPrevious targets the most recent target of the session history which differs from the current target,
+// so that repeated calls toggle between two targets
+func targetPrevious(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams) error {
+	entries, err := history.ReadEntries(pathHistory)
+	if err != nil {
+		return err
+	}
+	current := TargetPathFromStack(targetReader.ReadTarget(pathTarget).Stack()).String()
+	for i := len(entries) - 1; i >= 0; i-- {
+		path := historyTargetPath(entries[i]).String()
+		if entries[i].Garden == "" || path == current {
+			continue
+		}
+		return targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, path)
+	}
+	return NewNotFoundError("no previous target in the history of the session")
+}
+
+// pushTarget saves the current target on the pushed targets of the session. If a target path, bookmark or
+// name is given, it is targeted and the former target is only saved if that succeeds.
+func pushTarget(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, args []string) error {
+	stack := targetReader.ReadTarget(pathTarget).Stack()
+	if len(stack) == 0 {
+		return errTargetStackEmpty
+	}
+	if len(args) == 1 {
+		if err := targetArgument(targetReader, targetWriter, configReader, ioStreams, args[0]); err != nil {
+			return err
+		}
+	}
+	if err := gardenctl.PushTarget(pathPushedTargets, stack); err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Pushed %s\n", TargetPathFromStack(stack).String())
+	return nil
+}
+
+// popTarget targets the most recently pushed target of the session again and removes it from the pushed targets
+func popTarget(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams) error {
+	err := gardenctl.PopTarget(pathPushedTargets, func(stack []TargetMeta) error {
+		return targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, TargetPathFromStack(stack).String())
+	})
+	if err == gardenctl.ErrNoPushedTarget {
+		return NewNotFoundError("%v", err)
+	}
+	return err
+}
+
+// targetArgument targets a target path, a bookmark or a name
+func targetArgument(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, ioStreams IOStreams, arg string) error {
+	if IsTargetPath(arg) {
+		return targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, arg)
+	}
+	bookmark, err := findBookmark(arg)
+	if err != nil {
+		return err
+	}
+	if bookmark != nil {
+		return targetBookmark(bookmark, targetReader, targetWriter, configReader, ioStreams)
+	}
+	return targetName(targetReader, targetWriter, configReader, ioStreams, arg)
+}
+
+// dropAll drops everything but the garden from the target stack and targets the garden again
+func dropAll(targetReader TargetReader, targetWriter TargetWriter, configReader ConfigReader, historyWriter HistoryWriter, ioStreams IOStreams) error {
+	stack := targetReader.ReadTarget(pathTarget).Stack()
+	if len(stack) == 0 {
+		return errTargetStackEmpty
+	}
+	if stack[0].Kind != TargetKindGarden {
+		return errors.New("the target stack does not start with a garden")
+	}
+	for i := len(stack) - 1; i > 0; i-- {
+		fmt.Fprintf(ioStreams.Out, "Dropped %s %s\n", stack[i].Kind, stack[i].Name)
+	}
+	if err := targetPathWrapper(targetReader, targetWriter, configReader, ioStreams, stack[0].Name); err != nil {
+		return err
+	}
+	return historyWriter.WriteStringln(pathHistory, targetInfo)
+}
@@ -15,6 +15,8 @@
 package cmd_test
 
 import (
+	"errors"
+
 	"github.com/gardener/gardenctl/pkg/cmd"
 	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
 
@@ -158,7 +160,7 @@ var _ = Describe("Target command", func() {
 					Kind: cmd.TargetKindGarden,
 					Name: "prod",
 				},
-			}).Times(3)
+			}).AnyTimes()
 			clientSet := gardencorefake.NewSimpleClientset()
 			target.EXPECT().GardenerClient().Return(clientSet, nil)
 
@@ -172,13 +174,12 @@ var _ = Describe("Target command", func() {
 
 		It("targeting shoot name with multiple matches across projects", func() {
 			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
-			historyWriter.EXPECT().WriteStringln(gomock.Any(), gomock.Any()).Return(nil)
 			target.EXPECT().Stack().Return([]cmd.TargetMeta{
 				{
 					Kind: cmd.TargetKindGarden,
 					Name: "prod",
 				},
-			}).Times(3)
+			}).AnyTimes()
 			clientSet := gardencorefake.NewSimpleClientset(
 				&gardencorev1beta1.Shoot{
 					ObjectMeta: metav1.ObjectMeta{
@@ -217,12 +218,179 @@ var _ = Describe("Target command", func() {
 			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
 			err := execute(command, []string{"shoot", "foo"})
 
-			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.IsAmbiguousMatch(err)).To(BeTrue())
+			Expect(cmd.ExitCode(err)).To(Equal(cmd.ExitCodeAmbiguousMatch))
 			Expect(out.String()).To(ContainSubstring("- project: validation"))
 			Expect(out.String()).To(ContainSubstring("- project: prod"))
 		})
 	})
 
+	Context("with target path", func() {
+		var gardenConfig = &cmd.GardenConfig{
+			GardenClusters: []cmd.GardenClusterMeta{
+				{
+					Name: "prod",
+				},
+			},
+		}
+
+		It("should resolve garden and project and write the target once", func() {
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+			historyWriter.EXPECT().WriteStringln(gomock.Any(), gomock.Any()).Return(nil)
+
+			clientSet := gardencorefake.NewSimpleClientset(&gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "myproject",
+				},
+			})
+			target.EXPECT().GardenerClient().Return(clientSet, nil)
+
+			expectedStack := []cmd.TargetMeta{
+				{
+					Kind: cmd.TargetKindGarden,
+					Name: "prod",
+				},
+				{
+					Kind: cmd.TargetKindProject,
+					Name: "myproject",
+				},
+			}
+			gomock.InOrder(
+				target.EXPECT().SetStack(expectedStack[:1]),
+				target.EXPECT().SetStack(expectedStack),
+			)
+			target.EXPECT().Stack().Return(expectedStack)
+			targetWriter.EXPECT().WriteTarget(gomock.Any(), target).Times(1)
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
+			err := execute(command, []string{"prod/myproject"})
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not write the target when a segment does not match", func() {
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+			target.EXPECT().SetStack(gomock.Any())
+			target.EXPECT().GardenerClient().Return(gardencorefake.NewSimpleClientset(), nil)
+			targetWriter.EXPECT().WriteTarget(gomock.Any(), gomock.Any()).Times(0)
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
+			err := execute(command, []string{"prod/foo/bar"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no project matches \"foo\""))
+		})
+
+		It("should not write the target when a segment is ambiguous in a non-interactive session", func() {
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+			target.EXPECT().SetStack(gomock.Any())
+			target.EXPECT().GardenerClient().Return(gardencorefake.NewSimpleClientset(
+				&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "api-dev"}},
+				&gardencorev1beta1.Project{ObjectMeta: metav1.ObjectMeta{Name: "api-prod"}},
+			), nil)
+			targetWriter.EXPECT().WriteTarget(gomock.Any(), gomock.Any()).Times(0)
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
+			err := execute(command, []string{"prod/re:^api"})
+
+			Expect(cmd.IsAmbiguousMatch(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`"re:^api" matches 2 projects: api-dev, api-prod`))
+		})
+
+		It("should not write the target when the garden does not match", func() {
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
+			err := execute(command, []string{"dev/myproject"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no garden matches \"dev\""))
+		})
+	})
+
+	Context("with all gardens", func() {
+		It("targeting shoot name with matches in several gardens", func() {
+			gardenConfig := &cmd.GardenConfig{
+				GardenClusters: []cmd.GardenClusterMeta{{Name: "prod"}, {Name: "offline"}, {Name: "dev"}},
+			}
+			configReader.EXPECT().ReadConfig(gomock.Any()).Return(gardenConfig)
+			targetReader.EXPECT().ReadTarget(gomock.Any()).Return(target)
+
+			gardenClient := func(project string) *gardencorefake.Clientset {
+				namespace := "garden-" + project
+				return gardencorefake.NewSimpleClientset(
+					&gardencorev1beta1.Project{
+						ObjectMeta: metav1.ObjectMeta{Name: project},
+						Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace},
+					},
+					&gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: namespace},
+					},
+				)
+			}
+			target.EXPECT().GardenerClientToGarden("prod").Return(gardenClient("core"), nil)
+			target.EXPECT().GardenerClientToGarden("dev").Return(gardenClient("test"), nil)
+			target.EXPECT().GardenerClientToGarden("offline").Return(nil, errors.New("connection refused"))
+
+			ioStreams, _, out, errOut := cmd.NewTestIOStreams()
+			command = cmd.NewTargetCmd(targetReader, targetWriter, configReader, ioStreams, kcReader, historyWriter)
+			err := execute(command, []string{"shoot", "foo", "--all-gardens"})
+
+			Expect(cmd.IsAmbiguousMatch(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`"foo" matches 2 shoots: prod/core/foo, dev/test/foo`))
+			Expect(out.String()).To(Equal("gardens:\n- garden: prod\n  shoots:\n  - project: core\n    shoot: foo\n- garden: dev\n  shoots:\n  - project: test\n    shoot: foo\n"))
+			Expect(errOut.String()).To(Equal("Warning: skipping garden offline, it could not be searched: connection refused\n"))
+		})
+	})
+
+	DescribeTable("#ParseTargetPath",
+		func(path string, expected *cmd.TargetPath, expectedErr string) {
+			tp, err := cmd.ParseTargetPath(path)
+			if expectedErr != "" {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(expectedErr))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tp).To(Equal(expected))
+			Expect(tp.String()).To(Equal(path))
+		},
+		Entry("garden and project", "prod/my-project", &cmd.TargetPath{Garden: "prod", Project: "my-project"}, ""),
+		Entry("shoot via project", "prod/my-project/my-shoot", &cmd.TargetPath{Garden: "prod", Project: "my-project", Shoot: "my-shoot"}, ""),
+		Entry("shoot via seed", "prod/@seed-aws-eu1/my-shoot", &cmd.TargetPath{Garden: "prod", Seed: "seed-aws-eu1", Shoot: "my-shoot"}, ""),
+		Entry("shoot with namespace", "prod/my-project/my-shoot:kube-system", &cmd.TargetPath{Garden: "prod", Project: "my-project", Shoot: "my-shoot", Namespace: "kube-system"}, ""),
+		Entry("garden with namespace", "prod:garden", &cmd.TargetPath{Garden: "prod", Namespace: "garden"}, ""),
+		Entry("shoot pattern", "prod/my-project/re:^api", &cmd.TargetPath{Garden: "prod", Project: "my-project", Shoot: "re:^api"}, ""),
+		Entry("shoot pattern with namespace", "prod/my-project/re:^api:kube-system", &cmd.TargetPath{Garden: "prod", Project: "my-project", Shoot: "re:^api", Namespace: "kube-system"}, ""),
+		Entry("garden pattern with namespace", "re:^prod:garden", &cmd.TargetPath{Garden: "re:^prod", Namespace: "garden"}, ""),
+		Entry("empty segment", "prod//my-shoot", nil, "empty segment"),
+		Entry("empty seed", "prod/@/my-shoot", nil, "empty segment"),
+		Entry("empty namespace", "prod/my-project:", nil, "namespace must not be empty"),
+		Entry("too many segments", "prod/my-project/my-shoot/foo", nil, "expected <garden>"),
+	)
+
+	DescribeTable("#IsTargetPath",
+		func(arg string, expected bool) {
+			Expect(cmd.IsTargetPath(arg)).To(Equal(expected))
+		},
+		Entry("plain name", "my-shoot", false),
+		Entry("garden and project", "prod/my-project", true),
+		Entry("garden with namespace", "prod:garden", true),
+		Entry("url", "https://example.com", false),
+		Entry("regexp with prefix", "re:^prod-(aws|gcp)", false),
+		Entry("regexp with prefix and slash", "re:^prod/core", false),
+		Entry("regexp in slashes", "/^prod-(aws|gcp)/", false),
+		Entry("regexp in slashes with colon", "/^prod:[a-z]+/", false),
+	)
+
 	type targetCase struct {
 		args        []string
 		expectedErr string
@@ -266,5 +434,37 @@ var _ = Describe("Target command", func() {
 			args:        []string{"server"},
 			expectedErr: "command must be in the format: target server NAME",
 		}),
+		Entry("with missing shoot name and no interactive picker", targetCase{
+			args:        []string{"shoot", "--no-interactive"},
+			expectedErr: "command must be in the format: target shoot NAME",
+		}),
+		Entry("with missing namespace name and no interactive picker", targetCase{
+			args:        []string{"namespace", "--no-interactive"},
+			expectedErr: "command must be in the format: target namespace NAME",
+		}),
+		Entry("with previous target and a name", targetCase{
+			args:        []string{"-", "prod"},
+			expectedErr: "command must be in the format: target -",
+		}),
+		Entry("with pop and a name", targetCase{
+			args:        []string{"pop", "prod"},
+			expectedErr: "command must be in the format: target pop",
+		}),
+		Entry("with save without bookmark name", targetCase{
+			args:        []string{"save"},
+			expectedErr: "command must be in the format: target save BOOKMARK",
+		}),
+		Entry("with save of a reserved bookmark name", targetCase{
+			args:        []string{"save", "shoot"},
+			expectedErr: `invalid bookmark name "shoot", it is an argument of the target command`,
+		}),
 	)
+
+	Context("bookmark command", func() {
+		It("should require the name of the bookmark to remove", func() {
+			ioStreams, _, _, _ := cmd.NewTestIOStreams()
+			err := execute(cmd.NewBookmarkCmd(ioStreams), []string{"rm"})
+			Expect(err).To(MatchError("command must be in the format: bookmark rm NAME"))
+		})
+	})
 })
@@ -15,7 +15,6 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,7 +25,7 @@ import (
 )
 
 // NewTerraformCmd returns a new terraform command.
-func NewTerraformCmd(targetReader TargetReader) *cobra.Command {
+func NewTerraformCmd(targetReader TargetReader, configReader ConfigReader, ioStreams IOStreams) *cobra.Command {
 	return &cobra.Command{
 		Use:          "terraform <args>",
 		Short:        "e.g. \"gardenctl terraform init\"",
@@ -34,23 +33,28 @@ func NewTerraformCmd(targetReader TargetReader) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			target := targetReader.ReadTarget(pathTarget)
 			if !CheckShootIsTargeted(target) {
-				return errors.New("no shoot targeted")
+				return NewNotTargetedError(TargetKindShoot)
+			}
+			if !IsReadOnlyTerraformCommand(args) {
+				if err := checkReadOnly(target, configReader, "terraform "+strings.Join(args, " ")); err != nil {
+					return err
+				}
+			}
+			if err := enforceTargetAccessRestrictions(target, configReader, ioStreams); err != nil {
+				return err
 			}
 
 			arguments := "terraform " + strings.Join(args[:], " ")
-			terraform(arguments, targetReader)
-
-			return nil
+			return terraform(arguments, targetReader)
 		},
 	}
 }
 
 // terraform executes a terraform command on targeted cluster
-func terraform(args string, targetReader TargetReader) {
+func terraform(args string, targetReader TargetReader) error {
 	_, err := exec.LookPath("terraform")
 	if err != nil {
-		fmt.Println("Terraform is not installed on your system")
-		os.Exit(2)
+		return NewToolMissingError("terraform")
 	}
 	target := targetReader.ReadTarget(pathTarget)
 	gardenName := target.Stack()[0].Name
@@ -63,22 +67,26 @@ func terraform(args string, targetReader TargetReader) {
 	}
 
 	if strings.HasSuffix(args, "init") {
-		pathTerraform = downloadTerraformFiles("infra", targetReader)
+		pathTerraform, err = downloadTerraformFiles("infra", targetReader)
+		if err != nil {
+			return err
+		}
 		fmt.Println("Downloaded terraform config to " + pathTerraform)
 	}
 
 	_, err = os.Stat(pathTerraform)
 	if os.IsNotExist(err) {
-		fmt.Println("Please run terraform init first to fetch terraform config")
-		os.Exit(2)
+		return NewNotFoundError("no terraform config found, please run terraform init first to fetch terraform config")
+	}
+	pathTerraform, err = cachedPath(pathTerraform)
+	if err != nil {
+		return err
 	}
 
 	err = os.Chdir(pathTerraform)
 	if err != nil {
-		fmt.Println("Could not move into the directory " + pathTerraform)
-		os.Exit(2)
+		return fmt.Errorf("could not move into the directory %s: %v", pathTerraform, err)
 	}
 
-	err = ExecCmd(nil, args, false)
-	checkError(err)
+	return ExecCmd(nil, args, false)
 }
@@ -15,8 +15,14 @@
 package cmd
 
 import (
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+	"github.com/gardener/gardenctl/pkg/internal/cache"
+	"github.com/gardener/gardenctl/pkg/internal/history"
 	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // TargetReader reads the current target.
@@ -45,7 +51,10 @@ type HistoryWriter interface {
 }
 
 // GardenctlTargetReader implements TargetReader.
-type GardenctlTargetReader struct{}
+type GardenctlTargetReader struct {
+	// ClientFactory creates the clients of the read targets
+	ClientFactory ClientFactory
+}
 
 // GardenctlTargetWriter implements TargetWriter.
 type GardenctlTargetWriter struct{}
@@ -66,35 +75,56 @@ type TargetInterface interface {
 	Kind() (TargetKind, error)
 	K8SClient() (kubernetes.Interface, error)
 	K8SClientToKind(TargetKind) (kubernetes.Interface, error)
+	RESTConfigToKind(TargetKind) (*rest.Config, error)
+	KubeconfigPathToKind(TargetKind) (string, error)
 	GardenerClient() (gardencoreclientset.Interface, error)
+	GardenerClientToGarden(name string) (gardencoreclientset.Interface, error)
+}
+
+// ClientFactory creates the clients to the clusters of a target stack.
+type ClientFactory interface {
+	KubeconfigPath(stack []TargetMeta, kind TargetKind) (string, error)
+	RESTConfig(stack []TargetMeta, kind TargetKind) (*rest.Config, error)
+	Kubernetes(stack []TargetMeta, kind TargetKind) (kubernetes.Interface, error)
+	Gardener(stack []TargetMeta) (gardencoreclientset.Interface, error)
 }
 
 // Target contains the current target.
 type Target struct {
 	Target []TargetMeta `yaml:"target,omitempty" json:"target,omitempty"`
+
+	clients ClientFactory
 }
 
 // TargetKind is a valid value for target kind.
-type TargetKind string
+type TargetKind = gardenctl.TargetKind
 
 // These are valid target kinds.
 const (
 	// TargetKindGarden points to garden cluster.
-	TargetKindGarden TargetKind = "garden"
+	TargetKindGarden = gardenctl.TargetKindGarden
 	// TargetKindProject points to project.
-	TargetKindProject TargetKind = "project"
+	TargetKindProject = gardenctl.TargetKindProject
 	// TargetKindSeed points to seed cluster.
-	TargetKindSeed TargetKind = "seed"
+	TargetKindSeed = gardenctl.TargetKindSeed
 	// TargetKindShoot points to shoot cluster.
-	TargetKindShoot TargetKind = "shoot"
+	TargetKindShoot = gardenctl.TargetKindShoot
 	// TargetKindNamespace points to namespace.
-	TargetKindNamespace TargetKind = "namespace"
+	TargetKindNamespace = gardenctl.TargetKindNamespace
 )
 
 // TargetMeta contains kind and name of target.
-type TargetMeta struct {
-	Kind TargetKind `yaml:"kind,omitempty" json:"kind,omitempty"`
-	Name string     `yaml:"name,omitempty" json:"name,omitempty"`
+type TargetMeta = gardenctl.TargetMeta
+
+// Bookmarks contains the bookmarks of targets
+type Bookmarks struct {
+	Bookmarks []BookmarkMeta `yaml:"bookmarks,omitempty" json:"bookmarks,omitempty"`
+}
+
+// BookmarkMeta contains the name of a bookmark and its target path
+type BookmarkMeta struct {
+	Name   string `yaml:"name" json:"name"`
+	Target string `yaml:"target" json:"target"`
 }
 
 // Projects contains list of all projects
@@ -104,8 +134,14 @@ type Projects struct {
 
 // ProjectMeta contains project and shoots of project
 type ProjectMeta struct {
-	Project string   `yaml:"project,omitempty" json:"project,omitempty"`
-	Shoots  []string `yaml:"shoots,omitempty" json:"shoots,omitempty"`
+	Project string             `yaml:"project,omitempty" json:"project,omitempty"`
+	Shoots  []ProjectShootMeta `yaml:"shoots,omitempty" json:"shoots,omitempty"`
+}
+
+// ProjectShootMeta contains a shoot of a project
+type ProjectShootMeta struct {
+	Name       string `yaml:"name" json:"name"`
+	Hibernated bool   `yaml:"hibernated" json:"hibernated"`
 }
 
 // Seeds contains list of all seeds
@@ -113,10 +149,59 @@ type Seeds struct {
 	Seeds []SeedMeta `yaml:"seeds,omitempty" json:"seeds,omitempty"`
 }
 
-// SeedMeta contains shoots per seed
+// SeedMeta contains the overview of a seed
 type SeedMeta struct {
-	Seed   string   `yaml:"seed,omitempty" json:"seed,omitempty"`
-	Shoots []string `yaml:"shoots,omitempty" json:"shoots,omitempty"`
+	Seed              string `yaml:"seed,omitempty" json:"seed,omitempty"`
+	Provider          string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Region            string `yaml:"region,omitempty" json:"region,omitempty"`
+	KubernetesVersion string `yaml:"kubernetesVersion,omitempty" json:"kubernetesVersion,omitempty"`
+	Health            string `yaml:"health,omitempty" json:"health,omitempty"`
+	// Visible is whether the scheduler considers the seed for new shoots
+	Visible bool `yaml:"visible" json:"visible"`
+	// Protected is whether the seed only hosts shoots of the garden namespace
+	Protected bool            `yaml:"protected" json:"protected"`
+	Taints    []string        `yaml:"taints,omitempty" json:"taints,omitempty"`
+	Backup    *SeedBackupMeta `yaml:"backup,omitempty" json:"backup,omitempty"`
+	// Capacity is the number of shoots the seed can host, see seedCapacity of the garden configuration
+	Capacity int `yaml:"capacity,omitempty" json:"capacity,omitempty"`
+	// Allocated is the number of shoots scheduled on the seed
+	Allocated int `yaml:"allocated" json:"allocated"`
+	// Free is the number of shoots the seed can host in addition, it is unset if the capacity is unknown
+	Free       *int            `yaml:"free,omitempty" json:"free,omitempty"`
+	Hibernated int             `yaml:"hibernated" json:"hibernated"`
+	Issues     int             `yaml:"issues" json:"issues"`
+	Conditions []ConditionMeta `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+}
+
+// SeedBackupMeta contains the object store of the backups of the shoots of a seed
+type SeedBackupMeta struct {
+	Provider string `yaml:"provider" json:"provider"`
+	Region   string `yaml:"region,omitempty" json:"region,omitempty"`
+}
+
+// HistoryEntries contains the entries of the target history
+type HistoryEntries struct {
+	Entries []HistoryEntry `yaml:"entries,omitempty" json:"entries,omitempty"`
+}
+
+// HistoryEntry is an entry of the target history with the number to target it again with history use
+type HistoryEntry struct {
+	Number        int    `yaml:"number" json:"number"`
+	Target        string `yaml:"target" json:"target"`
+	history.Entry `yaml:",inline"`
+}
+
+// Sessions contains list of all sessions
+type Sessions struct {
+	Sessions []SessionMeta `yaml:"sessions,omitempty" json:"sessions,omitempty"`
+}
+
+// SessionMeta contains the current target and the last use of a session
+type SessionMeta struct {
+	ID       string    `yaml:"id,omitempty" json:"id,omitempty"`
+	Current  bool      `yaml:"current,omitempty" json:"current,omitempty"`
+	Target   string    `yaml:"target,omitempty" json:"target,omitempty"`
+	LastUsed time.Time `yaml:"lastUsed,omitempty" json:"lastUsed,omitempty"`
 }
 
 // ConfigReader reads the configuration.
@@ -128,11 +213,13 @@ type ConfigReader interface {
 type GardenConfigReader struct{}
 
 //GardenConfig contains config for gardenctl
-type GardenConfig struct {
-	Email          string              `yaml:"email,omitempty" json:"email,omitempty"`
-	GithubURL      string              `yaml:"githubURL,omitempty" json:"githubURL,omitempty"`
-	GardenClusters []GardenClusterMeta `yaml:"gardenClusters,omitempty" json:"gardenClusters,omitempty"`
-}
+type GardenConfig = gardenctl.GardenConfig
+
+// ConfigError is an error in the gardenctl configuration with its line
+type ConfigError = gardenctl.ConfigError
+
+// CacheConfig contains the settings of the cache of fetched credentials
+type CacheConfig = gardenctl.CacheConfig
 
 // GardenClusters contains all gardenclusters
 type GardenClusters struct {
@@ -140,26 +227,206 @@ type GardenClusters struct {
 }
 
 // GardenClusterMeta contains name and path to kubeconfig of gardencluster
-type GardenClusterMeta struct {
-	Name               string              `yaml:"name,omitempty" json:"name,omitempty"`
-	KubeConfig         string              `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty"`
-	DashboardURL       string              `yaml:"dashboardUrl,omitempty" json:"dashboardUrl,omitempty"`
-	AccessRestrictions []AccessRestriction `yaml:"accessRestrictions,omitempty" json:"accessRestrictions,omitempty"`
-}
+type GardenClusterMeta = gardenctl.GardenClusterMeta
+
+// ReadOnlyTarget selects read-only projects and shoots by name patterns
+type ReadOnlyTarget = gardenctl.ReadOnlyTarget
 
 // AccessRestrictionsOption contains key / notifyIf / msg
-type AccessRestrictionsOption struct {
-	Key      string `yaml:"key,omitempty" json:"key,omitempty"`
-	NotifyIf bool   `yaml:"notifyIf,omitempty" json:"notifyIf,omitempty"`
-	Msg      string `yaml:"msg,omitempty" json:"msg,omitempty"`
+type AccessRestrictionsOption = gardenctl.AccessRestrictionsOption
+
+// AccessRestriction contains key / notifyIf / msg / options / enforcement / selector
+type AccessRestriction = gardenctl.AccessRestriction
+
+// AccessRestrictionSelector selects the shoots an access restriction applies to
+type AccessRestrictionSelector = gardenctl.AccessRestrictionSelector
+
+// KeyValueSelector selects labels or annotations like a Kubernetes label selector
+type KeyValueSelector = gardenctl.KeyValueSelector
+
+// RestrictionEnforcement is the enforcement level of an access restriction
+type RestrictionEnforcement = gardenctl.RestrictionEnforcement
+
+// These are valid enforcement levels.
+const (
+	// RestrictionEnforcementWarn prints the messages of the restriction.
+	RestrictionEnforcementWarn = gardenctl.RestrictionEnforcementWarn
+	// RestrictionEnforcementConfirm requires a confirmation of the access.
+	RestrictionEnforcementConfirm = gardenctl.RestrictionEnforcementConfirm
+	// RestrictionEnforcementDeny denies the access.
+	RestrictionEnforcementDeny = gardenctl.RestrictionEnforcementDeny
+)
+
+// CacheInfo contains the settings and the entries of the credentials cache
+type CacheInfo struct {
+	Dir        string        `yaml:"dir,omitempty" json:"dir,omitempty"`
+	TTL        string        `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	Encryption string        `yaml:"encryption,omitempty" json:"encryption,omitempty"`
+	Entries    []cache.Entry `yaml:"entries" json:"entries"`
+}
+
+// Shoots contains the listed shoots
+type Shoots struct {
+	Shoots []ShootMeta `yaml:"shoots,omitempty" json:"shoots,omitempty"`
+}
+
+// ShootMeta contains the details of a listed shoot
+type ShootMeta struct {
+	Name              string             `yaml:"name" json:"name"`
+	Project           string             `yaml:"project,omitempty" json:"project,omitempty"`
+	Namespace         string             `yaml:"namespace" json:"namespace"`
+	Seed              string             `yaml:"seed,omitempty" json:"seed,omitempty"`
+	Provider          string             `yaml:"provider" json:"provider"`
+	Region            string             `yaml:"region" json:"region"`
+	KubernetesVersion string             `yaml:"kubernetesVersion" json:"kubernetesVersion"`
+	Purpose           string             `yaml:"purpose,omitempty" json:"purpose,omitempty"`
+	Hibernated        bool               `yaml:"hibernated" json:"hibernated"`
+	CreatedBy         string             `yaml:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreationTimestamp time.Time          `yaml:"creationTimestamp" json:"creationTimestamp"`
+	Workers           []WorkerMeta       `yaml:"workers,omitempty" json:"workers,omitempty"`
+	Health            string             `yaml:"health" json:"health"`
+	LastOperation     *LastOperationMeta `yaml:"lastOperation,omitempty" json:"lastOperation,omitempty"`
 }
 
-// AccessRestriction contains key / notifyIf / msg / options
-type AccessRestriction struct {
-	Key      string                     `yaml:"key,omitempty" json:"key,omitempty"`
-	NotifyIf bool                       `yaml:"notifyIf,omitempty" json:"notifyIf,omitempty"`
-	Msg      string                     `yaml:"msg,omitempty" json:"msg,omitempty"`
-	Options  []AccessRestrictionsOption `yaml:"options,omitempty" json:"options,omitempty"`
+// WorkerMeta contains a worker pool of a shoot
+type WorkerMeta struct {
+	Name        string `yaml:"name" json:"name"`
+	MachineType string `yaml:"machineType" json:"machineType"`
+	Minimum     int32  `yaml:"minimum" json:"minimum"`
+	Maximum     int32  `yaml:"maximum" json:"maximum"`
+}
+
+// Landscape contains the number of shoots per seed of a garden
+type Landscape struct {
+	Garden      string              `yaml:"garden" json:"garden"`
+	Seeds       []LandscapeSeedMeta `yaml:"seeds" json:"seeds"`
+	Total       int                 `yaml:"total" json:"total"`
+	Active      int                 `yaml:"active" json:"active"`
+	Hibernated  int                 `yaml:"hibernated" json:"hibernated"`
+	Unscheduled int                 `yaml:"unscheduled" json:"unscheduled"`
+}
+
+// LandscapeSeedMeta contains the number of shoots of a seed
+type LandscapeSeedMeta struct {
+	Seed       string `yaml:"seed" json:"seed"`
+	Total      int    `yaml:"total" json:"total"`
+	Active     int    `yaml:"active" json:"active"`
+	Hibernated int    `yaml:"hibernated" json:"hibernated"`
+}
+
+// Diagnosis contains the diagnostic information of a shoot
+type Diagnosis struct {
+	Shoot                DiagnosisShootMeta        `yaml:"shoot" json:"shoot"`
+	LastOperation        *LastOperationMeta        `yaml:"lastOperation,omitempty" json:"lastOperation,omitempty"`
+	Conditions           []ConditionMeta           `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+	Workers              []DiagnosisWorkerMeta     `yaml:"workers,omitempty" json:"workers,omitempty"`
+	NodeMetrics          []NodeMetricsMeta         `yaml:"nodeMetrics,omitempty" json:"nodeMetrics,omitempty"`
+	SystemComponents     []PodMeta                 `yaml:"systemComponents,omitempty" json:"systemComponents,omitempty"`
+	DaemonSets           []DaemonSetMeta           `yaml:"daemonSets,omitempty" json:"daemonSets,omitempty"`
+	Nodes                []NodeMeta                `yaml:"nodes,omitempty" json:"nodes,omitempty"`
+	PodDisruptionBudgets []PodDisruptionBudgetMeta `yaml:"podDisruptionBudgets,omitempty" json:"podDisruptionBudgets,omitempty"`
+	MutatingWebhooks     []string                  `yaml:"mutatingWebhooks,omitempty" json:"mutatingWebhooks,omitempty"`
+	ControlPlanePods     []PodMeta                 `yaml:"controlPlanePods,omitempty" json:"controlPlanePods,omitempty"`
+}
+
+// DiagnosisShootMeta contains the general information of a diagnosed shoot
+type DiagnosisShootMeta struct {
+	Name              string    `yaml:"name" json:"name"`
+	KubernetesVersion string    `yaml:"kubernetesVersion" json:"kubernetesVersion"`
+	CreationTimestamp time.Time `yaml:"creationTimestamp" json:"creationTimestamp"`
+	CreatedBy         string    `yaml:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CloudProfile      string    `yaml:"cloudProfile" json:"cloudProfile"`
+	Region            string    `yaml:"region" json:"region"`
+	Purpose           string    `yaml:"purpose,omitempty" json:"purpose,omitempty"`
+	Seed              string    `yaml:"seed,omitempty" json:"seed,omitempty"`
+	Hibernated        bool      `yaml:"hibernated" json:"hibernated"`
+}
+
+// ConditionMeta contains a condition of a shoot
+type ConditionMeta struct {
+	Type               string    `yaml:"type" json:"type"`
+	Status             string    `yaml:"status" json:"status"`
+	Reason             string    `yaml:"reason,omitempty" json:"reason,omitempty"`
+	Message            string    `yaml:"message,omitempty" json:"message,omitempty"`
+	Codes              []string  `yaml:"codes,omitempty" json:"codes,omitempty"`
+	LastTransitionTime time.Time `yaml:"lastTransitionTime" json:"lastTransitionTime"`
+	// Duration is the time since the last transition, e.g. 2h30m0s
+	Duration string `yaml:"duration" json:"duration"`
+}
+
+// DiagnosisWorkerMeta contains a worker pool of a diagnosed shoot
+type DiagnosisWorkerMeta struct {
+	Name           string   `yaml:"name" json:"name"`
+	Minimum        int32    `yaml:"minimum" json:"minimum"`
+	Maximum        int32    `yaml:"maximum" json:"maximum"`
+	MaxUnavailable string   `yaml:"maxUnavailable,omitempty" json:"maxUnavailable,omitempty"`
+	MaxSurge       string   `yaml:"maxSurge,omitempty" json:"maxSurge,omitempty"`
+	MachineType    string   `yaml:"machineType" json:"machineType"`
+	ImageName      string   `yaml:"imageName,omitempty" json:"imageName,omitempty"`
+	ImageVersion   string   `yaml:"imageVersion,omitempty" json:"imageVersion,omitempty"`
+	Zones          []string `yaml:"zones,omitempty" json:"zones,omitempty"`
+	VolumeName     string   `yaml:"volumeName,omitempty" json:"volumeName,omitempty"`
+	VolumeType     string   `yaml:"volumeType,omitempty" json:"volumeType,omitempty"`
+	VolumeSize     string   `yaml:"volumeSize,omitempty" json:"volumeSize,omitempty"`
+}
+
+// NodeMetricsMeta contains the resource usage of a node
+type NodeMetricsMeta struct {
+	Name           string `yaml:"name" json:"name"`
+	CPUMilliCores  int64  `yaml:"cpuMilliCores" json:"cpuMilliCores"`
+	MemoryMebibyte int64  `yaml:"memoryMebibyte" json:"memoryMebibyte"`
+}
+
+// NodeMeta contains the capacity of a node
+type NodeMeta struct {
+	Name           string `yaml:"name" json:"name"`
+	ProviderID     string `yaml:"providerID,omitempty" json:"providerID,omitempty"`
+	InternalIP     string `yaml:"internalIP,omitempty" json:"internalIP,omitempty"`
+	CPUCores       int64  `yaml:"cpuCores" json:"cpuCores"`
+	MemoryMebibyte int64  `yaml:"memoryMebibyte" json:"memoryMebibyte"`
+}
+
+// DaemonSetMeta contains the number of scheduled pods of a daemon set
+type DaemonSetMeta struct {
+	Name      string `yaml:"name" json:"name"`
+	Desired   int32  `yaml:"desired" json:"desired"`
+	Available int32  `yaml:"available" json:"available"`
+}
+
+// PodDisruptionBudgetMeta contains a pod disruption budget
+type PodDisruptionBudgetMeta struct {
+	Name           string `yaml:"name" json:"name"`
+	MinAvailable   string `yaml:"minAvailable,omitempty" json:"minAvailable,omitempty"`
+	MaxUnavailable string `yaml:"maxUnavailable,omitempty" json:"maxUnavailable,omitempty"`
+}
+
+// Pods contains pods
+type Pods struct {
+	Pods []PodMeta `yaml:"pods" json:"pods"`
+}
+
+// PodMeta contains the status of a pod
+type PodMeta struct {
+	Name              string    `yaml:"name" json:"name"`
+	Namespace         string    `yaml:"namespace" json:"namespace"`
+	Ready             string    `yaml:"ready" json:"ready"`
+	Status            string    `yaml:"status" json:"status"`
+	Restarts          int32     `yaml:"restarts" json:"restarts"`
+	CreationTimestamp time.Time `yaml:"creationTimestamp" json:"creationTimestamp"`
+	IP                string    `yaml:"ip,omitempty" json:"ip,omitempty"`
+	Node              string    `yaml:"node,omitempty" json:"node,omitempty"`
+}
+
+// Namespaces contains the namespaces of the targeted cluster
+type Namespaces struct {
+	Namespaces []NamespaceMeta `yaml:"namespaces" json:"namespaces"`
+}
+
+// NamespaceMeta contains a namespace
+type NamespaceMeta struct {
+	Name              string    `yaml:"name" json:"name"`
+	Status            string    `yaml:"status,omitempty" json:"status,omitempty"`
+	CreationTimestamp time.Time `yaml:"creationTimestamp" json:"creationTimestamp"`
 }
 
 // Issues contains all projects with issues
@@ -169,11 +436,32 @@ type Issues struct {
 
 // IssuesMeta contains project related informations
 type IssuesMeta struct {
-	Project string     `yaml:"project,omitempty" json:"project,omitempty"`
-	Seed    string     `yaml:"seed,omitempty" json:"seed,omitempty"`
-	Shoot   string     `yaml:"shoot,omitempty" json:"shoot,omitempty"`
-	Health  string     `yaml:"health,omitempty" json:"health,omitempty"`
-	Status  StatusMeta `yaml:"status,omitempty" json:"status,omitempty"`
+	Project  string `yaml:"project,omitempty" json:"project,omitempty"`
+	Seed     string `yaml:"seed,omitempty" json:"seed,omitempty"`
+	Shoot    string `yaml:"shoot,omitempty" json:"shoot,omitempty"`
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Health   string `yaml:"health,omitempty" json:"health,omitempty"`
+	// Severity is low, medium, high or critical
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+	// Codes are the error codes of the issue, the root cause first
+	Codes      []string        `yaml:"codes,omitempty" json:"codes,omitempty"`
+	Since      time.Time       `yaml:"since" json:"since"`
+	Duration   string          `yaml:"duration,omitempty" json:"duration,omitempty"`
+	Conditions []ConditionMeta `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+	Status     StatusMeta      `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// IssueGroups contains the shoots with issues grouped by the error code of their root cause
+type IssueGroups struct {
+	Groups []IssueGroupMeta `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+// IssueGroupMeta contains the shoots with the same root cause
+type IssueGroupMeta struct {
+	Code     string   `yaml:"code" json:"code"`
+	Severity string   `yaml:"severity" json:"severity"`
+	Count    int      `yaml:"count" json:"count"`
+	Shoots   []string `yaml:"shoots" json:"shoots"`
 }
 
 // StatusMeta contains status for a project
@@ -190,3 +478,22 @@ type LastOperationMeta struct {
 	State          string `yaml:"state,omitempty" json:"state,omitempty"`
 	Type           string `yaml:"type,omitempty" json:"type,omitempty"`
 }
+
+// Endpoint contains the pods of a component and the URLs it is served at
+type Endpoint struct {
+	Pods     []PodMeta `yaml:"pods" json:"pods"`
+	URLs     []string  `yaml:"urls" json:"urls"`
+	Username string    `yaml:"username,omitempty" json:"username,omitempty"`
+}
+
+// InfraResources contains the infra resources of a shoot as listed by the CLI of its infrastructure provider
+type InfraResources struct {
+	Provider  string          `yaml:"provider" json:"provider"`
+	Resources []InfraResource `yaml:"resources" json:"resources"`
+}
+
+// InfraResource contains the output of a CLI command listing infra resources
+type InfraResource struct {
+	Command string `yaml:"command" json:"command"`
+	Output  string `yaml:"output" json:"output"`
+}
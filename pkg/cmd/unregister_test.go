@@ -16,6 +16,8 @@ package cmd_test
 
 import (
 	"github.com/gardener/gardenctl/pkg/cmd"
+	mockcmd "github.com/gardener/gardenctl/pkg/mock/cmd"
+	"github.com/golang/mock/gomock"
 	"github.com/spf13/cobra"
 
 	. "github.com/onsi/ginkgo"
@@ -25,7 +27,10 @@ import (
 var _ = Describe("Unregister command", func() {
 
 	var (
-		command *cobra.Command
+		ctrl         *gomock.Controller
+		targetReader *mockcmd.MockTargetReader
+		configReader *mockcmd.MockConfigReader
+		command      *cobra.Command
 
 		execute = func(command *cobra.Command, args []string) error {
 			command.SetArgs(args)
@@ -33,9 +38,19 @@ var _ = Describe("Unregister command", func() {
 		}
 	)
 
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		targetReader = mockcmd.NewMockTargetReader(ctrl)
+		configReader = mockcmd.NewMockConfigReader(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
 	Context("with >= 2 args", func() {
 		It("should return error", func() {
-			command = cmd.NewUnregisterCmd()
+			command = cmd.NewUnregisterCmd(targetReader, configReader)
 			err := execute(command, []string{"john.doe@example.com", "alice.doe@example.com"})
 
 			Expect(err).To(HaveOccurred())
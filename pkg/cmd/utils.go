@@ -15,45 +15,23 @@
 package cmd
 
 import (
-	"encoding/json"
-	"errors"
+	"bytes"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"log"
 	"net"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
+	"github.com/gardener/gardenctl/pkg/gardenctl"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardenerlogger "github.com/gardener/gardener/pkg/logger"
-	yaml "gopkg.in/yaml.v2"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
-// checkError checks if an error during execution occurred
-func checkError(err error) {
-	if err != nil {
-		if exiterr, ok := err.(*exec.ExitError); ok {
-			log.Println(string(exiterr.Stderr))
-		}
-
-		if debugSwitch {
-			_, fn, line, _ := runtime.Caller(1)
-			log.Fatalf("[error] %s:%d \n %v", fn, line, err)
-		} else {
-			log.Fatalf(err.Error())
-		}
-	}
-}
-
 // HomeDir returns homedirectory of user
 func HomeDir() string {
 	if h := os.Getenv("HOME"); h != "" {
@@ -75,187 +53,118 @@ func FileExists(path string) (bool, error) {
 }
 
 // CreateDir creates a directory if it does no exist
-func CreateDir(dirname string, permission os.FileMode) {
+func CreateDir(dirname string, permission os.FileMode) error {
 	exists, err := FileExists(dirname)
-	checkError(err)
-	if !exists {
-		err := os.MkdirAll(dirname, permission)
-		checkError(err)
+	if err != nil || exists {
+		return err
 	}
+	return os.MkdirAll(dirname, permission)
 }
 
 // CreateFileIfNotExists creates an empty file if it does not exist
-func CreateFileIfNotExists(filename string, permission os.FileMode) {
+func CreateFileIfNotExists(filename string, permission os.FileMode) error {
 	exists, err := FileExists(filename)
-	checkError(err)
-	if !exists {
-		err = ioutil.WriteFile(filename, []byte{}, permission)
-		checkError(err)
+	if err != nil || exists {
+		return err
 	}
+	return ioutil.WriteFile(filename, []byte{}, permission)
 }
 
-// ExecCmd executes a command within set environment
-func ExecCmd(input []byte, cmd string, suppressedOutput bool, environment ...string) (err error) {
-	var command *exec.Cmd
-	parts := strings.Fields(cmd)
-	head := parts[0]
-	if len(parts) > 1 {
-		parts = parts[1:]
-	} else {
-		parts = nil
-	}
-	command = exec.Command(head, parts...)
-	for index, env := range environment {
-		if index == 0 {
-			command.Env = append(os.Environ(),
-				env,
-			)
-		} else {
-			command.Env = append(command.Env,
-				env,
-			)
-		}
-	}
-	var stdin = os.Stdin
-	if input != nil {
-		r, w, err := os.Pipe()
-		if err != nil {
-			return err
-		}
-		defer r.Close()
-		go func() {
-			_, err = w.Write([]byte(input))
-			checkError(err)
-			w.Close()
-		}()
-		stdin = r
-	}
-	if suppressedOutput {
-		err = command.Run()
-		if err != nil {
-			os.Exit(2)
-		}
-	} else {
+// ExecCmd executes a command within set environment, a failure of the command is returned as ToolError
+func ExecCmd(input []byte, cmd string, suppressedOutput bool, environment ...string) error {
+	command := newCmd(input, cmd, environment)
+	if !suppressedOutput {
 		command.Stdout = os.Stdout
 		command.Stderr = os.Stderr
-		command.Stdin = stdin
-		err = command.Run()
-		if err != nil {
-			os.Exit(2)
+		if input == nil {
+			command.Stdin = os.Stdin
 		}
 	}
-	return nil
+	return toolError(command.Args[0], command.Run())
 }
 
-//ExecCmdSaveOutputFile save command output to file
-func ExecCmdSaveOutputFile(input []byte, cmd string, fileName string, environment ...string) (err error) {
-	var command *exec.Cmd
-	parts := strings.Fields(cmd)
-	head := parts[0]
-	if len(parts) > 1 {
-		parts = parts[1:]
-	} else {
-		parts = nil
-	}
-	command = exec.Command(head, parts...)
-	for index, env := range environment {
-		if index == 0 {
-			command.Env = append(os.Environ(),
-				env,
-			)
-		} else {
-			command.Env = append(command.Env,
-				env,
-			)
-		}
-	}
-	var stdin = os.Stdin
-	if input != nil {
-		r, w, err := os.Pipe()
-		if err != nil {
-			return err
-		}
-		defer r.Close()
-		go func() {
-			_, err = w.Write([]byte(input))
-			checkError(err)
-			w.Close()
-		}()
-		stdin = r
-	}
-	if _, err := os.Stat(fileName); err == nil {
-		e := os.Remove(fileName)
-		checkError(e)
+//ExecCmdSaveOutputFile save command output to file, a failure of the command is returned as ToolError
+func ExecCmdSaveOutputFile(input []byte, cmd string, fileName string, environment ...string) error {
+	command := newCmd(input, cmd, environment)
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return err
 	}
 	outfile, err := os.Create(fileName)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 	defer outfile.Close()
 	command.Stdout = outfile
 	command.Stderr = os.Stderr
-	command.Stdin = stdin
-	err = command.Run()
-	if err != nil {
-		os.Exit(2)
+	if input == nil {
+		command.Stdin = os.Stdin
 	}
-	return nil
+	return toolError(command.Args[0], command.Run())
+}
+
+// newCmd returns the command cmd, split into its fields, with the given environment added to the one of
+// gardenctl and input, if any, as stdin.
+func newCmd(input []byte, cmd string, environment []string) *exec.Cmd {
+	parts := strings.Fields(cmd)
+	command := exec.Command(parts[0], parts[1:]...)
+	if len(environment) > 0 {
+		command.Env = append(os.Environ(), environment...)
+	}
+	if input != nil {
+		command.Stdin = bytes.NewReader(input)
+	}
+	return command
 }
 
-// ExecCmdReturnOutput execute cmd and return output
+// ExecCmdReturnOutput execute cmd and return output, a failure of the command is returned as ToolError
 func ExecCmdReturnOutput(cmd string, args ...string) (output string, err error) {
 	out, err := exec.Command(cmd, args...).Output()
-	return strings.TrimSpace(string(out[:])), err
+	return strings.TrimSpace(string(out[:])), toolError(cmd, err)
 }
 
 // ReadTarget file into Target
 // DEPRECATED: Use `TargetReader` instead.
-func ReadTarget(pathTarget string, target *Target) {
+func ReadTarget(pathTarget string, target *Target) error {
 	targetFile, err := ioutil.ReadFile(pathTarget)
-	checkError(err)
-	err = yaml.Unmarshal(targetFile, target)
-	checkError(err)
+	if err != nil {
+		return err
+	}
+	if target.Target, err = gardenctl.ParseTarget(targetFile); err != nil {
+		target.Target = nil
+		backupPath, recoverErr := gardenctl.RecoverCorruptedTarget(pathTarget)
+		if recoverErr != nil {
+			return recoverErr
+		}
+		if backupPath == "" {
+			// rewritten by a concurrent gardenctl call in the meantime
+			return ReadTarget(pathTarget, target)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: target file %s is corrupted (%s), it has been reset and the corrupted file was moved to %s\n", pathTarget, err, backupPath)
+	}
+	return nil
 }
 
 // NewConfigFromBytes returns a client from the given kubeconfig path
-func NewConfigFromBytes(kubeconfig string) *restclient.Config {
+func NewConfigFromBytes(kubeconfig string) (*restclient.Config, error) {
 	kubecf, err := ioutil.ReadFile(kubeconfig)
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 	configObj, err := clientcmd.Load(kubecf)
 	if err != nil {
-		fmt.Println("Could not load config")
-		os.Exit(2)
+		return nil, fmt.Errorf("could not load config: %w", err)
 	}
 	clientConfig := clientcmd.NewDefaultClientConfig(*configObj, &clientcmd.ConfigOverrides{})
-	client, err := clientConfig.ClientConfig()
-	checkError(err)
-	return client
+	return clientConfig.ClientConfig()
 }
 
 // ValidateClientConfig validates that the auth info of a given kubeconfig doesn't have unsupported fields.
-func ValidateClientConfig(config clientcmdapi.Config) error {
-	validFields := []string{"client-certificate-data", "client-key-data", "token", "username", "password"}
-	pathOfKubeconfig := getKubeConfigOfCurrentTarget()
-	for user, authInfo := range config.AuthInfos {
-		switch {
-		case authInfo.ClientCertificate != "":
-			return fmt.Errorf("client certificate files are not supported (user %q), these are the valid fields: %+v", user, validFields)
-		case authInfo.ClientKey != "":
-			return fmt.Errorf("client key files are not supported (user %q), these are the valid fields: %+v", user, validFields)
-		case authInfo.TokenFile != "":
-			return fmt.Errorf("token files are not supported (user %q), these are the valid fields: %+v", user, validFields)
-		case authInfo.Impersonate != "" || len(authInfo.ImpersonateGroups) > 0:
-			return fmt.Errorf("impersonation is not supported, these are the valid fields: %+v", validFields)
-		case authInfo.AuthProvider != nil && len(authInfo.AuthProvider.Config) > 0:
-			fmt.Printf("Kubeconfig under path %s contains auth provider configurations that could contain malicious code. Please only continue if you have verified it to be uncritical\n", pathOfKubeconfig)
-			return nil
-			// 	return fmt.Errorf("auth provider configurations are not supported (user %q), these are the valid fields: %+v", user, validFields)
-		case authInfo.Exec != nil:
-			fmt.Printf("Kubeconfig under path %s contains exec configurations that could contain malicious code. Please only continue if you have verified it to be uncritical\n", pathOfKubeconfig)
-			return nil
-			// 	return fmt.Errorf("exec configurations are not supported (user %q), these are the valid fields: %+v", user, validFields)
-		}
+func ValidateClientConfig(configReader ConfigReader, config clientcmdapi.Config) error {
+	pathOfKubeconfig, err := getKubeConfigOfCurrentTarget(configReader)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return gardenctl.ValidateClientConfig(config, pathOfKubeconfig, os.Stdout)
 }
 
 // FetchShootFromTarget fetches shoot object from given target
@@ -264,41 +173,12 @@ func FetchShootFromTarget(target TargetInterface) (*gardencorev1beta1.Shoot, err
 	if err != nil {
 		return nil, err
 	}
-
-	var shoot *gardencorev1beta1.Shoot
-	if target.Stack()[1].Kind == TargetKindProject {
-		project, err := gardenClientset.CoreV1beta1().Projects().Get(target.Stack()[1].Name, metav1.GetOptions{})
-		if err != nil {
-			return nil, err
-		}
-
-		shoot, err = gardenClientset.CoreV1beta1().Shoots(*project.Spec.Namespace).Get(target.Stack()[2].Name, metav1.GetOptions{})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		shootList, err := gardenClientset.CoreV1beta1().Shoots(metav1.NamespaceAll).List(metav1.ListOptions{})
-		if err != nil {
-			return nil, err
-		}
-
-		for index, s := range shootList.Items {
-			if s.Name == target.Stack()[2].Name && *s.Spec.SeedName == target.Stack()[1].Name {
-				shoot = &shootList.Items[index]
-				break
-			}
-		}
-	}
-
-	return shoot, nil
+	return gardenctl.FetchShoot(gardenClientset, target.Stack())
 }
 
 //TidyKubeconfigWithHomeDir check if kubeconfig path contains ~, replace ~ with user home dir
 func TidyKubeconfigWithHomeDir(pathToKubeconfig string) string {
-	if strings.Contains(pathToKubeconfig, "~") {
-		pathToKubeconfig = filepath.Clean(filepath.Join(HomeDir(), strings.Replace(pathToKubeconfig, "~", "", 1)))
-	}
-	return pathToKubeconfig
+	return gardenctl.ExpandHomeDir(pathToKubeconfig)
 }
 
 //CheckShootIsTargeted check if current target has shoot targeted
@@ -333,26 +213,6 @@ func CheckToolInstalled(cliName string) bool {
 	return true
 }
 
-//PrintoutObject print object in yaml or json format. Pass os.Stdout if desired
-func PrintoutObject(objectToPrint interface{}, writer io.Writer, outputFormat string) error {
-	if outputFormat == "yaml" {
-		yaml, err := yaml.Marshal(objectToPrint)
-		if err != nil {
-			return err
-		}
-		fmt.Fprint(writer, string(yaml))
-	} else if outputFormat == "json" {
-		json, err := json.MarshalIndent(objectToPrint, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Fprint(writer, string(json))
-	} else {
-		return errors.New("output format not supported: '" + outputFormat + "'")
-	}
-	return nil
-}
-
 //CheckIPPortReachable check whether IP with port is reachable within certain period of time
 func CheckIPPortReachable(ip string, port string) error {
 	attemptCount := 0
@@ -17,8 +17,11 @@ package cmd_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	. "github.com/gardener/gardenctl/pkg/cmd"
 	. "github.com/onsi/ginkgo"
@@ -72,7 +75,7 @@ var _ = Describe("Utils", func() {
 
 	Context("After creating a dir", func() {
 		It("os.Stat should return err == nil", func() {
-			CreateDir(pathDir, 0755)
+			Expect(CreateDir(pathDir, 0755)).To(Succeed())
 			_, err = os.Stat(pathDir)
 			Expect(err).To(BeNil())
 		})
@@ -87,7 +90,7 @@ var _ = Describe("Utils", func() {
 
 	Context("After creating a file", func() {
 		It("CreateFile should return err == nil and exists == true", func() {
-			CreateFileIfNotExists(pathFile, 0644)
+			Expect(CreateFileIfNotExists(pathFile, 0644)).To(Succeed())
 			exists, err := FileExists(pathFile)
 			Expect(err).To(BeNil())
 			Expect(exists).To(BeTrue())
@@ -99,6 +102,20 @@ var _ = Describe("Utils", func() {
 			err := ExecCmd(nil, "sleep 1", false)
 			Expect(err).To(BeNil())
 		})
+
+		It("ExecCmd should return the exit status of a failed command", func() {
+			Expect(ExitCode(ExecCmd(nil, "false", true))).To(Equal(1))
+		})
+
+		It("ExecCmd should pass the input to the command", func() {
+			Expect(ExecCmd([]byte("foo"), "grep -q foo", true)).To(Succeed())
+			Expect(ExitCode(ExecCmd([]byte("bar"), "grep -q foo", true))).To(Equal(1))
+		})
+
+		It("ExecCmd should report a missing command as missing tool", func() {
+			err := ExecCmd(nil, "gardenctl-does-not-exist", true)
+			Expect(ExitCode(err)).To(Equal(ExitCodeToolMissing))
+		})
 	})
 
 	Context("After setting KUBECONFIG environment variable", func() {
@@ -119,11 +136,67 @@ var _ = Describe("Utils", func() {
 
 	Context("After targeting a shoot", func() {
 		It("readTarget should return target stack with three elements", func() {
-			ReadTarget(pathTarget, &target)
+			Expect(ReadTarget(pathTarget, &target)).To(Succeed())
 			Expect(len(target.Target)).To(Equal(3))
 		})
 	})
 
+	Context("After corrupting the target file", func() {
+		It("readTarget should reset the target and keep a backup", func() {
+			dir, err := ioutil.TempDir("", "gardenctl-target")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			corruptedTarget := filepath.Join(dir, "target")
+			Expect(ioutil.WriteFile(corruptedTarget, []byte("target:\n- kind: garden\n  name: [unterminated"), 0644)).To(Succeed())
+
+			var recovered Target
+			Expect(ReadTarget(corruptedTarget, &recovered)).To(Succeed())
+
+			Expect(recovered.Target).To(BeEmpty())
+			content, err := ioutil.ReadFile(corruptedTarget)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(BeEmpty())
+			backup, err := ioutil.ReadFile(corruptedTarget + ".corrupted")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(backup)).To(ContainSubstring("unterminated"))
+		})
+	})
+
+	Context("After writing the session files concurrently", func() {
+		It("history and target files should stay consistent", func() {
+			dir, err := ioutil.TempDir("", "gardenctl-session")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			sessionHistory := filepath.Join(dir, "history")
+			sessionTarget := filepath.Join(dir, "target")
+
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					name := fmt.Sprintf("shoot-%d", i)
+					Expect((&GardenctlHistoryWriter{}).WriteStringln(sessionHistory, map[string]string{"shoot": name})).To(Succeed())
+					Expect((&GardenctlTargetWriter{}).WriteTarget(sessionTarget, &Target{Target: []TargetMeta{{Kind: TargetKindGarden, Name: name}}})).To(Succeed())
+				}(i)
+			}
+			wg.Wait()
+
+			content, err := ioutil.ReadFile(sessionHistory)
+			Expect(err).NotTo(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			Expect(lines).To(HaveLen(20))
+			for _, line := range lines {
+				Expect(line).To(MatchRegexp(`^{"timestamp":"[^"]+","shoot":"shoot-\d+"}$`))
+			}
+
+			var written Target
+			Expect(ReadTarget(sessionTarget, &written)).To(Succeed())
+			Expect(written.Target).To(HaveLen(1))
+		})
+	})
+
 	Context("After creating target object", func() {
 		It("name of garden cluster should be garden-test", func() {
 			Expect(target.Target[0].Name).To(Equal("garden-test"))
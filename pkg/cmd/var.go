@@ -16,8 +16,6 @@ package cmd
 
 import (
 	"path/filepath"
-
-	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -27,24 +25,14 @@ const (
 )
 
 var (
-	// Client is Clientset to use for specified cluster
-	Client     *kubernetes.Clientset
-	kubeconfig *string
-
-	// KUBECONFIG contains path to file
-	KUBECONFIG string
-	garden     bool
-	seed       bool
-	project    bool
-
-	// credentials
-	username string
-	password string
+	garden  bool
+	seed    bool
+	project bool
 
 	// file pathes
-	pathGardenConfig   string
-	pathTarget         string
-	pathHistory        string
-	pathDefault        = filepath.Join(HomeDir(), ".garden")
-	pathDefaultSession = filepath.Join(HomeDir(), ".garden", "sessions")
+	pathGardenConfig  string
+	pathTarget        string
+	pathHistory       string
+	pathPushedTargets string
+	pathDefault       = filepath.Join(HomeDir(), ".garden")
 )
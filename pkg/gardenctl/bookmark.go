@@ -0,0 +1,123 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
+	"gopkg.in/yaml.v2"
+)
+
+// bookmarkName is the format of bookmark names, they must not be mistaken for a target path
+var bookmarkName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Bookmark is a target stack saved under a name
+type Bookmark struct {
+	Name   string       `yaml:"name" json:"name"`
+	Target []TargetMeta `yaml:"target" json:"target"`
+}
+
+// bookmarkFile is the content of the bookmarks file
+type bookmarkFile struct {
+	Bookmarks []Bookmark `yaml:"bookmarks,omitempty" json:"bookmarks,omitempty"`
+}
+
+// ValidateBookmarkName returns an error if name is no valid bookmark name
+func ValidateBookmarkName(name string) error {
+	if !bookmarkName.MatchString(name) {
+		return fmt.Errorf("invalid bookmark name %q, it must start with a letter or digit and may only contain letters, digits, '_', '.' and '-'", name)
+	}
+	return nil
+}
+
+// FindBookmark returns the bookmark with the given name or nil
+func FindBookmark(bookmarks []Bookmark, name string) *Bookmark {
+	for i := range bookmarks {
+		if bookmarks[i].Name == name {
+			return &bookmarks[i]
+		}
+	}
+	return nil
+}
+
+// ReadBookmarks returns the bookmarks stored in the file at path sorted by name
+func ReadBookmarks(path string) ([]Bookmark, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file bookmarkFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid bookmarks file %s: %v", path, err)
+	}
+	return file.Bookmarks, nil
+}
+
+// UpdateBookmarks replaces the bookmarks stored in the file at path with the result of update,
+// the file is locked meanwhile
+func UpdateBookmarks(path string, update func([]Bookmark) ([]Bookmark, error)) error {
+	return lockedfile.Transform(path, 0600, func(content []byte) ([]byte, error) {
+		var file bookmarkFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("invalid bookmarks file %s: %v", path, err)
+		}
+		bookmarks, err := update(file.Bookmarks)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(bookmarks, func(i, j int) bool {
+			return bookmarks[i].Name < bookmarks[j].Name
+		})
+		file.Bookmarks = bookmarks
+		return yaml.Marshal(&file)
+	})
+}
+
+// SaveBookmark stores the target stack under name, an existing bookmark with the name is replaced
+func SaveBookmark(path, name string, target []TargetMeta) error {
+	if err := ValidateBookmarkName(name); err != nil {
+		return err
+	}
+	if len(target) == 0 {
+		return fmt.Errorf("no target to save as bookmark %q", name)
+	}
+	return UpdateBookmarks(path, func(bookmarks []Bookmark) ([]Bookmark, error) {
+		if b := FindBookmark(bookmarks, name); b != nil {
+			b.Target = target
+			return bookmarks, nil
+		}
+		return append(bookmarks, Bookmark{Name: name, Target: target}), nil
+	})
+}
+
+// RemoveBookmark removes the bookmark with the given name, an error is returned if it does not exist
+func RemoveBookmark(path, name string) error {
+	return UpdateBookmarks(path, func(bookmarks []Bookmark) ([]Bookmark, error) {
+		for i := range bookmarks {
+			if bookmarks[i].Name == name {
+				return append(bookmarks[:i], bookmarks[i+1:]...), nil
+			}
+		}
+		return nil, NewNotFoundError("bookmark %q not found", name)
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Bookmarks", func() {
+	var (
+		dir  string
+		path string
+
+		shoot = []gardenctl.TargetMeta{
+			{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+			{Kind: gardenctl.TargetKindProject, Name: "core"},
+			{Kind: gardenctl.TargetKindShoot, Name: "api"},
+		}
+		garden = []gardenctl.TargetMeta{{Kind: gardenctl.TargetKindGarden, Name: "dev"}}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gardenctl-bookmarks")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "bookmarks.yaml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should return no bookmarks if the file does not exist", func() {
+		bookmarks, err := gardenctl.ReadBookmarks(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bookmarks).To(BeEmpty())
+	})
+
+	It("should save bookmarks sorted by name and replace existing ones", func() {
+		Expect(gardenctl.SaveBookmark(path, "prod-api", garden)).To(Succeed())
+		Expect(gardenctl.SaveBookmark(path, "dev", garden)).To(Succeed())
+		Expect(gardenctl.SaveBookmark(path, "prod-api", shoot)).To(Succeed())
+
+		bookmarks, err := gardenctl.ReadBookmarks(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bookmarks).To(Equal([]gardenctl.Bookmark{
+			{Name: "dev", Target: garden},
+			{Name: "prod-api", Target: shoot},
+		}))
+		Expect(gardenctl.FindBookmark(bookmarks, "prod-api").Target).To(Equal(shoot))
+		Expect(gardenctl.FindBookmark(bookmarks, "other")).To(BeNil())
+	})
+
+	It("should refuse invalid names and empty targets", func() {
+		Expect(gardenctl.SaveBookmark(path, "prod/api", shoot)).To(MatchError(ContainSubstring(`invalid bookmark name "prod/api"`)))
+		Expect(gardenctl.SaveBookmark(path, "-api", shoot)).To(HaveOccurred())
+		Expect(gardenctl.SaveBookmark(path, "api", nil)).To(MatchError(`no target to save as bookmark "api"`))
+	})
+
+	It("should remove bookmarks", func() {
+		Expect(gardenctl.SaveBookmark(path, "dev", garden)).To(Succeed())
+		Expect(gardenctl.SaveBookmark(path, "prod-api", shoot)).To(Succeed())
+		Expect(gardenctl.RemoveBookmark(path, "dev")).To(Succeed())
+
+		bookmarks, err := gardenctl.ReadBookmarks(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bookmarks).To(Equal([]gardenctl.Bookmark{{Name: "prod-api", Target: shoot}}))
+
+		err = gardenctl.RemoveBookmark(path, "dev")
+		Expect(gardenctl.IsNotFound(err)).To(BeTrue())
+		Expect(err).To(MatchError(`bookmark "dev" not found`))
+	})
+})
@@ -0,0 +1,344 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ConfigLoader loads the gardenctl configuration.
+type ConfigLoader interface {
+	LoadConfig() (*GardenConfig, error)
+}
+
+// KubeconfigCache contains the kubeconfigs of the seeds and shoots fetched from the gardens.
+type KubeconfigCache interface {
+	// Dir returns the directory of the cache
+	Dir() string
+	// Path returns the path of a readable copy of the cached file at path
+	Path(path string) (string, error)
+	// Expired returns whether the cached file at path has expired and has to be fetched again
+	Expired(path string) bool
+	// Write stores data at path and returns the path of a readable copy
+	Write(path string, data []byte) (string, error)
+}
+
+// DirCache is a KubeconfigCache of unencrypted files in a directory.
+type DirCache string
+
+// Dir returns the directory of the cache
+func (d DirCache) Dir() string {
+	return string(d)
+}
+
+// Path returns path, the files of the cache are readable as they are
+func (d DirCache) Path(path string) (string, error) {
+	return path, nil
+}
+
+// Expired returns false, the files of the cache do not expire
+func (d DirCache) Expired(path string) bool {
+	return false
+}
+
+// Write stores data at path, which is readable by the owner only
+func (d DirCache) Write(path string, data []byte) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	return path, ioutil.WriteFile(path, data, 0600)
+}
+
+// ClientFactory creates the clients to the clusters of a target stack. It caches the rest
+// configs and clients per kubeconfig, so the same cluster is only loaded once and clients to
+// different clusters can be used concurrently.
+type ClientFactory struct {
+	// Warnings receives the warnings about kubeconfigs which execute commands, they are discarded if it is nil
+	Warnings io.Writer
+
+	config      ConfigLoader
+	kubeconfigs KubeconfigCache
+
+	mutex           sync.Mutex
+	configs         map[string]*rest.Config
+	clients         map[string]kubernetes.Interface
+	gardenerClients map[string]gardencoreclientset.Interface
+}
+
+// NewClientFactory returns a client factory which looks up the garden kubeconfigs in the
+// configuration and the seed and shoot kubeconfigs in the given cache.
+func NewClientFactory(config ConfigLoader, kubeconfigs KubeconfigCache) *ClientFactory {
+	return &ClientFactory{
+		config:          config,
+		kubeconfigs:     kubeconfigs,
+		configs:         map[string]*rest.Config{},
+		clients:         map[string]kubernetes.Interface{},
+		gardenerClients: map[string]gardencoreclientset.Interface{},
+	}
+}
+
+// KubeconfigPath returns the path to the kubeconfig of the cluster of the given kind in the target stack.
+func (f *ClientFactory) KubeconfigPath(stack []TargetMeta, kind TargetKind) (string, error) {
+	if len(stack) > 0 && stack[len(stack)-1].Kind == TargetKindNamespace {
+		stack = stack[:len(stack)-1]
+	}
+	if len(stack) == 0 || stack[0].Kind != TargetKindGarden {
+		return "", NewNotTargetedError(TargetKindGarden)
+	}
+
+	gardenName := stack[0].Name
+	var path string
+	var seedName string
+	switch kind {
+	case TargetKindGarden, TargetKindProject:
+		config, err := f.config.LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		garden := config.Garden(gardenName)
+		if garden == nil {
+			return "", NewNotFoundError("garden %q is not configured", gardenName)
+		}
+		return ExpandHomeDir(garden.KubeConfig), nil
+	case TargetKindSeed:
+		if len(stack) > 1 && stack[1].Kind == TargetKindSeed {
+			seedName = stack[1].Name
+		} else if len(stack) == 3 {
+			var err error
+			if seedName, err = f.seedOfShoot(stack); err != nil {
+				return "", err
+			}
+		} else {
+			return "", NewNotTargetedError(TargetKindSeed)
+		}
+		path = SeedKubeconfigPath(f.kubeconfigs.Dir(), gardenName, seedName)
+	case TargetKindShoot:
+		if len(stack) != 3 {
+			return "", NewNotTargetedError(TargetKindShoot)
+		}
+		path = ShootKubeconfigPath(f.kubeconfigs.Dir(), stack)
+	default:
+		return "", fmt.Errorf("unknown target kind %q", kind)
+	}
+
+	if f.kubeconfigs.Expired(path) {
+		return f.refetchKubeconfig(stack, seedName, path)
+	}
+	return f.kubeconfigs.Path(path)
+}
+
+// refetchKubeconfig fetches the expired kubeconfig of the seed with the given name, or of the shoot
+// targeted in stack if seedName is empty, from the garden and stores it at path in the cache
+func (f *ClientFactory) refetchKubeconfig(stack []TargetMeta, seedName, path string) (string, error) {
+	gardenerClient, err := f.Gardener(stack)
+	if err != nil {
+		return "", err
+	}
+	gardenClient, err := f.Kubernetes(stack, TargetKindGarden)
+	if err != nil {
+		return "", err
+	}
+
+	var namespace, name string
+	if seedName != "" {
+		seed, err := gardenerClient.CoreV1beta1().Seeds().Get(seedName, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if seed.Spec.SecretRef == nil {
+			return "", fmt.Errorf("Spec.SecretRef is missing in seed %q, seed not reachable", seed.Name)
+		}
+		namespace, name = seed.Spec.SecretRef.Namespace, seed.Spec.SecretRef.Name
+	} else {
+		shoot, err := FetchShoot(gardenerClient, stack)
+		if err != nil {
+			return "", err
+		}
+		namespace, name = shoot.Namespace, shoot.Name+".kubeconfig"
+	}
+
+	secret, err := gardenClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the expired kubeconfig %s again: %v", path, err)
+	}
+	return f.kubeconfigs.Write(path, secret.Data["kubeconfig"])
+}
+
+// RESTConfig returns the rest config of the cluster of the given kind in the target stack.
+func (f *ClientFactory) RESTConfig(stack []TargetMeta, kind TargetKind) (*rest.Config, error) {
+	path, err := f.KubeconfigPath(stack, kind)
+	if err != nil {
+		return nil, err
+	}
+	return f.restConfig(path)
+}
+
+// Kubernetes returns a kubernetes client for the cluster of the given kind in the target stack.
+func (f *ClientFactory) Kubernetes(stack []TargetMeta, kind TargetKind) (kubernetes.Interface, error) {
+	path, err := f.KubeconfigPath(stack, kind)
+	if err != nil {
+		return nil, err
+	}
+	config, err := f.restConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if client, ok := f.clients[path]; ok {
+		return client, nil
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	f.clients[path] = client
+	return client, nil
+}
+
+// Gardener returns a gardener client for the garden of the target stack.
+func (f *ClientFactory) Gardener(stack []TargetMeta) (gardencoreclientset.Interface, error) {
+	path, err := f.KubeconfigPath(stack, TargetKindGarden)
+	if err != nil {
+		return nil, err
+	}
+	config, err := f.restConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if client, ok := f.gardenerClients[path]; ok {
+		return client, nil
+	}
+	client, err := gardencoreclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	f.gardenerClients[path] = client
+	return client, nil
+}
+
+// restConfig loads and validates the kubeconfig at path
+func (f *ClientFactory) restConfig(path string) (*rest.Config, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if config, ok := f.configs[path]; ok {
+		return config, nil
+	}
+
+	kubeconfig, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	warnings := f.Warnings
+	if warnings == nil {
+		warnings = ioutil.Discard
+	}
+	if err := ValidateClientConfig(rawConfig, path, warnings); err != nil {
+		return nil, err
+	}
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	f.configs[path] = config
+	return config, nil
+}
+
+// seedOfShoot returns the name of the seed hosting the shoot at the top of the target stack
+func (f *ClientFactory) seedOfShoot(stack []TargetMeta) (string, error) {
+	gardenClient, err := f.Gardener(stack)
+	if err != nil {
+		return "", err
+	}
+	shoot, err := FetchShoot(gardenClient, stack)
+	if err != nil {
+		return "", err
+	}
+	if shoot.Spec.SeedName == nil {
+		return "", fmt.Errorf("shoot %q is not scheduled to a seed yet", shoot.Name)
+	}
+	return *shoot.Spec.SeedName, nil
+}
+
+// SeedKubeconfigPath returns the path of the kubeconfig of a seed in the cache directory
+func SeedKubeconfigPath(cacheDir, gardenName, seedName string) string {
+	return filepath.Join(cacheDir, gardenName, "seeds", seedName, "kubeconfig.yaml")
+}
+
+// ShootKubeconfigPath returns the path of the kubeconfig of the shoot targeted in stack in the cache directory
+func ShootKubeconfigPath(cacheDir string, stack []TargetMeta) string {
+	if stack[1].Kind == TargetKindSeed {
+		return filepath.Join(cacheDir, stack[0].Name, "seeds", stack[1].Name, stack[2].Name, "kubeconfig.yaml")
+	}
+	return filepath.Join(cacheDir, stack[0].Name, "projects", stack[1].Name, stack[2].Name, "kubeconfig.yaml")
+}
+
+// ExpandHomeDir replaces a ~ in path with the home directory of the user
+func ExpandHomeDir(path string) string {
+	if strings.Contains(path, "~") {
+		path = filepath.Clean(filepath.Join(homeDir(), strings.Replace(path, "~", "", 1)))
+	}
+	return path
+}
+
+// ValidateClientConfig validates that the auth info of a given kubeconfig doesn't have unsupported fields.
+// Auth provider and exec configurations are allowed, but a warning about the kubeconfig at path is written to warnings.
+func ValidateClientConfig(config clientcmdapi.Config, path string, warnings io.Writer) error {
+	validFields := []string{"client-certificate-data", "client-key-data", "token", "username", "password"}
+	for user, authInfo := range config.AuthInfos {
+		switch {
+		case authInfo.ClientCertificate != "":
+			return fmt.Errorf("client certificate files are not supported (user %q), these are the valid fields: %+v", user, validFields)
+		case authInfo.ClientKey != "":
+			return fmt.Errorf("client key files are not supported (user %q), these are the valid fields: %+v", user, validFields)
+		case authInfo.TokenFile != "":
+			return fmt.Errorf("token files are not supported (user %q), these are the valid fields: %+v", user, validFields)
+		case authInfo.Impersonate != "" || len(authInfo.ImpersonateGroups) > 0:
+			return fmt.Errorf("impersonation is not supported, these are the valid fields: %+v", validFields)
+		case authInfo.AuthProvider != nil && len(authInfo.AuthProvider.Config) > 0:
+			fmt.Fprintf(warnings, "Kubeconfig under path %s contains auth provider configurations that could contain malicious code. Please only continue if you have verified it to be uncritical\n", path)
+			return nil
+		case authInfo.Exec != nil:
+			fmt.Fprintf(warnings, "Kubeconfig under path %s contains exec configurations that could contain malicious code. Please only continue if you have verified it to be uncritical\n", path)
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+// staticConfig is a ConfigLoader of a fixed configuration
+type staticConfig gardenctl.GardenConfig
+
+func (c *staticConfig) LoadConfig() (*gardenctl.GardenConfig, error) {
+	return (*gardenctl.GardenConfig)(c), nil
+}
+
+// expiringCache is a DirCache whose files are expired
+type expiringCache struct {
+	gardenctl.DirCache
+}
+
+func (expiringCache) Expired(path string) bool {
+	return true
+}
+
+var _ = Describe("Client factory", func() {
+	var (
+		tmpDir   string
+		server   *httptest.Server
+		requests []string
+		config   *staticConfig
+		stack    = []gardenctl.TargetMeta{{Kind: gardenctl.TargetKindGarden, Name: "prod"}, {Kind: gardenctl.TargetKindSeed, Name: "aws"}}
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "gardenctl-client-factory")
+		Expect(err).NotTo(HaveOccurred())
+
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/apis/core.gardener.cloud/v1beta1/seeds/aws":
+				fmt.Fprint(w, `{"apiVersion":"core.gardener.cloud/v1beta1","kind":"Seed","metadata":{"name":"aws"},"spec":{"secretRef":{"namespace":"garden","name":"seed-aws"}}}`)
+			case "/api/v1/namespaces/garden/secrets/seed-aws":
+				fmt.Fprintf(w, `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"seed-aws","namespace":"garden"},"data":{"kubeconfig":%q}}`, base64.StdEncoding.EncodeToString([]byte("fresh")))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+
+		gardenKubeconfig := filepath.Join(tmpDir, "garden.yaml")
+		Expect(ioutil.WriteFile(gardenKubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: %s
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: prod
+current-context: prod
+users:
+- name: prod
+  user:
+    token: token
+`, server.URL)), 0600)).To(Succeed())
+		config = &staticConfig{GardenClusters: []gardenctl.GardenClusterMeta{{Name: "prod", KubeConfig: gardenKubeconfig}}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should use a cached kubeconfig which has not expired", func() {
+		cache := gardenctl.DirCache(filepath.Join(tmpDir, "cache"))
+		path := gardenctl.SeedKubeconfigPath(cache.Dir(), "prod", "aws")
+		_, err := cache.Write(path, []byte("cached"))
+		Expect(err).NotTo(HaveOccurred())
+
+		factory := gardenctl.NewClientFactory(config, cache)
+		Expect(factory.KubeconfigPath(stack, gardenctl.TargetKindSeed)).To(Equal(path))
+		Expect(ioutil.ReadFile(path)).To(Equal([]byte("cached")))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("should fetch an expired kubeconfig again", func() {
+		cache := expiringCache{gardenctl.DirCache(filepath.Join(tmpDir, "cache"))}
+		path := gardenctl.SeedKubeconfigPath(cache.Dir(), "prod", "aws")
+		_, err := cache.Write(path, []byte("expired"))
+		Expect(err).NotTo(HaveOccurred())
+
+		factory := gardenctl.NewClientFactory(config, cache)
+		Expect(factory.KubeconfigPath(stack, gardenctl.TargetKindSeed)).To(Equal(path))
+		Expect(ioutil.ReadFile(path)).To(Equal([]byte("fresh")))
+		Expect(requests).To(Equal([]string{"/apis/core.gardener.cloud/v1beta1/seeds/aws", "/api/v1/namespaces/garden/secrets/seed-aws"}))
+	})
+})
@@ -0,0 +1,217 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// LoadConfig reads the gardenctl configuration at path, see DecodeConfig
+func LoadConfig(path string) (*GardenConfig, error) {
+	config, _, err := LoadConfigWithWarnings(path)
+	return config, err
+}
+
+// LoadConfigWithWarnings reads the gardenctl configuration at path and returns the warnings about
+// unknown and duplicate keys as well, see DecodeConfig
+func LoadConfigWithWarnings(path string) (*GardenConfig, []ConfigError, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, warnings, err := DecodeConfig(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gardenctl configuration %s: %v", path, err)
+	}
+	return config, warnings, nil
+}
+
+// SaveConfig writes the gardenctl configuration with ConfigAPIVersion to path. The file is replaced
+// atomically, comments of a former version of the file are not kept.
+func SaveConfig(path string, config *GardenConfig) error {
+	versioned := *config
+	versioned.APIVersion = ConfigAPIVersion
+	content, err := yaml.Marshal(&versioned)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AddGarden adds garden to the configuration, its name must not be configured yet
+func (c *GardenConfig) AddGarden(garden GardenClusterMeta) error {
+	if garden.Name == "" {
+		return fmt.Errorf("name of garden must not be empty")
+	}
+	if c.Garden(garden.Name) != nil {
+		return fmt.Errorf("garden %q is already configured", garden.Name)
+	}
+	c.GardenClusters = append(c.GardenClusters, garden)
+	return nil
+}
+
+// RemoveGarden removes the garden with the given name from the configuration
+func (c *GardenConfig) RemoveGarden(name string) error {
+	for i := range c.GardenClusters {
+		if c.GardenClusters[i].Name == name {
+			c.GardenClusters = append(c.GardenClusters[:i], c.GardenClusters[i+1:]...)
+			return nil
+		}
+	}
+	return NewNotFoundError("garden %q is not configured", name)
+}
+
+// ConfigKeys are the keys of the configuration which can be set, <name> is the name of a garden
+var ConfigKeys = []string{
+	"email",
+	"githubURL",
+	"cache.ttl",
+	"cache.encryption",
+	"cache.keyringCommand",
+	"gardenClusters.<name>.kubeConfig",
+	"gardenClusters.<name>.dashboardUrl",
+	"gardenClusters.<name>.readOnly",
+	"gardenClusters.<name>.seedCapacity",
+}
+
+// Set sets the value of the given key, see ConfigKeys for the keys which can be set
+func (c *GardenConfig) Set(key, value string) error {
+	switch key {
+	case "email":
+		c.Email = value
+		return nil
+	case "githubURL":
+		c.GithubURL = value
+		return nil
+	case "cache.ttl":
+		c.Cache.TTL = value
+		return nil
+	case "cache.encryption":
+		c.Cache.Encryption = value
+		return nil
+	case "cache.keyringCommand":
+		c.Cache.KeyringCommand = value
+		return nil
+	}
+
+	const prefix = "gardenClusters."
+	i := strings.LastIndex(key, ".")
+	if !strings.HasPrefix(key, prefix) || i < len(prefix) {
+		return fmt.Errorf("unknown key %q, must be one of: %s", key, strings.Join(ConfigKeys, ", "))
+	}
+	name, field := key[len(prefix):i], key[i+1:]
+	garden := c.Garden(name)
+	if garden == nil {
+		return NewNotFoundError("garden %q is not configured", name)
+	}
+	switch field {
+	case "kubeConfig":
+		garden.KubeConfig = value
+	case "dashboardUrl":
+		garden.DashboardURL = value
+	case "readOnly":
+		readOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s, must be true or false", value, key)
+		}
+		garden.ReadOnly = readOnly
+	case "seedCapacity":
+		capacity, err := strconv.Atoi(value)
+		if err != nil || capacity < 0 {
+			return fmt.Errorf("invalid value %q for %s, must be a number of shoots", value, key)
+		}
+		garden.SeedCapacity = capacity
+	default:
+		return fmt.Errorf("unknown key %q, must be one of: %s", key, strings.Join(ConfigKeys, ", "))
+	}
+	return nil
+}
+
+// ImportGardens returns a garden for every kubeconfig in dir, named after the file without its
+// extension. Files which are no kubeconfigs with a current context are returned with the reason
+// why they are skipped.
+func ImportGardens(dir string) ([]GardenClusterMeta, map[string]error, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var gardens []GardenClusterMeta
+	skipped := make(map[string]error)
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		path, err := filepath.Abs(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		config, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			skipped[path] = err
+			continue
+		}
+		if _, ok := config.Contexts[config.CurrentContext]; !ok {
+			skipped[path] = fmt.Errorf("no current context")
+			continue
+		}
+		gardens = append(gardens, GardenClusterMeta{
+			Name:       strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())),
+			KubeConfig: path,
+		})
+	}
+	return gardens, skipped, nil
+}
+
+// Garden returns the garden cluster with the given name or nil if it is not configured
+func (c *GardenConfig) Garden(name string) *GardenClusterMeta {
+	for i := range c.GardenClusters {
+		if c.GardenClusters[i].Name == name {
+			return &c.GardenClusters[i]
+		}
+	}
+	return nil
+}
+
+// AccessRestrictions returns the access restrictions of the garden with the given name
+func (c *GardenConfig) AccessRestrictions(gardenName string) []AccessRestriction {
+	if garden := c.Garden(gardenName); garden != nil {
+		return garden.AccessRestrictions
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: garden
+clusters:
+- name: garden
+  cluster:
+    server: https://api.garden.example.com
+contexts:
+- name: garden
+  context:
+    cluster: garden
+    user: admin
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+var _ = Describe("Config", func() {
+	var (
+		dir        string
+		kubeconfig string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gardenctl")
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfig = filepath.Join(dir, "prod.yaml")
+		Expect(ioutil.WriteFile(kubeconfig, []byte(testKubeconfig), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("#ValidateConfig", func() {
+		It("should accept a valid configuration", func() {
+			content := "gardenClusters:\n- name: prod\n  kubeConfig: " + kubeconfig + "\n  dashboardUrl: https://dashboard.example.com\n"
+			Expect(gardenctl.ValidateConfig([]byte(content), &bytes.Buffer{})).To(BeEmpty())
+		})
+
+		It("should report the line of a syntax error", func() {
+			errs := gardenctl.ValidateConfig([]byte("gardenClusters:\n- name: prod\n  kubeConfig: [\n"), &bytes.Buffer{})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Line).To(BeNumerically(">", 0))
+		})
+
+		It("should report the invalid fields with their lines", func() {
+			content := `email: john.doe@example.com
+gardenClusters:
+- name: prod
+  kubeConfig: ` + kubeconfig + `
+- name: prod
+  # the kubeconfig is missing
+  kubeConfig: ` + filepath.Join(dir, "missing.yaml") + `
+  dashboardUrl: dashboard.example.com
+  accessRestrictions:
+  - key: eu-access
+    enforcement: block
+  - key: production
+    selector:
+      projects: ["re:("]
+  seedCapacity: -1
+`
+			errs := gardenctl.ValidateConfig([]byte(content), &bytes.Buffer{})
+			Expect(errs).To(ConsistOf(
+				gardenctl.ConfigError{Path: "gardenClusters[1].name", Line: 5, Column: 3, Message: `garden "prod" is configured more than once`},
+				gardenctl.ConfigError{Path: "gardenClusters[1].kubeConfig", Line: 7, Column: 3, Message: "kubeconfig " + filepath.Join(dir, "missing.yaml") + " does not exist"},
+				gardenctl.ConfigError{Path: "gardenClusters[1].dashboardUrl", Line: 8, Column: 3, Message: `dashboard URL "dashboard.example.com" is not a valid http(s) URL`},
+				gardenctl.ConfigError{Path: "gardenClusters[1].accessRestrictions[0]", Line: 10, Column: 5, Message: `unknown enforcement "block", must be one of: warn, confirm, deny`},
+				gardenctl.ConfigError{Path: "gardenClusters[1].accessRestrictions[1]", Line: 12, Column: 5, Message: "invalid selector: invalid regular expression \"re:(\": error parsing regexp: missing closing ): `(`"},
+				gardenctl.ConfigError{Path: "gardenClusters[1].seedCapacity", Line: 15, Column: 3, Message: "seed capacity must not be negative"},
+			))
+		})
+
+		It("should report the positions of fields in flow style, behind anchors and comments", func() {
+			content := `gardenClusters:
+    # both gardens use the same kubeconfig
+    - {name: prod, kubeConfig: &kubeconfig ` + kubeconfig + `}
+    - {name: dev, kubeConfig: *kubeconfig,
+       seedCapacity: -1}
+    - name: dev
+      kubeConfig: *kubeconfig
+`
+			errs := gardenctl.ValidateConfig([]byte(content), &bytes.Buffer{})
+			Expect(errs).To(ConsistOf(
+				gardenctl.ConfigError{Path: "gardenClusters[1].seedCapacity", Line: 5, Column: 8, Message: "seed capacity must not be negative"},
+				gardenctl.ConfigError{Path: "gardenClusters[2].name", Line: 6, Column: 7, Message: `garden "dev" is configured more than once`},
+			))
+		})
+	})
+
+	Describe("editing", func() {
+		var config *gardenctl.GardenConfig
+
+		BeforeEach(func() {
+			config = &gardenctl.GardenConfig{GardenClusters: []gardenctl.GardenClusterMeta{{Name: "prod", KubeConfig: kubeconfig}}}
+		})
+
+		It("should add and remove gardens", func() {
+			Expect(config.AddGarden(gardenctl.GardenClusterMeta{Name: "dev", KubeConfig: kubeconfig})).To(Succeed())
+			Expect(config.AddGarden(gardenctl.GardenClusterMeta{Name: "dev"})).To(MatchError(`garden "dev" is already configured`))
+			Expect(config.RemoveGarden("prod")).To(Succeed())
+			Expect(gardenctl.IsNotFound(config.RemoveGarden("prod"))).To(BeTrue())
+			Expect(config.GardenClusters).To(Equal([]gardenctl.GardenClusterMeta{{Name: "dev", KubeConfig: kubeconfig}}))
+		})
+
+		It("should set values", func() {
+			Expect(config.Set("email", "john.doe@example.com")).To(Succeed())
+			Expect(config.Set("cache.ttl", "8h")).To(Succeed())
+			Expect(config.Set("gardenClusters.prod.readOnly", "true")).To(Succeed())
+			Expect(config.Set("gardenClusters.prod.dashboardUrl", "https://dashboard.example.com")).To(Succeed())
+			Expect(config.Set("gardenClusters.prod.seedCapacity", "250")).To(Succeed())
+			Expect(config.Email).To(Equal("john.doe@example.com"))
+			Expect(config.Cache.TTL).To(Equal("8h"))
+			Expect(config.GardenClusters[0].ReadOnly).To(BeTrue())
+			Expect(config.GardenClusters[0].DashboardURL).To(Equal("https://dashboard.example.com"))
+			Expect(config.GardenClusters[0].SeedCapacity).To(Equal(250))
+		})
+
+		It("should fail for unknown keys, gardens and invalid values", func() {
+			Expect(config.Set("name", "x")).To(MatchError(ContainSubstring(`unknown key "name"`)))
+			Expect(config.Set("gardenClusters.prod.name", "x")).To(MatchError(ContainSubstring(`unknown key "gardenClusters.prod.name"`)))
+			Expect(gardenctl.IsNotFound(config.Set("gardenClusters.dev.readOnly", "true"))).To(BeTrue())
+			Expect(config.Set("gardenClusters.prod.readOnly", "yes")).To(HaveOccurred())
+			Expect(config.Set("gardenClusters.prod.seedCapacity", "-1")).To(MatchError(`invalid value "-1" for gardenClusters.prod.seedCapacity, must be a number of shoots`))
+		})
+
+		It("should save and load the configuration", func() {
+			path := filepath.Join(dir, "config")
+			Expect(gardenctl.SaveConfig(path, config)).To(Succeed())
+			loaded, err := gardenctl.LoadConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			config.APIVersion = gardenctl.ConfigAPIVersion
+			Expect(loaded).To(Equal(config))
+		})
+	})
+
+	Describe("#DecodeConfig", func() {
+		It("should convert a configuration without apiVersion and apply the defaults", func() {
+			config, warnings, err := gardenctl.DecodeConfig([]byte("gardenClusters:\n- name: prod\n  accessRestrictions:\n  - key: eu-access\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(config.APIVersion).To(Equal(gardenctl.ConfigAPIVersion))
+			Expect(config.GardenClusters[0].AccessRestrictions[0].Enforcement).To(Equal(gardenctl.RestrictionEnforcementWarn))
+		})
+
+		It("should warn about unknown and duplicate keys", func() {
+			content := `apiVersion: ` + gardenctl.ConfigAPIVersion + `
+gardenClusters:
+- name: prod
+  accessRestrictions:
+  - key: eu-access
+    enforcment: deny
+  readonly: true
+  readOnly: true
+  readOnly: false
+`
+			config, warnings, err := gardenctl.DecodeConfig([]byte(content))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.GardenClusters[0].Name).To(Equal("prod"))
+			Expect(warnings).To(ConsistOf(
+				gardenctl.ConfigError{Line: 6, Message: `unknown field "enforcment"`},
+				gardenctl.ConfigError{Line: 7, Message: `unknown field "readonly"`},
+				gardenctl.ConfigError{Line: 9, Message: `duplicate key "readOnly"`},
+			))
+		})
+
+		It("should fail for an unsupported apiVersion", func() {
+			_, _, err := gardenctl.DecodeConfig([]byte("apiVersion: gardenctl.gardener.cloud/v2\n"))
+			Expect(err).To(MatchError(`unsupported apiVersion "gardenctl.gardener.cloud/v2", must be ` + gardenctl.ConfigAPIVersion))
+		})
+	})
+
+	Describe("#MigrateConfig", func() {
+		It("should convert the configuration and keep a backup", func() {
+			path := filepath.Join(dir, "config")
+			content := []byte("gardenClusters:\n- name: prod\n  kubeConfig: " + kubeconfig + "\n  dashboardURL: https://dashboard.example.com\n")
+			Expect(ioutil.WriteFile(path, content, 0644)).To(Succeed())
+
+			backup, version, warnings, err := gardenctl.MigrateConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(BeEmpty())
+			Expect(warnings).To(ConsistOf(gardenctl.ConfigError{Line: 4, Message: `unknown field "dashboardURL"`}))
+			Expect(ioutil.ReadFile(backup)).To(Equal(content))
+			Expect(gardenctl.ConfigVersion(mustReadFile(path))).To(Equal(gardenctl.ConfigAPIVersion))
+
+			backup, version, _, err = gardenctl.MigrateConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backup).To(BeEmpty())
+			Expect(version).To(Equal(gardenctl.ConfigAPIVersion))
+		})
+
+		It("should not overwrite an existing backup", func() {
+			path := filepath.Join(dir, "config")
+			Expect(ioutil.WriteFile(path+".bak", []byte("original"), 0600)).To(Succeed())
+			content := []byte("gardenClusters:\n- name: prod\n  kubeConfig: " + kubeconfig + "\n")
+			Expect(ioutil.WriteFile(path, content, 0644)).To(Succeed())
+
+			backup, _, _, err := gardenctl.MigrateConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backup).To(HavePrefix(path + ".bak."))
+			Expect(ioutil.ReadFile(backup)).To(Equal(content))
+			Expect(ioutil.ReadFile(path + ".bak")).To(Equal([]byte("original")))
+		})
+	})
+
+	Describe("#ImportGardens", func() {
+		It("should return a garden for every kubeconfig", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("no kubeconfig"), 0600)).To(Succeed())
+
+			gardens, skipped, err := gardenctl.ImportGardens(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gardens).To(Equal([]gardenctl.GardenClusterMeta{{Name: "prod", KubeConfig: kubeconfig}}))
+			Expect(skipped).To(HaveKey(filepath.Join(dir, "notes.txt")))
+		})
+	})
+})
+
+func mustReadFile(path string) []byte {
+	content, err := ioutil.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	return content
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ConfigError is an error in the gardenctl configuration
+type ConfigError struct {
+	// Path is the path of the invalid field, e.g. gardenClusters[1].kubeConfig
+	Path string
+	// Line is the line of the invalid field in the configuration, 0 if it is unknown
+	Line int
+	// Column is the column of the invalid field in the configuration, 0 if it is unknown
+	Column  int
+	Message string
+}
+
+// Error returns the line, the path and the message of the error
+func (e ConfigError) Error() string {
+	var prefix string
+	if e.Line > 0 && e.Column > 0 {
+		prefix = fmt.Sprintf("line %d, column %d: ", e.Line, e.Column)
+	} else if e.Line > 0 {
+		prefix = fmt.Sprintf("line %d: ", e.Line)
+	}
+	if e.Path != "" {
+		prefix += e.Path + ": "
+	}
+	return prefix + e.Message
+}
+
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// ValidateConfig validates the gardenctl configuration with the given content. It checks that the
+// names of the gardens are unique, their kubeconfigs exist and are supported, their dashboard URLs
+// are valid and that their access restrictions and read-only targets are well-formed. Warnings
+// about unknown keys, a missing apiVersion and kubeconfigs are written to warnings.
+func ValidateConfig(content []byte, warnings io.Writer) []ConfigError {
+	version, err := ConfigVersion(content)
+	if err != nil {
+		return []ConfigError{yamlConfigError(err)}
+	}
+	// the positions of the errors are unknown if the document cannot be parsed into nodes, e.g. because
+	// of duplicate keys, which are warnings only
+	var root yamlv3.Node
+	if yamlv3.Unmarshal(content, &root) != nil {
+		root = yamlv3.Node{}
+	}
+	if !isSupportedConfigVersion(version) {
+		line, column := positionOf(&root, "apiVersion")
+		return []ConfigError{{
+			Path:    "apiVersion",
+			Line:    line,
+			Column:  column,
+			Message: fmt.Sprintf("unsupported apiVersion %q, must be %s", version, ConfigAPIVersion),
+		}}
+	}
+	config, decodeWarnings, err := DecodeConfig(content)
+	if err != nil {
+		return []ConfigError{yamlConfigError(err)}
+	}
+	if version == "" && len(config.GardenClusters) > 0 {
+		fmt.Fprintf(warnings, "Warning: configuration has no apiVersion, it can be migrated to %s\n", ConfigAPIVersion)
+	}
+	for _, w := range decodeWarnings {
+		fmt.Fprintf(warnings, "Warning: %v\n", w)
+	}
+
+	var errs []ConfigError
+	add := func(message string, path ...interface{}) {
+		line, column := positionOf(&root, path...)
+		errs = append(errs, ConfigError{Path: fieldPath(path), Line: line, Column: column, Message: message})
+	}
+
+	names := make(map[string]bool)
+	for i, garden := range config.GardenClusters {
+		switch {
+		case garden.Name == "":
+			add("name must not be empty", "gardenClusters", i)
+		case names[garden.Name]:
+			add(fmt.Sprintf("garden %q is configured more than once", garden.Name), "gardenClusters", i, "name")
+		}
+		names[garden.Name] = true
+
+		if err := ValidateKubeconfig(garden.KubeConfig, warnings); err != nil {
+			add(err.Error(), "gardenClusters", i, "kubeConfig")
+		}
+		if garden.DashboardURL != "" {
+			if u, err := url.Parse(garden.DashboardURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				add(fmt.Sprintf("dashboard URL %q is not a valid http(s) URL", garden.DashboardURL), "gardenClusters", i, "dashboardUrl")
+			}
+		}
+
+		keys := make(map[string]bool)
+		for j, restriction := range garden.AccessRestrictions {
+			for _, message := range validateAccessRestriction(restriction) {
+				add(message, "gardenClusters", i, "accessRestrictions", j)
+			}
+			if keys[restriction.Key] {
+				add(fmt.Sprintf("access restriction %q is configured more than once", restriction.Key), "gardenClusters", i, "accessRestrictions", j, "key")
+			}
+			keys[restriction.Key] = true
+		}
+
+		if garden.SeedCapacity < 0 {
+			add("seed capacity must not be negative", "gardenClusters", i, "seedCapacity")
+		}
+
+		for j, t := range garden.ReadOnlyTargets {
+			for _, pattern := range []string{t.Project, t.Shoot} {
+				if _, err := matchesPattern(pattern, ""); err != nil {
+					add(fmt.Sprintf("invalid pattern %q: %v", pattern, err), "gardenClusters", i, "readOnlyTargets", j)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// yamlConfigError returns the error of decoding the configuration with its line, if it is known
+func yamlConfigError(err error) ConfigError {
+	message := err.Error()
+	if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ConfigError{Line: line, Message: strings.TrimPrefix(message, m[0])}
+	}
+	return ConfigError{Message: message}
+}
+
+// ValidateKubeconfig returns an error if the kubeconfig at path does not exist or is not supported
+func ValidateKubeconfig(path string, warnings io.Writer) error {
+	if path == "" {
+		return fmt.Errorf("kubeconfig must not be empty")
+	}
+	expanded := ExpandHomeDir(path)
+	if _, err := os.Stat(expanded); err != nil {
+		return fmt.Errorf("kubeconfig %s does not exist", path)
+	}
+	config, err := clientcmd.LoadFromFile(expanded)
+	if err != nil {
+		return fmt.Errorf("kubeconfig %s cannot be parsed: %v", path, err)
+	}
+	if _, ok := config.Contexts[config.CurrentContext]; !ok {
+		return fmt.Errorf("kubeconfig %s has no current context", path)
+	}
+	return ValidateClientConfig(*config, expanded, warnings)
+}
+
+// validateAccessRestriction returns the problems of an access restriction
+func validateAccessRestriction(restriction AccessRestriction) []string {
+	var messages []string
+	if restriction.Key == "" {
+		messages = append(messages, "key must not be empty")
+	}
+	switch restriction.Enforcement {
+	case "", RestrictionEnforcementWarn, RestrictionEnforcementConfirm, RestrictionEnforcementDeny:
+	default:
+		messages = append(messages, fmt.Sprintf("unknown enforcement %q, must be one of: warn, confirm, deny", restriction.Enforcement))
+	}
+	for _, option := range restriction.Options {
+		if option.Key == "" {
+			messages = append(messages, "key of option must not be empty")
+		}
+	}
+	if restriction.Selector != nil {
+		if err := restriction.Selector.Validate(); err != nil {
+			messages = append(messages, fmt.Sprintf("invalid selector: %v", err))
+		}
+	}
+	return messages
+}
+
+// fieldPath formats a path of mapping keys and sequence indices, e.g. gardenClusters[1].kubeConfig
+func fieldPath(path []interface{}) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		case string:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(p)
+		}
+	}
+	return b.String()
+}
+
+// positionOf returns the line and the column of the node at path in the YAML document root, or 0 if
+// the node cannot be located. path consists of mapping keys and sequence indices, the position of a key
+// is the position of the key itself.
+func positionOf(root *yamlv3.Node, path ...interface{}) (int, int) {
+	if root == nil || len(root.Content) == 0 {
+		return 0, 0
+	}
+	node := root.Content[0]
+	line, column := node.Line, node.Column
+	for _, p := range path {
+		if node.Kind == yamlv3.AliasNode {
+			node = node.Alias
+		}
+		switch p := p.(type) {
+		case string:
+			if node.Kind != yamlv3.MappingNode {
+				return 0, 0
+			}
+			var value *yamlv3.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if key := node.Content[i]; key.Value == p {
+					line, column, value = key.Line, key.Column, node.Content[i+1]
+					break
+				}
+			}
+			if value == nil {
+				return 0, 0
+			}
+			node = value
+		case int:
+			if node.Kind != yamlv3.SequenceNode || p >= len(node.Content) {
+				return 0, 0
+			}
+			node = node.Content[p]
+			line, column = node.Line, node.Column
+		}
+	}
+	return line, column
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigAPIVersion is the version of the configuration format read and written by gardenctl.
+// Configurations without an apiVersion predate the versioning and are converted when they are read.
+const ConfigAPIVersion = "gardenctl.gardener.cloud/v1alpha1"
+
+// ConfigVersions are the supported versions of the configuration format, the empty version is the
+// format without apiVersion
+var ConfigVersions = []string{"", ConfigAPIVersion}
+
+var (
+	yamlUnknownField   = regexp.MustCompile(`^line (\d+): field (\S+) not found in type \S+$`)
+	yamlDuplicateKey   = regexp.MustCompile(`^line (\d+): key (.+) already set in map$`)
+	yamlDuplicateField = regexp.MustCompile(`^line (\d+): field (\S+) already set in type \S+$`)
+)
+
+// DecodeConfig decodes a configuration of any supported version, converts it to ConfigAPIVersion
+// and applies the defaults. Unknown and duplicate keys are no errors but returned as warnings, they
+// would be silently dropped otherwise.
+func DecodeConfig(content []byte) (*GardenConfig, []ConfigError, error) {
+	var config GardenConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, nil, err
+	}
+	if !isSupportedConfigVersion(config.APIVersion) {
+		return nil, nil, fmt.Errorf("unsupported apiVersion %q, must be %s", config.APIVersion, ConfigAPIVersion)
+	}
+
+	var warnings []ConfigError
+	if err := yaml.UnmarshalStrict(content, &GardenConfig{}); err != nil {
+		terr, ok := err.(*yaml.TypeError)
+		if !ok {
+			return nil, nil, err
+		}
+		for _, message := range terr.Errors {
+			warnings = append(warnings, strictDecodingWarning(message))
+		}
+	}
+
+	config.APIVersion = ConfigAPIVersion
+	SetConfigDefaults(&config)
+	return &config, warnings, nil
+}
+
+// SetConfigDefaults sets the defaults of the fields which are not set in the configuration
+func SetConfigDefaults(config *GardenConfig) {
+	for i := range config.GardenClusters {
+		for j := range config.GardenClusters[i].AccessRestrictions {
+			restriction := &config.GardenClusters[i].AccessRestrictions[j]
+			if restriction.Enforcement == "" {
+				restriction.Enforcement = RestrictionEnforcementWarn
+			}
+		}
+	}
+}
+
+// ConfigVersion returns the apiVersion of the configuration, the empty string if it has none
+func ConfigVersion(content []byte) (string, error) {
+	var meta struct {
+		APIVersion string `yaml:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(content, &meta); err != nil {
+		return "", err
+	}
+	return meta.APIVersion, nil
+}
+
+// MigrateConfig converts the configuration at path to ConfigAPIVersion. The former file is kept as
+// backup next to it, an existing backup is never overwritten. The path of the backup is returned together with the version the configuration had and the
+// warnings about the unknown keys, which are dropped by the migration. A configuration which already
+// has ConfigAPIVersion is not changed and no backup is returned.
+func MigrateConfig(path string) (string, string, []ConfigError, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", nil, err
+	}
+	version, err := ConfigVersion(content)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid gardenctl configuration %s: %v", path, err)
+	}
+	if version == ConfigAPIVersion {
+		return "", version, nil, nil
+	}
+	config, warnings, err := DecodeConfig(content)
+	if err != nil {
+		return "", version, nil, fmt.Errorf("invalid gardenctl configuration %s: %v", path, err)
+	}
+
+	backup, err := writeBackup(path, content)
+	if err != nil {
+		return "", version, nil, err
+	}
+	if err = SaveConfig(path, config); err != nil {
+		return "", version, nil, err
+	}
+	return backup, version, warnings, nil
+}
+
+// writeBackup writes content to <path>.bak, or to <path>.bak.<timestamp> if there is a backup already,
+// and returns the path of the backup
+func writeBackup(path string, content []byte) (string, error) {
+	backup := path + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		backup = fmt.Sprintf("%s.bak.%s", path, time.Now().Format("20060102150405.000000000"))
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	file, err := os.OpenFile(backup, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	return backup, file.Close()
+}
+
+// isSupportedConfigVersion returns true if version is one of ConfigVersions
+func isSupportedConfigVersion(version string) bool {
+	for _, v := range ConfigVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// strictDecodingWarning turns an error of the strict decoding into a warning with line
+func strictDecodingWarning(message string) ConfigError {
+	if m := yamlUnknownField.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ConfigError{Line: line, Message: fmt.Sprintf("unknown field %q", m[2])}
+	}
+	if m := yamlDuplicateKey.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ConfigError{Line: line, Message: fmt.Sprintf("duplicate key %s", m[2])}
+	}
+	if m := yamlDuplicateField.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ConfigError{Line: line, Message: fmt.Sprintf("duplicate key %q", m[2])}
+	}
+	return ConfigError{Message: message}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gardenctl contains the targeting and client resolution of gardenctl as a library.
+//
+// A Session holds the target stack of a shell and the gardenctl configuration, names are
+// resolved against the gardens of the configuration and a ClientFactory constructs the clients
+// to the clusters of a target stack. The gardenctl commands are built on top of this package,
+// so tools using it resolve targets exactly like the CLI:
+//
+//	session := gardenctl.SessionFromEnv()
+//	stack, err := session.ReadTarget()
+//	if err != nil {
+//		return err
+//	}
+//	clients := session.ClientFactory()
+//	shootClient, err := clients.Kubernetes(stack, gardenctl.TargetKindShoot)
+package gardenctl
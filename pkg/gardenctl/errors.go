@@ -0,0 +1,163 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorReason classifies the failures of gardenctl
+type ErrorReason string
+
+const (
+	// ReasonNotTargeted means the command requires a target which is not set
+	ReasonNotTargeted ErrorReason = "NotTargeted"
+	// ReasonNotFound means a named object does not exist
+	ReasonNotFound ErrorReason = "NotFound"
+	// ReasonForbidden means the user is not allowed to perform an operation
+	ReasonForbidden ErrorReason = "Forbidden"
+	// ReasonAmbiguousMatch means a name matches more than one object
+	ReasonAmbiguousMatch ErrorReason = "AmbiguousMatch"
+	// ReasonToolMissing means a required external tool is not installed
+	ReasonToolMissing ErrorReason = "ToolMissing"
+	// ReasonRemoteFailure means a cluster or cloud provider cannot be reached or fails
+	ReasonRemoteFailure ErrorReason = "RemoteFailure"
+)
+
+// Error is a failure of gardenctl with a reason, which the CLI maps to its exit code
+type Error struct {
+	Reason  ErrorReason
+	Message string
+	Err     error
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying error, if any
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewNotTargetedError returns an error for a command which requires a target of the given kind
+func NewNotTargetedError(kind TargetKind) error {
+	message := fmt.Sprintf("no %s targeted", kind)
+	if kind == TargetKindGarden {
+		message = "no garden cluster targeted"
+	}
+	return &Error{Reason: ReasonNotTargeted, Message: message}
+}
+
+// NewNotFoundError returns an error for an object which does not exist
+func NewNotFoundError(format string, a ...interface{}) error {
+	return &Error{Reason: ReasonNotFound, Message: fmt.Sprintf(format, a...)}
+}
+
+// NewForbiddenError returns an error for an operation the user is not allowed to perform
+func NewForbiddenError(format string, a ...interface{}) error {
+	return &Error{Reason: ReasonForbidden, Message: fmt.Sprintf(format, a...)}
+}
+
+// NewAmbiguousMatchError returns an error for a name which matches several objects of the given kind
+func NewAmbiguousMatchError(kind TargetKind, name string, matches []string) error {
+	return &Error{
+		Reason:  ReasonAmbiguousMatch,
+		Message: fmt.Sprintf("%q matches %d %ss: %s", name, len(matches), kind, strings.Join(matches, ", ")),
+	}
+}
+
+// NewToolMissingError returns an error for an external tool which is not installed
+func NewToolMissingError(tool string) error {
+	return &Error{Reason: ReasonToolMissing, Message: fmt.Sprintf("%s is not installed on your system", tool)}
+}
+
+// NewRemoteError returns an error for a cluster or cloud provider which failed
+func NewRemoteError(err error, format string, a ...interface{}) error {
+	return &Error{Reason: ReasonRemoteFailure, Message: fmt.Sprintf(format, a...), Err: err}
+}
+
+// ReasonForError returns the reason of err. Errors of the kubernetes API and network errors are
+// classified as well, all other errors have an empty reason.
+func ReasonForError(err error) ErrorReason {
+	var gardenctlErr *Error
+	if errors.As(err, &gardenctlErr) && gardenctlErr.Reason != "" {
+		return gardenctlErr.Reason
+	}
+
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		switch {
+		case apierrors.IsNotFound(err):
+			return ReasonNotFound
+		case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+			return ReasonForbidden
+		case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err), apierrors.IsServiceUnavailable(err),
+			apierrors.IsInternalError(err), apierrors.IsTooManyRequests(err), apierrors.IsUnexpectedServerError(err):
+			return ReasonRemoteFailure
+		}
+		return ""
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return ReasonRemoteFailure
+	}
+	return ""
+}
+
+// IsNotTargeted returns true if err is caused by a missing target
+func IsNotTargeted(err error) bool {
+	return ReasonForError(err) == ReasonNotTargeted
+}
+
+// IsNotFound returns true if err is caused by an object which does not exist
+func IsNotFound(err error) bool {
+	return ReasonForError(err) == ReasonNotFound
+}
+
+// IsForbidden returns true if err is caused by missing permissions
+func IsForbidden(err error) bool {
+	return ReasonForError(err) == ReasonForbidden
+}
+
+// IsAmbiguousMatch returns true if err is caused by a name matching several objects
+func IsAmbiguousMatch(err error) bool {
+	return ReasonForError(err) == ReasonAmbiguousMatch
+}
+
+// IsToolMissing returns true if err is caused by a missing external tool
+func IsToolMissing(err error) bool {
+	return ReasonForError(err) == ReasonToolMissing
+}
+
+// IsRemoteFailure returns true if err is caused by an unreachable or failing cluster or cloud provider
+func IsRemoteFailure(err error) bool {
+	return ReasonForError(err) == ReasonRemoteFailure
+}
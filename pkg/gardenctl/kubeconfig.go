@@ -0,0 +1,63 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// SetKubeconfigNamespace returns kubeconfig with namespace set in its current context and the name
+// of the current context. The other contexts of the kubeconfig are left as they are.
+func SetKubeconfigNamespace(kubeconfig []byte, namespace string) ([]byte, string, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, "", err
+	}
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, "", fmt.Errorf("current context %q not found", config.CurrentContext)
+	}
+	context.Namespace = namespace
+
+	data, err := marshalKubeconfig(config)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, config.CurrentContext, nil
+}
+
+// marshalKubeconfig converts config to v1 and marshals it. The codec of clientcmd is not used,
+// because it breaks on maps with the vendored json-iterator.
+func marshalKubeconfig(config *clientcmdapi.Config) ([]byte, error) {
+	var v1Config clientcmdapiv1.Config
+	if err := clientcmdlatest.Scheme.Convert(config, &v1Config, nil); err != nil {
+		return nil, err
+	}
+	v1Config.APIVersion, v1Config.Kind = "v1", "Config"
+	return yaml.Marshal(&v1Config)
+}
+
+// SessionKubeconfigPath returns the path of the kubeconfig of a session in the cache directory. It is
+// a copy of the kubeconfig of the target with the targeted namespace set, so sessions targeting the
+// same cluster can use different namespaces.
+func SessionKubeconfigPath(cacheDir, sessionID string) string {
+	return filepath.Join(cacheDir, "sessions", sessionID, "kubeconfig.yaml")
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Kubeconfig", func() {
+	Describe("#SetKubeconfigNamespace", func() {
+		kubeconfig := []byte(`apiVersion: v1
+kind: Config
+current-context: shoot
+clusters:
+- name: shoot
+  cluster:
+    server: https://api.shoot.example.com
+contexts:
+- name: shoot
+  context:
+    cluster: shoot
+    user: admin
+- name: other
+  context:
+    cluster: shoot
+    user: admin
+    namespace: other
+users:
+- name: admin
+  user:
+    token: secret
+`)
+
+		It("should only set the namespace of the current context", func() {
+			data, context, err := gardenctl.SetKubeconfigNamespace(kubeconfig, "kube-system")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context).To(Equal("shoot"))
+
+			config, err := clientcmd.Load(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Contexts["shoot"].Namespace).To(Equal("kube-system"))
+			Expect(config.Contexts["other"].Namespace).To(Equal("other"))
+			Expect(config.AuthInfos["admin"].Token).To(Equal("secret"))
+		})
+
+		It("should fail for a kubeconfig without current context", func() {
+			_, _, err := gardenctl.SetKubeconfigNamespace([]byte("apiVersion: v1\nkind: Config\n"), "kube-system")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ValidateNamespace", func() {
+		client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})
+
+		It("should accept an existing namespace", func() {
+			Expect(gardenctl.ValidateNamespace(client, "kube-system")).To(Succeed())
+		})
+
+		It("should return a not found error for a missing namespace", func() {
+			err := gardenctl.ValidateNamespace(client, "missing")
+			Expect(gardenctl.IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`namespace "missing" not found`))
+		})
+	})
+
+	It("should keep the kubeconfig of a session in the cache", func() {
+		session := &gardenctl.Session{ID: "test", Home: "/home/user/.garden"}
+		Expect(session.KubeconfigPath()).To(Equal("/home/user/.garden/cache/sessions/test/kubeconfig.yaml"))
+	})
+})
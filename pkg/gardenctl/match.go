@@ -0,0 +1,161 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexpPrefix marks a pattern as regular expression, e.g. re:^prod-(aws|gcp)
+const RegexpPrefix = "re:"
+
+type patternKind string
+
+const (
+	patternKindName   patternKind = "name"
+	patternKindGlob   patternKind = "glob"
+	patternKindRegexp patternKind = "regular expression"
+)
+
+// Matcher matches names against a pattern. A pattern is either a name, a shell glob like
+// dev-*-eu or prod-[ab]?, or a regular expression prefixed with re: or enclosed in slashes
+// like /^prod-(aws|gcp)/.
+type Matcher struct {
+	// Pattern is the pattern the matcher was created from
+	Pattern string
+	// IgnoreCase is true if names are matched case-insensitively
+	IgnoreCase bool
+
+	kind patternKind
+	re   *regexp.Regexp
+}
+
+// NewMatcher returns a matcher for pattern, an error is returned if pattern is not a valid glob
+// or regular expression.
+func NewMatcher(pattern string, ignoreCase bool) (*Matcher, error) {
+	m := &Matcher{Pattern: pattern, IgnoreCase: ignoreCase}
+
+	var expr string
+	switch {
+	case strings.HasPrefix(pattern, RegexpPrefix):
+		m.kind, expr = patternKindRegexp, strings.TrimPrefix(pattern, RegexpPrefix)
+	case IsRegexpPattern(pattern):
+		m.kind, expr = patternKindRegexp, pattern[1:len(pattern)-1]
+	case strings.ContainsAny(pattern, "*?["):
+		m.kind, expr = patternKindGlob, globToRegexp(pattern)
+	default:
+		m.kind, expr = patternKindName, "^"+regexp.QuoteMeta(pattern)+"$"
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %v", m.kind, pattern, err)
+	}
+	m.re = re
+	return m, nil
+}
+
+// IsRegexpPattern returns true if pattern is a regular expression, i.e. prefixed with re: or enclosed in slashes
+func IsRegexpPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, RegexpPrefix) ||
+		len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
+// MatchAll returns a matcher matching every name
+func MatchAll() *Matcher {
+	m, _ := NewMatcher("*", false)
+	return m
+}
+
+// Match returns true if name matches the pattern
+func (m *Matcher) Match(name string) bool {
+	return m.re.MatchString(name)
+}
+
+// Filter returns the names matching the pattern
+func (m *Matcher) Filter(names []string) []string {
+	var matches []string
+	for _, name := range names {
+		if m.Match(name) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// Name returns the name the matcher matches exactly and true, or false if the pattern may
+// match several names.
+func (m *Matcher) Name() (string, bool) {
+	if m.kind != patternKindName || m.IgnoreCase {
+		return "", false
+	}
+	return m.Pattern, true
+}
+
+// String describes the pattern, e.g. glob "dev-*-eu" (ignoring case)
+func (m *Matcher) String() string {
+	s := fmt.Sprintf("%s %q", m.kind, m.Pattern)
+	if m.IgnoreCase {
+		s += " (ignoring case)"
+	}
+	return s
+}
+
+// Explain describes which of names were matched by the pattern
+func (m *Matcher) Explain(kind TargetKind, matches []string) string {
+	if len(matches) == 0 {
+		return fmt.Sprintf("%s matches no %s", m, kind)
+	}
+	return fmt.Sprintf("%s matches %d %s(s): %s", m, len(matches), kind, strings.Join(matches, ", "))
+}
+
+// globToRegexp translates a shell glob into an anchored regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	inClass := false
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case inClass:
+			if c == ']' {
+				inClass = false
+			} else if c == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(c)
+		case c == '*':
+			b.WriteString(".*")
+		case c == '?':
+			b.WriteString(".")
+		case c == '[':
+			inClass = true
+			b.WriteByte('[')
+			if i+1 < len(glob) && glob[i+1] == '!' {
+				b.WriteByte('^')
+				i++
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
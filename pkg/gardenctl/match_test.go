@@ -0,0 +1,80 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Matcher", func() {
+	DescribeTable("#Match",
+		func(pattern string, ignoreCase bool, name string, expected bool) {
+			m, err := gardenctl.NewMatcher(pattern, ignoreCase)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Match(name)).To(Equal(expected))
+		},
+		Entry("exact name", "prod", false, "prod", true),
+		Entry("different name", "prod", false, "production", false),
+		Entry("name with other case", "prod", false, "PROD", false),
+		Entry("name ignoring case", "prod", true, "PROD", true),
+		Entry("prefix glob", "prod*", false, "production", true),
+		Entry("suffix glob", "*tion", false, "production", true),
+		Entry("infix glob", "*duct*", false, "production", true),
+		Entry("glob with inner wildcard", "dev-*-eu", false, "dev-aws-eu", true),
+		Entry("glob with inner wildcard not matching", "dev-*-eu", false, "dev-aws-us", false),
+		Entry("glob with single character", "prod-?", false, "prod-1", true),
+		Entry("glob with character class", "prod-[ab]", false, "prod-b", true),
+		Entry("glob with negated character class", "prod-[!ab]", false, "prod-b", false),
+		Entry("glob ignoring case", "PROD-*", true, "prod-aws", true),
+		Entry("glob with regexp meta characters", "prod.*", false, "prod-aws", false),
+		Entry("regexp with prefix", "re:^prod-(aws|gcp)", false, "prod-gcp-eu", true),
+		Entry("regexp in slashes", "/^prod-(aws|gcp)/", false, "prod-azure", false),
+		Entry("regexp ignoring case", "re:^PROD", true, "prod-aws", true),
+	)
+
+	It("should fail for an invalid pattern", func() {
+		_, err := gardenctl.NewMatcher("re:prod-(", false)
+		Expect(err).To(MatchError(ContainSubstring(`invalid regular expression "re:prod-("`)))
+
+		_, err = gardenctl.NewMatcher("prod-[ab", false)
+		Expect(err).To(MatchError(ContainSubstring(`invalid glob "prod-[ab"`)))
+	})
+
+	It("should only return an exact name for a case-sensitive name", func() {
+		m, _ := gardenctl.NewMatcher("prod", false)
+		name, ok := m.Name()
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("prod"))
+
+		m, _ = gardenctl.NewMatcher("prod", true)
+		_, ok = m.Name()
+		Expect(ok).To(BeFalse())
+
+		m, _ = gardenctl.NewMatcher("prod*", false)
+		_, ok = m.Name()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should filter and explain the matching names", func() {
+		m, _ := gardenctl.NewMatcher("a*", false)
+		matches := m.Filter([]string{"abc", "bcd", "acd"})
+		Expect(matches).To(Equal([]string{"abc", "acd"}))
+		Expect(m.Explain(gardenctl.TargetKindProject, matches)).To(Equal(`glob "a*" matches 2 project(s): abc, acd`))
+	})
+})
@@ -0,0 +1,45 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl
+
+// IsReadOnly returns true if the garden is read-only or one of its read-only targets matches
+// project and shoot. project and shoot are empty if no project or shoot is targeted, a read-only
+// target with a pattern for them does not match then.
+func (g *GardenClusterMeta) IsReadOnly(project, shoot string) (bool, error) {
+	if g.ReadOnly {
+		return true, nil
+	}
+	for _, t := range g.ReadOnlyTargets {
+		projectMatches, err := matchesPattern(t.Project, project)
+		if err != nil {
+			return false, err
+		}
+		shootMatches, err := matchesPattern(t.Shoot, shoot)
+		if err != nil {
+			return false, err
+		}
+		if projectMatches && shootMatches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchesPattern returns true if value matches pattern, an empty pattern matches everything
+func matchesPattern(pattern, value string) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	return matchesAnyPattern([]string{pattern}, value)
+}
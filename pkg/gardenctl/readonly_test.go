@@ -0,0 +1,46 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Read-only", func() {
+	DescribeTable("#IsReadOnly",
+		func(garden gardenctl.GardenClusterMeta, project, shoot string, expected bool) {
+			readOnly, err := garden.IsReadOnly(project, shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readOnly).To(Equal(expected))
+		},
+		Entry("writable garden", gardenctl.GardenClusterMeta{}, "prod", "live", false),
+		Entry("read-only garden", gardenctl.GardenClusterMeta{ReadOnly: true}, "", "", true),
+		Entry("read-only target without patterns", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{}}}, "", "", true),
+		Entry("read-only project", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Project: "prod-*"}}}, "prod-eu", "live", true),
+		Entry("other project", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Project: "prod-*"}}}, "dev", "live", false),
+		Entry("seed of read-only project", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Project: "prod-*"}}}, "", "", false),
+		Entry("read-only shoot", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Project: "core", Shoot: "*-live"}}}, "core", "eu-live", true),
+		Entry("project of read-only shoot", gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Project: "core", Shoot: "*-live"}}}, "core", "", false),
+	)
+
+	It("should fail for an invalid pattern", func() {
+		garden := gardenctl.GardenClusterMeta{ReadOnlyTargets: []gardenctl.ReadOnlyTarget{{Shoot: "re:("}}}
+		_, err := garden.IsReadOnly("core", "live")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,173 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ProjectNameLabel is the label of a project namespace holding the name of the project
+const ProjectNameLabel = "project.gardener.cloud/name"
+
+// ResolveGardens returns the names of the configured gardens matching m
+func ResolveGardens(config *GardenConfig, m *Matcher) []string {
+	var names []string
+	for _, garden := range config.GardenClusters {
+		names = append(names, garden.Name)
+	}
+	return m.Filter(names)
+}
+
+// ResolveGardenByDashboardURL returns the name of the garden whose dashboard URL contains dashboardURL
+func ResolveGardenByDashboardURL(config *GardenConfig, dashboardURL string) (string, error) {
+	gardenName := ""
+	for _, garden := range config.GardenClusters {
+		if strings.Contains(garden.DashboardURL, dashboardURL) {
+			gardenName = garden.Name
+		}
+	}
+	if gardenName == "" {
+		return "", NewNotFoundError("a garden could not be matched for the provided dashboard url")
+	}
+	return gardenName, nil
+}
+
+// ResolveProjects returns the names of the projects of the garden matching m
+func ResolveProjects(client gardencoreclientset.Interface, m *Matcher) ([]string, error) {
+	if name, ok := m.Name(); ok {
+		project, err := client.CoreV1beta1().Projects().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return []string{}, nil
+		}
+		return []string{project.Name}, nil
+	}
+
+	projectList, err := client.CoreV1beta1().Projects().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, project := range projectList.Items {
+		names = append(names, project.Name)
+	}
+	return m.Filter(names), nil
+}
+
+// ResolveSeeds returns the names of the seeds of the garden matching m
+func ResolveSeeds(client gardencoreclientset.Interface, m *Matcher) ([]string, error) {
+	seedList, err := client.CoreV1beta1().Seeds().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, seed := range seedList.Items {
+		names = append(names, seed.Name)
+	}
+	return m.Filter(names), nil
+}
+
+// ResolveShoots returns the shoots matching m. If a project or a seed is targeted in
+// stack, only the shoots of the project or the seed are returned.
+func ResolveShoots(client gardencoreclientset.Interface, stack []TargetMeta, m *Matcher) ([]gardencorev1beta1.Shoot, error) {
+	listOptions := metav1.ListOptions{}
+	if name, ok := m.Name(); ok {
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+
+	namespace := metav1.NamespaceAll
+	if len(stack) == 2 && stack[1].Kind == TargetKindProject {
+		project, err := client.CoreV1beta1().Projects().Get(stack[1].Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		namespace = *project.Spec.Namespace
+	}
+	shootList, err := client.CoreV1beta1().Shoots(namespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []gardencorev1beta1.Shoot
+	for _, shoot := range shootList.Items {
+		if len(stack) == 2 && stack[1].Kind == TargetKindSeed && (shoot.Spec.SeedName == nil || *shoot.Spec.SeedName != stack[1].Name) {
+			continue
+		}
+		if !m.Match(shoot.Name) {
+			continue
+		}
+		matches = append(matches, shoot)
+	}
+	return matches, nil
+}
+
+// FetchShoot returns the shoot targeted in stack
+func FetchShoot(client gardencoreclientset.Interface, stack []TargetMeta) (*gardencorev1beta1.Shoot, error) {
+	if len(stack) < 3 {
+		return nil, NewNotTargetedError(TargetKindShoot)
+	}
+
+	if stack[1].Kind == TargetKindProject {
+		project, err := client.CoreV1beta1().Projects().Get(stack[1].Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return client.CoreV1beta1().Shoots(*project.Spec.Namespace).Get(stack[2].Name, metav1.GetOptions{})
+	}
+
+	shootList, err := client.CoreV1beta1().Shoots(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for index, s := range shootList.Items {
+		if s.Name == stack[2].Name && s.Spec.SeedName != nil && *s.Spec.SeedName == stack[1].Name {
+			return &shootList.Items[index], nil
+		}
+	}
+	return nil, NewNotFoundError("shoot %q not found on seed %q", stack[2].Name, stack[1].Name)
+}
+
+// ValidateNamespace returns a not found error if namespace does not exist in the cluster of client
+func ValidateNamespace(client kubernetes.Interface, namespace string) error {
+	_, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return NewNotFoundError("namespace %q not found", namespace)
+	}
+	return err
+}
+
+// ProjectOfNamespace returns the name of the project of the given namespace in the garden
+func ProjectOfNamespace(client kubernetes.Interface, namespace string) (string, error) {
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", NewNotFoundError("namespace %q not found", namespace)
+		}
+		return "", err
+	}
+
+	labelValue, ok := ns.Labels[ProjectNameLabel]
+	if !ok {
+		return "", fmt.Errorf("label %q on namespace %q not found", ProjectNameLabel, ns.Name)
+	}
+	return labelValue, nil
+}
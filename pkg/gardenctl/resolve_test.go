@@ -0,0 +1,115 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorefake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Resolve", func() {
+	var (
+		namespace = "garden-myproject"
+		seedA     = "seed-a"
+		seedB     = "seed-b"
+		client    *gardencorefake.Clientset
+	)
+
+	BeforeEach(func() {
+		client = gardencorefake.NewSimpleClientset(
+			&gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "myproject"},
+				Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace},
+			},
+			&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot-1", Namespace: namespace},
+				Spec:       gardencorev1beta1.ShootSpec{SeedName: &seedA},
+			},
+			&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot-2", Namespace: "garden-other"},
+				Spec:       gardencorev1beta1.ShootSpec{SeedName: &seedB},
+			},
+			&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot-3", Namespace: "garden-other"},
+			},
+		)
+	})
+
+	Describe("#ResolveShoots", func() {
+		matcher := func(pattern string) *gardenctl.Matcher {
+			m, err := gardenctl.NewMatcher(pattern, false)
+			Expect(err).NotTo(HaveOccurred())
+			return m
+		}
+		names := func(shoots []gardencorev1beta1.Shoot) []string {
+			var result []string
+			for _, shoot := range shoots {
+				result = append(result, shoot.Name)
+			}
+			return result
+		}
+
+		It("should only return the shoots of the targeted project", func() {
+			stack := []gardenctl.TargetMeta{
+				{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+				{Kind: gardenctl.TargetKindProject, Name: "myproject"},
+			}
+			shoots, err := gardenctl.ResolveShoots(client, stack, matcher("shoot-*"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(shoots)).To(ConsistOf("shoot-1"))
+		})
+
+		It("should only return the shoots of the targeted seed and skip unscheduled shoots", func() {
+			stack := []gardenctl.TargetMeta{
+				{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+				{Kind: gardenctl.TargetKindSeed, Name: seedB},
+			}
+			shoots, err := gardenctl.ResolveShoots(client, stack, matcher("re:shoot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(shoots)).To(ConsistOf("shoot-2"))
+		})
+	})
+
+	Describe("#FetchShoot", func() {
+		It("should return a not targeted error if no shoot is targeted", func() {
+			_, err := gardenctl.FetchShoot(client, []gardenctl.TargetMeta{{Kind: gardenctl.TargetKindGarden, Name: "prod"}})
+			Expect(gardenctl.IsNotTargeted(err)).To(BeTrue())
+		})
+
+		It("should fetch the shoot targeted via its seed", func() {
+			shoot, err := gardenctl.FetchShoot(client, []gardenctl.TargetMeta{
+				{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+				{Kind: gardenctl.TargetKindSeed, Name: seedA},
+				{Kind: gardenctl.TargetKindShoot, Name: "shoot-1"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shoot.Namespace).To(Equal(namespace))
+		})
+
+		It("should return a not found error if the shoot is not on the targeted seed", func() {
+			_, err := gardenctl.FetchShoot(client, []gardenctl.TargetMeta{
+				{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+				{Kind: gardenctl.TargetKindSeed, Name: seedA},
+				{Kind: gardenctl.TargetKindShoot, Name: "shoot-2"},
+			})
+			Expect(gardenctl.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
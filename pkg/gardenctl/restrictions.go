@@ -0,0 +1,242 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AppliedRestriction is an access restriction which applies to a shoot
+type AppliedRestriction struct {
+	Key         string
+	Enforcement RestrictionEnforcement
+	// Messages are the message of the restriction and the messages of its applying options
+	Messages []string
+}
+
+// ApplyingAccessRestrictions returns the access restrictions which apply to shoot of project. A
+// restriction applies if its selector selects the shoot, a restriction without a selector applies
+// if the seed selector of the shoot has its key set to its notifyIf value. The options of an
+// applying restriction apply if the annotations of the shoot have their key set to their notifyIf value.
+func ApplyingAccessRestrictions(restrictions []AccessRestriction, shoot *gardencorev1beta1.Shoot, project string) ([]AppliedRestriction, error) {
+	shootAnnotations := shoot.GetAnnotations()
+
+	var applied []AppliedRestriction
+	for _, ar := range restrictions {
+		applies, err := ar.Selects(shoot, project)
+		if err != nil {
+			return nil, err
+		}
+		if !applies {
+			continue
+		}
+		restriction := AppliedRestriction{
+			Key:         ar.Key,
+			Enforcement: ar.Enforcement,
+			Messages:    []string{ar.Msg},
+		}
+		if restriction.Enforcement == "" {
+			restriction.Enforcement = RestrictionEnforcementWarn
+		}
+		for _, option := range ar.Options {
+			if value, ok := shootAnnotations[option.Key]; ok && value == strconv.FormatBool(option.NotifyIf) {
+				restriction.Messages = append(restriction.Messages, option.Msg)
+			}
+		}
+		applied = append(applied, restriction)
+	}
+	return applied, nil
+}
+
+// Selects returns true if the restriction applies to shoot of project
+func (ar *AccessRestriction) Selects(shoot *gardencorev1beta1.Shoot, project string) (bool, error) {
+	if ar.Selector == nil {
+		if shoot.Spec.SeedSelector == nil {
+			return false, nil
+		}
+		value, ok := shoot.Spec.SeedSelector.MatchLabels[ar.Key]
+		return ok && value == strconv.FormatBool(ar.NotifyIf), nil
+	}
+	selects, err := ar.Selector.Selects(shoot, project)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector of access restriction %q: %v", ar.Key, err)
+	}
+	return selects, nil
+}
+
+// Selects returns true if all criteria of the selector match shoot of project
+func (s *AccessRestrictionSelector) Selects(shoot *gardencorev1beta1.Shoot, project string) (bool, error) {
+	if ok, err := s.Labels.Matches(shoot.GetLabels()); !ok || err != nil {
+		return false, err
+	}
+	if ok, err := s.Annotations.Matches(shoot.GetAnnotations()); !ok || err != nil {
+		return false, err
+	}
+
+	var purpose, seed string
+	if shoot.Spec.Purpose != nil {
+		purpose = string(*shoot.Spec.Purpose)
+	}
+	if shoot.Spec.SeedName != nil {
+		seed = *shoot.Spec.SeedName
+	}
+	fields := []struct {
+		patterns []string
+		value    string
+	}{
+		{s.Purposes, purpose},
+		{s.Regions, shoot.Spec.Region},
+		{s.Providers, shoot.Spec.Provider.Type},
+		{s.Projects, project},
+		{s.Seeds, seed},
+	}
+	for _, field := range fields {
+		if ok, err := matchesAnyPattern(field.patterns, field.value); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Validate returns an error if a label selector or a pattern of the selector is invalid
+func (s *AccessRestrictionSelector) Validate() error {
+	for _, kv := range []*KeyValueSelector{s.Labels, s.Annotations} {
+		if _, err := kv.Matches(nil); err != nil {
+			return err
+		}
+	}
+	for _, patterns := range [][]string{s.Purposes, s.Regions, s.Providers, s.Projects, s.Seeds} {
+		for _, pattern := range patterns {
+			if _, err := NewMatcher(pattern, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the selector matches the given labels or annotations, a nil
+// selector matches everything
+func (s *KeyValueSelector) Matches(values map[string]string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      s.MatchLabels,
+		MatchExpressions: s.MatchExpressions,
+	})
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(values)), nil
+}
+
+// matchesAnyPattern returns true if value matches one of the patterns, or if there are no patterns.
+// An empty value never matches a pattern.
+func matchesAnyPattern(patterns []string, value string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	if value == "" {
+		return false, nil
+	}
+	for _, pattern := range patterns {
+		m, err := NewMatcher(pattern, false)
+		if err != nil {
+			return false, err
+		}
+		if m.Match(value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RestrictionGate enforces the access restrictions of a shoot before a channel to it is opened.
+type RestrictionGate struct {
+	// Confirmed are the keys of the restrictions confirmed in advance, e.g. by automation
+	Confirmed []string
+	// In is read for the confirmation, restrictions which require a confirmation deny the access if it is nil
+	In io.Reader
+	// Out receives the messages of the restrictions and the confirmation prompt
+	Out io.Writer
+}
+
+// Enforce prints the messages of the restrictions which apply to shoot. It returns a forbidden
+// error if one of them denies the access or requires a confirmation which is not given.
+// A confirmation is given by typing the name of the shoot.
+func (g *RestrictionGate) Enforce(restrictions []AccessRestriction, shoot *gardencorev1beta1.Shoot, project string) error {
+	out := g.Out
+	if out == nil {
+		out = ioutil.Discard
+	}
+
+	applied, err := ApplyingAccessRestrictions(restrictions, shoot, project)
+	if err != nil {
+		return err
+	}
+	var unconfirmed []string
+	for _, restriction := range applied {
+		for _, msg := range restriction.Messages {
+			fmt.Fprintln(out, msg)
+		}
+		switch restriction.Enforcement {
+		case RestrictionEnforcementWarn:
+		case RestrictionEnforcementDeny:
+			return NewForbiddenError("access to shoot %q is denied by access restriction %q", shoot.Name, restriction.Key)
+		case RestrictionEnforcementConfirm:
+			if !contains(g.Confirmed, restriction.Key) {
+				unconfirmed = append(unconfirmed, restriction.Key)
+			}
+		default:
+			return fmt.Errorf("access restriction %q has unknown enforcement %q, must be one of: warn, confirm, deny", restriction.Key, restriction.Enforcement)
+		}
+	}
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+
+	if g.In == nil {
+		return NewForbiddenError("access to shoot %q requires confirming access restriction(s) %s", shoot.Name, strings.Join(unconfirmed, ", "))
+	}
+	fmt.Fprintf(out, "Type the name of the shoot to confirm the access to %q: ", shoot.Name)
+	answer, err := bufio.NewReader(g.In).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if strings.TrimSpace(answer) != shoot.Name {
+		return NewForbiddenError("access to shoot %q was not confirmed", shoot.Name)
+	}
+	return nil
+}
+
+// contains returns true if values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
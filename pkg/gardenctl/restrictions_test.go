@@ -0,0 +1,233 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"bytes"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Restrictions", func() {
+	var (
+		shoot        *gardencorev1beta1.Shoot
+		restrictions []gardenctl.AccessRestriction
+	)
+
+	BeforeEach(func() {
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-shoot",
+				Annotations: map[string]string{"support.gardener.cloud/eu-access-for-cluster-addons": "false"},
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				SeedSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"seed.gardener.cloud/eu-access": "true"}},
+			},
+		}
+		restrictions = []gardenctl.AccessRestriction{{
+			Key:      "seed.gardener.cloud/eu-access",
+			NotifyIf: true,
+			Msg:      "EU access only",
+			Options: []gardenctl.AccessRestrictionsOption{{
+				Key:      "support.gardener.cloud/eu-access-for-cluster-addons",
+				NotifyIf: false,
+				Msg:      "no cluster addons",
+			}},
+		}}
+	})
+
+	It("should decode the legacy and the selector format of the configuration", func() {
+		var config gardenctl.GardenConfig
+		Expect(yaml.Unmarshal([]byte(`gardenClusters:
+- name: prod
+  accessRestrictions:
+  - key: seed.gardener.cloud/eu-access
+    notifyIf: true
+    msg: EU access only
+  - key: production
+    msg: production cluster
+    enforcement: confirm
+    selector:
+      purposes: [production]
+      regions: ["eu-*"]
+      labels:
+        matchLabels:
+          tier: critical
+        matchExpressions:
+        - key: team
+          operator: In
+          values: [core]
+`), &config)).To(Succeed())
+
+		restrictions := config.AccessRestrictions("prod")
+		Expect(restrictions).To(HaveLen(2))
+		Expect(restrictions[0].Selector).To(BeNil())
+		Expect(restrictions[1].Selector).To(Equal(&gardenctl.AccessRestrictionSelector{
+			Purposes: []string{"production"},
+			Regions:  []string{"eu-*"},
+			Labels: &gardenctl.KeyValueSelector{
+				MatchLabels:      map[string]string{"tier": "critical"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"core"}}},
+			},
+		}))
+	})
+
+	Describe("#ApplyingAccessRestrictions", func() {
+		It("should return the matching restrictions with the messages of the matching options", func() {
+			applied, err := gardenctl.ApplyingAccessRestrictions(restrictions, shoot, "my-project")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(Equal([]gardenctl.AppliedRestriction{{
+				Key:         "seed.gardener.cloud/eu-access",
+				Enforcement: gardenctl.RestrictionEnforcementWarn,
+				Messages:    []string{"EU access only", "no cluster addons"},
+			}}))
+		})
+
+		It("should not apply a restriction without selector to a shoot without seed selector", func() {
+			shoot.Spec.SeedSelector = nil
+			Expect(gardenctl.ApplyingAccessRestrictions(restrictions, shoot, "my-project")).To(BeEmpty())
+		})
+
+		Context("with selector", func() {
+			BeforeEach(func() {
+				purpose, seed := gardencorev1beta1.ShootPurposeProduction, "aws-eu1"
+				shoot.Labels = map[string]string{"tier": "critical"}
+				shoot.Spec.SeedSelector = nil
+				shoot.Spec.Purpose = &purpose
+				shoot.Spec.Region = "eu-west-1"
+				shoot.Spec.Provider.Type = "aws"
+				shoot.Spec.SeedName = &seed
+				restrictions = []gardenctl.AccessRestriction{{Key: "production", Msg: "production cluster"}}
+			})
+
+			applies := func(selector gardenctl.AccessRestrictionSelector) bool {
+				restrictions[0].Selector = &selector
+				applied, err := gardenctl.ApplyingAccessRestrictions(restrictions, shoot, "my-project")
+				Expect(err).NotTo(HaveOccurred())
+				return len(applied) == 1
+			}
+
+			It("should apply to a shoot without seed selector", func() {
+				Expect(applies(gardenctl.AccessRestrictionSelector{})).To(BeTrue())
+			})
+
+			It("should match the fields of the shoot", func() {
+				Expect(applies(gardenctl.AccessRestrictionSelector{
+					Purposes:  []string{"production"},
+					Regions:   []string{"eu-*"},
+					Providers: []string{"gcp", "aws"},
+					Projects:  []string{"re:^my-"},
+					Seeds:     []string{"aws-eu1"},
+				})).To(BeTrue())
+				Expect(applies(gardenctl.AccessRestrictionSelector{Regions: []string{"us-*"}})).To(BeFalse())
+				Expect(applies(gardenctl.AccessRestrictionSelector{Projects: []string{"other"}})).To(BeFalse())
+			})
+
+			It("should not match the seed of an unscheduled shoot", func() {
+				shoot.Spec.SeedName = nil
+				Expect(applies(gardenctl.AccessRestrictionSelector{Seeds: []string{"*"}})).To(BeFalse())
+			})
+
+			It("should match labels and annotations", func() {
+				Expect(applies(gardenctl.AccessRestrictionSelector{
+					Labels: &gardenctl.KeyValueSelector{MatchLabels: map[string]string{"tier": "critical"}},
+					Annotations: &gardenctl.KeyValueSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "support.gardener.cloud/eu-access-for-cluster-addons",
+						Operator: metav1.LabelSelectorOpExists,
+					}}},
+				})).To(BeTrue())
+				Expect(applies(gardenctl.AccessRestrictionSelector{
+					Labels: &gardenctl.KeyValueSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "tier",
+						Operator: metav1.LabelSelectorOpNotIn,
+						Values:   []string{"critical"},
+					}}},
+				})).To(BeFalse())
+			})
+
+			It("should fail for an invalid selector", func() {
+				restrictions[0].Selector = &gardenctl.AccessRestrictionSelector{
+					Labels: &gardenctl.KeyValueSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Like"}}},
+				}
+				_, err := gardenctl.ApplyingAccessRestrictions(restrictions, shoot, "my-project")
+				Expect(err).To(MatchError(ContainSubstring(`invalid selector of access restriction "production"`)))
+			})
+		})
+	})
+
+	Describe("#Enforce", func() {
+		var out *bytes.Buffer
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+		})
+
+		It("should only print the messages of a restriction without enforcement", func() {
+			gate := &gardenctl.RestrictionGate{Out: out}
+			Expect(gate.Enforce(restrictions, shoot, "my-project")).To(Succeed())
+			Expect(out.String()).To(Equal("EU access only\nno cluster addons\n"))
+		})
+
+		It("should deny the access", func() {
+			restrictions[0].Enforcement = gardenctl.RestrictionEnforcementDeny
+			gate := &gardenctl.RestrictionGate{Out: out}
+			err := gate.Enforce(restrictions, shoot, "my-project")
+			Expect(gardenctl.IsForbidden(err)).To(BeTrue())
+		})
+
+		Context("with confirmation", func() {
+			BeforeEach(func() {
+				restrictions[0].Enforcement = gardenctl.RestrictionEnforcementConfirm
+			})
+
+			It("should allow the access if the restriction is confirmed in advance", func() {
+				gate := &gardenctl.RestrictionGate{Confirmed: []string{"seed.gardener.cloud/eu-access"}, Out: out}
+				Expect(gate.Enforce(restrictions, shoot, "my-project")).To(Succeed())
+			})
+
+			It("should allow the access if the name of the shoot is typed", func() {
+				gate := &gardenctl.RestrictionGate{In: strings.NewReader("my-shoot\n"), Out: out}
+				Expect(gate.Enforce(restrictions, shoot, "my-project")).To(Succeed())
+				Expect(out.String()).To(ContainSubstring(`Type the name of the shoot to confirm the access to "my-shoot": `))
+			})
+
+			It("should deny the access if another name is typed", func() {
+				gate := &gardenctl.RestrictionGate{In: strings.NewReader("other-shoot\n"), Out: out}
+				err := gate.Enforce(restrictions, shoot, "my-project")
+				Expect(gardenctl.IsForbidden(err)).To(BeTrue())
+				Expect(err.Error()).To(Equal(`access to shoot "my-shoot" was not confirmed`))
+			})
+
+			It("should deny the access if the confirmation cannot be read", func() {
+				gate := &gardenctl.RestrictionGate{Out: out}
+				err := gate.Enforce(restrictions, shoot, "my-project")
+				Expect(gardenctl.IsForbidden(err)).To(BeTrue())
+			})
+		})
+
+		It("should fail for an unknown enforcement", func() {
+			restrictions[0].Enforcement = "block"
+			gate := &gardenctl.RestrictionGate{Out: out}
+			Expect(gate.Enforce(restrictions, shoot, "my-project")).To(MatchError(ContainSubstring(`unknown enforcement "block"`)))
+		})
+	})
+})
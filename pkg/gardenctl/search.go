@@ -0,0 +1,102 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ShootMatch is a shoot found by SearchShoots
+type ShootMatch struct {
+	Garden  string
+	Project string
+	Shoot   gardencorev1beta1.Shoot
+}
+
+// Path returns the target path of the shoot, e.g. prod/my-project/my-shoot
+func (s ShootMatch) Path() string {
+	return s.Garden + "/" + s.Project + "/" + s.Shoot.Name
+}
+
+// GardenerClientFunc returns the gardener client of the garden with the given name
+type GardenerClientFunc func(gardenName string) (gardencoreclientset.Interface, error)
+
+// SearchShoots resolves m in all configured gardens concurrently. m is matched against the
+// names and the technical IDs of the shoots. The matches are grouped by garden in the order of
+// the configuration, the gardens which could not be searched are returned with their error.
+func SearchShoots(config *GardenConfig, clients GardenerClientFunc, m *Matcher) ([]ShootMatch, map[string]error) {
+	results := make([][]ShootMatch, len(config.GardenClusters))
+	errs := make([]error, len(config.GardenClusters))
+
+	var wg sync.WaitGroup
+	for i, garden := range config.GardenClusters {
+		wg.Add(1)
+		go func(i int, gardenName string) {
+			defer wg.Done()
+			results[i], errs[i] = searchShootsInGarden(gardenName, clients, m)
+		}(i, garden.Name)
+	}
+	wg.Wait()
+
+	var matches []ShootMatch
+	unreachable := map[string]error{}
+	for i, garden := range config.GardenClusters {
+		if errs[i] != nil {
+			unreachable[garden.Name] = errs[i]
+			continue
+		}
+		matches = append(matches, results[i]...)
+	}
+	return matches, unreachable
+}
+
+// searchShootsInGarden returns the shoots of a garden whose name or technical ID matches m
+func searchShootsInGarden(gardenName string, clients GardenerClientFunc, m *Matcher) ([]ShootMatch, error) {
+	client, err := clients(gardenName)
+	if err != nil {
+		return nil, err
+	}
+	projectList, err := client.CoreV1beta1().Projects().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	projectOfNamespace := map[string]string{}
+	for _, project := range projectList.Items {
+		if project.Spec.Namespace != nil {
+			projectOfNamespace[*project.Spec.Namespace] = project.Name
+		}
+	}
+	shootList, err := client.CoreV1beta1().Shoots(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []ShootMatch
+	for _, shoot := range shootList.Items {
+		if !m.Match(shoot.Name) && (shoot.Status.TechnicalID == "" || !m.Match(shoot.Status.TechnicalID)) {
+			continue
+		}
+		matches = append(matches, ShootMatch{
+			Garden:  gardenName,
+			Project: projectOfNamespace[shoot.Namespace],
+			Shoot:   shoot,
+		})
+	}
+	return matches, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"errors"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencoreclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
+	gardencorefake "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("SearchShoots", func() {
+	var (
+		config  *gardenctl.GardenConfig
+		clients gardenctl.GardenerClientFunc
+	)
+
+	gardenClient := func(project string, shoots ...string) gardencoreclientset.Interface {
+		namespace := "garden-" + project
+		objects := []runtime.Object{&gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: project},
+			Spec:       gardencorev1beta1.ProjectSpec{Namespace: &namespace},
+		}}
+		for _, shoot := range shoots {
+			objects = append(objects, &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: shoot, Namespace: namespace},
+				Status:     gardencorev1beta1.ShootStatus{TechnicalID: "shoot--" + project + "--" + shoot},
+			})
+		}
+		return gardencorefake.NewSimpleClientset(objects...)
+	}
+
+	BeforeEach(func() {
+		config = &gardenctl.GardenConfig{
+			GardenClusters: []gardenctl.GardenClusterMeta{{Name: "prod"}, {Name: "offline"}, {Name: "dev"}},
+		}
+		gardens := map[string]gardencoreclientset.Interface{
+			"prod": gardenClient("core", "foo", "bar"),
+			"dev":  gardenClient("test", "foo"),
+		}
+		clients = func(name string) (gardencoreclientset.Interface, error) {
+			if client, ok := gardens[name]; ok {
+				return client, nil
+			}
+			return nil, errors.New("connection refused")
+		}
+	})
+
+	It("should return the matches grouped by garden and skip unreachable gardens", func() {
+		m, err := gardenctl.NewMatcher("foo", false)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, unreachable := gardenctl.SearchShoots(config, clients, m)
+
+		var paths []string
+		for _, match := range matches {
+			paths = append(paths, match.Path())
+		}
+		Expect(paths).To(Equal([]string{"prod/core/foo", "dev/test/foo"}))
+		Expect(unreachable).To(HaveLen(1))
+		Expect(unreachable["offline"]).To(MatchError("connection refused"))
+	})
+
+	It("should match the technical ID of the shoots", func() {
+		m, err := gardenctl.NewMatcher("shoot--core--bar", false)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, _ := gardenctl.SearchShoots(config, clients, m)
+
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Path()).To(Equal("prod/core/bar"))
+	})
+})
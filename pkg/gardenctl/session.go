@@ -0,0 +1,146 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSessionID is used if $GARDEN_SESSION_ID is not set
+	DefaultSessionID = "plantingSession"
+	// SessionIDEnvVar is the environment variable holding the ID of the current session
+	SessionIDEnvVar = "GARDEN_SESSION_ID"
+	// HomeEnvVar is the environment variable overriding the gardenctl home directory
+	HomeEnvVar = "GARDENCTL_HOME"
+	// ConfigEnvVar is the environment variable overriding the path of the gardenctl configuration
+	ConfigEnvVar = "GARDENCONFIG"
+)
+
+// Session is the state gardenctl keeps for a shell. Every session has its own target stack and
+// history, while the configuration and the cached credentials are shared.
+type Session struct {
+	// ID identifies the session
+	ID string
+	// Dir contains the target and the history of the session
+	Dir string
+	// Home is the gardenctl home directory, which contains the cache of the credentials
+	Home string
+	// ConfigPath is the path of the gardenctl configuration
+	ConfigPath string
+}
+
+// SessionFromEnv returns the session of the current shell, based on the same environment variables as gardenctl
+func SessionFromEnv() *Session {
+	userHome := homeDir()
+	home := os.Getenv(HomeEnvVar)
+	if home == "" {
+		home = filepath.Join(userHome, ".garden")
+	} else {
+		home = strings.Replace(home, "~", userHome, 1)
+	}
+	id := os.Getenv(SessionIDEnvVar)
+	if id == "" {
+		id = DefaultSessionID
+	}
+	configPath := os.Getenv(ConfigEnvVar)
+	if configPath == "" {
+		configPath = filepath.Join(home, "config")
+	}
+	return &Session{
+		ID:         id,
+		Dir:        filepath.Join(SessionsDir(), id),
+		Home:       home,
+		ConfigPath: configPath,
+	}
+}
+
+// SessionsDir returns the directory containing the directories of all sessions
+func SessionsDir() string {
+	return filepath.Join(homeDir(), ".garden", "sessions")
+}
+
+// TargetPath returns the path of the target file of the session
+func (s *Session) TargetPath() string {
+	return filepath.Join(s.Dir, "target")
+}
+
+// HistoryPath returns the path of the history file of the session
+func (s *Session) HistoryPath() string {
+	return filepath.Join(s.Dir, "history")
+}
+
+// LastUsedPath returns the path of the marker file whose modification time is the last use of the session
+func (s *Session) LastUsedPath() string {
+	return filepath.Join(s.Dir, "lastUsed")
+}
+
+// MarkUsed records that a command runs in the session now
+func (s *Session) MarkUsed() error {
+	now := time.Now()
+	err := os.Chtimes(s.LastUsedPath(), now, now)
+	if os.IsNotExist(err) {
+		return ioutil.WriteFile(s.LastUsedPath(), nil, 0644)
+	}
+	return err
+}
+
+// PushedTargetsPath returns the path of the file of the targets pushed in the session
+func (s *Session) PushedTargetsPath() string {
+	return filepath.Join(s.Dir, "pushed")
+}
+
+// CacheDir returns the directory of the cached kubeconfigs and other credentials
+func (s *Session) CacheDir() string {
+	return filepath.Join(s.Home, "cache")
+}
+
+// KubeconfigPath returns the path of the kubeconfig of the session which has the targeted namespace set
+func (s *Session) KubeconfigPath() string {
+	return SessionKubeconfigPath(s.CacheDir(), s.ID)
+}
+
+// LoadConfig reads the gardenctl configuration of the session
+func (s *Session) LoadConfig() (*GardenConfig, error) {
+	return LoadConfig(s.ConfigPath)
+}
+
+// ReadTarget returns the target stack of the session
+func (s *Session) ReadTarget() ([]TargetMeta, error) {
+	return ReadTarget(s.TargetPath())
+}
+
+// WriteTarget replaces the target stack of the session
+func (s *Session) WriteTarget(stack []TargetMeta) error {
+	return WriteTarget(s.TargetPath(), stack)
+}
+
+// ClientFactory returns a client factory for the gardens of the configuration and the
+// kubeconfigs cached in the home directory of the session
+func (s *Session) ClientFactory() *ClientFactory {
+	return NewClientFactory(s, DirCache(s.CacheDir()))
+}
+
+// homeDir returns the home directory of the user
+func homeDir() string {
+	if h := os.Getenv("HOME"); h != "" {
+		return h
+	}
+	return os.Getenv("USERPROFILE")
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
+	"gopkg.in/yaml.v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ShootAccessNamespace is the namespace in the shoot of the service accounts of short-lived kubeconfigs
+	ShootAccessNamespace = "kube-system"
+	// MinShootAccessTTL is the minimum time to live of a short-lived kubeconfig accepted by the token API
+	MinShootAccessTTL = 10 * time.Minute
+
+	shootAccessPrefix               = "gardenctl-"
+	shootAccessManagedByLabel       = "app.kubernetes.io/managed-by"
+	shootAccessRoleAnnotation       = "gardenctl.gardener.cloud/role"
+	shootAccessExpirationAnnotation = "gardenctl.gardener.cloud/expiration-timestamp"
+)
+
+// ShootAccessRoles maps the roles of short-lived kubeconfigs to the cluster roles bound to their service accounts
+var ShootAccessRoles = map[string]string{
+	"view":  "view",
+	"edit":  "edit",
+	"admin": "cluster-admin",
+}
+
+// ShootAccess is a service account created in a shoot for a short-lived kubeconfig. It is recorded
+// locally, so it can be revoked after it is not needed anymore.
+type ShootAccess struct {
+	Garden string `yaml:"garden" json:"garden"`
+	// Namespace is the namespace of the shoot in the garden
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Shoot     string `yaml:"shoot" json:"shoot"`
+	// ServiceAccount is the name of the service account and its cluster role binding in the shoot
+	ServiceAccount      string    `yaml:"serviceAccount" json:"serviceAccount"`
+	Role                string    `yaml:"role" json:"role"`
+	ExpirationTimestamp time.Time `yaml:"expirationTimestamp" json:"expirationTimestamp"`
+}
+
+// shootAccessFile is the content of the file recording the shoot accesses
+type shootAccessFile struct {
+	Accesses []ShootAccess `yaml:"accesses,omitempty" json:"accesses,omitempty"`
+}
+
+// ShootAccessRoleNames returns the sorted names of the roles of short-lived kubeconfigs
+func ShootAccessRoleNames() []string {
+	var roles []string
+	for role := range ShootAccessRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// ShootAdminKubeconfig returns the admin kubeconfig of the shoot with the given name and namespace
+// from the garden. Kubeconfigs with unsupported fields are refused, warnings about them are written to
+// warnings.
+func ShootAdminKubeconfig(gardenClient kubernetes.Interface, namespace, shoot string, warnings io.Writer) ([]byte, error) {
+	secret, err := gardenClient.CoreV1().Secrets(namespace).Get(shoot+".kubeconfig", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig := secret.Data["kubeconfig"]
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateClientConfig(*config, fmt.Sprintf("%s/%s.kubeconfig", namespace, shoot), warnings); err != nil {
+		return nil, err
+	}
+	return kubeconfig, nil
+}
+
+// KubernetesFromKubeconfig returns a kubernetes client for the current context of kubeconfig
+func KubernetesFromKubeconfig(kubeconfig []byte) (kubernetes.Interface, error) {
+	clientConfig, err := clientcmd.NewClientConfigFromBytes(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// CreateShootAccess creates a service account bound to the cluster role of role in the shoot of
+// client and returns it with a token expiring after ttl. The garden, namespace and shoot of the
+// returned access are left to the caller.
+func CreateShootAccess(client kubernetes.Interface, role string, ttl time.Duration) (*ShootAccess, string, error) {
+	clusterRole, ok := ShootAccessRoles[role]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown role %q, must be one of: %s", role, strings.Join(ShootAccessRoleNames(), ", "))
+	}
+	if ttl < MinShootAccessTTL {
+		return nil, "", fmt.Errorf("kubeconfig ttl %s is too short, it must be at least %s", ttl, MinShootAccessTTL)
+	}
+
+	name := shootAccessPrefix + utilrand.String(8)
+	expiration := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	meta := metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{shootAccessManagedByLabel: "gardenctl"},
+		Annotations: map[string]string{
+			shootAccessRoleAnnotation:       role,
+			shootAccessExpirationAnnotation: expiration.Format(time.RFC3339),
+		},
+	}
+	serviceAccountMeta := *meta.DeepCopy()
+	serviceAccountMeta.Namespace = ShootAccessNamespace
+	if _, err := client.CoreV1().ServiceAccounts(ShootAccessNamespace).Create(&corev1.ServiceAccount{ObjectMeta: serviceAccountMeta}); err != nil {
+		return nil, "", err
+	}
+
+	access := &ShootAccess{ServiceAccount: name, Role: role, ExpirationTimestamp: expiration}
+	token, err := createShootAccessToken(client, meta, clusterRole, ttl)
+	if err != nil {
+		// nothing is left behind in the shoot, the access is not recorded
+		if revokeErr := RevokeShootAccess(client, name); revokeErr != nil {
+			return nil, "", fmt.Errorf("%v, the service account %s/%s could not be deleted: %v", err, ShootAccessNamespace, name, revokeErr)
+		}
+		return nil, "", err
+	}
+	if !token.Status.ExpirationTimestamp.IsZero() {
+		access.ExpirationTimestamp = token.Status.ExpirationTimestamp.UTC()
+	}
+	return access, token.Status.Token, nil
+}
+
+// createShootAccessToken binds the service account of meta to clusterRole and requests a token for it
+func createShootAccessToken(client kubernetes.Interface, meta metav1.ObjectMeta, clusterRole string, ttl time.Duration) (*authenticationv1.TokenRequest, error) {
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      meta.Name,
+			Namespace: ShootAccessNamespace,
+		}},
+	}
+	if _, err := client.RbacV1().ClusterRoleBindings().Create(binding); err != nil {
+		return nil, err
+	}
+
+	expirationSeconds := int64(ttl.Seconds())
+	return client.CoreV1().ServiceAccounts(ShootAccessNamespace).CreateToken(meta.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	})
+}
+
+// RevokeShootAccess deletes the service account with the given name and its cluster role binding from
+// the shoot of client. Service accounts which have not been created by gardenctl are refused.
+func RevokeShootAccess(client kubernetes.Interface, name string) error {
+	if !strings.HasPrefix(name, shootAccessPrefix) {
+		return fmt.Errorf("service account %q has not been created by gardenctl", name)
+	}
+	if err := client.RbacV1().ClusterRoleBindings().Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := client.CoreV1().ServiceAccounts(ShootAccessNamespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ShootAccessKubeconfig returns a kubeconfig with the cluster of the current context of adminKubeconfig
+// which authenticates with token
+func ShootAccessKubeconfig(adminKubeconfig []byte, token string) ([]byte, error) {
+	admin, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	context, ok := admin.Contexts[admin.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("current context %q not found", admin.CurrentContext)
+	}
+	cluster, ok := admin.Clusters[context.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q of current context not found", context.Cluster)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[context.Cluster] = cluster
+	config.AuthInfos[context.Cluster] = &clientcmdapi.AuthInfo{Token: token}
+	config.Contexts[context.Cluster] = &clientcmdapi.Context{Cluster: context.Cluster, AuthInfo: context.Cluster}
+	config.CurrentContext = context.Cluster
+	return marshalKubeconfig(config)
+}
+
+// ReadShootAccesses returns the shoot accesses recorded in the file at path
+func ReadShootAccesses(path string) ([]ShootAccess, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file shootAccessFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	return file.Accesses, nil
+}
+
+// UpdateShootAccesses replaces the shoot accesses recorded in the file at path with the result of
+// update, the file is locked meanwhile
+func UpdateShootAccesses(path string, update func([]ShootAccess) []ShootAccess) error {
+	return lockedfile.Transform(path, 0600, func(content []byte) ([]byte, error) {
+		var file shootAccessFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			return nil, err
+		}
+		file.Accesses = update(file.Accesses)
+		return yaml.Marshal(&file)
+	})
+}
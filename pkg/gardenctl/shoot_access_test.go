@@ -0,0 +1,156 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gardenctl_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+const shootAdminKubeconfig = `apiVersion: v1
+kind: Config
+current-context: shoot
+clusters:
+- name: shoot
+  cluster:
+    server: https://api.shoot.example.com
+    certificate-authority-data: Y2E=
+contexts:
+- name: shoot
+  context:
+    cluster: shoot
+    user: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`
+
+var _ = Describe("ShootAccess", func() {
+	var client *fake.Clientset
+
+	BeforeEach(func() {
+		client = fake.NewSimpleClientset()
+	})
+
+	Describe("#CreateShootAccess", func() {
+		It("should create a service account bound to the cluster role with a token", func() {
+			expiration := metav1.NewTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+			var requestedSeconds int64
+			client.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				create := action.(k8stesting.CreateAction)
+				if create.GetSubresource() != "token" {
+					return false, nil, nil
+				}
+				requestedSeconds = *create.GetObject().(*authenticationv1.TokenRequest).Spec.ExpirationSeconds
+				return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "token", ExpirationTimestamp: expiration}}, nil
+			})
+
+			access, token, err := gardenctl.CreateShootAccess(client, "admin", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("token"))
+			Expect(requestedSeconds).To(Equal(int64(3600)))
+			Expect(access.ServiceAccount).To(HavePrefix("gardenctl-"))
+			Expect(access.Role).To(Equal("admin"))
+			Expect(access.ExpirationTimestamp).To(Equal(expiration.Time))
+
+			_, err = client.CoreV1().ServiceAccounts(gardenctl.ShootAccessNamespace).Get(access.ServiceAccount, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			binding, err := client.RbacV1().ClusterRoleBindings().Get(access.ServiceAccount, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.RoleRef.Name).To(Equal("cluster-admin"))
+			Expect(binding.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: access.ServiceAccount, Namespace: gardenctl.ShootAccessNamespace}}))
+		})
+
+		It("should delete the service account if no token can be requested", func() {
+			client.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "token" {
+					return false, nil, nil
+				}
+				return true, nil, errors.New("token request failed")
+			})
+
+			_, _, err := gardenctl.CreateShootAccess(client, "view", time.Hour)
+			Expect(err).To(MatchError("token request failed"))
+			serviceAccounts, err := client.CoreV1().ServiceAccounts(gardenctl.ShootAccessNamespace).List(metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceAccounts.Items).To(BeEmpty())
+			bindings, err := client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings.Items).To(BeEmpty())
+		})
+
+		It("should fail for an unknown role or a too short ttl", func() {
+			_, _, err := gardenctl.CreateShootAccess(client, "owner", time.Hour)
+			Expect(err).To(MatchError(`unknown role "owner", must be one of: admin, edit, view`))
+			_, _, err = gardenctl.CreateShootAccess(client, "view", time.Minute)
+			Expect(err).To(MatchError("kubeconfig ttl 1m0s is too short, it must be at least 10m0s"))
+		})
+	})
+
+	Describe("#RevokeShootAccess", func() {
+		It("should only delete service accounts created by gardenctl", func() {
+			Expect(gardenctl.RevokeShootAccess(client, "gardenctl-missing")).To(Succeed())
+			Expect(gardenctl.RevokeShootAccess(client, "default")).To(MatchError(`service account "default" has not been created by gardenctl`))
+		})
+	})
+
+	Describe("#ShootAccessKubeconfig", func() {
+		It("should keep the cluster and replace the credentials with the token", func() {
+			kubeconfig, err := gardenctl.ShootAccessKubeconfig([]byte(shootAdminKubeconfig), "token")
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := clientcmd.Load(kubeconfig)
+			Expect(err).NotTo(HaveOccurred())
+			context := config.Contexts[config.CurrentContext]
+			Expect(config.Clusters[context.Cluster].Server).To(Equal("https://api.shoot.example.com"))
+			Expect(config.Clusters[context.Cluster].CertificateAuthorityData).To(Equal([]byte("ca")))
+			Expect(config.AuthInfos[context.AuthInfo].Token).To(Equal("token"))
+			Expect(config.AuthInfos[context.AuthInfo].ClientCertificateData).To(BeEmpty())
+		})
+	})
+
+	Describe("#UpdateShootAccesses", func() {
+		It("should record the accesses", func() {
+			dir, err := ioutil.TempDir("", "gardenctl")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "shoot-access.yaml")
+
+			Expect(gardenctl.ReadShootAccesses(path)).To(BeEmpty())
+			access := gardenctl.ShootAccess{Garden: "prod", Namespace: "garden-core", Shoot: "api", ServiceAccount: "gardenctl-abc", Role: "view",
+				ExpirationTimestamp: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+			Expect(gardenctl.UpdateShootAccesses(path, func(accesses []gardenctl.ShootAccess) []gardenctl.ShootAccess {
+				return append(accesses, access)
+			})).To(Succeed())
+			Expect(gardenctl.ReadShootAccesses(path)).To(Equal([]gardenctl.ShootAccess{access}))
+		})
+	})
+})
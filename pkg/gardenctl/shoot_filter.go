@@ -0,0 +1,105 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"time"
+
+	"github.com/Masterminds/semver"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// CreatedByAnnotation is the annotation of a shoot naming the user who created it
+const CreatedByAnnotation = "gardener.cloud/created-by"
+
+// These are the health states of a shoot.
+const (
+	ShootHealthReady    = "Ready"
+	ShootHealthNotReady = "NotReady"
+	ShootHealthUnknown  = "Unknown"
+)
+
+// ShootFilter selects shoots by their specification and status, unset fields match every shoot
+type ShootFilter struct {
+	Provider  *Matcher
+	Region    *Matcher
+	Seed      *Matcher
+	Purpose   *Matcher
+	CreatedBy *Matcher
+	// KubernetesVersion is a version constraint, e.g. ">= 1.17, < 1.19"
+	KubernetesVersion *semver.Constraints
+	// Hibernated selects the hibernated shoots if true and the awake shoots if false
+	Hibernated *bool
+	// MinAge and MaxAge restrict the time since the creation of the shoot, zero means no restriction
+	MinAge time.Duration
+	MaxAge time.Duration
+}
+
+// Match returns whether the shoot is selected by the filter at time now
+func (f *ShootFilter) Match(shoot *gardencorev1beta1.Shoot, now time.Time) bool {
+	if f.Provider != nil && !f.Provider.Match(shoot.Spec.Provider.Type) {
+		return false
+	}
+	if f.Region != nil && !f.Region.Match(shoot.Spec.Region) {
+		return false
+	}
+	if f.Seed != nil && (shoot.Spec.SeedName == nil || !f.Seed.Match(*shoot.Spec.SeedName)) {
+		return false
+	}
+	if f.Purpose != nil && (shoot.Spec.Purpose == nil || !f.Purpose.Match(string(*shoot.Spec.Purpose))) {
+		return false
+	}
+	if f.CreatedBy != nil && !f.CreatedBy.Match(shoot.Annotations[CreatedByAnnotation]) {
+		return false
+	}
+	if f.KubernetesVersion != nil {
+		version, err := semver.NewVersion(shoot.Spec.Kubernetes.Version)
+		if err != nil || !f.KubernetesVersion.Check(version) {
+			return false
+		}
+	}
+	if f.Hibernated != nil && *f.Hibernated != shoot.Status.IsHibernated {
+		return false
+	}
+	age := now.Sub(shoot.CreationTimestamp.Time)
+	if f.MinAge > 0 && age < f.MinAge {
+		return false
+	}
+	if f.MaxAge > 0 && age > f.MaxAge {
+		return false
+	}
+	return true
+}
+
+// ShootHealth returns ShootHealthNotReady if a condition of the shoot is false, ShootHealthReady if
+// a condition is true and ShootHealthUnknown otherwise
+func ShootHealth(shoot *gardencorev1beta1.Shoot) string {
+	return ConditionsHealth(shoot.Status.Conditions)
+}
+
+// ConditionsHealth returns ShootHealthNotReady if a condition is false, ShootHealthReady if a condition
+// is true and ShootHealthUnknown otherwise
+func ConditionsHealth(conditions []gardencorev1beta1.Condition) string {
+	health := ShootHealthUnknown
+	for _, condition := range conditions {
+		switch condition.Status {
+		case gardencorev1beta1.ConditionFalse:
+			return ShootHealthNotReady
+		case gardencorev1beta1.ConditionTrue:
+			health = ShootHealthReady
+		}
+	}
+	return health
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"time"
+
+	"github.com/Masterminds/semver"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("ShootFilter", func() {
+	var (
+		now        = time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC)
+		seed       = "aws-eu1"
+		purpose    = gardencorev1beta1.ShootPurposeProduction
+		hibernated = true
+		awake      = false
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "api",
+				Namespace:         "garden-core",
+				CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+				Annotations:       map[string]string{gardenctl.CreatedByAnnotation: "jane.doe@example.com"},
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				Provider:   gardencorev1beta1.Provider{Type: "aws"},
+				Region:     "eu-west-1",
+				SeedName:   &seed,
+				Purpose:    &purpose,
+				Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.17.5"},
+			},
+		}
+
+		matcher = func(pattern string) *gardenctl.Matcher {
+			m, err := gardenctl.NewMatcher(pattern, false)
+			Expect(err).NotTo(HaveOccurred())
+			return m
+		}
+		constraint = func(c string) *semver.Constraints {
+			constraints, err := semver.NewConstraint(c)
+			Expect(err).NotTo(HaveOccurred())
+			return constraints
+		}
+	)
+
+	DescribeTable("#Match",
+		func(filter func() *gardenctl.ShootFilter, expected bool) {
+			Expect(filter().Match(shoot, now)).To(Equal(expected))
+		},
+		Entry("empty filter", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{} }, true),
+		Entry("provider", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Provider: matcher("aws")} }, true),
+		Entry("other provider", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Provider: matcher("gcp")} }, false),
+		Entry("region pattern", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Region: matcher("eu-*")} }, true),
+		Entry("other seed", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Seed: matcher("gcp-*")} }, false),
+		Entry("purpose", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Purpose: matcher("production")} }, true),
+		Entry("creator", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{CreatedBy: matcher("jane.*")} }, true),
+		Entry("version in range", func() *gardenctl.ShootFilter {
+			return &gardenctl.ShootFilter{KubernetesVersion: constraint(">= 1.17, < 1.18")}
+		}, true),
+		Entry("version out of range", func() *gardenctl.ShootFilter {
+			return &gardenctl.ShootFilter{KubernetesVersion: constraint(">= 1.18")}
+		}, false),
+		Entry("hibernated", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Hibernated: &hibernated} }, false),
+		Entry("awake", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{Hibernated: &awake} }, true),
+		Entry("older than minimum age", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{MinAge: 24 * time.Hour} }, true),
+		Entry("younger than minimum age", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{MinAge: 72 * time.Hour} }, false),
+		Entry("older than maximum age", func() *gardenctl.ShootFilter { return &gardenctl.ShootFilter{MaxAge: 24 * time.Hour} }, false),
+	)
+
+	DescribeTable("#ShootHealth",
+		func(conditions []gardencorev1beta1.ConditionStatus, expected string) {
+			s := &gardencorev1beta1.Shoot{}
+			for _, status := range conditions {
+				s.Status.Conditions = append(s.Status.Conditions, gardencorev1beta1.Condition{Status: status})
+			}
+			Expect(gardenctl.ShootHealth(s)).To(Equal(expected))
+		},
+		Entry("without conditions", nil, gardenctl.ShootHealthUnknown),
+		Entry("with true conditions", []gardencorev1beta1.ConditionStatus{gardencorev1beta1.ConditionTrue, gardencorev1beta1.ConditionUnknown}, gardenctl.ShootHealthReady),
+		Entry("with a false condition", []gardencorev1beta1.ConditionStatus{gardencorev1beta1.ConditionTrue, gardencorev1beta1.ConditionFalse}, gardenctl.ShootHealthNotReady),
+	)
+})
@@ -0,0 +1,192 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// IssueSeverity ranks the issues of shoots, a higher severity needs attention sooner
+type IssueSeverity int
+
+// These are the severities of issues.
+const (
+	// IssueSeverityLow is a transient issue, e.g. a running operation or a flapping condition
+	IssueSeverityLow IssueSeverity = iota
+	// IssueSeverityMedium is an unhealthy condition or an error which is retried
+	IssueSeverityMedium
+	// IssueSeverityHigh is an error which is not healed by retrying, e.g. exceeded infrastructure quotas
+	IssueSeverityHigh
+	// IssueSeverityCritical is an unavailable API server or an operation which failed for good
+	IssueSeverityCritical
+)
+
+var issueSeverityNames = []string{"low", "medium", "high", "critical"}
+
+// String returns the name of the severity
+func (s IssueSeverity) String() string {
+	if s < IssueSeverityLow || s > IssueSeverityCritical {
+		return fmt.Sprintf("IssueSeverity(%d)", int(s))
+	}
+	return issueSeverityNames[s]
+}
+
+// ParseIssueSeverity returns the severity with the name, see IssueSeverity.String
+func ParseIssueSeverity(name string) (IssueSeverity, error) {
+	for i, n := range issueSeverityNames {
+		if strings.EqualFold(n, name) {
+			return IssueSeverity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid severity %q, must be one of %s", name, strings.Join(issueSeverityNames, ", "))
+}
+
+// errorCodeSeverities are the severities of the well-known error codes, unknown codes are of medium severity
+var errorCodeSeverities = map[gardencorev1beta1.ErrorCode]IssueSeverity{
+	gardencorev1beta1.ErrorInfraUnauthorized:           IssueSeverityHigh,
+	gardencorev1beta1.ErrorInfraInsufficientPrivileges: IssueSeverityHigh,
+	gardencorev1beta1.ErrorInfraQuotaExceeded:          IssueSeverityHigh,
+	gardencorev1beta1.ErrorInfraResourcesDepleted:      IssueSeverityHigh,
+	gardencorev1beta1.ErrorConfigurationProblem:        IssueSeverityHigh,
+	gardencorev1beta1.ErrorInfraDependencies:           IssueSeverityMedium,
+	gardencorev1beta1.ErrorCleanupClusterResources:     IssueSeverityMedium,
+}
+
+// ErrorCodeSeverity returns the severity of an error code
+func ErrorCodeSeverity(code gardencorev1beta1.ErrorCode) IssueSeverity {
+	if severity, ok := errorCodeSeverities[code]; ok {
+		return severity
+	}
+	return IssueSeverityMedium
+}
+
+// ShootIssue is the classified issue of a shoot
+type ShootIssue struct {
+	Severity IssueSeverity
+	// Codes are the error codes of the conditions and the last errors, the most severe first
+	Codes []gardencorev1beta1.ErrorCode
+	// Conditions are the conditions of the shoot which are not true
+	Conditions []gardencorev1beta1.Condition
+	// Since is the time since when the shoot has the issue
+	Since time.Time
+}
+
+// RootCause returns the most severe error code of the issue or an empty string if it has no code
+func (i *ShootIssue) RootCause() gardencorev1beta1.ErrorCode {
+	if len(i.Codes) == 0 {
+		return ""
+	}
+	return i.Codes[0]
+}
+
+// HasCode returns whether an error code of the issue matches m
+func (i *ShootIssue) HasCode(m *Matcher) bool {
+	for _, code := range i.Codes {
+		if m.Match(string(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShootIssueOf returns the issue of the shoot, or nil if its last operation succeeded and none of its conditions is false
+func ShootIssueOf(shoot *gardencorev1beta1.Shoot) *ShootIssue {
+	issue := &ShootIssue{Severity: IssueSeverityLow}
+	hasIssue := false
+
+	if op := shoot.Status.LastOperation; op == nil {
+		// the shoot has not been processed yet
+		hasIssue = true
+	} else {
+		switch op.State {
+		case gardencorev1beta1.LastOperationStateSucceeded:
+			hasIssue = op.Progress != 100 || op.Type != gardencorev1beta1.LastOperationTypeCreate && op.Type != gardencorev1beta1.LastOperationTypeReconcile
+		case gardencorev1beta1.LastOperationStateFailed:
+			hasIssue = true
+			issue.raise(IssueSeverityCritical)
+		case gardencorev1beta1.LastOperationStateError, gardencorev1beta1.LastOperationStateAborted:
+			hasIssue = true
+			issue.raise(IssueSeverityMedium)
+		default:
+			hasIssue = true
+		}
+		if hasIssue {
+			issue.seen(op.LastUpdateTime.Time)
+		}
+	}
+
+	codes := map[gardencorev1beta1.ErrorCode]bool{}
+	for _, condition := range shoot.Status.Conditions {
+		if condition.Status == gardencorev1beta1.ConditionTrue {
+			continue
+		}
+		issue.Conditions = append(issue.Conditions, condition)
+		if condition.Status == gardencorev1beta1.ConditionFalse {
+			hasIssue = true
+			if condition.Type == gardencorev1beta1.ShootAPIServerAvailable {
+				issue.raise(IssueSeverityCritical)
+			} else {
+				issue.raise(IssueSeverityMedium)
+			}
+			issue.seen(condition.LastTransitionTime.Time)
+		}
+		for _, code := range condition.Codes {
+			codes[code] = true
+		}
+	}
+	if !hasIssue {
+		return nil
+	}
+	if issue.Since.IsZero() {
+		issue.Since = shoot.CreationTimestamp.Time
+	}
+
+	for _, lastError := range shoot.Status.LastErrors {
+		for _, code := range lastError.Codes {
+			codes[code] = true
+		}
+	}
+	for code := range codes {
+		issue.Codes = append(issue.Codes, code)
+		issue.raise(ErrorCodeSeverity(code))
+	}
+	sort.Slice(issue.Codes, func(i, j int) bool {
+		si, sj := ErrorCodeSeverity(issue.Codes[i]), ErrorCodeSeverity(issue.Codes[j])
+		if si != sj {
+			return si > sj
+		}
+		return issue.Codes[i] < issue.Codes[j]
+	})
+	return issue
+}
+
+// raise raises the severity of the issue to at least severity
+func (i *ShootIssue) raise(severity IssueSeverity) {
+	if severity > i.Severity {
+		i.Severity = severity
+	}
+}
+
+// seen moves the start of the issue back to t if it is earlier
+func (i *ShootIssue) seen(t time.Time) {
+	if !t.IsZero() && (i.Since.IsZero() || t.Before(i.Since)) {
+		i.Since = t
+	}
+}
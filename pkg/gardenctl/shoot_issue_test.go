@@ -0,0 +1,119 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("ShootIssue", func() {
+	var (
+		now     = time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC)
+		created = metav1.NewTime(now.Add(-48 * time.Hour))
+
+		succeeded = &gardencorev1beta1.LastOperation{
+			Type:           gardencorev1beta1.LastOperationTypeReconcile,
+			State:          gardencorev1beta1.LastOperationStateSucceeded,
+			Progress:       100,
+			LastUpdateTime: metav1.NewTime(now.Add(-time.Hour)),
+		}
+		condition = func(conditionType gardencorev1beta1.ConditionType, status gardencorev1beta1.ConditionStatus, since time.Duration, codes ...gardencorev1beta1.ErrorCode) gardencorev1beta1.Condition {
+			return gardencorev1beta1.Condition{Type: conditionType, Status: status, LastTransitionTime: metav1.NewTime(now.Add(-since)), Codes: codes}
+		}
+		shoot = func(op *gardencorev1beta1.LastOperation, conditions ...gardencorev1beta1.Condition) *gardencorev1beta1.Shoot {
+			return &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", CreationTimestamp: created},
+				Status:     gardencorev1beta1.ShootStatus{LastOperation: op, Conditions: conditions},
+			}
+		}
+	)
+
+	It("should return no issue for a healthy shoot", func() {
+		Expect(gardenctl.ShootIssueOf(shoot(succeeded,
+			condition(gardencorev1beta1.ShootAPIServerAvailable, gardencorev1beta1.ConditionTrue, time.Hour),
+			condition(gardencorev1beta1.ShootEveryNodeReady, gardencorev1beta1.ConditionUnknown, time.Hour),
+		))).To(BeNil())
+	})
+
+	DescribeTable("#ShootIssueOf",
+		func(s *gardencorev1beta1.Shoot, severity gardenctl.IssueSeverity, since time.Time) {
+			issue := gardenctl.ShootIssueOf(s)
+			Expect(issue).NotTo(BeNil())
+			Expect(issue.Severity).To(Equal(severity))
+			Expect(issue.Since).To(Equal(since))
+		},
+		Entry("not processed", shoot(nil), gardenctl.IssueSeverityLow, created.Time),
+		Entry("processing", shoot(&gardencorev1beta1.LastOperation{
+			Type:           gardencorev1beta1.LastOperationTypeReconcile,
+			State:          gardencorev1beta1.LastOperationStateProcessing,
+			LastUpdateTime: metav1.NewTime(now.Add(-time.Minute)),
+		}), gardenctl.IssueSeverityLow, now.Add(-time.Minute)),
+		Entry("retried error", shoot(&gardencorev1beta1.LastOperation{
+			Type:  gardencorev1beta1.LastOperationTypeReconcile,
+			State: gardencorev1beta1.LastOperationStateError,
+		}), gardenctl.IssueSeverityMedium, created.Time),
+		Entry("failed operation", shoot(&gardencorev1beta1.LastOperation{
+			Type:  gardencorev1beta1.LastOperationTypeCreate,
+			State: gardencorev1beta1.LastOperationStateFailed,
+		}), gardenctl.IssueSeverityCritical, created.Time),
+		Entry("unhealthy nodes", shoot(succeeded,
+			condition(gardencorev1beta1.ShootEveryNodeReady, gardencorev1beta1.ConditionFalse, 3*time.Hour),
+			condition(gardencorev1beta1.ShootSystemComponentsHealthy, gardencorev1beta1.ConditionFalse, 2*time.Hour),
+		), gardenctl.IssueSeverityMedium, now.Add(-3*time.Hour)),
+		Entry("unavailable API server", shoot(succeeded,
+			condition(gardencorev1beta1.ShootAPIServerAvailable, gardencorev1beta1.ConditionFalse, time.Hour),
+		), gardenctl.IssueSeverityCritical, now.Add(-time.Hour)),
+		Entry("exceeded quota", shoot(succeeded,
+			condition(gardencorev1beta1.ShootEveryNodeReady, gardencorev1beta1.ConditionFalse, time.Hour, gardencorev1beta1.ErrorInfraQuotaExceeded),
+		), gardenctl.IssueSeverityHigh, now.Add(-time.Hour)),
+	)
+
+	It("should order the error codes by their severity", func() {
+		s := shoot(&gardencorev1beta1.LastOperation{
+			Type:  gardencorev1beta1.LastOperationTypeReconcile,
+			State: gardencorev1beta1.LastOperationStateError,
+		}, condition(gardencorev1beta1.ShootControlPlaneHealthy, gardencorev1beta1.ConditionFalse, time.Hour, gardencorev1beta1.ErrorInfraDependencies))
+		s.Status.LastErrors = []gardencorev1beta1.LastError{
+			{Description: "quota", Codes: []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded, gardencorev1beta1.ErrorInfraDependencies}},
+		}
+
+		issue := gardenctl.ShootIssueOf(s)
+		Expect(issue.Codes).To(Equal([]gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraQuotaExceeded, gardencorev1beta1.ErrorInfraDependencies}))
+		Expect(issue.RootCause()).To(Equal(gardencorev1beta1.ErrorInfraQuotaExceeded))
+		Expect(issue.Conditions).To(HaveLen(1))
+
+		m, err := gardenctl.NewMatcher("ERR_INFRA_DEP*", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issue.HasCode(m)).To(BeTrue())
+	})
+
+	It("should parse the severities", func() {
+		severity, err := gardenctl.ParseIssueSeverity("High")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(severity).To(Equal(gardenctl.IssueSeverityHigh))
+		Expect(severity.String()).To(Equal("high"))
+
+		_, err = gardenctl.ParseIssueSeverity("urgent")
+		Expect(err).To(MatchError(`invalid severity "urgent", must be one of low, medium, high, critical`))
+	})
+})
@@ -0,0 +1,168 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
+	"gopkg.in/yaml.v2"
+)
+
+// targetFile is the content of a target file
+type targetFile struct {
+	Target []TargetMeta `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+// pushedTargetsFile is the content of the file of the targets saved by PushTarget, the most recent last
+type pushedTargetsFile struct {
+	Targets []targetFile `yaml:"targets,omitempty" json:"targets,omitempty"`
+}
+
+// ErrNoPushedTarget is returned by PopTarget if no target was pushed
+var ErrNoPushedTarget = errors.New("no pushed target, save the current target with \"gardenctl target push\" first")
+
+// ReadTarget returns the target stack stored in the target file at path
+func ReadTarget(path string) ([]TargetMeta, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTarget(content)
+}
+
+// ParseTarget unmarshals the content of a target file and validates that every stack entry has a kind and a name
+func ParseTarget(content []byte) ([]TargetMeta, error) {
+	var target targetFile
+	if err := yaml.Unmarshal(content, &target); err != nil {
+		return nil, err
+	}
+	for _, t := range target.Target {
+		if t.Kind == "" || t.Name == "" {
+			return nil, errors.New("target stack contains an entry without kind or name")
+		}
+	}
+	return target.Target, nil
+}
+
+// WriteTarget atomically writes stack to the target file at path
+func WriteTarget(path string, stack []TargetMeta) error {
+	content, err := yaml.Marshal(targetFile{Target: stack})
+	if err != nil {
+		return err
+	}
+	return lockedfile.WriteFile(path, content, 0644)
+}
+
+// RecoverCorruptedTarget moves a corrupted target file aside and replaces it with an empty one.
+// The file is checked again under its lock, because a concurrent gardenctl call might have rewritten it in the meantime.
+// It returns the path of the corrupted file, or an empty path if the file is valid.
+func RecoverCorruptedTarget(path string) (string, error) {
+	unlock, err := lockedfile.Lock(path)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := ParseTarget(content); err == nil {
+		return "", nil
+	}
+
+	backupPath := path + ".corrupted"
+	if err := os.Rename(path, backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, ioutil.WriteFile(path, []byte{}, 0644)
+}
+
+// KindOfStack returns the kind of the current target of the target stack, a trailing namespace is ignored
+func KindOfStack(stack []TargetMeta) (TargetKind, error) {
+	if len(stack) > 0 && stack[len(stack)-1].Kind == TargetKindNamespace {
+		stack = stack[:len(stack)-1]
+	}
+	switch len(stack) {
+	case 1:
+		return TargetKindGarden, nil
+	case 2:
+		if stack[1].Kind == TargetKindSeed {
+			return TargetKindSeed, nil
+		}
+		return TargetKindProject, nil
+	case 3:
+		return TargetKindShoot, nil
+	default:
+		return "", errors.New("no target selected")
+	}
+}
+
+// ReadPushedTargets returns the target stacks saved by PushTarget in the file at path, the most recent first
+func ReadPushedTargets(path string) ([][]TargetMeta, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file pushedTargetsFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	var stacks [][]TargetMeta
+	for i := len(file.Targets) - 1; i >= 0; i-- {
+		stacks = append(stacks, file.Targets[i].Target)
+	}
+	return stacks, nil
+}
+
+// PushTarget saves stack on top of the targets in the file at path
+func PushTarget(path string, stack []TargetMeta) error {
+	if len(stack) == 0 {
+		return errors.New("no target to push")
+	}
+	return lockedfile.Transform(path, 0644, func(content []byte) ([]byte, error) {
+		var file pushedTargetsFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			return nil, err
+		}
+		file.Targets = append(file.Targets, targetFile{Target: stack})
+		return yaml.Marshal(&file)
+	})
+}
+
+// PopTarget calls use with the most recently pushed target of the file at path and removes it from the file,
+// it is kept if use returns an error. ErrNoPushedTarget is returned if there is none.
+func PopTarget(path string, use func([]TargetMeta) error) error {
+	return lockedfile.Transform(path, 0644, func(content []byte) ([]byte, error) {
+		var file pushedTargetsFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			return nil, err
+		}
+		if len(file.Targets) == 0 {
+			return nil, ErrNoPushedTarget
+		}
+		if err := use(file.Targets[len(file.Targets)-1].Target); err != nil {
+			return nil, err
+		}
+		file.Targets = file.Targets[:len(file.Targets)-1]
+		return yaml.Marshal(&file)
+	})
+}
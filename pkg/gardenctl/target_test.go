@@ -0,0 +1,121 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl/pkg/gardenctl"
+)
+
+var _ = Describe("Target", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "gardenctl")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should write and read the target stack of a session", func() {
+		session := &gardenctl.Session{ID: "test", Dir: dir}
+		stack := []gardenctl.TargetMeta{
+			{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+			{Kind: gardenctl.TargetKindProject, Name: "myproject"},
+			{Kind: gardenctl.TargetKindShoot, Name: "myshoot"},
+		}
+
+		Expect(session.WriteTarget(stack)).To(Succeed())
+		Expect(session.TargetPath()).To(Equal(filepath.Join(dir, "target")))
+
+		actual, err := session.ReadTarget()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(stack))
+
+		kind, err := gardenctl.KindOfStack(actual)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kind).To(Equal(gardenctl.TargetKindShoot))
+	})
+
+	It("should ignore a trailing namespace in the kind of the target stack", func() {
+		kind, err := gardenctl.KindOfStack([]gardenctl.TargetMeta{
+			{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+			{Kind: gardenctl.TargetKindSeed, Name: "aws-eu1"},
+			{Kind: gardenctl.TargetKindNamespace, Name: "kube-system"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kind).To(Equal(gardenctl.TargetKindSeed))
+	})
+
+	It("should return an empty stack for an empty target file", func() {
+		stack, err := gardenctl.ParseTarget([]byte{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stack).To(BeEmpty())
+	})
+
+	Context("pushed targets", func() {
+		var (
+			path   string
+			garden = []gardenctl.TargetMeta{{Kind: gardenctl.TargetKindGarden, Name: "prod"}}
+			shoot  = []gardenctl.TargetMeta{
+				{Kind: gardenctl.TargetKindGarden, Name: "prod"},
+				{Kind: gardenctl.TargetKindProject, Name: "core"},
+				{Kind: gardenctl.TargetKindShoot, Name: "api"},
+			}
+		)
+
+		BeforeEach(func() {
+			path = filepath.Join(dir, "pushed")
+		})
+
+		It("should pop the targets in reverse order of pushing", func() {
+			Expect(gardenctl.PushTarget(path, garden)).To(Succeed())
+			Expect(gardenctl.PushTarget(path, shoot)).To(Succeed())
+			Expect(gardenctl.ReadPushedTargets(path)).To(Equal([][]gardenctl.TargetMeta{shoot, garden}))
+
+			var popped [][]gardenctl.TargetMeta
+			use := func(stack []gardenctl.TargetMeta) error {
+				popped = append(popped, stack)
+				return nil
+			}
+			Expect(gardenctl.PopTarget(path, use)).To(Succeed())
+			Expect(gardenctl.PopTarget(path, use)).To(Succeed())
+			Expect(popped).To(Equal([][]gardenctl.TargetMeta{shoot, garden}))
+			Expect(gardenctl.PopTarget(path, use)).To(Equal(gardenctl.ErrNoPushedTarget))
+		})
+
+		It("should keep the target if it cannot be used", func() {
+			Expect(gardenctl.PushTarget(path, shoot)).To(Succeed())
+			Expect(gardenctl.PopTarget(path, func([]gardenctl.TargetMeta) error {
+				return errors.New("shoot not found")
+			})).To(MatchError("shoot not found"))
+			Expect(gardenctl.ReadPushedTargets(path)).To(Equal([][]gardenctl.TargetMeta{shoot}))
+		})
+
+		It("should refuse to push an empty target", func() {
+			Expect(gardenctl.PushTarget(path, nil)).To(MatchError("no target to push"))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardenctl
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetKind is a valid value for target kind.
+type TargetKind string
+
+// These are valid target kinds.
+const (
+	// TargetKindGarden points to garden cluster.
+	TargetKindGarden TargetKind = "garden"
+	// TargetKindProject points to project.
+	TargetKindProject TargetKind = "project"
+	// TargetKindSeed points to seed cluster.
+	TargetKindSeed TargetKind = "seed"
+	// TargetKindShoot points to shoot cluster.
+	TargetKindShoot TargetKind = "shoot"
+	// TargetKindNamespace points to namespace.
+	TargetKindNamespace TargetKind = "namespace"
+)
+
+// TargetMeta contains kind and name of target.
+type TargetMeta struct {
+	Kind TargetKind `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name string     `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
+// GardenConfig contains config for gardenctl
+type GardenConfig struct {
+	// APIVersion is the version of the configuration format, see ConfigAPIVersion
+	APIVersion     string              `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`
+	Email          string              `yaml:"email,omitempty" json:"email,omitempty"`
+	GithubURL      string              `yaml:"githubURL,omitempty" json:"githubURL,omitempty"`
+	GardenClusters []GardenClusterMeta `yaml:"gardenClusters,omitempty" json:"gardenClusters,omitempty"`
+	Cache          CacheConfig         `yaml:"cache,omitempty" json:"cache,omitempty"`
+}
+
+// CacheConfig contains the settings of the cache of fetched credentials
+type CacheConfig struct {
+	// TTL is the time to live of cached credentials, e.g. 8h, 0 disables the expiration
+	TTL string `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	// Encryption is the source of the key of the at-rest encryption, passphrase or keyring
+	Encryption string `yaml:"encryption,omitempty" json:"encryption,omitempty"`
+	// KeyringCommand prints the passphrase stored in the keyring, e.g. secret-tool lookup service gardenctl
+	KeyringCommand string `yaml:"keyringCommand,omitempty" json:"keyringCommand,omitempty"`
+}
+
+// GardenClusterMeta contains name and path to kubeconfig of gardencluster
+type GardenClusterMeta struct {
+	Name               string              `yaml:"name,omitempty" json:"name,omitempty"`
+	KubeConfig         string              `yaml:"kubeConfig,omitempty" json:"kubeConfig,omitempty"`
+	DashboardURL       string              `yaml:"dashboardUrl,omitempty" json:"dashboardUrl,omitempty"`
+	AccessRestrictions []AccessRestriction `yaml:"accessRestrictions,omitempty" json:"accessRestrictions,omitempty"`
+	// ReadOnly refuses all mutating operations on the garden and its seeds and shoots
+	ReadOnly bool `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+	// ReadOnlyTargets are the projects and shoots of the garden on which mutating operations are refused
+	ReadOnlyTargets []ReadOnlyTarget `yaml:"readOnlyTargets,omitempty" json:"readOnlyTargets,omitempty"`
+	// SeedCapacity is the number of shoots a seed of the garden can host, like the shoot capacity of the
+	// gardenlets. It is used to show the free capacity of the seeds, 0 means unknown.
+	SeedCapacity int `yaml:"seedCapacity,omitempty" json:"seedCapacity,omitempty"`
+}
+
+// ReadOnlyTarget selects read-only projects and shoots by name patterns, an empty pattern matches
+// everything. The patterns are exact names, globs or regular expressions like the names given to target.
+type ReadOnlyTarget struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Shoot   string `yaml:"shoot,omitempty" json:"shoot,omitempty"`
+}
+
+// AccessRestrictionsOption contains key / notifyIf / msg
+type AccessRestrictionsOption struct {
+	Key      string `yaml:"key,omitempty" json:"key,omitempty"`
+	NotifyIf bool   `yaml:"notifyIf,omitempty" json:"notifyIf,omitempty"`
+	Msg      string `yaml:"msg,omitempty" json:"msg,omitempty"`
+}
+
+// AccessRestriction contains key / notifyIf / msg / options / enforcement / selector
+type AccessRestriction struct {
+	Key      string                     `yaml:"key,omitempty" json:"key,omitempty"`
+	NotifyIf bool                       `yaml:"notifyIf,omitempty" json:"notifyIf,omitempty"`
+	Msg      string                     `yaml:"msg,omitempty" json:"msg,omitempty"`
+	Options  []AccessRestrictionsOption `yaml:"options,omitempty" json:"options,omitempty"`
+	// Enforcement is the enforcement level of the restriction, warn if it is empty
+	Enforcement RestrictionEnforcement `yaml:"enforcement,omitempty" json:"enforcement,omitempty"`
+	// Selector selects the shoots the restriction applies to. Without a selector the restriction
+	// applies if the seed selector of the shoot has key set to notifyIf.
+	Selector *AccessRestrictionSelector `yaml:"selector,omitempty" json:"selector,omitempty"`
+}
+
+// AccessRestrictionSelector selects shoots. All given criteria must match, a list of name
+// patterns matches if one of its patterns matches. The patterns are exact names, globs or
+// regular expressions like the names given to target.
+type AccessRestrictionSelector struct {
+	Labels      *KeyValueSelector `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations *KeyValueSelector `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	Purposes    []string          `yaml:"purposes,omitempty" json:"purposes,omitempty"`
+	Regions     []string          `yaml:"regions,omitempty" json:"regions,omitempty"`
+	Providers   []string          `yaml:"providers,omitempty" json:"providers,omitempty"`
+	Projects    []string          `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Seeds       []string          `yaml:"seeds,omitempty" json:"seeds,omitempty"`
+}
+
+// KeyValueSelector selects labels or annotations like a Kubernetes label selector
+type KeyValueSelector struct {
+	MatchLabels      map[string]string                 `yaml:"matchLabels,omitempty" json:"matchLabels,omitempty"`
+	MatchExpressions []metav1.LabelSelectorRequirement `yaml:"matchExpressions,omitempty" json:"matchExpressions,omitempty"`
+}
+
+// RestrictionEnforcement is the enforcement level of an access restriction
+type RestrictionEnforcement string
+
+// These are valid enforcement levels.
+const (
+	// RestrictionEnforcementWarn prints the messages of the restriction.
+	RestrictionEnforcementWarn RestrictionEnforcement = "warn"
+	// RestrictionEnforcementConfirm requires a confirmation of the access.
+	RestrictionEnforcementConfirm RestrictionEnforcement = "confirm"
+	// RestrictionEnforcementDeny denies the access.
+	RestrictionEnforcementDeny RestrictionEnforcement = "deny"
+)
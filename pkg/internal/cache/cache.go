@@ -0,0 +1,377 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache stores the credentials gardenctl fetches from the garden, e.g. seed and shoot
+// kubeconfigs, ssh keys and terraform files, with restrictive permissions, an optional
+// at-rest encryption and a time to live.
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
+)
+
+const (
+	// filePerm is the permission of cached files
+	filePerm os.FileMode = 0600
+	// dirPerm is the permission of the cache directories
+	dirPerm os.FileMode = 0700
+	// lockSuffix is the suffix of the lock files created next to cached files
+	lockSuffix = ".lock"
+)
+
+// Cache stores files below Dir. If encryption is enabled, files are encrypted at rest and
+// decrypted copies, which are handed to external tools like kubectl, ssh or terraform, are
+// kept below PlainDir.
+type Cache struct {
+	// Dir is the directory of the cache
+	Dir string
+	// PlainDir is the directory of the decrypted copies of encrypted files
+	PlainDir string
+	// TTL is the time to live of cached files, zero means they never expire
+	TTL time.Duration
+
+	key []byte
+}
+
+// Entry describes a file in the cache.
+type Entry struct {
+	Path      string     `yaml:"path" json:"path"`
+	Kind      string     `yaml:"kind" json:"kind"`
+	Size      int64      `yaml:"size" json:"size"`
+	Modified  time.Time  `yaml:"modified" json:"modified"`
+	Expires   *time.Time `yaml:"expires,omitempty" json:"expires,omitempty"`
+	Expired   bool       `yaml:"expired,omitempty" json:"expired,omitempty"`
+	Encrypted bool       `yaml:"encrypted" json:"encrypted"`
+	Decrypted bool       `yaml:"decrypted,omitempty" json:"decrypted,omitempty"`
+}
+
+// ErrNoPlainDir is returned if encrypted files have to be decrypted, but there is no PlainDir
+// to keep the decrypted copies in.
+var ErrNoPlainDir = errors.New("there is no directory for the decrypted copies of the cache")
+
+// New returns a cache below dir whose files expire after ttl.
+func New(dir, plainDir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, PlainDir: plainDir, TTL: ttl}
+}
+
+// Encrypted returns whether files are encrypted at rest.
+func (c *Cache) Encrypted() bool {
+	return c.key != nil
+}
+
+// Write stores data at path, which must be below Dir, and returns the path of the plain
+// file which can be handed to external tools.
+func (c *Cache) Write(path string, data []byte) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return "", err
+	}
+	rel, ok := c.rel(path)
+	if !ok || !c.Encrypted() {
+		return path, lockedfile.WriteFile(path, data, filePerm)
+	}
+	if c.PlainDir == "" {
+		return "", ErrNoPlainDir
+	}
+
+	encrypted, err := encrypt(c.key, data)
+	if err != nil {
+		return "", err
+	}
+	if err := lockedfile.WriteFile(path, encrypted, filePerm); err != nil {
+		return "", err
+	}
+	return c.writePlain(rel, data)
+}
+
+// Read returns the decrypted content of the file at path.
+func (c *Cache) Read(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil || !isEncrypted(data) {
+		return data, err
+	}
+	if !c.Encrypted() {
+		return nil, fmt.Errorf("%s is encrypted, but the cache encryption is not configured", path)
+	}
+	return decrypt(c.key, data)
+}
+
+// Path returns the path of the plain file or directory which can be handed to external tools
+// instead of path. Encrypted files are decrypted below PlainDir if there is no up-to-date copy yet.
+func (c *Cache) Path(path string) (string, error) {
+	rel, ok := c.rel(path)
+	if !ok || !c.Encrypted() {
+		return path, nil
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return path, nil
+	} else if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return c.decryptFile(path, rel, info)
+	}
+
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || strings.HasSuffix(file, lockSuffix) {
+			return err
+		}
+		fileRel, _ := c.rel(file)
+		_, err = c.decryptFile(file, fileRel, info)
+		return err
+	})
+	return filepath.Join(c.PlainDir, rel), err
+}
+
+// Expired returns whether the file at path exists, but is older than TTL.
+func (c *Cache) Expired(path string) bool {
+	if c.TTL <= 0 {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) > c.TTL
+}
+
+// List returns the files in the cache sorted by path.
+func (c *Cache) List() ([]Entry, error) {
+	entries := []Entry{}
+	err := c.walk(c.Dir, func(file, rel string, info os.FileInfo) error {
+		entry := Entry{
+			Path:     rel,
+			Kind:     kindOf(rel),
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		}
+		if c.TTL > 0 {
+			expires := info.ModTime().Add(c.TTL)
+			entry.Expires = &expires
+			entry.Expired = time.Now().After(expires)
+		}
+		encrypted, err := fileIsEncrypted(file)
+		if err != nil {
+			return err
+		}
+		entry.Encrypted = encrypted
+		if c.PlainDir != "" {
+			if _, err := os.Stat(filepath.Join(c.PlainDir, rel)); err == nil {
+				entry.Decrypted = true
+			}
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, err
+}
+
+// Remove deletes the given entries together with their decrypted copies.
+func (c *Cache) Remove(entries []Entry) error {
+	for _, entry := range entries {
+		if err := c.RemoveAll(filepath.Join(c.Dir, entry.Path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveAll deletes the file or directory at path, which must be below Dir, together with
+// its decrypted copies.
+func (c *Cache) RemoveAll(path string) error {
+	rel, ok := c.rel(path)
+	if !ok {
+		return fmt.Errorf("%s is not part of the cache %s", path, c.Dir)
+	}
+	for _, p := range []string{path, path + lockSuffix} {
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	if c.PlainDir != "" {
+		plainPath := filepath.Join(c.PlainDir, rel)
+		for _, p := range []string{plainPath, plainPath + lockSuffix} {
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Prune restricts the permissions of all cached files, e.g. written by former versions, and
+// removes the expired ones except those at or below the keep paths. It returns the removed entries.
+func (c *Cache) Prune(keep ...string) ([]Entry, error) {
+	for _, dir := range []string{c.Dir, c.PlainDir} {
+		if err := harden(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	expired := []Entry{}
+	for _, entry := range entries {
+		if entry.Expired && !c.kept(entry.Path, keep) {
+			expired = append(expired, entry)
+		}
+	}
+	if err := c.Remove(expired); err != nil {
+		return nil, err
+	}
+
+	// decrypted copies of files which were removed in the meantime are orphaned
+	err = c.walk(c.PlainDir, func(file, rel string, info os.FileInfo) error {
+		if _, err := os.Stat(filepath.Join(c.Dir, rel)); os.IsNotExist(err) {
+			return os.Remove(file)
+		}
+		return nil
+	})
+	return expired, err
+}
+
+// decryptFile writes the decrypted copy of the file at path below PlainDir unless it is up-to-date,
+// files written before the encryption was enabled are copied as they are
+func (c *Cache) decryptFile(path, rel string, info os.FileInfo) (string, error) {
+	plainPath := filepath.Join(c.PlainDir, rel)
+	if plainInfo, err := os.Stat(plainPath); err == nil && !plainInfo.ModTime().Before(info.ModTime()) {
+		return plainPath, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if isEncrypted(data) {
+		if data, err = decrypt(c.key, data); err != nil {
+			return "", fmt.Errorf("failed to decrypt %s: %v", path, err)
+		}
+	}
+	return c.writePlain(rel, data)
+}
+
+// writePlain writes the decrypted copy of the file with the given path relative to Dir
+func (c *Cache) writePlain(rel string, data []byte) (string, error) {
+	if c.PlainDir == "" {
+		return "", ErrNoPlainDir
+	}
+	plainPath := filepath.Join(c.PlainDir, rel)
+	if err := os.MkdirAll(filepath.Dir(plainPath), dirPerm); err != nil {
+		return "", err
+	}
+	return plainPath, lockedfile.WriteFile(plainPath, data, filePerm)
+}
+
+// kept returns whether the file with the given path relative to Dir is at or below one of the keep paths
+func (c *Cache) kept(rel string, keep []string) bool {
+	for _, path := range keep {
+		keepRel, ok := c.rel(path)
+		if ok && (rel == keepRel || strings.HasPrefix(rel, keepRel+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
+// rel returns the path relative to Dir and whether path is below Dir
+func (c *Cache) rel(path string) (string, bool) {
+	rel, err := filepath.Rel(c.Dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// walk calls fn for all cached files below dir, skipping lock and key files
+func (c *Cache) walk(dir string, fn func(file, rel string, info os.FileInfo) error) error {
+	if dir == "" {
+		return nil
+	}
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(file, lockSuffix) || filepath.Base(file) == saltFile {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		return fn(file, rel, info)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// harden restricts the permissions of all files and directories below dir
+func harden(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		perm := filePerm
+		if info.IsDir() {
+			perm = dirPerm
+		}
+		if info.Mode().Perm() == perm {
+			return nil
+		}
+		return os.Chmod(file, perm)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// kindOf returns the kind of credentials stored in the file with the given path relative to Dir
+func kindOf(rel string) string {
+	switch base := filepath.Base(rel); {
+	case strings.HasPrefix(base, "kubeconfig"):
+		return "kubeconfig"
+	case base == "key" || base == "key.pub":
+		return "ssh-key"
+	case strings.Contains(rel, string(filepath.Separator)+"terraform"+string(filepath.Separator)):
+		return "terraform"
+	}
+	return "file"
+}
+
+// fileIsEncrypted returns whether the file at path is encrypted
+func fileIsEncrypted(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(encryptedHeader))
+	n, _ := f.Read(header)
+	return bytes.Equal(header[:n], encryptedHeader), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
+	"golang.org/x/crypto/scrypt"
+)
+
+// saltFile is the name of the file in the cache directory holding the salt of the key derivation
+const saltFile = ".salt"
+
+// encryptedHeader prefixes the content of encrypted files
+var encryptedHeader = []byte("gardenctl-encrypted:v1\n")
+
+// EnableEncryption derives the key from passphrase and encrypts all files written afterwards.
+// The salt of the key derivation is created once per cache directory.
+func (c *Cache) EnableEncryption(passphrase []byte) error {
+	if len(passphrase) == 0 {
+		return errors.New("the passphrase of the cache encryption must not be empty")
+	}
+	salt, err := c.salt()
+	if err != nil {
+		return err
+	}
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return err
+	}
+	c.key = key
+	return nil
+}
+
+// salt returns the salt of the cache directory and creates it if necessary
+func (c *Cache) salt() ([]byte, error) {
+	if err := os.MkdirAll(c.Dir, dirPerm); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(c.Dir, saltFile)
+	var salt []byte
+	err := lockedfile.Transform(path, filePerm, func(content []byte) ([]byte, error) {
+		if len(content) > 0 {
+			salt = content
+			return content, nil
+		}
+		salt = make([]byte, 16)
+		_, err := rand.Read(salt)
+		return salt, err
+	})
+	return salt, err
+}
+
+// encrypt seals data with AES-GCM
+func encrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append(append([]byte{}, encryptedHeader...), nonce...)
+	return gcm.Seal(out, nonce, data, encryptedHeader), nil
+}
+
+// decrypt opens data sealed by encrypt
+func decrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[len(encryptedHeader):]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted file is truncated")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], encryptedHeader)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted file")
+	}
+	return plain, nil
+}
+
+// newGCM returns the AES-GCM cipher for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// isEncrypted returns whether data was sealed by encrypt
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedHeader)
+}
@@ -15,18 +15,24 @@
 package history
 
 import (
-	"bufio"
 	"encoding/json"
-	"fmt"
-	"io"
-	"log"
+	"errors"
+	"io/ioutil"
 	"os"
-	"reflect"
 	"strings"
+	"time"
 
+	"github.com/gardener/gardenctl/pkg/internal/lockedfile"
 	"github.com/manifoldco/promptui"
 )
 
+// MaxEntries is the number of entries kept in a history file. If it is exceeded, the older half of the
+// entries is moved to the rotated file <path>.1, which replaces a former rotated file.
+const MaxEntries = 1000
+
+// ErrAborted is returned by Select if the selection is aborted
+var ErrAborted = errors.New("selection of target history aborted")
+
 //History contains the history path, binary name and history items.
 type History struct {
 	ConfigPath string
@@ -34,8 +40,27 @@ type History struct {
 	Item       string
 	Prompt     []PromptItem
 	PromptItem PromptItem
+	// Bookmarks are offered by Select before the history items, Item is empty if one of them is selected
+	Bookmarks []PromptItem
 }
 
+// Entry is a target of the history. Entries written by former versions have no timestamp.
+type Entry struct {
+	Timestamp   time.Time `yaml:"timestamp,omitempty" json:"timestamp,omitempty"`
+	Cmd         string    `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Garden      string    `yaml:"garden,omitempty" json:"garden,omitempty"`
+	Project     string    `yaml:"project,omitempty" json:"project,omitempty"`
+	Seed        string    `yaml:"seed,omitempty" json:"seed,omitempty"`
+	Shoot       string    `yaml:"shoot,omitempty" json:"shoot,omitempty"`
+	Namespace   string    `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	TechnicalID string    `yaml:"shootTechnicalID,omitempty" json:"shootTechnicalID,omitempty"`
+	// Bookmark is the name of the bookmark which was targeted
+	Bookmark string `yaml:"bookmark,omitempty" json:"bookmark,omitempty"`
+}
+
+//PromptItem is an entry shown by Select
+type PromptItem = Entry
+
 //SetPath set History path
 func SetPath(path string) *History {
 	return &History{
@@ -44,51 +69,37 @@ func SetPath(path string) *History {
 }
 
 //Load the history record
-func (h *History) Load() *History {
-	f, err := os.Open(h.ConfigPath)
+func (h *History) Load() (*History, error) {
+	entries, lines, err := read(h.ConfigPath)
 	if err != nil {
-		fmt.Println("cannot open the file", err)
-		os.Exit(1)
+		return nil, err
 	}
-	defer f.Close()
-	rd := bufio.NewReader(f)
-
-	var items []string
-
-	for {
-		line, _, err := rd.ReadLine()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			fmt.Println("Load err:", err)
-			os.Exit(1)
-		}
-		items = append(items, string(line))
+	h.Items = nil
+	for i := range entries {
+		h.Items = append(h.Items, lines[i])
 	}
-	h.Items = items
-	return h
+	return h, nil
 }
 
-//List History records order by Ascending
+//List keeps the most recent MaxEntries History records order by Ascending
 func (h *History) List() *History {
-	if len(h.Items) > 1000 {
-		temp := make([]string, 1000)
-		copy(temp, h.Items[:1000])
-		h.Items = temp
-		return h
+	if len(h.Items) > MaxEntries {
+		h.Items = h.Items[len(h.Items)-MaxEntries:]
 	}
 	return h
 }
 
 //Reverse History records order by Descending
 func (h *History) Reverse() *History {
-	h.Items = reverse(h.Items)
+	for i, j := 0, len(h.Items)-1; i < j; i, j = i+1, j-1 {
+		h.Items[i], h.Items[j] = h.Items[j], h.Items[i]
+	}
 	return h
 }
 
-//Select one History item from history
-func (h *History) Select() *History {
-	h.Prompt = PromptItems(h.Items)
+//Select one History item from history, ErrAborted is returned if the prompt is aborted
+func (h *History) Select() (*History, error) {
+	h.Prompt = append(append([]PromptItem{}, h.Bookmarks...), PromptItems(h.Items)...)
 	templates := &promptui.SelectTemplates{
 		Label:    "{{ . }}?",
 		Active:   "\U0001F4CC {{ .Cmd | cyan }} ",
@@ -101,6 +112,7 @@ func (h *History) Select() *History {
 {{ "Seed:" | faint }}{{ if eq .Garden "live" }}	{{ .Seed | red }}{{ else }}	{{ .Seed }}{{end}}
 {{ "Namespace:" | faint }}{{ if eq .Garden "live" }}	{{ .Namespace | red }}{{ else }}	{{ .Namespace }}{{end}}
 {{ "Shoot:" | faint }}{{ if eq .Garden "live" }}	{{ .Shoot | red }}{{ else }}	{{ .Shoot }}{{end}}
+{{ if .Bookmark }}{{ "Bookmark:" | faint }}	{{ .Bookmark }}{{end}}
 `,
 	}
 	searcher := func(input string, index int) bool {
@@ -119,55 +131,129 @@ func (h *History) Select() *History {
 	}
 
 	i, _, err := prompt.Run()
-
 	if err != nil {
-		fmt.Print("Prompt Exit")
-		os.Exit(0)
+		return nil, ErrAborted
 	}
 
 	h.PromptItem = h.Prompt[i]
-	h.Item = h.Items[i]
-	return h
+	h.Item = ""
+	if i >= len(h.Bookmarks) {
+		h.Item = h.Items[i-len(h.Bookmarks)]
+	}
+	return h, nil
 }
 
-func reverse(s []string) []string {
-	n := reflect.ValueOf(s).Len()
-	swap := reflect.Swapper(s)
-	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
-		swap(i, j)
+//PromptItems generate prompt items, lines which are no history entries are skipped
+func PromptItems(load []string) []PromptItem {
+	items := []PromptItem{}
+	for _, line := range load {
+		if entry, err := ParseEntry(line); err == nil {
+			items = append(items, entry)
+		}
 	}
-	return s
+	return items
+}
+
+// ParseEntry parses a line of a history file
+func ParseEntry(line string) (Entry, error) {
+	var entry Entry
+	err := json.Unmarshal([]byte(line), &entry)
+	return entry, err
 }
 
-func factory(str string) *PromptItem {
-	p := &PromptItem{}
-	if err := json.Unmarshal([]byte(str), &p); err != nil {
-		log.Fatal(err)
+// SameTarget returns true if e and other target the same garden, project, seed, shoot and namespace
+func (e Entry) SameTarget(other Entry) bool {
+	return e.Garden == other.Garden && e.Project == other.Project && e.Seed == other.Seed &&
+		e.Shoot == other.Shoot && e.Namespace == other.Namespace
+}
+
+// ReadEntries returns the entries of the history file at path, order by Ascending. Lines which are no
+// history entries are skipped.
+func ReadEntries(path string) ([]Entry, error) {
+	entries, _, err := read(path)
+	return entries, err
+}
+
+// Append appends entry to the history file at path. If the last entry has the same target, it is
+// replaced instead, so repeated targeting doesn't flood the history. The file is rotated if it
+// exceeds MaxEntries.
+func Append(path string, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
 	}
-	return p
+	return transform(path, func(entries []Entry, lines []string) ([]string, error) {
+		if n := len(entries); n > 0 && entries[n-1].SameTarget(entry) {
+			lines = lines[:n-1]
+		}
+		lines = append(lines, string(line))
+		if len(lines) <= MaxEntries {
+			return lines, nil
+		}
+		rotated := len(lines) - MaxEntries/2
+		if err := lockedfile.WriteFile(path+".1", []byte(strings.Join(lines[:rotated], "\n")+"\n"), 0644); err != nil {
+			return nil, err
+		}
+		return lines[rotated:], nil
+	})
+}
+
+// Prune removes the entries of the history file at path for which keep returns false and returns
+// the number of removed entries
+func Prune(path string, keep func(Entry) bool) (int, error) {
+	var removed int
+	err := transform(path, func(entries []Entry, lines []string) ([]string, error) {
+		var kept []string
+		for i, entry := range entries {
+			if keep(entry) {
+				kept = append(kept, lines[i])
+			}
+		}
+		removed = len(lines) - len(kept)
+		return kept, nil
+	})
+	return removed, err
 }
 
-//PromptItem struct
-type PromptItem struct {
-	Cmd       string `json:"cmd,omitempty"`
-	Garden    string `json:"garden,omitempty"`
-	Project   string `json:"project,omitempty"`
-	Seed      string `json:"seed,omitempty"`
-	Namespace string `json:"namespace,omitempty"`
-	Shoot     string `json:"shoot,omitempty"`
+// read returns the entries of the history file at path together with their lines
+func read(path string) ([]Entry, []string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	entries, lines := parse(content)
+	return entries, lines, nil
 }
 
-//Prompt struct
-type Prompt struct {
-	Items []PromptItem
+// transform replaces the lines of the history file at path with the result of fn under its lock
+func transform(path string, fn func(entries []Entry, lines []string) ([]string, error)) error {
+	return lockedfile.Transform(path, 0644, func(content []byte) ([]byte, error) {
+		entries, lines := parse(content)
+		lines, err := fn(entries, lines)
+		if err != nil || len(lines) == 0 {
+			return nil, err
+		}
+		return []byte(strings.Join(lines, "\n") + "\n"), nil
+	})
 }
 
-//PromptItems generate prompt items
-func PromptItems(load []string) []PromptItem {
-	items := []PromptItem{}
-	Prompt := Prompt{items}
-	for _, i := range load {
-		Prompt.Items = append(Prompt.Items, *factory(i))
+// parse returns the entries of the content of a history file together with their lines
+func parse(content []byte) ([]Entry, []string) {
+	var (
+		entries []Entry
+		lines   []string
+	)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		entry, err := ParseEntry(line)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+		lines = append(lines, line)
 	}
-	return Prompt.Items
+	return entries, lines
 }
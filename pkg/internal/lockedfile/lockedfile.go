@@ -0,0 +1,111 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lockedfile serializes access to the session and cache files of gardenctl
+// across concurrently running gardenctl processes.
+package lockedfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// lockSuffix is appended to the path of a file to get the path of its lock file.
+const lockSuffix = ".lock"
+
+// Lock acquires an exclusive lock for the file at path and returns a function releasing it.
+// The lock is held on a separate "<path>.lock" file, so it stays valid across atomic renames of path.
+func Lock(path string) (func() error, error) {
+	f, err := os.OpenFile(path+lockSuffix, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() error {
+		err := unlock(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+// WriteFile atomically replaces the file at path with data while holding its lock.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	return Transform(path, perm, func([]byte) ([]byte, error) {
+		return data, nil
+	})
+}
+
+// Transform passes the current content of the file at path to fn and atomically replaces
+// the file with the result. The lock is held for the whole read-modify-write cycle, so
+// concurrent transformations, e.g. appending history lines, never lose updates.
+func Transform(path string, perm os.FileMode, fn func([]byte) ([]byte, error)) (err error) {
+	unlock, err := Lock(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+
+	current, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	data, err := fn(current)
+	if err != nil {
+		return err
+	}
+
+	return writeAtomic(path, data, perm)
+}
+
+// writeAtomic writes data to a temporary file next to path and renames it to path,
+// so readers either see the old or the new content but never a partially written file.
+func writeAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -9,6 +9,7 @@ import (
 	versioned "github.com/gardener/gardener/pkg/client/core/clientset/versioned"
 	gomock "github.com/golang/mock/gomock"
 	kubernetes "k8s.io/client-go/kubernetes"
+	rest "k8s.io/client-go/rest"
 	reflect "reflect"
 )
 
@@ -50,6 +51,21 @@ func (mr *MockTargetInterfaceMockRecorder) GardenerClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GardenerClient", reflect.TypeOf((*MockTargetInterface)(nil).GardenerClient))
 }
 
+// GardenerClientToGarden mocks base method
+func (m *MockTargetInterface) GardenerClientToGarden(arg0 string) (versioned.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GardenerClientToGarden", arg0)
+	ret0, _ := ret[0].(versioned.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GardenerClientToGarden indicates an expected call of GardenerClientToGarden
+func (mr *MockTargetInterfaceMockRecorder) GardenerClientToGarden(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GardenerClientToGarden", reflect.TypeOf((*MockTargetInterface)(nil).GardenerClientToGarden), arg0)
+}
+
 // K8SClient mocks base method
 func (m *MockTargetInterface) K8SClient() (kubernetes.Interface, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +111,36 @@ func (mr *MockTargetInterfaceMockRecorder) Kind() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kind", reflect.TypeOf((*MockTargetInterface)(nil).Kind))
 }
 
+// KubeconfigPathToKind mocks base method
+func (m *MockTargetInterface) KubeconfigPathToKind(arg0 cmd.TargetKind) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeconfigPathToKind", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KubeconfigPathToKind indicates an expected call of KubeconfigPathToKind
+func (mr *MockTargetInterfaceMockRecorder) KubeconfigPathToKind(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeconfigPathToKind", reflect.TypeOf((*MockTargetInterface)(nil).KubeconfigPathToKind), arg0)
+}
+
+// RESTConfigToKind mocks base method
+func (m *MockTargetInterface) RESTConfigToKind(arg0 cmd.TargetKind) (*rest.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RESTConfigToKind", arg0)
+	ret0, _ := ret[0].(*rest.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RESTConfigToKind indicates an expected call of RESTConfigToKind
+func (mr *MockTargetInterfaceMockRecorder) RESTConfigToKind(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RESTConfigToKind", reflect.TypeOf((*MockTargetInterface)(nil).RESTConfigToKind), arg0)
+}
+
 // SetStack mocks base method
 func (m *MockTargetInterface) SetStack(arg0 []cmd.TargetMeta) {
 	m.ctrl.T.Helper()